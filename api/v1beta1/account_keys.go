@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *Account) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// AccountName returns the name of the Slurm account.
+func (o *Account) AccountName() string {
+	if o.Spec.AccountName != "" {
+		return o.Spec.AccountName
+	}
+	return o.Name
+}
+
+// ParentAccountName returns the name of the parent Slurm account.
+func (o *Account) ParentAccountName() string {
+	if o.Spec.ParentAccount != "" {
+		return o.Spec.ParentAccount
+	}
+	return "root"
+}
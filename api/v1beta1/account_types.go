@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AccountKind = "Account"
+)
+
+var (
+	AccountGVK        = GroupVersion.WithKind(AccountKind)
+	AccountAPIVersion = GroupVersion.String()
+)
+
+// AccountSpec defines the desired state of Account
+// +kubebuilder:validation:XValidation:rule="has(self.controllerRef) != has(self.accountingRef)", message="exactly one of controllerRef or accountingRef must be set"
+type AccountSpec struct {
+	SlurmdbRef `json:",inline"`
+
+	// AccountName is the name of the Slurm account.
+	// Defaults to the name of the Account.
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[a-z0-9_.-]+$"
+	AccountName string `json:"accountName,omitempty"`
+
+	// Description of the Slurm account.
+	// Defaults to the account name.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Description
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Organization of the Slurm account.
+	// Defaults to the parent account name.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Organization
+	// +optional
+	Organization string `json:"organization,omitempty"`
+
+	// ParentAccount is the name of the parent Slurm account.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Parent
+	// +optional
+	// +default:="root"
+	ParentAccount string `json:"parentAccount,omitempty"`
+
+	// Fairshare is the raw share of the account association.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Fairshare
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Fairshare *int32 `json:"fairshare,omitempty"`
+
+	// QOS is the list of QOS which the account association may use.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_QosLevel
+	// +optional
+	// +listType=set
+	QOS []string `json:"qos,omitempty"`
+
+	// DefaultQOS is the QOS of jobs which do not request one.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_DefaultQOS
+	// +optional
+	DefaultQOS string `json:"defaultQos,omitempty"`
+}
+
+// AccountStatus defines the observed state of Account
+type AccountStatus struct {
+	// Clusters is the list of Slurm clusters the account is associated with.
+	// +optional
+	// +listType=set
+	Clusters []string `json:"clusters,omitempty"`
+
+	// Represents the latest available observations of an Account's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=accounts;acct
+// +kubebuilder:printcolumn:name="PARENT",type="string",JSONPath=".spec.parentAccount",description="The parent of the Slurm account."
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Whether the account is synced into slurmdbd."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Account is the Schema for the accounts API
+type Account struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccountSpec   `json:"spec,omitempty"`
+	Status AccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccountList contains a list of Account
+type AccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Account `json:"items"`
+}
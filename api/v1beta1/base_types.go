@@ -205,3 +205,17 @@ type ExternalConfig struct {
 	// +required
 	Port int `json:"port,omitzero"`
 }
+
+// SlurmdbRef references the Slurm database (slurmdbd) into which an object is
+// synced, by way of either a Controller or an Accounting.
+type SlurmdbRef struct {
+	// controllerRef is a reference to the Controller CR, into whose cluster
+	// this is synced.
+	// +optional
+	ControllerRef *corev1.LocalObjectReference `json:"controllerRef,omitempty"`
+
+	// accountingRef is a reference to the Accounting CR, into the clusters of
+	// whose Controllers this is synced.
+	// +optional
+	AccountingRef *corev1.LocalObjectReference `json:"accountingRef,omitempty"`
+}
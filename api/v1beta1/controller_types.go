@@ -73,16 +73,19 @@ type ControllerSpec struct {
 	Slurmctld ContainerWrapper `json:"slurmctld,omitempty"`
 
 	// Indicates how reconfigure is handled when Slurm configuration changes.
-	// When true, the reconfigure sidecar issue reconfigures without pod recreate.
+	// When true, the operator diffs the Slurm configuration and reconfigures
+	// through the Slurm REST API, once the reconfigure sidecar reports that
+	// slurmctld has the changes mounted. Only keys which require a restart
+	// restart slurmctld, then the NodeSet pods by their update strategy.
 	// When false, the pod will be recreated and reconfigure issued only on startup.
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_reconfigure
 	// +optional
 	// +default:=false
 	InplaceReconfigure bool `json:"inplaceReconfigure"`
 
-	// The reconfigure container configuration.
-	// If not empty, then slurmctld will reconfigure in-place, without recreating
-	// the pod when Slurm configuration changes.
-	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_reconfigure
+	// The reconfigure sidecar configuration.
+	// The sidecar reports the Slurm configuration which slurmctld has mounted,
+	// when inplaceReconfigure is true.
 	// +optional
 	Reconfigure ContainerWrapper `json:"reconfigure,omitzero"`
 
@@ -143,6 +146,26 @@ type ControllerSpec struct {
 	// Metrics defines the metric collection configuration.
 	// +optional
 	Metrics Metrics `json:"metrics,omitzero"`
+
+	// PowerSaving defines the Slurm power saving configuration.
+	// Only used when a NodeSet has power saving enabled.
+	// Ref: https://slurm.schedmd.com/power_save.html
+	// +optional
+	PowerSaving ControllerPowerSaving `json:"powerSaving,omitzero"`
+
+	// Topology defines the Slurm topologies generated from Kubernetes node
+	// labels, which are rendered as `topology.yaml` unless provided by
+	// ConfigFileRefs. The Kubernetes node topology annotation takes precedence.
+	// Ref: https://slurm.schedmd.com/topology.yaml.html
+	// +optional
+	Topology ControllerTopology `json:"topology,omitzero"`
+
+	// Scheduling defines the typed Slurm scheduling and resource selection
+	// configuration, which is rendered into `slurm.conf`. ExtraConf takes
+	// precedence, except for list parameters whose options are merged.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_SCHEDULING
+	// +optional
+	Scheduling ControllerScheduling `json:"scheduling,omitzero"`
 }
 
 // High Availability configuration.
@@ -159,6 +182,221 @@ type ControllerHighAvailability struct {
 	Backups *int32 `json:"backups,omitempty"`
 }
 
+// Power Saving configuration.
+type ControllerPowerSaving struct {
+	// Endpoint is the URL of the slurm-operator power saving endpoint, which
+	// is notified by the ResumeProgram and SuspendProgram. When empty, the
+	// power state changes are observed on the next NodeSet resync.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// ResumeTimeout is the maximum duration for a resumed node to register.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_ResumeTimeout
+	// +optional
+	// +kubebuilder:default:="5m"
+	ResumeTimeout metav1.Duration `json:"resumeTimeout,omitempty"`
+
+	// SuspendTimeout is the maximum duration for a suspended node to shut down.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SuspendTimeout
+	// +optional
+	// +kubebuilder:default:="1m"
+	SuspendTimeout metav1.Duration `json:"suspendTimeout,omitempty"`
+}
+
+// Topology configuration.
+type ControllerTopology struct {
+	// Topologies is the list of Slurm topologies.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Topologies []ControllerTopologySpec `json:"topologies,omitempty"`
+}
+
+// ControllerTopologySpec defines a Slurm topology. Exactly one of Tree or
+// Block must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.tree) != has(self.block)", message="exactly one of tree or block must be set"
+type ControllerTopologySpec struct {
+	// Name is the name of the Slurm topology (e.g. topo-switch).
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]+$`
+	// +required
+	Name string `json:"name"`
+
+	// ClusterDefault indicates if this is the default topology of the cluster.
+	// +optional
+	ClusterDefault bool `json:"clusterDefault,omitempty"`
+
+	// Tree defines a `topology/tree` topology of switches.
+	// +optional
+	Tree *ControllerTopologyTree `json:"tree,omitempty"`
+
+	// Block defines a `topology/block` topology of blocks.
+	// +optional
+	Block *ControllerTopologyBlock `json:"block,omitempty"`
+}
+
+// ControllerTopologyTree maps Kubernetes node labels to switch tiers.
+type ControllerTopologyTree struct {
+	// SwitchLabels are the Kubernetes node labels of each switch tier, ordered
+	// from the top tier to the leaf switches (e.g. topology.kubernetes.io/zone,
+	// then a rack label). A switch is generated for each distinct label value
+	// within its parent switch, and nodes are attached to their leaf switch.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	SwitchLabels []string `json:"switchLabels"`
+}
+
+// ControllerTopologyBlock maps a Kubernetes node label to blocks.
+type ControllerTopologyBlock struct {
+	// BlockLabel is the Kubernetes node label of the block (e.g. an NVLink
+	// domain label). A block is generated for each distinct label value.
+	// +required
+	BlockLabel string `json:"blockLabel"`
+
+	// BlockSizes are the planning base block sizes.
+	// Ref: https://slurm.schedmd.com/topology.yaml.html#OPT_block_sizes
+	// +optional
+	BlockSizes []int32 `json:"blockSizes,omitempty"`
+}
+
+// Scheduling configuration.
+type ControllerScheduling struct {
+	// SchedulerType is the Slurm scheduler plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SchedulerType
+	// +kubebuilder:validation:Enum=sched/backfill;sched/builtin
+	// +optional
+	SchedulerType string `json:"schedulerType,omitempty"`
+
+	// SchedulerParameters are the Slurm scheduler options (e.g. bf_continue,
+	// bf_window=4320).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SchedulerParameters
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z_]+(=[^,\s]+)?$`
+	// +listType=set
+	// +optional
+	SchedulerParameters []string `json:"schedulerParameters,omitempty"`
+
+	// SelectType is the Slurm resource selection plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SelectType
+	// +kubebuilder:validation:Enum=select/cons_tres;select/linear
+	// +optional
+	SelectType string `json:"selectType,omitempty"`
+
+	// SelectTypeParameters are the Slurm resource selection options
+	// (e.g. CR_Core_Memory).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SelectTypeParameters
+	// +kubebuilder:validation:items:Enum=CR_CPU;CR_CPU_Memory;CR_Core;CR_Core_Memory;CR_Socket;CR_Socket_Memory;CR_Memory;CR_LLN;CR_Pack_Nodes;CR_ONE_TASK_PER_CORE;CR_CORE_DEFAULT_DIST_BLOCK;LL_SHARED_GRES;MULTIPLE_SHARING_GRES_PJ;ENFORCE_BINDING_GRES;ONE_TASK_PER_SHARING_GRES
+	// +listType=set
+	// +optional
+	SelectTypeParameters []string `json:"selectTypeParameters,omitempty"`
+
+	// Priority defines the Slurm job priority configuration.
+	// Ref: https://slurm.schedmd.com/priority_multifactor.html
+	// +optional
+	Priority ControllerPriority `json:"priority,omitzero"`
+
+	// Preemption defines the Slurm job preemption configuration.
+	// Ref: https://slurm.schedmd.com/preempt.html
+	// +optional
+	Preemption ControllerPreemption `json:"preemption,omitzero"`
+
+	// JobDefaults defines the Slurm job resource defaults.
+	// +optional
+	JobDefaults ControllerJobDefaults `json:"jobDefaults,omitzero"`
+}
+
+// Priority configuration.
+type ControllerPriority struct {
+	// Type is the Slurm job priority plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityType
+	// +kubebuilder:validation:Enum=priority/basic;priority/multifactor
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// WeightAge is the weight of the job age factor.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightAge
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WeightAge *int32 `json:"weightAge,omitempty"`
+
+	// WeightAssoc is the weight of the job association factor.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightAssoc
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WeightAssoc *int32 `json:"weightAssoc,omitempty"`
+
+	// WeightFairshare is the weight of the fair-share factor.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightFairshare
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WeightFairshare *int32 `json:"weightFairshare,omitempty"`
+
+	// WeightJobSize is the weight of the job size factor.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightJobSize
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WeightJobSize *int32 `json:"weightJobSize,omitempty"`
+
+	// WeightPartition is the weight of the partition factor.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightPartition
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WeightPartition *int32 `json:"weightPartition,omitempty"`
+
+	// WeightQOS is the weight of the QOS factor.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightQOS
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	WeightQOS *int32 `json:"weightQOS,omitempty"`
+
+	// WeightTRES are the weights of each TRES type (e.g. CPU, Mem, GRES/gpu).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityWeightTRES
+	// +optional
+	WeightTRES map[string]int32 `json:"weightTRES,omitempty"`
+}
+
+// Preemption configuration.
+type ControllerPreemption struct {
+	// Type is the Slurm job preemption plugin.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PreemptType
+	// +kubebuilder:validation:Enum=preempt/none;preempt/partition_prio;preempt/qos
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Mode is the Slurm job preemption mode, and its options.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PreemptMode
+	// +kubebuilder:validation:items:Enum=OFF;CANCEL;GANG;REQUEUE;SUSPEND;PRIORITY;WITHIN
+	// +listType=set
+	// +optional
+	Mode []string `json:"mode,omitempty"`
+}
+
+// Job defaults configuration.
+// +kubebuilder:validation:XValidation:rule="!(has(self.defMemPerCPU) && has(self.defMemPerNode))", message="defMemPerCPU and defMemPerNode are mutually exclusive"
+type ControllerJobDefaults struct {
+	// DefMemPerCPU is the default memory per allocated CPU, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefMemPerCPU
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DefMemPerCPU *int64 `json:"defMemPerCPU,omitempty"`
+
+	// DefMemPerNode is the default memory per allocated node, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefMemPerNode
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DefMemPerNode *int64 `json:"defMemPerNode,omitempty"`
+
+	// DefMemPerGPU is the default memory per allocated GPU, in megabytes.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefMemPerGPU
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DefMemPerGPU *int64 `json:"defMemPerGPU,omitempty"`
+
+	// DefCpuPerGPU is the default number of CPUs per allocated GPU.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefCpuPerGPU
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	DefCpuPerGPU *int32 `json:"defCpuPerGPU,omitempty"`
+}
+
 type ControllerPersistence struct {
 	// Enabled controls if persistent storage is enabled.
 	// +default:=true
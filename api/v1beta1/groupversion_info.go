@@ -27,11 +27,17 @@ var (
 
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
+		&Account{}, &AccountList{},
 		&Accounting{}, &AccountingList{},
 		&Controller{}, &ControllerList{},
 		&LoginSet{}, &LoginSetList{},
 		&NodeSet{}, &NodeSetList{},
+		&Partition{}, &PartitionList{},
+		&QOS{}, &QOSList{},
+		&Reservation{}, &ReservationList{},
 		&RestApi{}, &RestApiList{},
+		&SlurmJob{}, &SlurmJobList{},
+		&SlurmUser{}, &SlurmUserList{},
 		&Token{}, &TokenList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
@@ -61,11 +61,15 @@ type NodeSetSpec struct {
 	Template PodTemplate `json:"template,omitempty"`
 
 	// ExtraConf is added to the slurmd args as `--conf <extraConf>`.
+	// Changes to only the `Features` (or `Feature`) and `Weight` keys are
+	// applied to the registered Slurm nodes without recreating the pods.
 	// Ref: https://slurm.schedmd.com/slurmd.html#OPT_conf-%3Cnode-parameters%3E
 	// +optional
 	ExtraConf string `json:"extraConf,omitzero"`
 
 	// Partition defines the Slurm partition configuration for this NodeSet.
+	// Partition membership is defined by the Slurm configuration of the
+	// Controller, hence changing it does not recreate the NodeSet pods.
 	// +optional
 	Partition NodeSetPartition `json:"partition,omitzero"`
 
@@ -137,11 +141,86 @@ type NodeSetSpec struct {
 	// +kubebuilder:default:=Never
 	PruneSlurmNodeRecords NodeSetPruneSlurmNodeRecordType `json:"pruneSlurmNodeRecords,omitempty"`
 
+	// PreRegisterSlurmNodes indicates Slurm node records are created in the FUTURE state
+	// for all replicas, before their pods exist, such that Slurm can plan jobs against them.
+	// Node records beyond the replicas, which are not backed by a pod, are deleted.
+	// Used only when `scalingMode=StatefulSet`.
+	// +optional
+	// +default:=false
+	PreRegisterSlurmNodes bool `json:"preRegisterSlurmNodes,omitempty"`
+
 	// OversubscribeNode indicates these NodeSet Pods can reside on the same Kubernetes Node (no anti-affinity).
 	// WARNING: This option is **NOT** recommended for production usage.
 	// +optional
 	// +default:=false
 	OversubscribeNode bool `json:"oversubscribeNode,omitempty"`
+
+	// Autoscaling controls demand-driven scaling of the NodeSet replicas based
+	// on the Slurm job queue.
+	// Used only when `scalingMode=StatefulSet`.
+	// +optional
+	Autoscaling NodeSetAutoscaling `json:"autoscaling,omitzero"`
+
+	// PowerSaving registers the NodeSet nodes with Slurm power saving, such
+	// that slurmctld resumes and suspends the NodeSet pods on demand.
+	// Used only when `scalingMode=StatefulSet`.
+	// Ref: https://slurm.schedmd.com/power_save.html
+	// +optional
+	PowerSaving NodeSetPowerSaving `json:"powerSaving,omitzero"`
+
+	// DrainPolicy bounds how long the NodeSet waits for a Slurm node to drain
+	// before a pod is deleted by scale-in or rolling update, or while cordoned.
+	// +optional
+	DrainPolicy NodeSetDrainPolicy `json:"drainPolicy,omitzero"`
+
+	// NodeHealthPolicy reflects Slurm nodes which were set DOWN or DRAIN outside
+	// of the operator (e.g. by a HealthCheckProgram) onto the Kubernetes nodes
+	// of their pods, such that other workloads also avoid the unhealthy hosts.
+	// +optional
+	NodeHealthPolicy NodeSetNodeHealthPolicy `json:"nodeHealthPolicy,omitzero"`
+
+	// ConditionDrainPolicy drains the Slurm node of a pod while the Kubernetes
+	// node of the pod has any of the listed node conditions (e.g. GPU health
+	// from node-problem-detector), and undrains it once they clear. This is
+	// independent of the Kubernetes node being cordoned.
+	// +optional
+	ConditionDrainPolicy NodeSetConditionDrainPolicy `json:"conditionDrainPolicy,omitzero"`
+
+	// RemediationPolicy replaces pods whose Slurm node has persistently failed
+	// (e.g. slurmd is stuck NOT_RESPONDING) and has no running jobs.
+	// +optional
+	RemediationPolicy NodeSetRemediationPolicy `json:"remediationPolicy,omitzero"`
+
+	// Gres derives the Slurm node GRES from the extended resource limits of
+	// the slurmd container, or of the pod if the container has none.
+	// Ref: https://slurm.schedmd.com/gres.html
+	// +optional
+	Gres NodeSetGres `json:"gres,omitzero"`
+
+	// CpuTopology derives the Slurm node CPU topology from the Kubernetes node
+	// the pod is bound to, such that Slurm binds tasks in accordance with it.
+	// Requires the pod binding webhook.
+	// +optional
+	CpuTopology NodeSetCpuTopology `json:"cpuTopology,omitzero"`
+
+	// FeatureLabels maps the labels of the Kubernetes node the pod is bound to
+	// onto Slurm node features. These are applied in the `k8s/` namespace,
+	// merged with those of the `features.slinky.slurm.net/spec` node annotation.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Features
+	// +listType=map
+	// +listMapKey=key
+	// +optional
+	FeatureLabels []NodeSetFeatureLabel `json:"featureLabels,omitempty"`
+
+	// PodInfoNodeLabels are the labels of the Kubernetes node the pod is bound
+	// to, which are recorded with the pod info in the Extra field of the Slurm
+	// node (e.g. zone, instance type, node pool). Nodes without a label omit it.
+	// Defaults to the well-known zone, region, instance type, and node pool
+	// labels when unset. An empty list records no node labels.
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Extra
+	// +listType=set
+	// +optional
+	PodInfoNodeLabels []string `json:"podInfoNodeLabels"`
 }
 
 // ScalingModeType is a string enumeration of how a NodeSet scales its pods.
@@ -169,6 +248,324 @@ type NodeSetPartition struct {
 	Config string `json:"config,omitzero"`
 }
 
+// NodeSetAutoscaling defines the demand-driven autoscaling configuration for the NodeSet.
+type NodeSetAutoscaling struct {
+	// Enabled will scale the NodeSet replicas based on pending and running
+	// Slurm jobs. While enabled, the operator manages `replicas`.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// MinReplicas is the lower bound of replicas the autoscaler may scale down to.
+	// +optional
+	// +default:=0
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper bound of replicas the autoscaler may scale up to.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// Partitions is the list of Slurm partitions whose jobs count towards the
+	// demand of this NodeSet. Defaults to the NodeSet partition.
+	// +nullable
+	// +optional
+	Partitions []string `json:"partitions,omitempty"`
+
+	// ScaleUpStabilizationWindow is the duration demand must be sustained
+	// before replicas are scaled up.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	// +kubebuilder:default:="30s"
+	ScaleUpStabilizationWindow metav1.Duration `json:"scaleUpStabilizationWindow,omitempty"`
+
+	// ScaleDownStabilizationWindow is the duration demand must be reduced
+	// before replicas are scaled down. Only IDLE Slurm nodes are scaled down.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	// +kubebuilder:default:="5m"
+	ScaleDownStabilizationWindow metav1.Duration `json:"scaleDownStabilizationWindow,omitempty"`
+}
+
+// NodeSetPowerSaving defines the Slurm power saving configuration for the NodeSet.
+type NodeSetPowerSaving struct {
+	// Enabled will register `replicas` nodes in Slurm with `State=CLOUD`.
+	// A NodeSet pod is created when slurmctld resumes its node, and deleted
+	// when slurmctld suspends its node. Requires the NodeSet partition.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// SuspendTime is the duration a node must be idle before slurmctld
+	// suspends it. Applied to the NodeSet partition.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_SuspendTime
+	// +optional
+	// +kubebuilder:default:="10m"
+	SuspendTime metav1.Duration `json:"suspendTime,omitempty"`
+}
+
+// NodeSetDrainPolicy defines how draining Slurm nodes of the NodeSet are bounded.
+type NodeSetDrainPolicy struct {
+	// MaxDrainDuration is the duration a Slurm node may remain draining before
+	// the EscalationAction is taken on its running jobs. Running jobs whose
+	// time limit ends within the duration are not escalated.
+	// If unset, the NodeSet waits indefinitely for the Slurm node to drain.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	MaxDrainDuration metav1.Duration `json:"maxDrainDuration,omitzero"`
+
+	// EscalationAction is the action taken on the running jobs of a Slurm node
+	// which did not drain within the MaxDrainDuration.
+	// +optional
+	EscalationAction DrainEscalationActionType `json:"escalationAction,omitempty"`
+}
+
+// DrainEscalationActionType is a string enumeration of actions taken on running
+// jobs when a Slurm node did not drain in time.
+// +enum
+// +kubebuilder:validation:Enum:=Wait;Requeue;Cancel
+type DrainEscalationActionType string
+
+const (
+	// DrainEscalationActionWait keeps waiting for the running jobs to complete,
+	// reporting that the drain exceeded its MaxDrainDuration.
+	DrainEscalationActionWait DrainEscalationActionType = "Wait"
+
+	// DrainEscalationActionRequeue sets the Slurm node DOWN, such that Slurm
+	// requeues its running batch jobs. Jobs which cannot be requeued are terminated.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_JobRequeue
+	DrainEscalationActionRequeue DrainEscalationActionType = "Requeue"
+
+	// DrainEscalationActionCancel cancels the running jobs of the Slurm node.
+	DrainEscalationActionCancel DrainEscalationActionType = "Cancel"
+)
+
+// NodeSetNodeHealthPolicy defines how the Slurm node health is reflected onto
+// Kubernetes nodes.
+type NodeSetNodeHealthPolicy struct {
+	// Enabled sets the `SlurmNodeUnhealthy` condition on the Kubernetes node of
+	// a pod whose Slurm node was set DOWN or DRAIN outside of the operator, with
+	// the Slurm reason as message. It is removed once Slurm clears the state.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_HealthCheckProgram
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// Action is additionally taken on the Kubernetes node while its Slurm node
+	// is unhealthy, and reverted once healthy.
+	// +optional
+	Action NodeHealthActionType `json:"action,omitempty"`
+}
+
+// NodeHealthActionType is a string enumeration of actions taken on Kubernetes
+// nodes whose Slurm node is unhealthy.
+// +enum
+// +kubebuilder:validation:Enum:=None;Taint;Cordon
+type NodeHealthActionType string
+
+const (
+	// NodeHealthActionNone only sets the node condition.
+	NodeHealthActionNone NodeHealthActionType = "None"
+
+	// NodeHealthActionTaint adds the `nodeset.slinky.slurm.net/slurm-unhealthy`
+	// NoSchedule taint to the node.
+	NodeHealthActionTaint NodeHealthActionType = "Taint"
+
+	// NodeHealthActionCordon cordons the node.
+	NodeHealthActionCordon NodeHealthActionType = "Cordon"
+)
+
+// NodeSetConditionDrainPolicy defines which Kubernetes node conditions drain
+// the Slurm nodes of the NodeSet.
+type NodeSetConditionDrainPolicy struct {
+	// Conditions are the Kubernetes node conditions which drain the Slurm node.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []NodeSetDrainCondition `json:"conditions,omitempty"`
+}
+
+// NodeSetDrainCondition defines a Kubernetes node condition which drains the
+// Slurm node.
+type NodeSetDrainCondition struct {
+	// Type is the type of the Kubernetes node condition.
+	// Ref: https://kubernetes.io/docs/reference/node/node-status/#condition
+	// +required
+	Type corev1.NodeConditionType `json:"type"`
+
+	// Status is the status of the condition which drains the Slurm node.
+	// +kubebuilder:validation:Enum:=True;False;Unknown
+	// +optional
+	Status corev1.ConditionStatus `json:"status,omitempty"`
+
+	// DrainAfter is the duration the condition must have had the Status before
+	// the Slurm node is drained, such that a flapping condition is ignored.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	DrainAfter metav1.Duration `json:"drainAfter,omitzero"`
+
+	// UndrainAfter is the duration the condition must have cleared before the
+	// Slurm node is undrained. A condition which is removed from the Kubernetes
+	// node is cleared immediately.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +optional
+	UndrainAfter metav1.Duration `json:"undrainAfter,omitzero"`
+}
+
+// NodeSetRemediationPolicy defines how pods of persistently failed Slurm nodes
+// are replaced.
+type NodeSetRemediationPolicy struct {
+	// Enabled deletes a pod whose Slurm node has been NOT_RESPONDING, FAIL, or
+	// INVALID_REG for the FailedDuration and has no running jobs, such that the
+	// pod is recreated.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// FailedDuration is the duration a Slurm node must have failed before its
+	// pod is replaced.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +kubebuilder:default:="10m"
+	// +optional
+	FailedDuration metav1.Duration `json:"failedDuration,omitzero"`
+
+	// MaxRemediations is the maximum number of pods replaced within the
+	// RemediationWindow, such that a cluster-wide problem does not replace
+	// every pod of the NodeSet.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default:=1
+	// +optional
+	MaxRemediations int32 `json:"maxRemediations,omitempty"`
+
+	// RemediationWindow is the duration over which MaxRemediations applies.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// +kubebuilder:default:="1h"
+	// +optional
+	RemediationWindow metav1.Duration `json:"remediationWindow,omitzero"`
+}
+
+// NodeSetGres defines how the Slurm node GRES of the NodeSet are derived.
+type NodeSetGres struct {
+	// Enabled will register the Slurm nodes with the GRES of their extended
+	// resource limits, unless `extraConf` defines `Gres`, and render a matching
+	// `gres.conf`. A `gres.conf` provided by the Controller `configFileRefs`
+	// takes precedence.
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// Resources maps Kubernetes extended resources to Slurm GRES.
+	// Defaults to `nvidia.com/gpu` and `amd.com/gpu` as the "gpu" GRES.
+	// +listType=map
+	// +listMapKey=resourceName
+	// +optional
+	Resources []NodeSetGresResource `json:"resources,omitempty"`
+
+	// AutoDetect is the mechanism slurmd uses to detect the GRES devices
+	// without a File (e.g. nvidia, nvml, rsmi, oneapi, nrt).
+	// If unset, those GRES are configured by count only.
+	// Ref: https://slurm.schedmd.com/gres.conf.html#OPT_AutoDetect
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[a-z]+$"
+	AutoDetect string `json:"autoDetect,omitempty"`
+}
+
+// NodeSetGresResource maps a Kubernetes extended resource to a Slurm GRES.
+type NodeSetGresResource struct {
+	// ResourceName is the name of the Kubernetes extended resource
+	// (e.g. `nvidia.com/gpu`).
+	// +required
+	ResourceName corev1.ResourceName `json:"resourceName"`
+
+	// Name is the name of the Slurm GRES (e.g. `gpu`).
+	// Ref: https://slurm.schedmd.com/gres.conf.html#OPT_Name
+	// +required
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9_]+$"
+	Name string `json:"name"`
+
+	// Type is the type of the Slurm GRES (e.g. `a100`).
+	// Ref: https://slurm.schedmd.com/gres.conf.html#OPT_Type
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9_.-]+$"
+	Type string `json:"type,omitempty"`
+
+	// File is the device file(s) of the Slurm GRES, as a hostlist expression
+	// (e.g. `/dev/nvidia[0-3]`). If set, AutoDetect is not used for this GRES.
+	// Ref: https://slurm.schedmd.com/gres.conf.html#OPT_File
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[^\\s]+$"
+	File string `json:"file,omitempty"`
+}
+
+// NodeSetCpuTopology defines how the Slurm node CPU topology of the NodeSet is derived.
+type NodeSetCpuTopology struct {
+	// Enabled will register the Slurm nodes with the Sockets, CoresPerSocket,
+	// and ThreadsPerCore of the Kubernetes node, unless `extraConf` defines
+	// them. When `oversubscribeNode` is set, the CPUs and memory of the node
+	// allocatable which exceed the pod limits are reserved by CoreSpecCount
+	// and MemSpecLimit.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_CoreSpecCount
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MemSpecLimit
+	// +default:=false
+	Enabled bool `json:"enabled"`
+
+	// Sockets is the number of sockets of the Slurm node.
+	// Takes precedence over the node label.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Sockets
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Sockets *int32 `json:"sockets,omitempty"`
+
+	// CoresPerSocket is the number of cores in a socket of the Slurm node.
+	// Takes precedence over the node label.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_CoresPerSocket
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CoresPerSocket *int32 `json:"coresPerSocket,omitempty"`
+
+	// ThreadsPerCore is the number of threads in a core of the Slurm node.
+	// Takes precedence over the node label.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_ThreadsPerCore
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ThreadsPerCore *int32 `json:"threadsPerCore,omitempty"`
+
+	// NodeLabels are the Kubernetes node labels the CPU topology is read from
+	// (e.g. labels published by Node Feature Discovery).
+	// +optional
+	NodeLabels NodeSetCpuTopologyLabels `json:"nodeLabels,omitzero"`
+}
+
+// NodeSetCpuTopologyLabels defines the Kubernetes node labels of the CPU topology.
+type NodeSetCpuTopologyLabels struct {
+	// Sockets is the node label of the number of sockets.
+	// Defaults to `nodeset.slinky.slurm.net/cpu-sockets`.
+	// +optional
+	Sockets string `json:"sockets,omitempty"`
+
+	// CoresPerSocket is the node label of the number of cores in a socket.
+	// Defaults to `nodeset.slinky.slurm.net/cpu-cores-per-socket`.
+	// +optional
+	CoresPerSocket string `json:"coresPerSocket,omitempty"`
+
+	// ThreadsPerCore is the node label of the number of threads in a core.
+	// Defaults to `nodeset.slinky.slurm.net/cpu-threads-per-core`.
+	// +optional
+	ThreadsPerCore string `json:"threadsPerCore,omitempty"`
+}
+
+// NodeSetFeatureLabel maps a Kubernetes node label to a Slurm node feature.
+type NodeSetFeatureLabel struct {
+	// Key is the Kubernetes node label key (e.g. `nvidia.com/gpu.product`).
+	// Nodes without the label do not get the feature.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Template is the Slurm node feature, where `{key}` and `{value}` are
+	// replaced by the label key and value (e.g. `gpu_{value}`).
+	// Defaults to `{value}`.
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[^,&|\\s]+$"
+	Template string `json:"template,omitempty"`
+}
+
 // NodeSetSsh defines SSH configuration for NodeSet worker pods.
 type NodeSetSsh struct {
 	// Enabled controls whether SSH access is enabled for this NodeSet.
@@ -188,7 +585,7 @@ type NodeSetSsh struct {
 // NodeSetUpdateStrategy indicates the strategy that the NodeSet
 // controller will be used to perform updates. It includes any additional
 // parameters necessary to perform the update for the indicated strategy.
-// +kubebuilder:validation:XValidation:rule="self.type != 'ScheduledUpdate' || (has(self.scheduledUpdate.startTime) && has(self.scheduledUpdate.duration))", message="scheduledUpdate.startTime and scheduledUpdate.duration are required when type is ScheduledUpdate"
+// +kubebuilder:validation:XValidation:rule="self.type != 'ScheduledUpdate' || ((has(self.scheduledUpdate.startTime) || has(self.scheduledUpdate.schedule)) && has(self.scheduledUpdate.duration))", message="scheduledUpdate.startTime or scheduledUpdate.schedule, and scheduledUpdate.duration are required when type is ScheduledUpdate"
 type NodeSetUpdateStrategy struct {
 	// Type indicates the type of the NodeSetUpdateStrategy.
 	// One of: RollingUpdate; OnDelete; ScheduledUpdate.
@@ -281,6 +678,38 @@ type RollingUpdateNodeSetStrategy struct {
 	// +optional
 	// +kubebuilder:default:="25%"
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// The maximum number of pods that can be created over the desired number
+	// of pods during the update. Surge pods are created at new ordinals, and
+	// old pods are only drained and deleted once replaced by updated pods whose
+	// Slurm nodes are IDLE, hence maxUnavailable is not used. Terminating pods
+	// count against the surge.
+	// Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up.
+	// Used only when `scalingMode=StatefulSet`. Defaults to 0 (no surge).
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// Partition indicates the ordinal at which the NodeSet should be partitioned
+	// for updates. During a rolling update, only pods with an ordinal greater
+	// than or equal to the partition are updated. Pods with an ordinal less than
+	// the partition remain at, and are recreated at, the current revision.
+	// Used only when `scalingMode=StatefulSet`. Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Partition *int32 `json:"partition,omitempty"`
+
+	// Paused indicates that the rolling update is paused. No pods are updated,
+	// and pods are created at the current revision, until it is resumed.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// CanarySelector selects the Kubernetes nodes whose pods are updated during
+	// a rolling update. Pods on other nodes remain at, and are created at, the
+	// current revision. If nil, pods on all nodes are updated.
+	// Used only when `scalingMode=DaemonSet`.
+	// +optional
+	CanarySelector *metav1.LabelSelector `json:"canarySelector,omitempty"`
 }
 
 // ScheduledUpdateNodeSetStrategy is used to communicate parameters for
@@ -288,11 +717,22 @@ type RollingUpdateNodeSetStrategy struct {
 // reservation for the update timeframe
 type ScheduledUpdateNodeSetStrategy struct {
 	// An RFC3339 timestamp at which to begin NodeSet updates.
+	// Ignored when a schedule is set.
 	// Ref: https://datatracker.ietf.org/doc/html/rfc3339#section-5.8
 	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_StartTime_1
 	// +optional
 	StartTime metav1.Time `json:"startTime,omitempty"`
 
+	// A cron schedule of recurring maintenance windows in which to conduct
+	// NodeSet updates, each lasting for the duration. A Slurm reservation is
+	// made for one window at a time, and pods are only updated within it.
+	// The schedule is evaluated in UTC, unless prefixed with `CRON_TZ=`.
+	// The day of week may have a `#N` suffix to only match the Nth such
+	// weekday of the month (e.g. "0 2 * * SUN#1" is the first Sunday at 02:00).
+	// Ref: https://pkg.go.dev/github.com/robfig/cron/v3#hdr-CRON_Expression_Format
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
 	// The duration for which NodeSet updates should be conducted.
 	// Ref: https://pkg.go.dev/time#ParseDuration
 	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Duration
@@ -335,6 +775,22 @@ type NodeSetStatus struct {
 	// +optional
 	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
 
+	// Total number of non-terminated pods targeted by this NodeSet that are at
+	// the currentRevision.
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+
+	// currentRevision is the "controller-revision-hash" of the revision which
+	// pods were at before the rolling update. It becomes the updateRevision once
+	// all pods are updated.
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
+
+	// updateRevision is the "controller-revision-hash" of the revision which
+	// pods are updated to.
+	// +optional
+	UpdateRevision string `json:"updateRevision,omitempty"`
+
 	// readyReplicas is the number of pods targeted by this NodeSet with a Ready Condition.
 	// +optional
 	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
@@ -378,6 +834,26 @@ type NodeSetStatus struct {
 	// +optional
 	SlurmDrain int32 `json:"slurmDrain,omitempty"`
 
+	// The number of Slurm nodes which are pre-registered in the FUTURE state
+	// and are not yet backed by a NodeSet pod.
+	// +optional
+	SlurmPreRegistered int32 `json:"slurmPreRegistered,omitempty"`
+
+	// The number of running Slurm jobs allocated to the Slurm nodes of the
+	// NodeSet pods.
+	// +optional
+	SlurmRunningJobs int32 `json:"slurmRunningJobs,omitempty"`
+
+	// The number of pending Slurm jobs submitted to the partitions of the Slurm
+	// nodes of the NodeSet pods.
+	// +optional
+	SlurmPendingJobs int32 `json:"slurmPendingJobs,omitempty"`
+
+	// The number of non-terminated pods over the desired number of pods,
+	// created as surge pods during a rolling update.
+	// +optional
+	SurgeReplicas int32 `json:"surgeReplicas,omitempty"`
+
 	// observedGeneration is the most recent generation observed for this NodeSet. It corresponds to the
 	// NodeSet's generation, which is updated on mutation by the API Server.
 	// +optional
@@ -406,10 +882,107 @@ type NodeSetStatus struct {
 	// +nullable
 	OrdinalToNode map[string]string `json:"ordinalToNode,omitempty"`
 
+	// Autoscaling is the last observed state of the NodeSet autoscaler.
+	// +optional
+	Autoscaling *NodeSetAutoscalingStatus `json:"autoscaling,omitempty"`
+
+	// Remediation is the last observed state of the NodeSet RemediationPolicy.
+	// +optional
+	Remediation *NodeSetRemediationStatus `json:"remediation,omitempty"`
+
+	// MaintenanceWindow is the active maintenance window of the ScheduledUpdate
+	// schedule, or else the next one.
+	// +optional
+	MaintenanceWindow *NodeSetMaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// NodeCpuTopology is the derived CPU topology of the Slurm nodes, by Slurm node name.
+	// +optional
+	NodeCpuTopology map[string]NodeSetNodeCpuTopology `json:"nodeCpuTopology,omitempty"`
+
 	// Add Selector to status for HPA support in the scale subresource.
 	Selector string `json:"selector"`
 }
 
+// NodeSetMaintenanceWindow defines a maintenance window of the NodeSet.
+type NodeSetMaintenanceWindow struct {
+	// StartTime is when the maintenance window begins.
+	StartTime metav1.Time `json:"startTime"`
+
+	// EndTime is when the maintenance window ends.
+	EndTime metav1.Time `json:"endTime"`
+
+	// Active is true when the maintenance window has begun.
+	// +optional
+	Active bool `json:"active,omitempty"`
+}
+
+// NodeSetNodeCpuTopology is the CPU topology and specialized resources a Slurm
+// node was registered with.
+type NodeSetNodeCpuTopology struct {
+	// Sockets is the number of sockets of the Slurm node.
+	// +optional
+	Sockets int32 `json:"sockets,omitempty"`
+
+	// CoresPerSocket is the number of cores in a socket of the Slurm node.
+	// +optional
+	CoresPerSocket int32 `json:"coresPerSocket,omitempty"`
+
+	// ThreadsPerCore is the number of threads in a core of the Slurm node.
+	// +optional
+	ThreadsPerCore int32 `json:"threadsPerCore,omitempty"`
+
+	// CoreSpecCount is the number of cores reserved for system use.
+	// +optional
+	CoreSpecCount int32 `json:"coreSpecCount,omitempty"`
+
+	// MemSpecLimit is the amount of memory, in MiB, reserved for system use.
+	// +optional
+	MemSpecLimit int64 `json:"memSpecLimit,omitempty"`
+}
+
+// NodeSetAutoscalingStatus defines the observed state of the NodeSet autoscaler.
+type NodeSetAutoscalingStatus struct {
+	// DesiredReplicas is the number of replicas last recommended by the autoscaler.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+
+	// The number of pending Slurm jobs in the autoscaled partitions.
+	// +optional
+	PendingJobs int32 `json:"pendingJobs,omitempty"`
+
+	// The number of Slurm nodes requested by the pending Slurm jobs.
+	// +optional
+	PendingNodes int32 `json:"pendingNodes,omitempty"`
+
+	// The number of running Slurm jobs in the autoscaled partitions.
+	// +optional
+	RunningJobs int32 `json:"runningJobs,omitempty"`
+
+	// LastScaleTime is the last time the autoscaler changed the replicas.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+}
+
+// NodeSetRemediationStatus defines the observed state of the NodeSet RemediationPolicy.
+type NodeSetRemediationStatus struct {
+	// Remediations is the total number of pods replaced by the RemediationPolicy.
+	// +optional
+	Remediations int32 `json:"remediations,omitempty"`
+
+	// WindowRemediations is the number of pods replaced within the current
+	// RemediationWindow.
+	// +optional
+	WindowRemediations int32 `json:"windowRemediations,omitempty"`
+
+	// WindowStartTime is when the current RemediationWindow began.
+	// +optional
+	WindowStartTime *metav1.Time `json:"windowStartTime,omitempty"`
+
+	// LastRemediationTime is the last time a pod was replaced.
+	// +optional
+	LastRemediationTime *metav1.Time `json:"lastRemediationTime,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=nodesets;nss;slurmd
@@ -417,11 +990,14 @@ type NodeSetStatus struct {
 // +kubebuilder:printcolumn:name="DESIRED",type="integer",JSONPath=".status.desired",priority=0,description="The number of nodes match the node selector and tolerations (DaemonSet mode) or replicas (StatefulSet mode)."
 // +kubebuilder:printcolumn:name="REPLICAS",type="integer",JSONPath=".status.replicas",priority=0,description="The current number of pods."
 // +kubebuilder:printcolumn:name="UPDATED",type="integer",JSONPath=".status.updatedReplicas",priority=0,description="The number of pods updated."
+// +kubebuilder:printcolumn:name="SURGE",type="integer",JSONPath=".status.surgeReplicas",priority=1,description="The number of surge pods over the desired number of pods."
 // +kubebuilder:printcolumn:name="READY",type="integer",JSONPath=".status.readyReplicas",priority=0,description="The number of pods ready."
 // +kubebuilder:printcolumn:name="IDLE",type="integer",JSONPath=".status.slurmIdle",priority=1,description="The number of IDLE slurm nodes."
 // +kubebuilder:printcolumn:name="ALLOCATED",type="integer",JSONPath=".status.slurmAllocated",priority=1,description="The number of ALLOCATED/MIXED slurm nodes."
 // +kubebuilder:printcolumn:name="DOWN",type="integer",JSONPath=".status.slurmDown",priority=1,description="The number of DOWN slurm nodes."
 // +kubebuilder:printcolumn:name="DRAIN",type="integer",JSONPath=".status.slurmDrain",priority=1,description="The number of DRAIN slurm nodes."
+// +kubebuilder:printcolumn:name="FUTURE",type="integer",JSONPath=".status.slurmPreRegistered",priority=1,description="The number of pre-registered FUTURE slurm nodes not yet backed by a pod."
+// +kubebuilder:printcolumn:name="WINDOW",type="string",JSONPath=".status.maintenanceWindow.startTime",priority=1,description="The start of the active or next maintenance window."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // NodeSet is the Schema for the nodesets API
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PartitionKind = "Partition"
+)
+
+var (
+	PartitionGVK        = GroupVersion.WithKind(PartitionKind)
+	PartitionAPIVersion = GroupVersion.String()
+)
+
+// PartitionSpec defines the desired state of Partition
+type PartitionSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// +required
+	ControllerRef corev1.LocalObjectReference `json:"controllerRef"`
+
+	// NodeSetSelector selects the NodeSets, by label, whose Slurm nodes are
+	// members of the partition. An empty selector selects all NodeSets of the
+	// Controller; a null selector selects none.
+	// +optional
+	NodeSetSelector *metav1.LabelSelector `json:"nodeSetSelector,omitempty"`
+
+	// State of the partition.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_State_1
+	// +optional
+	// +default:="UP"
+	State PartitionState `json:"state,omitempty"`
+
+	// MaxTime is the maximum run time limit for jobs, in a Slurm time format
+	// (e.g. `60`, `1-00:00:00`, `UNLIMITED`).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_MaxTime
+	// +optional
+	// +kubebuilder:validation:Pattern:="^(UNLIMITED|INFINITE|[0-9][0-9:-]*)$"
+	MaxTime string `json:"maxTime,omitempty"`
+
+	// DefaultTime is the run time limit for jobs which do not request one, in
+	// a Slurm time format (e.g. `60`, `1-00:00:00`).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_DefaultTime
+	// +optional
+	// +kubebuilder:validation:Pattern:="^(UNLIMITED|INFINITE|[0-9][0-9:-]*)$"
+	DefaultTime string `json:"defaultTime,omitempty"`
+
+	// AllowAccounts is the list of Slurm accounts which may run jobs in the
+	// partition. Defaults to all accounts.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_AllowAccounts
+	// +optional
+	// +listType=set
+	AllowAccounts []string `json:"allowAccounts,omitempty"`
+
+	// PriorityTier of the partition. Jobs in higher tier partitions are
+	// scheduled, and may preempt, before those in lower tier partitions.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_PriorityTier
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65533
+	PriorityTier *int32 `json:"priorityTier,omitempty"`
+
+	// OverSubscribe controls the ability of the partition to execute more
+	// than one job at a time on each resource (e.g. `NO`, `FORCE:4`).
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_OverSubscribe
+	// +optional
+	// +kubebuilder:validation:Pattern:="^(NO|EXCLUSIVE|YES|FORCE)(:[0-9]+)?$"
+	OverSubscribe string `json:"overSubscribe,omitempty"`
+
+	// Config is added to the end of the partition line, taking precedence
+	// over the typed options.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[^\\n]+$"
+	Config string `json:"config,omitzero"`
+}
+
+// PartitionState is the state of a Slurm partition.
+// +enum
+// +kubebuilder:validation:Enum:=UP;DOWN;DRAIN;INACTIVE
+type PartitionState string
+
+const (
+	// PartitionStateUp allocates and runs jobs.
+	PartitionStateUp PartitionState = "UP"
+
+	// PartitionStateDown queues jobs, which are neither allocated nor run.
+	PartitionStateDown PartitionState = "DOWN"
+
+	// PartitionStateDrain rejects new jobs, while queued jobs are allocated
+	// and run.
+	PartitionStateDrain PartitionState = "DRAIN"
+
+	// PartitionStateInactive neither accepts nor allocates jobs.
+	PartitionStateInactive PartitionState = "INACTIVE"
+)
+
+// PartitionStatus defines the observed state of Partition
+type PartitionStatus struct {
+	// NodeSets is the list of NodeSets selected by the partition.
+	// +optional
+	// +listType=set
+	NodeSets []string `json:"nodeSets,omitempty"`
+
+	// Nodes is the number of Slurm nodes in the partition.
+	// +optional
+	Nodes int32 `json:"nodes,omitempty"`
+
+	// RunningJobs is the number of running Slurm jobs in the partition.
+	// +optional
+	RunningJobs int32 `json:"runningJobs,omitempty"`
+
+	// PendingJobs is the number of pending Slurm jobs in the partition.
+	// +optional
+	PendingJobs int32 `json:"pendingJobs,omitempty"`
+
+	// Represents the latest available observations of a Partition's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=partitions;part
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".spec.state",description="The state of the partition."
+// +kubebuilder:printcolumn:name="NODES",type="integer",JSONPath=".status.nodes",description="The number of Slurm nodes in the partition."
+// +kubebuilder:printcolumn:name="RUNNING",type="integer",JSONPath=".status.runningJobs",description="The number of running Slurm jobs in the partition."
+// +kubebuilder:printcolumn:name="PENDING",type="integer",JSONPath=".status.pendingJobs",description="The number of pending Slurm jobs in the partition."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Partition is the Schema for the partitions API
+type Partition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PartitionSpec   `json:"spec,omitempty"`
+	Status PartitionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PartitionList contains a list of Partition
+type PartitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Partition `json:"items"`
+}
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *QOS) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// QOSName returns the name of the Slurm QOS.
+func (o *QOS) QOSName() string {
+	if o.Spec.QOSName != "" {
+		return o.Spec.QOSName
+	}
+	return o.Name
+}
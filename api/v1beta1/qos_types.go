@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	QOSKind = "QOS"
+)
+
+var (
+	QOSGVK        = GroupVersion.WithKind(QOSKind)
+	QOSAPIVersion = GroupVersion.String()
+)
+
+// QOSSpec defines the desired state of QOS
+// +kubebuilder:validation:XValidation:rule="has(self.controllerRef) != has(self.accountingRef)", message="exactly one of controllerRef or accountingRef must be set"
+type QOSSpec struct {
+	SlurmdbRef `json:",inline"`
+
+	// QOSName is the name of the Slurm QOS.
+	// Defaults to the name of the QOS.
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[a-z0-9_.-]+$"
+	QOSName string `json:"qosName,omitempty"`
+
+	// Description of the Slurm QOS.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Description_1
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Priority of the QOS, which is factored into the job priority.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Priority
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority *int32 `json:"priority,omitempty"`
+
+	// List of flags of the QOS (e.g. `DenyOnLimit`, `NoReserve`).
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Flags
+	// +optional
+	// +listType=set
+	Flags []string `json:"flags,omitempty"`
+
+	// Preempt is the list of QOS which jobs of this QOS may preempt.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Preempt
+	// +optional
+	// +listType=set
+	Preempt []string `json:"preempt,omitempty"`
+
+	// MaxWallDurationPerJob is the maximum run time of each job.
+	// It is truncated to minutes.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_MaxWallDurationPerJob
+	// +optional
+	MaxWallDurationPerJob *metav1.Duration `json:"maxWallDurationPerJob,omitempty"`
+}
+
+// QOSStatus defines the observed state of QOS
+type QOSStatus struct {
+	// Represents the latest available observations of a QOS's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=qos,shortName=qos
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".spec.priority",description="The priority of the QOS."
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Whether the QOS is synced into slurmdbd."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// QOS is the Schema for the qos API
+type QOS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   QOSSpec   `json:"spec,omitempty"`
+	Status QOSStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// QOSList contains a list of QOS
+type QOSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QOS `json:"items"`
+}
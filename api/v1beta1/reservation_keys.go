@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *Reservation) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// ReservationName returns the name of the Slurm reservation.
+func (o *Reservation) ReservationName() string {
+	return o.Name
+}
+
+func (o *Reservation) Duration() time.Duration {
+	duration := time.Hour
+	if o.Spec.Duration.Duration > 0 {
+		duration = o.Spec.Duration.Duration
+	}
+	return duration
+}
+
+// EndTime returns the time at which the reservation ends.
+func (o *Reservation) EndTime() time.Time {
+	return o.Spec.StartTime.Add(o.Duration())
+}
+
+// RecurrencePeriod returns the period at which the reservation recurs, by its
+// recurring flags (e.g. `DAILY`), or zero if it does not recur.
+func (o *Reservation) RecurrencePeriod() time.Duration {
+	var period time.Duration
+	for _, flag := range o.Spec.Flags {
+		switch strings.ToUpper(flag) {
+		case "HOURLY":
+			period = max(period, time.Hour)
+		case "DAILY", "WEEKDAY", "WEEKEND":
+			period = max(period, 24*time.Hour)
+		case "WEEKLY":
+			period = max(period, 7*24*time.Hour)
+		}
+	}
+	return period
+}
+
+// HasEnded returns true if the reservation ended before the given time. A
+// recurring reservation never ends, as Slurm moves it to its next occurrence.
+func (o *Reservation) HasEnded(now time.Time) bool {
+	return o.RecurrencePeriod() == 0 && o.EndTime().Before(now)
+}
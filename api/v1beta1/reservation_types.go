@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ReservationKind = "Reservation"
+)
+
+var (
+	ReservationGVK        = GroupVersion.WithKind(ReservationKind)
+	ReservationAPIVersion = GroupVersion.String()
+)
+
+// ReservationSpec defines the desired state of Reservation
+// +kubebuilder:validation:XValidation:rule="has(self.users) || has(self.accounts)", message="users or accounts must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.nodeSets) || has(self.podSelector)", message="nodeSets or podSelector must be set"
+type ReservationSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// +required
+	ControllerRef corev1.LocalObjectReference `json:"controllerRef"`
+
+	// NodeSets is the list of NodeSet names whose Slurm nodes may be reserved.
+	// +optional
+	// +listType=set
+	NodeSets []string `json:"nodeSets,omitempty"`
+
+	// PodSelector selects the NodeSet pods, by label, whose Slurm nodes may be
+	// reserved. When NodeSets is also set, the pods must match both.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// NodeCount is the number of Slurm nodes to reserve among the selected
+	// ones. Defaults to all selected Slurm nodes.
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_NodeCnt
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	NodeCount *int32 `json:"nodeCount,omitempty"`
+
+	// Users is the list of Slurm users which may use the reservation.
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Users
+	// +optional
+	// +listType=set
+	Users []string `json:"users,omitempty"`
+
+	// Accounts is the list of Slurm accounts which may use the reservation.
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Accounts
+	// +optional
+	// +listType=set
+	Accounts []string `json:"accounts,omitempty"`
+
+	// List of flags of the Slurm reservation (e.g. `IGNORE_JOBS`, `DAILY`).
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Flags
+	// +optional
+	// +listType=set
+	Flags []string `json:"flags,omitempty"`
+
+	// An RFC3339 timestamp at which the reservation starts.
+	// Ref: https://datatracker.ietf.org/doc/html/rfc3339#section-5.8
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_StartTime_1
+	// +required
+	StartTime metav1.Time `json:"startTime"`
+
+	// The duration of the reservation.
+	// Ref: https://pkg.go.dev/time#ParseDuration
+	// Ref: https://slurm.schedmd.com/scontrol.html#OPT_Duration
+	// +optional
+	// +kubebuilder:default:="1h"
+	Duration metav1.Duration `json:"duration,omitempty"`
+}
+
+// ReservationState is the state of a Slurm reservation.
+// +enum
+type ReservationState string
+
+const (
+	// ReservationStatePending indicates the reservation has not started yet.
+	ReservationStatePending ReservationState = "Pending"
+
+	// ReservationStateActive indicates the reservation is running.
+	ReservationStateActive ReservationState = "Active"
+
+	// ReservationStateEnded indicates the reservation has ended, and was
+	// removed from Slurm.
+	ReservationStateEnded ReservationState = "Ended"
+)
+
+// ReservationStatus defines the observed state of Reservation
+type ReservationStatus struct {
+	// State of the Slurm reservation.
+	// +optional
+	State ReservationState `json:"state,omitempty"`
+
+	// Nodes is the hostlist expression of the Slurm nodes assigned to the reservation.
+	// +optional
+	Nodes string `json:"nodes,omitempty"`
+
+	// NodeCount is the number of Slurm nodes assigned to the reservation.
+	// +optional
+	NodeCount int32 `json:"nodeCount,omitempty"`
+
+	// StartTime is the time at which the Slurm reservation starts.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time at which the Slurm reservation ends.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Represents the latest available observations of a Reservation's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=reservations;resv
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state",description="The state of the reservation."
+// +kubebuilder:printcolumn:name="START",type="date",JSONPath=".status.startTime",description="The start time of the reservation."
+// +kubebuilder:printcolumn:name="END",type="date",JSONPath=".status.endTime",description="The end time of the reservation."
+// +kubebuilder:printcolumn:name="NODES",type="integer",JSONPath=".status.nodeCount",description="The number of Slurm nodes in the reservation."
+// +kubebuilder:printcolumn:name="NODELIST",type="string",JSONPath=".status.nodes",priority=1,description="The Slurm nodes in the reservation."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Reservation is the Schema for the reservations API
+type Reservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReservationSpec   `json:"spec,omitempty"`
+	Status ReservationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReservationList contains a list of Reservation
+type ReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Reservation `json:"items"`
+}
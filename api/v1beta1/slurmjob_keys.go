@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *SlurmJob) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// JobName returns the name of the Slurm job.
+func (o *SlurmJob) JobName() string {
+	if o.Spec.Options.Name != "" {
+		return o.Spec.Options.Name
+	}
+	return o.Name
+}
+
+// JobComment returns the comment of the Slurm job, which identifies the
+// SlurmJob which submitted it.
+func (o *SlurmJob) JobComment() string {
+	return SlurmJobPrefix + string(o.UID)
+}
+
+// TTLAfterFinished returns the lifetime of the SlurmJob once finished, and
+// whether it is limited.
+func (o *SlurmJob) TTLAfterFinished() (time.Duration, bool) {
+	if o.Spec.TTLSecondsAfterFinished == nil {
+		return 0, false
+	}
+	return time.Duration(*o.Spec.TTLSecondsAfterFinished) * time.Second, true
+}
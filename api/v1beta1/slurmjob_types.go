@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmJobKind = "SlurmJob"
+)
+
+var (
+	SlurmJobGVK        = GroupVersion.WithKind(SlurmJobKind)
+	SlurmJobAPIVersion = GroupVersion.String()
+)
+
+// SlurmJobSpec defines the desired state of SlurmJob
+type SlurmJobSpec struct {
+	// controllerRef is a reference to the Controller CR to which this has membership.
+	// +required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="controllerRef is immutable"
+	ControllerRef corev1.LocalObjectReference `json:"controllerRef"`
+
+	// Username is the Slurm user whom the job is submitted as. The operator
+	// issues a JWT for the user, signed with the `auth/jwt` key of the
+	// Controller. The user must exist in the slurmctld container, and be
+	// allowed for the namespace by the webhook.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="!(self in ['root', 'slurm'])", message="username must not be a privileged Slurm user"
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="username is immutable"
+	Username string `json:"username"`
+
+	// Script is the batch script of the job.
+	// +required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="script is immutable"
+	Script SlurmJobScript `json:"script"`
+
+	// Options of the job, as would otherwise be given to `sbatch`.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="options are immutable"
+	Options SlurmJobOptions `json:"options,omitzero"`
+
+	// TTLSecondsAfterFinished limits the lifetime of a SlurmJob that has
+	// finished execution (either Complete or Failed). If set, the SlurmJob is
+	// deleted once it has been finished for that many seconds. If unset, the
+	// SlurmJob is not automatically deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// SlurmJobScript is the source of the batch script of a job.
+// +kubebuilder:validation:XValidation:rule="has(self.inline) != has(self.configMapKeyRef)", message="exactly one of inline or configMapKeyRef must be set"
+type SlurmJobScript struct {
+	// Inline is the batch script itself.
+	// +optional
+	Inline *string `json:"inline,omitempty"`
+
+	// ConfigMapKeyRef selects the key of a ConfigMap holding the batch script.
+	// The ConfigMap is read once, when the job is submitted.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// SlurmJobOptions are the options of a job.
+// Ref: https://slurm.schedmd.com/sbatch.html
+type SlurmJobOptions struct {
+	// Name of the job. Defaults to the name of the SlurmJob.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_job-name
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Partition to submit the job to.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_partition
+	// +optional
+	Partition string `json:"partition,omitempty"`
+
+	// Account to charge the job to.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_account
+	// +optional
+	Account string `json:"account,omitempty"`
+
+	// QOS of the job.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_qos
+	// +optional
+	QOS string `json:"qos,omitempty"`
+
+	// Nodes is the minimum, or the range, of nodes of the job (e.g. `2`, `2-4`).
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_nodes
+	// +optional
+	Nodes string `json:"nodes,omitempty"`
+
+	// Tasks is the number of tasks of the job.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_ntasks
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Tasks *int32 `json:"tasks,omitempty"`
+
+	// CPUsPerTask is the number of CPUs of each task.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_cpus-per-task
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CPUsPerTask *int32 `json:"cpusPerTask,omitempty"`
+
+	// MemoryPerNode is the real memory required per node.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_mem
+	// +optional
+	MemoryPerNode *resource.Quantity `json:"memoryPerNode,omitempty"`
+
+	// TimeLimit is the wall time of the job. It is truncated to minutes.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_time
+	// +optional
+	TimeLimit *metav1.Duration `json:"timeLimit,omitempty"`
+
+	// Constraints are the node features required by the job.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_constraint
+	// +optional
+	Constraints string `json:"constraints,omitempty"`
+
+	// WorkingDirectory of the batch script.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_chdir
+	// +optional
+	// +default:="/tmp"
+	WorkingDirectory string `json:"workingDirectory,omitempty"`
+
+	// Environment of the job. Defaults to a `PATH` of the usual system
+	// directories.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_export
+	// +optional
+	Environment map[string]string `json:"environment,omitempty"`
+
+	// StandardOutput is the path to which the standard output of the batch
+	// script is written.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_output
+	// +optional
+	StandardOutput string `json:"standardOutput,omitempty"`
+
+	// StandardError is the path to which the standard error of the batch
+	// script is written.
+	// Ref: https://slurm.schedmd.com/sbatch.html#OPT_error
+	// +optional
+	StandardError string `json:"standardError,omitempty"`
+}
+
+// SlurmJobStatus defines the observed state of SlurmJob
+type SlurmJobStatus struct {
+	// JobID is the ID of the Slurm job, once submitted.
+	// +optional
+	JobID *int32 `json:"jobId,omitempty"`
+
+	// State of the Slurm job (e.g. `PENDING`, `RUNNING`, `COMPLETED`).
+	// Ref: https://slurm.schedmd.com/job_state_codes.html
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// ExitCode is the exit code of the batch script, once finished.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Nodes is the hostlist expression of the Slurm nodes allocated to the job.
+	// +optional
+	Nodes string `json:"nodes,omitempty"`
+
+	// SubmitTime is the time at which the job was submitted.
+	// +optional
+	SubmitTime *metav1.Time `json:"submitTime,omitempty"`
+
+	// StartTime is the time at which the job started running.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time at which the job finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Represents the latest available observations of a SlurmJob's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=slurmjobs;sjob
+// +kubebuilder:printcolumn:name="JOBID",type="integer",JSONPath=".status.jobId",description="The ID of the Slurm job."
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state",description="The state of the Slurm job."
+// +kubebuilder:printcolumn:name="EXITCODE",type="integer",JSONPath=".status.exitCode",description="The exit code of the Slurm job."
+// +kubebuilder:printcolumn:name="NODELIST",type="string",JSONPath=".status.nodes",description="The Slurm nodes allocated to the job.",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmJob is the Schema for the slurmjobs API
+type SlurmJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmJobSpec   `json:"spec,omitempty"`
+	Status SlurmJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmJobList contains a list of SlurmJob
+type SlurmJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmJob `json:"items"`
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (o *SlurmUser) Key() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+}
+
+// UserName returns the name of the Slurm user.
+func (o *SlurmUser) UserName() string {
+	if o.Spec.UserName != "" {
+		return o.Spec.UserName
+	}
+	return o.Name
+}
+
+// AccountNames returns the sorted and unique names of the Slurm accounts the
+// user is associated with, including the default account.
+func (o *SlurmUser) AccountNames() []string {
+	accounts := make([]string, 0, len(o.Spec.Accounts)+1)
+	accounts = append(accounts, o.Spec.DefaultAccount)
+	accounts = append(accounts, o.Spec.Accounts...)
+	slices.Sort(accounts)
+	return slices.Compact(accounts)
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SlurmUserKind = "SlurmUser"
+)
+
+var (
+	SlurmUserGVK        = GroupVersion.WithKind(SlurmUserKind)
+	SlurmUserAPIVersion = GroupVersion.String()
+)
+
+// SlurmUserSpec defines the desired state of SlurmUser
+// +kubebuilder:validation:XValidation:rule="has(self.controllerRef) != has(self.accountingRef)", message="exactly one of controllerRef or accountingRef must be set"
+type SlurmUserSpec struct {
+	SlurmdbRef `json:",inline"`
+
+	// UserName is the name of the Slurm user.
+	// Defaults to the name of the SlurmUser.
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[a-z_][a-z0-9_.-]*$"
+	UserName string `json:"userName,omitempty"`
+
+	// DefaultAccount is the account of jobs which do not request one.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_DefaultAccount
+	// +required
+	DefaultAccount string `json:"defaultAccount"`
+
+	// Accounts is the list of additional Slurm accounts the user is
+	// associated with, besides the default account.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_Account
+	// +optional
+	// +listType=set
+	Accounts []string `json:"accounts,omitempty"`
+
+	// AdminLevel of the Slurm user.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_AdminLevel
+	// +optional
+	// +default:="None"
+	AdminLevel SlurmUserAdminLevel `json:"adminLevel,omitempty"`
+
+	// QOS is the list of QOS which the user associations may use.
+	// Defaults to those of the parent account associations.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_QosLevel
+	// +optional
+	// +listType=set
+	QOS []string `json:"qos,omitempty"`
+
+	// DefaultQOS is the QOS of jobs which do not request one.
+	// Ref: https://slurm.schedmd.com/sacctmgr.html#OPT_DefaultQOS
+	// +optional
+	DefaultQOS string `json:"defaultQos,omitempty"`
+}
+
+// SlurmUserAdminLevel is the administrative level of a Slurm user.
+// +enum
+// +kubebuilder:validation:Enum:=None;Operator;Administrator
+type SlurmUserAdminLevel string
+
+const (
+	SlurmUserAdminLevelNone          SlurmUserAdminLevel = "None"
+	SlurmUserAdminLevelOperator      SlurmUserAdminLevel = "Operator"
+	SlurmUserAdminLevelAdministrator SlurmUserAdminLevel = "Administrator"
+)
+
+// SlurmUserStatus defines the observed state of SlurmUser
+type SlurmUserStatus struct {
+	// Clusters is the list of Slurm clusters the user is associated with.
+	// +optional
+	// +listType=set
+	Clusters []string `json:"clusters,omitempty"`
+
+	// Represents the latest available observations of a SlurmUser's current state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=slurmusers;suser
+// +kubebuilder:printcolumn:name="ACCOUNT",type="string",JSONPath=".spec.defaultAccount",description="The default account of the Slurm user."
+// +kubebuilder:printcolumn:name="ADMIN",type="string",JSONPath=".spec.adminLevel",description="The admin level of the Slurm user."
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type==\"Synced\")].status",description="Whether the user is synced into slurmdbd."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmUser is the Schema for the slurmusers API
+type SlurmUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmUserSpec   `json:"spec,omitempty"`
+	Status SlurmUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmUserList contains a list of SlurmUser
+type SlurmUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlurmUser `json:"items"`
+}
@@ -7,11 +7,16 @@ package v1beta1
 const (
 	SlinkyPrefix = "slinky.slurm.net/"
 
-	ControllerPrefix = "controller." + SlinkyPrefix
-	NodeSetPrefix    = "nodeset." + SlinkyPrefix
-	LoginSetPrefix   = "loginset." + SlinkyPrefix
-	TopologyPrefix   = "topology." + SlinkyPrefix
-	FeaturesPrefix   = "features." + SlinkyPrefix
+	ControllerPrefix  = "controller." + SlinkyPrefix
+	NodeSetPrefix     = "nodeset." + SlinkyPrefix
+	LoginSetPrefix    = "loginset." + SlinkyPrefix
+	ReservationPrefix = "reservation." + SlinkyPrefix
+	AccountPrefix     = "account." + SlinkyPrefix
+	SlurmUserPrefix   = "slurmuser." + SlinkyPrefix
+	QOSPrefix         = "qos." + SlinkyPrefix
+	SlurmJobPrefix    = "slurmjob." + SlinkyPrefix
+	TopologyPrefix    = "topology." + SlinkyPrefix
+	FeaturesPrefix    = "features." + SlinkyPrefix
 )
 
 // Well Known Annotations
@@ -30,6 +35,31 @@ const (
 	// workload by. Pods with an earlier deadline are preferred to be deleted before pods with a later deadline.
 	// NOTE: this is honored on a best-effort basis, and does not offer guarantees on pod deletion order.
 	AnnotationPodDeadline = NodeSetPrefix + "pod-deadline"
+
+	// AnnotationPodDrainStart stores a time.RFC3339 timestamp, indicating when the NodeSet started to drain the Slurm
+	// node of the pod. The NodeSet DrainPolicy is enforced relative to it.
+	AnnotationPodDrainStart = NodeSetPrefix + "pod-drain-start"
+
+	// AnnotationPodCpuSpec indicates the Slurm node CPU topology and specialized resources (e.g.
+	// "CoresPerSocket=32 Sockets=2 ThreadsPerCore=2") which slurmd registers with, derived from the node the NodeSet
+	// pod is bound to.
+	// NOTE: Set by the pod binding webhook.
+	AnnotationPodCpuSpec = NodeSetPrefix + "pod-cpu-spec"
+
+	// AnnotationPodDrainEscalated stores a comma-separated list of Slurm job IDs, indicating the NodeSet DrainPolicy
+	// escalation action was taken on them because the Slurm node did not drain in time.
+	AnnotationPodDrainEscalated = NodeSetPrefix + "pod-drain-escalated"
+
+	// AnnotationPodConditionDrain stores a comma-separated list of Kubernetes node condition types, indicating the
+	// NodeSet ConditionDrainPolicy drained the Slurm node of the pod because of them.
+	// NOTE: Set by the NodeSet controller.
+	AnnotationPodConditionDrain = NodeSetPrefix + "pod-condition-drain"
+
+	// AnnotationPodInPlaceUpdate stores a JSON object, indicating the pod was updated in place. It records the Slurm
+	// node attributes which slurmd registers with, and the slurmd restart count when the attributes of the pod
+	// revision were last applied, such that they are applied again after slurmd re-registers.
+	// NOTE: Set by the NodeSet controller.
+	AnnotationPodInPlaceUpdate = NodeSetPrefix + "pod-inplace-update"
 )
 
 // Well Known Annotations for Objects of type corev1.Node
@@ -53,6 +83,34 @@ const (
 	// pod scheduled on the node. When present, the value is used verbatim as the pod's spec.hostname
 	// (and therefore the Slurm node name) instead of the default derived from the node name.
 	AnnotationNodeHostnameOverride = NodeSetPrefix + "hostname-override"
+
+	// AnnotationNodeSlurmUnhealthyCordon indicates the node was cordoned by the NodeSet NodeHealthPolicy, such that it
+	// is only uncordoned by it once the Slurm node is healthy again.
+	// NOTE: Set by the NodeSet controller.
+	AnnotationNodeSlurmUnhealthyCordon = NodeSetPrefix + "slurm-unhealthy-cordon"
+)
+
+// Well Known Taints for Objects of type corev1.Node
+const (
+	// TaintNodeSlurmUnhealthy indicates the Slurm node of a NodeSet pod on the node was set DOWN or DRAIN outside of
+	// the operator.
+	// NOTE: Set by the NodeSet controller, with the NoSchedule effect.
+	TaintNodeSlurmUnhealthy = NodeSetPrefix + "slurm-unhealthy"
+)
+
+// Well Known Labels for Objects of type corev1.Node
+const (
+	// LabelNodeCpuSockets indicates the number of CPU sockets of the node.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Sockets
+	LabelNodeCpuSockets = NodeSetPrefix + "cpu-sockets"
+
+	// LabelNodeCpuCoresPerSocket indicates the number of CPU cores in a socket of the node.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_CoresPerSocket
+	LabelNodeCpuCoresPerSocket = NodeSetPrefix + "cpu-cores-per-socket"
+
+	// LabelNodeCpuThreadsPerCore indicates the number of CPU threads in a core of the node.
+	// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_ThreadsPerCore
+	LabelNodeCpuThreadsPerCore = NodeSetPrefix + "cpu-threads-per-core"
 )
 
 // Well Known Slurm node feature prefixes
@@ -100,4 +158,24 @@ const (
 	// FinalizerNodeSetReservation
 	// NOTE: Set by the NodeSet controller.
 	FinalizerNodeSetReservation = NodeSetPrefix + "reservation"
+
+	// FinalizerReservation
+	// NOTE: Set by the Reservation controller.
+	FinalizerReservation = ReservationPrefix + "reservation"
+
+	// FinalizerAccount
+	// NOTE: Set by the Account controller.
+	FinalizerAccount = AccountPrefix + "account"
+
+	// FinalizerSlurmUser
+	// NOTE: Set by the SlurmUser controller.
+	FinalizerSlurmUser = SlurmUserPrefix + "user"
+
+	// FinalizerQOS
+	// NOTE: Set by the QOS controller.
+	FinalizerQOS = QOSPrefix + "qos"
+
+	// FinalizerSlurmJob
+	// NOTE: Set by the SlurmJob controller.
+	FinalizerSlurmJob = SlurmJobPrefix + "job"
 )
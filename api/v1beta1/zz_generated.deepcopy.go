@@ -8,12 +8,124 @@
 package v1beta1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Account) DeepCopyInto(out *Account) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Account.
+func (in *Account) DeepCopy() *Account {
+	if in == nil {
+		return nil
+	}
+	out := new(Account)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Account) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountList) DeepCopyInto(out *AccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Account, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountList.
+func (in *AccountList) DeepCopy() *AccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountSpec) DeepCopyInto(out *AccountSpec) {
+	*out = *in
+	in.SlurmdbRef.DeepCopyInto(&out.SlurmdbRef)
+	if in.Fairshare != nil {
+		in, out := &in.Fairshare, &out.Fairshare
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QOS != nil {
+		in, out := &in.QOS, &out.QOS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountSpec.
+func (in *AccountSpec) DeepCopy() *AccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountStatus) DeepCopyInto(out *AccountStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountStatus.
+func (in *AccountStatus) DeepCopy() *AccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Accounting) DeepCopyInto(out *Accounting) {
 	*out = *in
@@ -79,17 +191,17 @@ func (in *AccountingSpec) DeepCopyInto(out *AccountingSpec) {
 	in.SlurmKeyRef.DeepCopyInto(&out.SlurmKeyRef)
 	if in.JwtHs256KeyRef != nil {
 		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.JwtKeyRef != nil {
 		in, out := &in.JwtKeyRef, &out.JwtKeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.JwksKeyRef != nil {
 		in, out := &in.JwksKeyRef, &out.JwksKeyRef
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	out.ExternalConfig = in.ExternalConfig
@@ -114,7 +226,7 @@ func (in *AccountingStatus) DeepCopyInto(out *AccountingStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -184,6 +296,41 @@ func (in *ControllerHighAvailability) DeepCopy() *ControllerHighAvailability {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerJobDefaults) DeepCopyInto(out *ControllerJobDefaults) {
+	*out = *in
+	if in.DefMemPerCPU != nil {
+		in, out := &in.DefMemPerCPU, &out.DefMemPerCPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefMemPerNode != nil {
+		in, out := &in.DefMemPerNode, &out.DefMemPerNode
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefMemPerGPU != nil {
+		in, out := &in.DefMemPerGPU, &out.DefMemPerGPU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefCpuPerGPU != nil {
+		in, out := &in.DefCpuPerGPU, &out.DefCpuPerGPU
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerJobDefaults.
+func (in *ControllerJobDefaults) DeepCopy() *ControllerJobDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerJobDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerList) DeepCopyInto(out *ControllerList) {
 	*out = *in
@@ -237,28 +384,145 @@ func (in *ControllerPersistence) DeepCopy() *ControllerPersistence {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerPowerSaving) DeepCopyInto(out *ControllerPowerSaving) {
+	*out = *in
+	out.ResumeTimeout = in.ResumeTimeout
+	out.SuspendTimeout = in.SuspendTimeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerPowerSaving.
+func (in *ControllerPowerSaving) DeepCopy() *ControllerPowerSaving {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerPowerSaving)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerPreemption) DeepCopyInto(out *ControllerPreemption) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerPreemption.
+func (in *ControllerPreemption) DeepCopy() *ControllerPreemption {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerPreemption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerPriority) DeepCopyInto(out *ControllerPriority) {
+	*out = *in
+	if in.WeightAge != nil {
+		in, out := &in.WeightAge, &out.WeightAge
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightAssoc != nil {
+		in, out := &in.WeightAssoc, &out.WeightAssoc
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightFairshare != nil {
+		in, out := &in.WeightFairshare, &out.WeightFairshare
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightJobSize != nil {
+		in, out := &in.WeightJobSize, &out.WeightJobSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightPartition != nil {
+		in, out := &in.WeightPartition, &out.WeightPartition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightQOS != nil {
+		in, out := &in.WeightQOS, &out.WeightQOS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WeightTRES != nil {
+		in, out := &in.WeightTRES, &out.WeightTRES
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerPriority.
+func (in *ControllerPriority) DeepCopy() *ControllerPriority {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerPriority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerScheduling) DeepCopyInto(out *ControllerScheduling) {
+	*out = *in
+	if in.SchedulerParameters != nil {
+		in, out := &in.SchedulerParameters, &out.SchedulerParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SelectTypeParameters != nil {
+		in, out := &in.SelectTypeParameters, &out.SelectTypeParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Priority.DeepCopyInto(&out.Priority)
+	in.Preemption.DeepCopyInto(&out.Preemption)
+	in.JobDefaults.DeepCopyInto(&out.JobDefaults)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerScheduling.
+func (in *ControllerScheduling) DeepCopy() *ControllerScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
 	*out = *in
 	in.SlurmKeyRef.DeepCopyInto(&out.SlurmKeyRef)
 	if in.JwtHs256KeyRef != nil {
 		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.JwtKeyRef != nil {
 		in, out := &in.JwtKeyRef, &out.JwtKeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.JwksKeyRef != nil {
 		in, out := &in.JwksKeyRef, &out.JwksKeyRef
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.AccountingRef != nil {
 		in, out := &in.AccountingRef, &out.AccountingRef
-		*out = new(v1.LocalObjectReference)
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 	out.ExternalConfig = in.ExternalConfig
@@ -269,32 +533,35 @@ func (in *ControllerSpec) DeepCopyInto(out *ControllerSpec) {
 	in.Template.DeepCopyInto(&out.Template)
 	if in.ConfigFileRefs != nil {
 		in, out := &in.ConfigFileRefs, &out.ConfigFileRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.PrologScriptRefs != nil {
 		in, out := &in.PrologScriptRefs, &out.PrologScriptRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.EpilogScriptRefs != nil {
 		in, out := &in.EpilogScriptRefs, &out.EpilogScriptRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.PrologSlurmctldScriptRefs != nil {
 		in, out := &in.PrologSlurmctldScriptRefs, &out.PrologSlurmctldScriptRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	if in.EpilogSlurmctldScriptRefs != nil {
 		in, out := &in.EpilogSlurmctldScriptRefs, &out.EpilogSlurmctldScriptRefs
-		*out = make([]v1.LocalObjectReference, len(*in))
+		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
 	in.Persistence.DeepCopyInto(&out.Persistence)
 	in.Service.DeepCopyInto(&out.Service)
 	in.Metrics.DeepCopyInto(&out.Metrics)
+	out.PowerSaving = in.PowerSaving
+	in.Topology.DeepCopyInto(&out.Topology)
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerSpec.
@@ -312,7 +579,7 @@ func (in *ControllerStatus) DeepCopyInto(out *ControllerStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -329,6 +596,93 @@ func (in *ControllerStatus) DeepCopy() *ControllerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerTopology) DeepCopyInto(out *ControllerTopology) {
+	*out = *in
+	if in.Topologies != nil {
+		in, out := &in.Topologies, &out.Topologies
+		*out = make([]ControllerTopologySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTopology.
+func (in *ControllerTopology) DeepCopy() *ControllerTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerTopologyBlock) DeepCopyInto(out *ControllerTopologyBlock) {
+	*out = *in
+	if in.BlockSizes != nil {
+		in, out := &in.BlockSizes, &out.BlockSizes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTopologyBlock.
+func (in *ControllerTopologyBlock) DeepCopy() *ControllerTopologyBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerTopologyBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerTopologySpec) DeepCopyInto(out *ControllerTopologySpec) {
+	*out = *in
+	if in.Tree != nil {
+		in, out := &in.Tree, &out.Tree
+		*out = new(ControllerTopologyTree)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Block != nil {
+		in, out := &in.Block, &out.Block
+		*out = new(ControllerTopologyBlock)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTopologySpec.
+func (in *ControllerTopologySpec) DeepCopy() *ControllerTopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerTopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerTopologyTree) DeepCopyInto(out *ControllerTopologyTree) {
+	*out = *in
+	if in.SwitchLabels != nil {
+		in, out := &in.SwitchLabels, &out.SwitchLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTopologyTree.
+func (in *ControllerTopologyTree) DeepCopy() *ControllerTopologyTree {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerTopologyTree)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalConfig) DeepCopyInto(out *ExternalConfig) {
 	*out = *in
@@ -435,7 +789,7 @@ func (in *LoginSetStatus) DeepCopyInto(out *LoginSetStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -540,37 +894,274 @@ func (in *NodeSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSetList) DeepCopyInto(out *NodeSetList) {
+func (in *NodeSetAutoscaling) DeepCopyInto(out *NodeSetAutoscaling) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]NodeSet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
+	out.ScaleUpStabilizationWindow = in.ScaleUpStabilizationWindow
+	out.ScaleDownStabilizationWindow = in.ScaleDownStabilizationWindow
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetList.
-func (in *NodeSetList) DeepCopy() *NodeSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetAutoscaling.
+func (in *NodeSetAutoscaling) DeepCopy() *NodeSetAutoscaling {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSetList)
+	out := new(NodeSetAutoscaling)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeSetList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetAutoscalingStatus) DeepCopyInto(out *NodeSetAutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetAutoscalingStatus.
+func (in *NodeSetAutoscalingStatus) DeepCopy() *NodeSetAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetConditionDrainPolicy) DeepCopyInto(out *NodeSetConditionDrainPolicy) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]NodeSetDrainCondition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetConditionDrainPolicy.
+func (in *NodeSetConditionDrainPolicy) DeepCopy() *NodeSetConditionDrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetConditionDrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetCpuTopology) DeepCopyInto(out *NodeSetCpuTopology) {
+	*out = *in
+	if in.Sockets != nil {
+		in, out := &in.Sockets, &out.Sockets
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CoresPerSocket != nil {
+		in, out := &in.CoresPerSocket, &out.CoresPerSocket
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ThreadsPerCore != nil {
+		in, out := &in.ThreadsPerCore, &out.ThreadsPerCore
+		*out = new(int32)
+		**out = **in
+	}
+	out.NodeLabels = in.NodeLabels
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetCpuTopology.
+func (in *NodeSetCpuTopology) DeepCopy() *NodeSetCpuTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetCpuTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetCpuTopologyLabels) DeepCopyInto(out *NodeSetCpuTopologyLabels) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetCpuTopologyLabels.
+func (in *NodeSetCpuTopologyLabels) DeepCopy() *NodeSetCpuTopologyLabels {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetCpuTopologyLabels)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetDrainCondition) DeepCopyInto(out *NodeSetDrainCondition) {
+	*out = *in
+	out.DrainAfter = in.DrainAfter
+	out.UndrainAfter = in.UndrainAfter
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetDrainCondition.
+func (in *NodeSetDrainCondition) DeepCopy() *NodeSetDrainCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetDrainCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetDrainPolicy) DeepCopyInto(out *NodeSetDrainPolicy) {
+	*out = *in
+	out.MaxDrainDuration = in.MaxDrainDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetDrainPolicy.
+func (in *NodeSetDrainPolicy) DeepCopy() *NodeSetDrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetDrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetFeatureLabel) DeepCopyInto(out *NodeSetFeatureLabel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetFeatureLabel.
+func (in *NodeSetFeatureLabel) DeepCopy() *NodeSetFeatureLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetFeatureLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetGres) DeepCopyInto(out *NodeSetGres) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]NodeSetGresResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetGres.
+func (in *NodeSetGres) DeepCopy() *NodeSetGres {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetGres)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetGresResource) DeepCopyInto(out *NodeSetGresResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetGresResource.
+func (in *NodeSetGresResource) DeepCopy() *NodeSetGresResource {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetGresResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetList) DeepCopyInto(out *NodeSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetList.
+func (in *NodeSetList) DeepCopy() *NodeSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetMaintenanceWindow) DeepCopyInto(out *NodeSetMaintenanceWindow) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetMaintenanceWindow.
+func (in *NodeSetMaintenanceWindow) DeepCopy() *NodeSetMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetNodeCpuTopology) DeepCopyInto(out *NodeSetNodeCpuTopology) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetNodeCpuTopology.
+func (in *NodeSetNodeCpuTopology) DeepCopy() *NodeSetNodeCpuTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetNodeCpuTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetNodeHealthPolicy) DeepCopyInto(out *NodeSetNodeHealthPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetNodeHealthPolicy.
+func (in *NodeSetNodeHealthPolicy) DeepCopy() *NodeSetNodeHealthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetNodeHealthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSetPartition) DeepCopyInto(out *NodeSetPartition) {
 	*out = *in
@@ -601,6 +1192,62 @@ func (in *NodeSetPersistentVolumeClaimRetentionPolicy) DeepCopy() *NodeSetPersis
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetPowerSaving) DeepCopyInto(out *NodeSetPowerSaving) {
+	*out = *in
+	out.SuspendTime = in.SuspendTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPowerSaving.
+func (in *NodeSetPowerSaving) DeepCopy() *NodeSetPowerSaving {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetPowerSaving)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetRemediationPolicy) DeepCopyInto(out *NodeSetRemediationPolicy) {
+	*out = *in
+	out.FailedDuration = in.FailedDuration
+	out.RemediationWindow = in.RemediationWindow
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetRemediationPolicy.
+func (in *NodeSetRemediationPolicy) DeepCopy() *NodeSetRemediationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetRemediationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetRemediationStatus) DeepCopyInto(out *NodeSetRemediationStatus) {
+	*out = *in
+	if in.WindowStartTime != nil {
+		in, out := &in.WindowStartTime, &out.WindowStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRemediationTime != nil {
+		in, out := &in.LastRemediationTime, &out.LastRemediationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetRemediationStatus.
+func (in *NodeSetRemediationStatus) DeepCopy() *NodeSetRemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetRemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
 	*out = *in
@@ -617,7 +1264,7 @@ func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
 	out.Partition = in.Partition
 	if in.VolumeClaimTemplates != nil {
 		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
+		*out = make([]corev1.PersistentVolumeClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -629,6 +1276,24 @@ func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	in.Autoscaling.DeepCopyInto(&out.Autoscaling)
+	out.PowerSaving = in.PowerSaving
+	out.DrainPolicy = in.DrainPolicy
+	out.NodeHealthPolicy = in.NodeHealthPolicy
+	in.ConditionDrainPolicy.DeepCopyInto(&out.ConditionDrainPolicy)
+	out.RemediationPolicy = in.RemediationPolicy
+	in.Gres.DeepCopyInto(&out.Gres)
+	in.CpuTopology.DeepCopyInto(&out.CpuTopology)
+	if in.FeatureLabels != nil {
+		in, out := &in.FeatureLabels, &out.FeatureLabels
+		*out = make([]NodeSetFeatureLabel, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodInfoNodeLabels != nil {
+		in, out := &in.PodInfoNodeLabels, &out.PodInfoNodeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSpec.
@@ -667,7 +1332,7 @@ func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -679,6 +1344,28 @@ func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(NodeSetAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(NodeSetRemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(NodeSetMaintenanceWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeCpuTopology != nil {
+		in, out := &in.NodeCpuTopology, &out.NodeCpuTopology
+		*out = make(map[string]NodeSetNodeCpuTopology, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetStatus.
@@ -708,6 +1395,123 @@ func (in *NodeSetUpdateStrategy) DeepCopy() *NodeSetUpdateStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Partition) DeepCopyInto(out *Partition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Partition.
+func (in *Partition) DeepCopy() *Partition {
+	if in == nil {
+		return nil
+	}
+	out := new(Partition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Partition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionList) DeepCopyInto(out *PartitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Partition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionList.
+func (in *PartitionList) DeepCopy() *PartitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PartitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionSpec) DeepCopyInto(out *PartitionSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.NodeSetSelector != nil {
+		in, out := &in.NodeSetSelector, &out.NodeSetSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowAccounts != nil {
+		in, out := &in.AllowAccounts, &out.AllowAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PriorityTier != nil {
+		in, out := &in.PriorityTier, &out.PriorityTier
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionSpec.
+func (in *PartitionSpec) DeepCopy() *PartitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionStatus) DeepCopyInto(out *PartitionStatus) {
+	*out = *in
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionStatus.
+func (in *PartitionStatus) DeepCopy() *PartitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSpecWrapper) DeepCopyInto(out *PodSpecWrapper) {
 	clone := in.DeepCopy()
@@ -721,12 +1525,266 @@ func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
 	in.PodSpecWrapper.DeepCopyInto(&out.PodSpecWrapper)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
-func (in *PodTemplate) DeepCopy() *PodTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QOS) DeepCopyInto(out *QOS) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QOS.
+func (in *QOS) DeepCopy() *QOS {
+	if in == nil {
+		return nil
+	}
+	out := new(QOS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QOS) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QOSList) DeepCopyInto(out *QOSList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]QOS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QOSList.
+func (in *QOSList) DeepCopy() *QOSList {
+	if in == nil {
+		return nil
+	}
+	out := new(QOSList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QOSList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QOSSpec) DeepCopyInto(out *QOSSpec) {
+	*out = *in
+	in.SlurmdbRef.DeepCopyInto(&out.SlurmdbRef)
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Preempt != nil {
+		in, out := &in.Preempt, &out.Preempt
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxWallDurationPerJob != nil {
+		in, out := &in.MaxWallDurationPerJob, &out.MaxWallDurationPerJob
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QOSSpec.
+func (in *QOSSpec) DeepCopy() *QOSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QOSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QOSStatus) DeepCopyInto(out *QOSStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QOSStatus.
+func (in *QOSStatus) DeepCopy() *QOSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QOSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Reservation) DeepCopyInto(out *Reservation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reservation.
+func (in *Reservation) DeepCopy() *Reservation {
+	if in == nil {
+		return nil
+	}
+	out := new(Reservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Reservation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationList) DeepCopyInto(out *ReservationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Reservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationList.
+func (in *ReservationList) DeepCopy() *ReservationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReservationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationSpec) DeepCopyInto(out *ReservationSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	if in.NodeSets != nil {
+		in, out := &in.NodeSets, &out.NodeSets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeCount != nil {
+		in, out := &in.NodeCount, &out.NodeCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationSpec.
+func (in *ReservationSpec) DeepCopy() *ReservationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationStatus) DeepCopyInto(out *ReservationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationStatus.
+func (in *ReservationStatus) DeepCopy() *ReservationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplate)
+	out := new(ReservationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -819,7 +1877,7 @@ func (in *RestApiStatus) DeepCopyInto(out *RestApiStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -844,6 +1902,21 @@ func (in *RollingUpdateNodeSetStrategy) DeepCopyInto(out *RollingUpdateNodeSetSt
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CanarySelector != nil {
+		in, out := &in.CanarySelector, &out.CanarySelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateNodeSetStrategy.
@@ -922,6 +1995,336 @@ func (in *ServiceSpecWrapper) DeepCopyInto(out *ServiceSpecWrapper) {
 	*out = *clone
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJob) DeepCopyInto(out *SlurmJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJob.
+func (in *SlurmJob) DeepCopy() *SlurmJob {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobList) DeepCopyInto(out *SlurmJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobList.
+func (in *SlurmJobList) DeepCopy() *SlurmJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobOptions) DeepCopyInto(out *SlurmJobOptions) {
+	*out = *in
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CPUsPerTask != nil {
+		in, out := &in.CPUsPerTask, &out.CPUsPerTask
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryPerNode != nil {
+		in, out := &in.MemoryPerNode, &out.MemoryPerNode
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.TimeLimit != nil {
+		in, out := &in.TimeLimit, &out.TimeLimit
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobOptions.
+func (in *SlurmJobOptions) DeepCopy() *SlurmJobOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobScript) DeepCopyInto(out *SlurmJobScript) {
+	*out = *in
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobScript.
+func (in *SlurmJobScript) DeepCopy() *SlurmJobScript {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobSpec) DeepCopyInto(out *SlurmJobSpec) {
+	*out = *in
+	out.ControllerRef = in.ControllerRef
+	in.Script.DeepCopyInto(&out.Script)
+	in.Options.DeepCopyInto(&out.Options)
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobSpec.
+func (in *SlurmJobSpec) DeepCopy() *SlurmJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobStatus) DeepCopyInto(out *SlurmJobStatus) {
+	*out = *in
+	if in.JobID != nil {
+		in, out := &in.JobID, &out.JobID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SubmitTime != nil {
+		in, out := &in.SubmitTime, &out.SubmitTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobStatus.
+func (in *SlurmJobStatus) DeepCopy() *SlurmJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmUser) DeepCopyInto(out *SlurmUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUser.
+func (in *SlurmUser) DeepCopy() *SlurmUser {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmUserList) DeepCopyInto(out *SlurmUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserList.
+func (in *SlurmUserList) DeepCopy() *SlurmUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmUserSpec) DeepCopyInto(out *SlurmUserSpec) {
+	*out = *in
+	in.SlurmdbRef.DeepCopyInto(&out.SlurmdbRef)
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QOS != nil {
+		in, out := &in.QOS, &out.QOS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserSpec.
+func (in *SlurmUserSpec) DeepCopy() *SlurmUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmUserStatus) DeepCopyInto(out *SlurmUserStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmUserStatus.
+func (in *SlurmUserStatus) DeepCopy() *SlurmUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmdbRef) DeepCopyInto(out *SlurmdbRef) {
+	*out = *in
+	if in.ControllerRef != nil {
+		in, out := &in.ControllerRef, &out.ControllerRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AccountingRef != nil {
+		in, out := &in.AccountingRef, &out.AccountingRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmdbRef.
+func (in *SlurmdbRef) DeepCopy() *SlurmdbRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmdbRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
 	*out = *in
@@ -1002,17 +2405,17 @@ func (in *TokenSpec) DeepCopyInto(out *TokenSpec) {
 	*out = *in
 	if in.JwtHs256KeyRef != nil {
 		in, out := &in.JwtHs256KeyRef, &out.JwtHs256KeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.JwtKeyRef != nil {
 		in, out := &in.JwtKeyRef, &out.JwtKeyRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Lifetime != nil {
 		in, out := &in.Lifetime, &out.Lifetime
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.Refresh != nil {
@@ -1022,7 +2425,7 @@ func (in *TokenSpec) DeepCopyInto(out *TokenSpec) {
 	}
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -1046,7 +2449,7 @@ func (in *TokenStatus) DeepCopyInto(out *TokenStatus) {
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4,7 +4,9 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -17,25 +19,35 @@ import (
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/account"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/accounting"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/controller"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/loginset"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/partition"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/qos"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/reservation"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/restapi"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmclient"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmuser"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/token"
 )
 
@@ -46,6 +58,10 @@ var (
 
 const defaultProfileAddr = "localhost:6060"
 
+// leaderLabel is set on the pod of the elected slurm-operator, such that the
+// power saving Service only selects the replica which serves the endpoint.
+const leaderLabel = slinkyv1beta1.SlinkyPrefix + "leader"
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
@@ -66,6 +82,7 @@ type Flags struct {
 	propagatedNodeConditions string
 	profile                  bool
 	profileAddr              string
+	powerSaveAddr            string
 }
 
 func parseFlags(flags *Flags) {
@@ -110,6 +127,12 @@ func parseFlags(flags *Flags) {
 		defaultProfileAddr,
 		"The address the Go profiling endpoint binds to. This should never be exposed publicly. If empty and profiling is enabled, defaults to localhost:6060.",
 	)
+	flag.StringVar(
+		&flags.powerSaveAddr,
+		"powersave-addr",
+		"0",
+		"The address the Slurm power saving endpoint binds to. Set this to '0' to disable the endpoint.",
+	)
 	flag.Parse()
 }
 
@@ -152,6 +175,81 @@ func startProfileServer(addr string) (*http.Server, error) {
 	return server, nil
 }
 
+// newPowerSaveServer returns a runnable which serves the Slurm power saving
+// endpoint until the manager is stopped.
+func newPowerSaveServer(addr string, handler http.Handler) manager.RunnableFunc {
+	return func(ctx context.Context) error {
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           handler,
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       10 * time.Second,
+			WriteTimeout:      10 * time.Second,
+		}
+
+		listener, err := net.Listen("tcp", server.Addr)
+		if err != nil {
+			return fmt.Errorf("listen on power saving address %q: %w", server.Addr, err)
+		}
+
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				setupLog.Error(err, "power saving server shutdown failed")
+			}
+		}()
+
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// newLeaderLabeler returns a runnable which labels the pod of the elected
+// slurm-operator, and removes the label when the manager is stopped.
+func newLeaderLabeler(c client.Client, pod types.NamespacedName) manager.RunnableFunc {
+	return func(ctx context.Context) error {
+		if err := setLeaderLabel(ctx, c, pod, true); err != nil {
+			return fmt.Errorf("label leader pod %s: %w", pod, err)
+		}
+		<-ctx.Done()
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := setLeaderLabel(cleanupCtx, c, pod, false); err != nil {
+			setupLog.Error(err, "unable to remove leader label", "pod", pod)
+		}
+		return nil
+	}
+}
+
+// setLeaderLabel adds or removes the leader label on the pod.
+func setLeaderLabel(ctx context.Context, c client.Client, pod types.NamespacedName, leader bool) error {
+	var value any
+	if leader {
+		value = "true"
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]any{
+				leaderLabel: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	obj := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		},
+	}
+	return c.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch))
+}
+
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;create;update;patch;watch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update;patch
 // +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
@@ -256,10 +354,54 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Accounting")
 		os.Exit(1)
 	}
-	if err := nodeset.NewReconciler(mgr.GetClient(), clientMap, propagatedNodeConditions).SetupWithManager(mgr); err != nil {
+	nodesetReconciler := nodeset.NewReconciler(mgr.GetClient(), clientMap, propagatedNodeConditions)
+	if err := nodesetReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeSet")
 		os.Exit(1)
 	}
+	if flags.powerSaveAddr != "0" {
+		if err := mgr.Add(newPowerSaveServer(flags.powerSaveAddr, nodesetReconciler.PowerSaveHandler())); err != nil {
+			setupLog.Error(err, "unable to set up power saving server")
+			os.Exit(1)
+		}
+		// Only the elected replica serves the power saving endpoint, the label
+		// points the power saving Service at it.
+		pod := types.NamespacedName{Namespace: os.Getenv("POD_NAMESPACE"), Name: os.Getenv("POD_NAME")}
+		if pod.Namespace != "" && pod.Name != "" {
+			// Clear a stale label, left behind when this replica lost the election.
+			if err := setLeaderLabel(context.Background(), mgr.GetClient(), pod, false); err != nil {
+				setupLog.Error(err, "unable to remove leader label", "pod", pod)
+			}
+			if err := mgr.Add(newLeaderLabeler(mgr.GetClient(), pod)); err != nil {
+				setupLog.Error(err, "unable to set up leader labeler")
+				os.Exit(1)
+			}
+		}
+	}
+	if err := partition.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Partition")
+		os.Exit(1)
+	}
+	if err := reservation.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Reservation")
+		os.Exit(1)
+	}
+	if err := account.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Account")
+		os.Exit(1)
+	}
+	if err := slurmuser.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmUser")
+		os.Exit(1)
+	}
+	if err := qos.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "QOS")
+		os.Exit(1)
+	}
+	if err := slurmjob.NewReconciler(mgr.GetClient(), clientMap).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SlurmJob")
+		os.Exit(1)
+	}
 	if err := loginset.NewReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LoginSet")
 		os.Exit(1)
@@ -4,15 +4,22 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func parseFlagsForTest(t *testing.T, args []string) Flags {
@@ -84,6 +91,79 @@ func Test_parseFlags_profile(t *testing.T) {
 	require.True(t, flags.enableLeaderElection)
 }
 
+func Test_parseFlags_powerSaveAddr(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "default is disabled",
+			args: []string{"test"},
+			want: "0",
+		},
+		{
+			name: "with address",
+			args: []string{"test", "--powersave-addr", ":8082"},
+			want: ":8082",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := parseFlagsForTest(t, tt.args)
+			require.Equal(t, tt.want, flags.powerSaveAddr)
+		})
+	}
+}
+
+func Test_newPowerSaveServer_bindError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, listener.Close())
+	})
+
+	err = newPowerSaveServer(listener.Addr().String(), http.NewServeMux())(context.Background())
+	require.Error(t, err)
+}
+
+func Test_newLeaderLabeler(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "slinky",
+			Name:      "slurm-operator-0",
+			Labels:    map[string]string{"app": "slurm-operator"},
+		},
+	}
+	key := client.ObjectKeyFromObject(pod)
+	c := fake.NewFakeClient(pod)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- newLeaderLabeler(c, key)(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		got := &corev1.Pod{}
+		require.NoError(t, c.Get(context.Background(), key, got))
+		return got.Labels[leaderLabel] == "true"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	got := &corev1.Pod{}
+	require.NoError(t, c.Get(context.Background(), key, got))
+	require.Equal(t, map[string]string{"app": "slurm-operator"}, got.Labels)
+}
+
+func Test_setLeaderLabel_notFound(t *testing.T) {
+	c := fake.NewFakeClient()
+	err := setLeaderLabel(context.Background(), c, types.NamespacedName{Namespace: "slinky", Name: "missing"}, false)
+	require.Error(t, err)
+}
+
 func Test_profileAddr(t *testing.T) {
 	tests := []struct {
 		name string
@@ -49,6 +49,7 @@ type Flags struct {
 	secureMetrics           bool
 	enableHTTP2             bool
 	namespaces              string
+	slurmJobUsers           string
 }
 
 func parseFlags(flags *Flags) {
@@ -84,6 +85,9 @@ func parseFlags(flags *Flags) {
 
 	flag.StringVar(&flags.namespaces, "namespaces", "",
 		"Comma-separated list of namespaces the webhook will watch. If empty, all namespaces are watched.")
+	flag.StringVar(&flags.slurmJobUsers, "slurmjob-users", "",
+		("Semicolon-separated list of <namespace>=<user>[,<user>...] entries, the Slurm users whom SlurmJobs may be submitted as. " +
+			"The namespace '*' applies to all namespaces. If empty, no SlurmJob is admitted."))
 	flag.StringVar(
 		&flags.serverAddr,
 		"server-addr",
@@ -144,6 +148,12 @@ func main() {
 		setupLog.Info("watching namespaces", "namespaces", flags.namespaces)
 	}
 
+	slurmJobUsers, err := slinkywebhook.ParseAllowedUsers(flags.slurmJobUsers)
+	if err != nil {
+		setupLog.Error(err, "unable to parse SlurmJob users", "slurmjob-users", flags.slurmJobUsers)
+		os.Exit(1)
+	}
+
 	metricsServerOptions := server.Options{
 		BindAddress:   flags.metricsAddr,
 		SecureServing: flags.secureMetrics,
@@ -215,6 +225,12 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Token")
 		os.Exit(1)
 	}
+	if err = (&slinkywebhook.SlurmJobWebhook{
+		AllowedUsers: slurmJobUsers,
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SlurmJob")
+		os.Exit(1)
+	}
 	if err = (&slinkywebhook.PodBindingWebhook{
 		Client: mgr.GetClient(),
 	}).SetupWebhookWithManager(mgr); err != nil {
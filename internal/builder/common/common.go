@@ -10,7 +10,11 @@ import (
 	"sort"
 	"strings"
 
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/set"
 
@@ -320,6 +324,28 @@ func parseSlurmConfKV(confRaw string) map[string]string {
 	return out
 }
 
+// ParseExtraConf parses a NodeSet's Spec.ExtraConf into a map of normalized keys
+// to their values. The expected shape is space-separated Key=Value pairs; each
+// value can be a comma-separated list (e.g. Features=a,b,c). Keys are title-cased
+// so "feature=a" and "Feature=a" both produce key "Feature".
+//
+// Tokens are split on whitespace, so leading, trailing, and repeated whitespace
+// are tolerated; any token without '=' returns an error. Shared by the builders
+// and the NodeSet validation webhook, keeping the accepted set identical at
+// admission and at build time.
+func ParseExtraConf(extraConf string) (map[string][]string, error) {
+	out := make(map[string][]string, 10)
+	for item := range strings.FieldsSeq(extraConf) {
+		pair := strings.SplitN(item, "=", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("malformed --conf item %q: expected Key=Value", item)
+		}
+		key := cases.Title(language.English).String(pair[0])
+		out[key] = append(out[key], strings.Split(pair[1], ",")...)
+	}
+	return out, nil
+}
+
 func parseKVKey(s string) string {
 	k, _, _ := strings.Cut(s, "=")
 	return strings.ToLower(k)
@@ -335,3 +361,42 @@ func GetSlurmNodeSetName(nodeset *slinkyv1beta1.NodeSet) string {
 	}
 	return nodeset.Name
 }
+
+// GetSlurmNodeNameForOrdinal returns the Slurm node name of the NodeSet pod
+// with the given ordinal, when `scalingMode=StatefulSet`.
+func GetSlurmNodeNameForOrdinal(nodeset *slinkyv1beta1.NodeSet, ordinal int) string {
+	format := fmt.Sprintf("%%0%vd", nodeset.Spec.OrdinalPadding)
+	paddedOrdinal := fmt.Sprintf(format, ordinal)
+	if hostname := nodeset.Spec.Template.PodSpecWrapper.Hostname; hostname != "" {
+		return hostname + paddedOrdinal
+	}
+	return fmt.Sprintf("%s-%s", nodeset.Name, paddedOrdinal)
+}
+
+// IsPowerSavingEnabled returns true if the NodeSet nodes are managed by Slurm power saving.
+func IsPowerSavingEnabled(nodeset *slinkyv1beta1.NodeSet) bool {
+	return nodeset.Spec.PowerSaving.Enabled && nodeset.Spec.ScalingMode != slinkyv1beta1.ScalingModeDaemonset
+}
+
+// GetNodeSetsForPartition returns the NodeSets of the list which are selected by
+// the Partition NodeSetSelector, sorted by name. A null or invalid selector
+// selects none.
+func GetNodeSetsForPartition(partition *slinkyv1beta1.Partition, nodesetList *slinkyv1beta1.NodeSetList) []slinkyv1beta1.NodeSet {
+	if partition.Spec.NodeSetSelector == nil || nodesetList == nil {
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(partition.Spec.NodeSetSelector)
+	if err != nil {
+		return nil
+	}
+	out := make([]slinkyv1beta1.NodeSet, 0, len(nodesetList.Items))
+	for _, nodeset := range nodesetList.Items {
+		if selector.Matches(k8slabels.Set(nodeset.Labels)) {
+			out = append(out, nodeset)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
@@ -513,3 +513,157 @@ func Test_parseKVKey(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSlurmNodeNameForOrdinal(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		ordinal int
+		want    string
+	}{
+		{
+			name: "default",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			},
+			ordinal: 1,
+			want:    "foo-1",
+		},
+		{
+			name: "padding",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					OrdinalPadding: 3,
+				},
+			},
+			ordinal: 12,
+			want:    "foo-012",
+		},
+		{
+			name: "hostname",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					Template: slinkyv1beta1.PodTemplate{
+						PodSpecWrapper: slinkyv1beta1.PodSpecWrapper{
+							PodSpec: corev1.PodSpec{
+								Hostname: "bar-",
+							},
+						},
+					},
+				},
+			},
+			ordinal: 0,
+			want:    "bar-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetSlurmNodeNameForOrdinal(tt.nodeset, tt.ordinal))
+		})
+	}
+}
+
+func TestGetNodeSetsForPartition(t *testing.T) {
+	newNodeSet := func(name string, labels map[string]string) slinkyv1beta1.NodeSet {
+		return slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+		}
+	}
+	nodesetList := &slinkyv1beta1.NodeSetList{
+		Items: []slinkyv1beta1.NodeSet{
+			newNodeSet("gpu-b", map[string]string{"gpu": "true"}),
+			newNodeSet("cpu", nil),
+			newNodeSet("gpu-a", map[string]string{"gpu": "true"}),
+		},
+	}
+	tests := []struct {
+		name     string
+		selector *metav1.LabelSelector
+		want     []string
+	}{
+		{
+			name:     "null selector",
+			selector: nil,
+			want:     []string{},
+		},
+		{
+			name:     "empty selector",
+			selector: &metav1.LabelSelector{},
+			want:     []string{"cpu", "gpu-a", "gpu-b"},
+		},
+		{
+			name: "match labels",
+			selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"gpu": "true"},
+			},
+			want: []string{"gpu-a", "gpu-b"},
+		},
+		{
+			name: "match expressions",
+			selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "gpu", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			},
+			want: []string{"cpu"},
+		},
+		{
+			name: "invalid selector",
+			selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "gpu", Operator: "Bogus"},
+				},
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partition := &slinkyv1beta1.Partition{
+				Spec: slinkyv1beta1.PartitionSpec{
+					NodeSetSelector: tt.selector,
+				},
+			}
+			got := []string{}
+			for _, nodeset := range GetNodeSetsForPartition(partition, nodesetList) {
+				got = append(got, nodeset.Name)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseExtraConf(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: map[string][]string{}},
+		{name: "single", input: "Weight=10", want: map[string][]string{"Weight": {"10"}}},
+		{name: "multiple keys", input: "Feature=a Weight=10", want: map[string][]string{"Feature": {"a"}, "Weight": {"10"}}},
+		{name: "csv value splits", input: "Features=a,b,c", want: map[string][]string{"Features": {"a", "b", "c"}}},
+		{name: "case normalised", input: "feature=a", want: map[string][]string{"Feature": {"a"}}},
+		{name: "repeated and surrounding whitespace tolerated", input: "  Weight=5  Feature=a ", want: map[string][]string{"Weight": {"5"}, "Feature": {"a"}}},
+		{name: "missing equals", input: "Weight10", wantErr: true},
+		{name: "missing equals among valid", input: "Weight=5 nope Feature=a", wantErr: true},
+		{name: "Feature and Features stay distinct keys", input: "Feature=a Features=b", want: map[string][]string{"Feature": {"a"}, "Features": {"b"}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseExtraConf(tc.input)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/utils/set"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
+)
+
+// ConfigRollout is how a change of the Slurm configuration is rolled out.
+type ConfigRollout string
+
+const (
+	// ConfigRolloutReconfigure reconfigures the Slurm daemons in-place.
+	ConfigRolloutReconfigure ConfigRollout = "Reconfigure"
+	// ConfigRolloutRestartSlurmctld restarts slurmctld, then reconfigures the
+	// Slurm daemons.
+	ConfigRolloutRestartSlurmctld ConfigRollout = "RestartSlurmctld"
+	// ConfigRolloutRestartAll restarts slurmctld and slurmd, then reconfigures
+	// the Slurm daemons.
+	ConfigRolloutRestartAll ConfigRollout = "RestartAll"
+)
+
+// slurmdRestartKeys are the `slurm.conf` keys which only take effect after a
+// restart of slurmctld and slurmd.
+//
+// Ref: https://slurm.schedmd.com/slurm.conf.html
+var slurmdRestartKeys = newLowerSet(
+	"AuthAltTypes",
+	"AuthType",
+	"CertmgrType",
+	"CommunicationParameters",
+	"CredType",
+	"GresTypes",
+	"HashPlugin",
+	"JobAcctGatherType",
+	"JobContainerType",
+	"NamespaceType",
+	"PluginDir",
+	"ProctrackType",
+	"PrologFlags",
+	"SlurmctldPort",
+	"SlurmdPort",
+	"SlurmdSpoolDir",
+	"SlurmdUser",
+	"SwitchType",
+	"TaskPlugin",
+	"TLSType",
+)
+
+// slurmctldRestartKeys are the `slurm.conf` keys which only take effect after a
+// restart of slurmctld.
+//
+// Ref: https://slurm.schedmd.com/slurm.conf.html
+var slurmctldRestartKeys = newLowerSet(
+	"AccountingStorageType",
+	"MetricsType",
+	"PreemptType",
+	"PriorityType",
+	"SchedulerType",
+	"SelectType",
+	"SelectTypeParameters",
+	"SlurmUser",
+	"StateSaveLocation",
+	"TopologyPlugin",
+)
+
+func newLowerSet(items ...string) set.Set[string] {
+	s := set.New[string]()
+	for _, item := range items {
+		s.Insert(strings.ToLower(item))
+	}
+	return s
+}
+
+// ClassifyConfigChanges returns how the `slurm.conf` changes are rolled out.
+// Changes of other config files are reconfigured in-place.
+func ClassifyConfigChanges(changes []config.Change) ConfigRollout {
+	rollout := ConfigRolloutReconfigure
+	for _, change := range changes {
+		key := strings.ToLower(change.Key)
+		switch {
+		case slurmdRestartKeys.Has(key):
+			return ConfigRolloutRestartAll
+		case slurmctldRestartKeys.Has(key):
+			rollout = ConfigRolloutRestartSlurmctld
+		}
+	}
+	return rollout
+}
+
+// SlurmctldRestartHash returns the hash of the `slurm.conf` keys which require
+// a restart of slurmctld, such that slurmctld is restarted when they change.
+func SlurmctldRestartHash(slurmConf string) string {
+	return restartHash(slurmConf, slurmctldRestartKeys.Union(slurmdRestartKeys))
+}
+
+// SlurmdRestartHash returns the hash of the `slurm.conf` keys which require a
+// restart of slurmd, such that slurmd is restarted when they change.
+func SlurmdRestartHash(slurmConf string) string {
+	return restartHash(slurmConf, slurmdRestartKeys)
+}
+
+// RestartHash returns the restart hash relative to its seed, the hash of the
+// configuration which the pods ran when the restart hashes were first recorded.
+// It is empty until the restart keys change from the seed, such that recording
+// the hashes, as on upgrade of the operator, does not restart the pods.
+func RestartHash(hash, seed string) string {
+	if seed == "" || hash == seed {
+		return ""
+	}
+	return hash
+}
+
+func restartHash(slurmConf string, keys set.Set[string]) string {
+	lines, err := config.Parse(slurmConf)
+	if err != nil {
+		// The operator renders the slurm.conf, it is only malformed if ExtraConf
+		// is, which slurmctld would fail on anyway.
+		return ""
+	}
+	values := make(map[string]string)
+	for _, line := range lines {
+		if line.IsMultiValue() || line.IsInclude() {
+			continue
+		}
+		for _, param := range line.Params {
+			if key := strings.ToLower(param.Key); keys.Has(key) {
+				values[key] = param.Value
+			}
+		}
+	}
+	items := make([]string, 0, len(values))
+	for key, value := range values {
+		items = append(items, key+"="+value)
+	}
+	sort.Strings(items)
+	return crypto.CheckSum([]byte(strings.Join(items, "\n")))
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+)
+
+func TestClassifyConfigChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []config.Change
+		want    ConfigRollout
+	}{
+		{
+			name: "live keys",
+			changes: []config.Change{
+				{Key: "DebugFlags", New: "Steps"},
+				{Key: "NodeName=foo", New: "CPUs=4"},
+				{Key: "cgroup.conf"},
+			},
+			want: ConfigRolloutReconfigure,
+		},
+		{
+			name: "slurmctld restart key",
+			changes: []config.Change{
+				{Key: "DebugFlags", New: "Steps"},
+				{Key: "selecttype", Old: "select/linear", New: "select/cons_tres"},
+			},
+			want: ConfigRolloutRestartSlurmctld,
+		},
+		{
+			name: "slurmd restart key",
+			changes: []config.Change{
+				{Key: "SelectType", Old: "select/linear", New: "select/cons_tres"},
+				{Key: "TaskPlugin", New: "task/affinity,task/cgroup"},
+			},
+			want: ConfigRolloutRestartAll,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ClassifyConfigChanges(tt.changes))
+		})
+	}
+}
+
+func TestRestartHash(t *testing.T) {
+	base := "ClusterName=slurm\nSelectType=select/cons_tres\nTaskPlugin=task/cgroup\nDebugFlags=Steps"
+
+	// Live keys do not change the hashes.
+	live := "ClusterName=slurm\nselecttype=select/cons_tres\nTaskPlugin=task/cgroup\nDebugFlags=Gres\nNodeName=foo CPUs=4"
+	require.Equal(t, SlurmctldRestartHash(base), SlurmctldRestartHash(live))
+	require.Equal(t, SlurmdRestartHash(base), SlurmdRestartHash(live))
+
+	// slurmctld restart keys only change the slurmctld hash.
+	slurmctld := "ClusterName=slurm\nSelectType=select/linear\nTaskPlugin=task/cgroup\nDebugFlags=Steps"
+	require.NotEqual(t, SlurmctldRestartHash(base), SlurmctldRestartHash(slurmctld))
+	require.Equal(t, SlurmdRestartHash(base), SlurmdRestartHash(slurmctld))
+
+	// slurmd restart keys change both hashes.
+	slurmd := "ClusterName=slurm\nSelectType=select/cons_tres\nTaskPlugin=task/affinity\nDebugFlags=Steps"
+	require.NotEqual(t, SlurmctldRestartHash(base), SlurmctldRestartHash(slurmd))
+	require.NotEqual(t, SlurmdRestartHash(base), SlurmdRestartHash(slurmd))
+}
+
+func TestRestartHashSeed(t *testing.T) {
+	base := "ClusterName=slurm\nSelectType=select/cons_tres"
+	changed := "ClusterName=slurm\nSelectType=select/linear"
+	seed := SlurmctldRestartHash(base)
+
+	// Without a seed, the pods are not restarted.
+	require.Empty(t, RestartHash(SlurmctldRestartHash(changed), ""))
+	// Unchanged from the seed, the pods are not restarted.
+	require.Empty(t, RestartHash(SlurmctldRestartHash(base), seed))
+	// Changed from the seed, the pods are restarted.
+	require.Equal(t, SlurmctldRestartHash(changed), RestartHash(SlurmctldRestartHash(changed), seed))
+}
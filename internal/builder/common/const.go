@@ -55,12 +55,18 @@ const (
 	SlurmdLogFilePath = SlurmLogFileDir + "/" + SlurmdLogFile
 
 	SlurmdSpoolDir = "/var/spool/slurmd"
+
+	// RebootProgram is a no-op, as slurmd cannot reboot its container. The
+	// NodeSet controller replaces the pod of a rebooting Slurm node instead.
+	RebootProgram = "/bin/true"
 )
 
 // Controller
 const (
 	SlurmctldPort = 6817
 
+	SlurmConfFile = "slurm.conf"
+
 	SlurmctldLogFile     = "slurmctld.log"
 	SlurmctldLogFilePath = SlurmLogFileDir + "/" + SlurmctldLogFile
 
@@ -89,3 +95,18 @@ const (
 	AnnotationSssdConfHash    = slinkyv1beta1.SlinkyPrefix + "sssd-conf-hash"
 	AnnotationSshHostKeysHash = slinkyv1beta1.SlinkyPrefix + "ssh-host-keys-hash"
 )
+
+const (
+	// AnnotationSlurmctldRestartHash is the hash of the Slurm configuration which
+	// slurmctld must be restarted for.
+	AnnotationSlurmctldRestartHash = slinkyv1beta1.SlinkyPrefix + "slurmctld-restart-hash"
+	// AnnotationSlurmdRestartHash is the hash of the Slurm configuration which
+	// slurmd must be restarted for.
+	AnnotationSlurmdRestartHash = slinkyv1beta1.SlinkyPrefix + "slurmd-restart-hash"
+	// AnnotationSlurmctldRestartHashSeed is the slurmctld restart hash of the
+	// Slurm configuration when the restart hashes were first recorded.
+	AnnotationSlurmctldRestartHashSeed = slinkyv1beta1.SlinkyPrefix + "slurmctld-restart-hash-seed"
+	// AnnotationSlurmdRestartHashSeed is the slurmd restart hash of the Slurm
+	// configuration when the restart hashes were first recorded.
+	AnnotationSlurmdRestartHashSeed = slinkyv1beta1.SlinkyPrefix + "slurmd-restart-hash-seed"
+)
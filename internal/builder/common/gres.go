@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+)
+
+// Gres is a Slurm GRES of a NodeSet, derived from an extended resource limit.
+type Gres struct {
+	// Name is the name of the Slurm GRES (e.g. gpu).
+	Name string
+	// Type is the type of the Slurm GRES (e.g. a100), if any.
+	Type string
+	// File is the device file(s) of the Slurm GRES, if any.
+	File string
+	// Count is the number of the Slurm GRES.
+	Count int64
+}
+
+// String returns the Slurm GRES as `name[:type]:count`.
+func (g Gres) String() string {
+	if g.Type != "" {
+		return fmt.Sprintf("%s:%s:%d", g.Name, g.Type, g.Count)
+	}
+	return fmt.Sprintf("%s:%d", g.Name, g.Count)
+}
+
+// GetGres returns the Slurm GRES of the NodeSet derived from the extended
+// resource limits, sorted by name and type. Returns nil if the derivation is
+// disabled, or if Spec.ExtraConf defines Gres, which then takes precedence.
+func GetGres(nodeset *slinkyv1beta1.NodeSet) []Gres {
+	spec := nodeset.Spec.Gres
+	if !spec.Enabled {
+		return nil
+	}
+	if conf, err := ParseExtraConf(nodeset.Spec.ExtraConf); err == nil {
+		if _, ok := conf["Gres"]; ok {
+			return nil
+		}
+	}
+
+	resources := spec.Resources
+	if len(resources) == 0 {
+		resources = defaults.DefaultNodeSetGresResources
+	}
+	limits := getExtendedResourceLimits(&nodeset.Spec)
+
+	// Resources mapped to the same GRES are summed (e.g. both vendors as "gpu").
+	gresMap := make(map[string]*Gres, len(resources))
+	for _, resource := range resources {
+		quantity, ok := limits[resource.ResourceName]
+		if !ok || quantity.Value() <= 0 {
+			continue
+		}
+		key := resource.Name + ":" + resource.Type
+		if gres, ok := gresMap[key]; ok {
+			gres.Count += quantity.Value()
+			continue
+		}
+		gresMap[key] = &Gres{
+			Name:  resource.Name,
+			Type:  resource.Type,
+			File:  resource.File,
+			Count: quantity.Value(),
+		}
+	}
+	if len(gresMap) == 0 {
+		return nil
+	}
+
+	out := make([]Gres, 0, len(gresMap))
+	for _, gres := range gresMap {
+		out = append(out, *gres)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Type < out[j].Type
+	})
+	return out
+}
+
+// FormatGres returns the value of the Slurm node Gres= option.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Gres_1
+func FormatGres(gres []Gres) string {
+	items := make([]string, 0, len(gres))
+	for _, g := range gres {
+		items = append(items, g.String())
+	}
+	return strings.Join(items, ",")
+}
+
+// getExtendedResourceLimits returns the extended resource limits of the slurmd
+// container, falling back to the NodeSet pod-level resource limits.
+func getExtendedResourceLimits(nodeset *slinkyv1beta1.NodeSetSpec) corev1.ResourceList {
+	out := corev1.ResourceList{}
+	if podResources := nodeset.Template.PodSpecWrapper.Resources; podResources != nil {
+		for name, quantity := range podResources.Limits {
+			out[name] = quantity
+		}
+	}
+	// Only override pod limits with container limits.
+	for name, quantity := range nodeset.Slurmd.Container.Resources.Limits {
+		out[name] = quantity
+	}
+	return out
+}
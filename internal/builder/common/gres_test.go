@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func newGresNodeSet(gres slinkyv1beta1.NodeSetGres, podLimits, containerLimits corev1.ResourceList) *slinkyv1beta1.NodeSet {
+	nodeset := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			Gres: gres,
+			Slurmd: slinkyv1beta1.ContainerWrapper{
+				Container: corev1.Container{
+					Resources: corev1.ResourceRequirements{Limits: containerLimits},
+				},
+			},
+		},
+	}
+	if podLimits != nil {
+		nodeset.Spec.Template.PodSpecWrapper.Resources = &corev1.ResourceRequirements{Limits: podLimits}
+	}
+	return nodeset
+}
+
+func TestGetGres(t *testing.T) {
+	fakeResources := []slinkyv1beta1.NodeSetGresResource{
+		{ResourceName: "example.com/fpga", Name: "fpga"},
+		{ResourceName: "example.com/gpu-a", Name: "gpu", Type: "a", File: "/dev/gpu-a[0-1]"},
+		{ResourceName: "example.com/gpu-b", Name: "gpu", Type: "b"},
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    []Gres
+	}{
+		{
+			name: "disabled",
+			nodeset: newGresNodeSet(slinkyv1beta1.NodeSetGres{}, nil, corev1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("4"),
+			}),
+			want: nil,
+		},
+		{
+			name: "default resources",
+			nodeset: newGresNodeSet(slinkyv1beta1.NodeSetGres{Enabled: true}, nil, corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("8"),
+				"nvidia.com/gpu":   resource.MustParse("4"),
+			}),
+			want: []Gres{{Name: "gpu", Count: 4}},
+		},
+		{
+			name: "default resources are summed",
+			nodeset: newGresNodeSet(slinkyv1beta1.NodeSetGres{Enabled: true}, nil, corev1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("4"),
+				"amd.com/gpu":    resource.MustParse("2"),
+			}),
+			want: []Gres{{Name: "gpu", Count: 6}},
+		},
+		{
+			name: "no extended resources",
+			nodeset: newGresNodeSet(slinkyv1beta1.NodeSetGres{Enabled: true}, nil, corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("8"),
+			}),
+			want: nil,
+		},
+		{
+			name: "mapped resources",
+			nodeset: newGresNodeSet(slinkyv1beta1.NodeSetGres{Enabled: true, Resources: fakeResources}, nil, corev1.ResourceList{
+				"example.com/gpu-b": resource.MustParse("1"),
+				"example.com/gpu-a": resource.MustParse("2"),
+				"example.com/fpga":  resource.MustParse("3"),
+				"nvidia.com/gpu":    resource.MustParse("4"),
+			}),
+			want: []Gres{
+				{Name: "fpga", Count: 3},
+				{Name: "gpu", Type: "a", File: "/dev/gpu-a[0-1]", Count: 2},
+				{Name: "gpu", Type: "b", Count: 1},
+			},
+		},
+		{
+			name: "container limits override pod limits",
+			nodeset: newGresNodeSet(slinkyv1beta1.NodeSetGres{Enabled: true, Resources: fakeResources},
+				corev1.ResourceList{
+					"example.com/fpga":  resource.MustParse("1"),
+					"example.com/gpu-b": resource.MustParse("8"),
+				},
+				corev1.ResourceList{
+					"example.com/gpu-b": resource.MustParse("2"),
+				}),
+			want: []Gres{
+				{Name: "fpga", Count: 1},
+				{Name: "gpu", Type: "b", Count: 2},
+			},
+		},
+		{
+			name: "extraConf takes precedence",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newGresNodeSet(slinkyv1beta1.NodeSetGres{Enabled: true}, nil, corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("4"),
+				})
+				nodeset.Spec.ExtraConf = "gres=gpu:h100:4"
+				return nodeset
+			}(),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetGres(tt.nodeset))
+		})
+	}
+}
+
+func TestFormatGres(t *testing.T) {
+	tests := []struct {
+		name string
+		gres []Gres
+		want string
+	}{
+		{
+			name: "empty",
+			gres: nil,
+			want: "",
+		},
+		{
+			name: "with and without type",
+			gres: []Gres{
+				{Name: "fpga", Count: 3},
+				{Name: "gpu", Type: "a", File: "/dev/gpu-a[0-1]", Count: 2},
+			},
+			want: "fpga:3,gpu:a:2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, FormatGres(tt.gres))
+		})
+	}
+}
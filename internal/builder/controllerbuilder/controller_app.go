@@ -110,7 +110,7 @@ func (b *ControllerBuilder) controllerPodTemplate(controller *slinkyv1beta1.Cont
 	var hashMap map[string]string
 	if controller.Spec.InplaceReconfigure {
 		var err error
-		hashMap, err = b.getAuthHashes(ctx, controller)
+		hashMap, err = b.getRestartHashes(ctx, controller)
 		if err != nil {
 			return corev1.PodTemplateSpec{}, err
 		}
@@ -354,13 +354,19 @@ func (b *ControllerBuilder) slurmctldContainer(merge corev1.Container, clusterNa
 	return b.CommonBuilder.BuildContainer(opts)
 }
 
+const (
+	// ReconfigureContainer reports the mounted Slurm configuration hash as the
+	// last field of its last log line.
+	ReconfigureContainer = "reconfigure"
+)
+
 //go:embed scripts/reconfigure.sh
 var reconfigureScript string
 
 func (b *ControllerBuilder) reconfigureContainer(container slinkyv1beta1.ContainerWrapper) corev1.Container {
 	opts := common.ContainerOpts{
 		Base: corev1.Container{
-			Name: "reconfigure",
+			Name: ReconfigureContainer,
 			Command: []string{
 				"tini",
 				"-g",
@@ -372,7 +378,6 @@ func (b *ControllerBuilder) reconfigureContainer(container slinkyv1beta1.Contain
 			RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
 			VolumeMounts: []corev1.VolumeMount{
 				{Name: common.SlurmEtcVolume, MountPath: common.SlurmEtcDir, ReadOnly: true},
-				{Name: common.SlurmAuthSocketVolume, MountPath: common.SlurmctldAuthSocketDir, ReadOnly: true},
 			},
 		},
 		Merge: container.Container,
@@ -407,6 +412,38 @@ func (b *ControllerBuilder) getHashes(ctx context.Context, controller *slinkyv1b
 	return hashMap, nil
 }
 
+// getRestartHashes returns the hashes of the configuration which slurmctld must
+// be restarted for, the remaining configuration is reconfigured in-place.
+func (b *ControllerBuilder) getRestartHashes(ctx context.Context, controller *slinkyv1beta1.Controller) (map[string]string, error) {
+	hashMap, err := b.getAuthHashes(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &corev1.ConfigMap{}
+	configKey := controller.ConfigKey()
+	if err := b.client.Get(ctx, configKey, config); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	if hash := SlurmctldRestartHash(config); hash != "" {
+		hashMap = structutils.MergeMaps(hashMap, map[string]string{
+			common.AnnotationSlurmctldRestartHash: hash,
+		})
+	}
+
+	return hashMap, nil
+}
+
+// SlurmctldRestartHash returns the slurmctld restart hash of the Controller
+// ConfigMap, relative to its seed.
+func SlurmctldRestartHash(config *corev1.ConfigMap) string {
+	hash := common.SlurmctldRestartHash(config.Data[SlurmConfFile])
+	return common.RestartHash(hash, config.Annotations[common.AnnotationSlurmctldRestartHashSeed])
+}
+
 func (b *ControllerBuilder) getAuthHashes(ctx context.Context, controller *slinkyv1beta1.Controller) (map[string]string, error) {
 	authSlurm := &corev1.Secret{}
 	authSlurmKey := controller.AuthSlurmKey()
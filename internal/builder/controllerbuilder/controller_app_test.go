@@ -257,3 +257,42 @@ func TestBuildController_HA(t *testing.T) {
 		t.Errorf("HA readiness path = %q, want %q", got, common.SlurmLivez)
 	}
 }
+
+func TestBuildController_InplaceReconfigure(t *testing.T) {
+	const seedConf = "ClusterName=slurm\nSelectType=select/cons_tres"
+	c := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{Name: "slurm", Namespace: "slurm"},
+		Spec: slinkyv1beta1.ControllerSpec{
+			InplaceReconfigure: true,
+		},
+	}
+	newConfig := func(slurmConf string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: c.ConfigKey().Namespace,
+				Name:      c.ConfigKey().Name,
+				Annotations: map[string]string{
+					common.AnnotationSlurmctldRestartHashSeed: common.SlurmctldRestartHash(seedConf),
+				},
+			},
+			Data: map[string]string{
+				SlurmConfFile: slurmConf,
+			},
+		}
+	}
+
+	// Unchanged from the seed, the pod template has no restart hash.
+	b := New(fake.NewFakeClient(newConfig(seedConf)))
+	sts, err := b.BuildController(c)
+	require.NoError(t, err)
+	require.NotContains(t, sts.Spec.Template.Annotations, common.AnnotationSlurmctldRestartHash)
+	require.Equal(t, ReconfigureContainer, sts.Spec.Template.Spec.InitContainers[0].Name)
+	require.Equal(t, ptr.To(corev1.ContainerRestartPolicyAlways), sts.Spec.Template.Spec.InitContainers[0].RestartPolicy)
+
+	// Changed from the seed, the pod template has the restart hash.
+	changed := "ClusterName=slurm\nSelectType=select/linear"
+	b = New(fake.NewFakeClient(newConfig(changed)))
+	sts, err = b.BuildController(c)
+	require.NoError(t, err)
+	require.Equal(t, common.SlurmctldRestartHash(changed), sts.Spec.Template.Annotations[common.AnnotationSlurmctldRestartHash])
+}
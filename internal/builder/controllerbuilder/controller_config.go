@@ -5,28 +5,57 @@ package controllerbuilder
 
 import (
 	"context"
+	_ "embed"
 	"fmt"
+	"maps"
 	"path"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/puttsk/hostlist"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/crypto"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/topologyutils"
 )
 
 const (
-	SlurmConfFile  = "slurm.conf"
+	SlurmConfFile  = common.SlurmConfFile
 	CgroupConfFile = "cgroup.conf"
+	GresConfFile   = "gres.conf"
+
+	TopologyConfFile = "topology.conf"
+	TopologyYamlFile = "topology.yaml"
+
+	ResumeProgramFile  = "resume.sh"
+	SuspendProgramFile = "suspend.sh"
+
+	// SlurmConfigHashFile is the hash of the Slurm configuration, which the
+	// reconfigure sidecar reports once it is mounted.
+	SlurmConfigHashFile = "slurm-config.hash"
+)
+
+const (
+	// AnnotationConfigRefsHash is the hash of the config files and scripts which
+	// the Controller references, as they are not part of its ConfigMap.
+	AnnotationConfigRefsHash = slinkyv1beta1.SlinkyPrefix + "config-refs-hash"
 )
 
+//go:embed scripts/powersave.sh
+var powerSaveScript string
+
 func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Controller) (*corev1.ConfigMap, error) {
 	ctx := context.TODO()
 
@@ -46,6 +75,19 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		return nil, err
 	}
 
+	partitionList, err := b.refResolver.GetPartitionsForController(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+
+	// The referenced config files and scripts, whose changes are rolled out.
+	refData := map[string]string{}
+	addRefData := func(cm *corev1.ConfigMap) {
+		for file, data := range cm.Data {
+			refData[path.Join(cm.Name, file)] = data
+		}
+	}
+
 	configFilesList := &corev1.ConfigMapList{
 		Items: make([]corev1.ConfigMap, 0, len(controller.Spec.ConfigFileRefs)),
 	}
@@ -58,13 +100,24 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		if err := b.client.Get(ctx, key, cm); err != nil {
 			return nil, err
 		}
+		addRefData(cm)
 		configFilesList.Items = append(configFilesList.Items, *cm)
 	}
 	hasCgroupConfFile := false
+	hasGresConfFile := false
+	hasTopologyFile := false
 	for _, configMap := range configFilesList.Items {
 		if _, ok := configMap.Data[CgroupConfFile]; ok {
 			hasCgroupConfFile = true
-			break
+		}
+		if _, ok := configMap.Data[GresConfFile]; ok {
+			hasGresConfFile = true
+		}
+		if _, ok := configMap.Data[TopologyConfFile]; ok {
+			hasTopologyFile = true
+		}
+		if _, ok := configMap.Data[TopologyYamlFile]; ok {
+			hasTopologyFile = true
 		}
 	}
 
@@ -78,6 +131,7 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		if err := b.client.Get(ctx, key, cm); err != nil {
 			return nil, err
 		}
+		addRefData(cm)
 		filenames := structutils.Keys(cm.Data)
 		sort.Strings(filenames)
 		prologScripts = append(prologScripts, filenames...)
@@ -93,6 +147,7 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		if err := b.client.Get(ctx, key, cm); err != nil {
 			return nil, err
 		}
+		addRefData(cm)
 		filenames := structutils.Keys(cm.Data)
 		sort.Strings(filenames)
 		epilogScripts = append(epilogScripts, filenames...)
@@ -108,6 +163,7 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		if err := b.client.Get(ctx, key, cm); err != nil {
 			return nil, err
 		}
+		addRefData(cm)
 		filenames := structutils.Keys(cm.Data)
 		sort.Strings(filenames)
 		prologSlurmctldScripts = append(prologSlurmctldScripts, filenames...)
@@ -123,20 +179,24 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 		if err := b.client.Get(ctx, key, cm); err != nil {
 			return nil, err
 		}
+		addRefData(cm)
 		filenames := structutils.Keys(cm.Data)
 		sort.Strings(filenames)
 		epilogSlurmctldScripts = append(epilogSlurmctldScripts, filenames...)
 	}
 
+	refsHash := crypto.CheckSumFromMap(refData)
 	opts := common.ConfigMapOpts{
 		Key: controller.ConfigKey(),
 		Metadata: slinkyv1beta1.Metadata{
-			Annotations: controller.Annotations,
-			Labels:      structutils.MergeMaps(controller.Labels, labels.NewBuilder().WithControllerLabels(controller).Build()),
+			Annotations: structutils.MergeMaps(controller.Annotations, map[string]string{
+				AnnotationConfigRefsHash: refsHash,
+			}),
+			Labels: structutils.MergeMaps(controller.Labels, labels.NewBuilder().WithControllerLabels(controller).Build()),
 		},
 		Data: map[string]string{
 			SlurmConfFile: buildSlurmConf(
-				controller, accounting, nodesetList,
+				controller, accounting, nodesetList, partitionList,
 				prologScripts, epilogScripts,
 				prologSlurmctldScripts, epilogSlurmctldScripts,
 			),
@@ -145,6 +205,35 @@ func (b *ControllerBuilder) BuildControllerConfig(controller *slinkyv1beta1.Cont
 	if !hasCgroupConfFile {
 		opts.Data[CgroupConfFile] = buildCgroupConf()
 	}
+	if !hasGresConfFile {
+		if gresConf := buildGresConf(nodesetList); gresConf != "" {
+			opts.Data[GresConfFile] = gresConf
+		}
+	}
+	if !hasTopologyFile && len(controller.Spec.Topology.Topologies) > 0 {
+		nodeList := &corev1.NodeList{}
+		if err := b.client.List(ctx, nodeList); err != nil {
+			return nil, err
+		}
+		topologyYaml, err := topologyutils.BuildTopologyYaml(controller.Spec.Topology, nodeList.Items)
+		if err != nil {
+			return nil, err
+		}
+		if topologyYaml != "" {
+			opts.Data[TopologyYamlFile] = topologyYaml
+		}
+	}
+	if isPowerSavingEnabled(nodesetList) {
+		script := buildPowerSaveScript(controller.Spec.PowerSaving.Endpoint)
+		opts.Data[ResumeProgramFile] = script
+		opts.Data[SuspendProgramFile] = script
+	}
+	if controller.Spec.InplaceReconfigure {
+		// The referenced config files are mounted with the ConfigMap.
+		opts.Data[SlurmConfigHashFile] = crypto.CheckSumFromMap(structutils.MergeMaps(opts.Data, map[string]string{
+			AnnotationConfigRefsHash: refsHash,
+		}))
+	}
 
 	return b.CommonBuilder.BuildConfigMap(opts, controller)
 }
@@ -154,6 +243,7 @@ func buildSlurmConf(
 	controller *slinkyv1beta1.Controller,
 	accounting *slinkyv1beta1.Accounting,
 	nodesetList *slinkyv1beta1.NodeSetList,
+	partitionList *slinkyv1beta1.PartitionList,
 	prologScripts, epilogScripts []string,
 	prologSlurmctldScripts, epilogSlurmctldScripts []string,
 ) string {
@@ -173,6 +263,19 @@ func buildSlurmConf(
 			return params
 		}(),
 	}
+	gresTypes := getGresTypes(nodesetList)
+	if len(gresTypes) > 0 {
+		mergeConfig["GresTypes"] = gresTypes
+	}
+	powerSaving := isPowerSavingEnabled(nodesetList)
+	if powerSaving {
+		mergeConfig["SlurmctldParameters"] = append(mergeConfig["SlurmctldParameters"],
+			"cloud_reg_addrs",
+			"idle_on_node_suspend",
+		)
+	}
+	scheduling := controller.Spec.Scheduling
+	maps.Copy(mergeConfig, buildSchedulingMergeConfig(scheduling))
 
 	conf := config.NewBuilder()
 
@@ -196,6 +299,7 @@ func buildSlurmConf(
 	conf.AddProperty(config.NewProperty("SlurmdPort", common.SlurmdPort))
 	conf.AddProperty(config.NewProperty("SlurmdSpoolDir", common.SlurmdSpoolDir))
 	conf.AddProperty(config.NewProperty("MaxNodeCount", 1024)) // A non-zero value is required.
+	conf.AddProperty(config.NewProperty("RebootProgram", common.RebootProgram))
 
 	conf.AddProperty(config.NewPropertyRaw("#"))
 	conf.AddProperty(config.NewPropertyRaw("### LOGGING ###"))
@@ -211,6 +315,9 @@ func buildSlurmConf(
 	conf.AddProperty(config.NewProperty("AuthAltParameters", strings.Join(mergeConfig["AuthAltParameters"], ",")))
 	conf.AddProperty(config.NewProperty("AuthInfo", strings.Join(mergeConfig["AuthInfo"], ",")))
 	conf.AddProperty(config.NewProperty("SlurmctldParameters", strings.Join(mergeConfig["SlurmctldParameters"], ",")))
+	if len(gresTypes) > 0 {
+		conf.AddProperty(config.NewProperty("GresTypes", strings.Join(gresTypes, ",")))
+	}
 
 	metricsEnabled := controller.Spec.Metrics.Enabled
 	if metricsEnabled {
@@ -227,6 +334,12 @@ func buildSlurmConf(
 		conf.AddProperty(config.NewProperty("AccountingStorageType", "accounting_storage/none"))
 	}
 
+	if snippet := buildSchedulingConf(scheduling); snippet != "" {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### SCHEDULING ###"))
+		conf.AddProperty(config.NewPropertyRaw(snippet))
+	}
+
 	if snippet := buildPrologEpilogSlurmctldConf(prologSlurmctldScripts, epilogSlurmctldScripts); snippet != "" {
 		conf.AddProperty(config.NewPropertyRaw("#"))
 		conf.AddProperty(config.NewPropertyRaw("### SLURMCTLD PROLOG & EPILOG ###"))
@@ -239,7 +352,13 @@ func buildSlurmConf(
 		conf.AddProperty(config.NewPropertyRaw(snippet))
 	}
 
-	if snippet := buildNodeSetConf(nodesetList); snippet != "" {
+	if powerSaving {
+		conf.AddProperty(config.NewPropertyRaw("#"))
+		conf.AddProperty(config.NewPropertyRaw("### POWER SAVING ###"))
+		conf.AddProperty(config.NewPropertyRaw(buildPowerSavingConf(controller)))
+	}
+
+	if snippet := buildNodeSetConf(nodesetList, partitionList); snippet != "" {
 		conf.AddProperty(config.NewPropertyRaw("#"))
 		conf.AddProperty(config.NewPropertyRaw("### NODESET & PARTITION ###"))
 		conf.AddProperty(config.NewPropertyRaw(snippet))
@@ -261,6 +380,105 @@ func buildSlurmConf(
 	return conf.Build()
 }
 
+// buildSchedulingMergeConfig() returns the typed scheduling list parameters,
+// which are merged with those of ExtraConf.
+func buildSchedulingMergeConfig(scheduling slinkyv1beta1.ControllerScheduling) map[string][]string {
+	mergeConfig := map[string][]string{}
+	if len(scheduling.SchedulerParameters) > 0 {
+		mergeConfig["SchedulerParameters"] = scheduling.SchedulerParameters
+	}
+	if len(scheduling.SelectTypeParameters) > 0 {
+		mergeConfig["SelectTypeParameters"] = scheduling.SelectTypeParameters
+	}
+	if len(scheduling.Preemption.Mode) > 0 {
+		mergeConfig["PreemptMode"] = scheduling.Preemption.Mode
+	}
+	return mergeConfig
+}
+
+// SchedulingConfKeys returns the slurm.conf keys set by the typed scheduling
+// config, which ExtraConf overrides as it is rendered later. The list
+// parameters are not returned, as they are merged with those of ExtraConf.
+func SchedulingConfKeys(scheduling slinkyv1beta1.ControllerScheduling) []string {
+	lines, err := config.Parse(buildSchedulingConf(scheduling))
+	if err != nil {
+		return nil
+	}
+	mergeConfig := buildSchedulingMergeConfig(scheduling)
+	keys := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if _, ok := mergeConfig[line.Key()]; ok {
+			continue
+		}
+		keys = append(keys, line.Key())
+	}
+	return keys
+}
+
+// buildSchedulingConf() returns a slurm.conf snippet containing the typed
+// scheduling, resource selection, priority, preemption, and job defaults config.
+//
+// https://slurm.schedmd.com/slurm.conf.html#SECTION_SCHEDULING
+func buildSchedulingConf(scheduling slinkyv1beta1.ControllerScheduling) string {
+	conf := config.NewBuilder()
+
+	addString := func(key, value string) {
+		if value != "" {
+			conf.AddProperty(config.NewProperty(key, value))
+		}
+	}
+	addList := func(key string, values []string) {
+		if len(values) > 0 {
+			conf.AddProperty(config.NewProperty(key, strings.Join(values, ",")))
+		}
+	}
+	addInt32 := func(key string, value *int32) {
+		if value != nil {
+			conf.AddProperty(config.NewProperty(key, *value))
+		}
+	}
+	addInt64 := func(key string, value *int64) {
+		if value != nil {
+			conf.AddProperty(config.NewProperty(key, *value))
+		}
+	}
+
+	addString("SchedulerType", scheduling.SchedulerType)
+	addList("SchedulerParameters", scheduling.SchedulerParameters)
+	addString("SelectType", scheduling.SelectType)
+	addList("SelectTypeParameters", scheduling.SelectTypeParameters)
+
+	priority := scheduling.Priority
+	addString("PriorityType", priority.Type)
+	addInt32("PriorityWeightAge", priority.WeightAge)
+	addInt32("PriorityWeightAssoc", priority.WeightAssoc)
+	addInt32("PriorityWeightFairshare", priority.WeightFairshare)
+	addInt32("PriorityWeightJobSize", priority.WeightJobSize)
+	addInt32("PriorityWeightPartition", priority.WeightPartition)
+	addInt32("PriorityWeightQOS", priority.WeightQOS)
+	if len(priority.WeightTRES) > 0 {
+		tresNames := structutils.Keys(priority.WeightTRES)
+		sort.Strings(tresNames)
+		weights := make([]string, 0, len(tresNames))
+		for _, name := range tresNames {
+			weights = append(weights, fmt.Sprintf("%s=%d", name, priority.WeightTRES[name]))
+		}
+		addList("PriorityWeightTRES", weights)
+	}
+
+	preemption := scheduling.Preemption
+	addString("PreemptType", preemption.Type)
+	addList("PreemptMode", preemption.Mode)
+
+	jobDefaults := scheduling.JobDefaults
+	addInt64("DefMemPerCPU", jobDefaults.DefMemPerCPU)
+	addInt64("DefMemPerNode", jobDefaults.DefMemPerNode)
+	addInt64("DefMemPerGPU", jobDefaults.DefMemPerGPU)
+	addInt32("DefCpuPerGPU", jobDefaults.DefCpuPerGPU)
+
+	return conf.WithFinalNewline(false).Build()
+}
+
 // buildPrologEpilogConf() returns a slurm.conf snippet containing PrologSlurmctld and EpilogSlurmctld config.
 //
 // https://slurm.schedmd.com/slurm.conf.html#OPT_PrologSlurmctld
@@ -303,11 +521,12 @@ func buildPrologEpilogConf(prologScripts, epilogScripts []string) string {
 	return conf.WithFinalNewline(false).Build()
 }
 
-// buildNodeSetConf() returns a slurm.conf snippet containing NodeSets and their Partitions.
+// buildNodeSetConf() returns a slurm.conf snippet containing NodeSets and their Partitions,
+// followed by the Partitions which select NodeSets by label.
 //
 // https://slurm.schedmd.com/slurm.conf.html#SECTION_NODESET-CONFIGURATION
 // https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
-func buildNodeSetConf(nodesetList *slinkyv1beta1.NodeSetList) string {
+func buildNodeSetConf(nodesetList *slinkyv1beta1.NodeSetList, partitionList *slinkyv1beta1.PartitionList) string {
 	conf := config.NewBuilder()
 
 	sort.Slice(nodesetList.Items, func(i, j int) bool {
@@ -315,6 +534,11 @@ func buildNodeSetConf(nodesetList *slinkyv1beta1.NodeSetList) string {
 	})
 	for _, nodeset := range nodesetList.Items {
 		name := common.GetSlurmNodeSetName(&nodeset)
+		if common.IsPowerSavingEnabled(&nodeset) {
+			if nodeLine := buildPowerSavingNodeLine(&nodeset); nodeLine != "" {
+				conf.AddProperty(config.NewPropertyRaw(nodeLine))
+			}
+		}
 		nodesetLine := []string{
 			fmt.Sprintf("NodeSet=%v", name),
 			fmt.Sprintf("Feature=%v", name),
@@ -328,15 +552,244 @@ func buildNodeSetConf(nodesetList *slinkyv1beta1.NodeSetList) string {
 		partitionLine := []string{
 			fmt.Sprintf("PartitionName=%v", name),
 			fmt.Sprintf("Nodes=%v", name),
-			partition.Config,
 		}
+		if common.IsPowerSavingEnabled(&nodeset) {
+			suspendTime := durationOrDefault(nodeset.Spec.PowerSaving.SuspendTime, defaults.DefaultNodeSetPowerSavingSuspendTime)
+			partitionLine = append(partitionLine, fmt.Sprintf("SuspendTime=%d", int64(suspendTime.Seconds())))
+		}
+		partitionLine = append(partitionLine, partition.Config)
 		partitionLineRendered := strings.Join(partitionLine, " ")
 		conf.AddProperty(config.NewPropertyRaw(partitionLineRendered))
 	}
 
+	if partitionList != nil {
+		sort.Slice(partitionList.Items, func(i, j int) bool {
+			return partitionList.Items[i].Name < partitionList.Items[j].Name
+		})
+		for _, partition := range partitionList.Items {
+			conf.AddProperty(config.NewPropertyRaw(buildPartitionLine(&partition, nodesetList)))
+		}
+	}
+
+	return conf.WithFinalNewline(false).Build()
+}
+
+// buildPartitionLine() returns the slurm.conf partition line of the Partition,
+// whose nodes are the NodeSets selected by label.
+//
+// https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+func buildPartitionLine(partition *slinkyv1beta1.Partition, nodesetList *slinkyv1beta1.NodeSetList) string {
+	spec := partition.Spec
+	partitionLine := []string{
+		fmt.Sprintf("PartitionName=%v", partition.PartitionName()),
+	}
+	nodesets := common.GetNodeSetsForPartition(partition, nodesetList)
+	if len(nodesets) > 0 {
+		names := make([]string, 0, len(nodesets))
+		for _, nodeset := range nodesets {
+			names = append(names, common.GetSlurmNodeSetName(&nodeset))
+		}
+		partitionLine = append(partitionLine, fmt.Sprintf("Nodes=%v", strings.Join(names, ",")))
+	}
+	if spec.State != "" {
+		partitionLine = append(partitionLine, fmt.Sprintf("State=%v", spec.State))
+	}
+	if spec.MaxTime != "" {
+		partitionLine = append(partitionLine, fmt.Sprintf("MaxTime=%v", spec.MaxTime))
+	}
+	if spec.DefaultTime != "" {
+		partitionLine = append(partitionLine, fmt.Sprintf("DefaultTime=%v", spec.DefaultTime))
+	}
+	if len(spec.AllowAccounts) > 0 {
+		partitionLine = append(partitionLine, fmt.Sprintf("AllowAccounts=%v", strings.Join(spec.AllowAccounts, ",")))
+	}
+	if spec.PriorityTier != nil {
+		partitionLine = append(partitionLine, fmt.Sprintf("PriorityTier=%d", *spec.PriorityTier))
+	}
+	if spec.OverSubscribe != "" {
+		partitionLine = append(partitionLine, fmt.Sprintf("OverSubscribe=%v", spec.OverSubscribe))
+	}
+	if spec.Config != "" {
+		partitionLine = append(partitionLine, spec.Config)
+	}
+	return strings.Join(partitionLine, " ")
+}
+
+// isPowerSavingEnabled returns true if any NodeSet has power saving enabled.
+func isPowerSavingEnabled(nodesetList *slinkyv1beta1.NodeSetList) bool {
+	for _, nodeset := range nodesetList.Items {
+		if common.IsPowerSavingEnabled(&nodeset) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildPowerSavingConf() returns a slurm.conf snippet containing power saving config.
+// SuspendTime is configured per NodeSet partition, such that only those nodes are suspended.
+//
+// https://slurm.schedmd.com/power_save.html
+// https://slurm.schedmd.com/slurm.conf.html#OPT_ResumeProgram
+// https://slurm.schedmd.com/slurm.conf.html#OPT_SuspendProgram
+func buildPowerSavingConf(controller *slinkyv1beta1.Controller) string {
+	conf := config.NewBuilder()
+
+	powerSaving := controller.Spec.PowerSaving
+	resumeTimeout := durationOrDefault(powerSaving.ResumeTimeout, defaults.DefaultControllerPowerSavingResumeTimeout)
+	suspendTimeout := durationOrDefault(powerSaving.SuspendTimeout, defaults.DefaultControllerPowerSavingSuspendTimeout)
+
+	conf.AddProperty(config.NewProperty("ResumeProgram", path.Join(common.SlurmEtcDir, ResumeProgramFile)))
+	conf.AddProperty(config.NewProperty("SuspendProgram", path.Join(common.SlurmEtcDir, SuspendProgramFile)))
+	conf.AddProperty(config.NewProperty("ResumeTimeout", int64(resumeTimeout.Seconds())))
+	conf.AddProperty(config.NewProperty("SuspendTimeout", int64(suspendTimeout.Seconds())))
+
 	return conf.WithFinalNewline(false).Build()
 }
 
+// buildPowerSavingNodeLine() returns a slurm.conf NodeName line which defines
+// the NodeSet replicas as CLOUD nodes, to be resumed and suspended by Slurm.
+//
+// https://slurm.schedmd.com/slurm.conf.html#SECTION_NODE-CONFIGURATION
+func buildPowerSavingNodeLine(nodeset *slinkyv1beta1.NodeSet) string {
+	nodelist := getNodeList(nodeset)
+	if nodelist == "" {
+		return ""
+	}
+
+	features := []string{common.GetSlurmNodeSetName(nodeset)}
+	extraConf := map[string][]string{}
+	if conf, err := common.ParseExtraConf(nodeset.Spec.ExtraConf); err == nil {
+		extraConf = conf
+	}
+	features = append(features, extraConf["Feature"]...)
+	features = append(features, extraConf["Features"]...)
+	if gres := common.GetGres(nodeset); len(gres) > 0 {
+		extraConf["Gres"] = []string{common.FormatGres(gres)}
+	}
+
+	nodeLine := []string{
+		fmt.Sprintf("NodeName=%v", nodelist),
+		"State=CLOUD",
+		fmt.Sprintf("Features=%v", strings.Join(structutils.SortedDedup(features), ",")),
+	}
+	keys := structutils.Keys(extraConf)
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key == "Feature" || key == "Features" {
+			continue
+		}
+		nodeLine = append(nodeLine, fmt.Sprintf("%s=%s", key, strings.Join(extraConf[key], ",")))
+	}
+
+	return strings.Join(nodeLine, " ")
+}
+
+// getNodeList returns the hostlist expression of the Slurm node names of the
+// NodeSet replicas, or empty if there are none.
+func getNodeList(nodeset *slinkyv1beta1.NodeSet) string {
+	replicas := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+	if replicas <= 0 {
+		return ""
+	}
+	nodeNames := make([]string, 0, replicas)
+	for ordinal := range replicas {
+		nodeNames = append(nodeNames, common.GetSlurmNodeNameForOrdinal(nodeset, ordinal))
+	}
+	nodelist, err := hostlist.Compress(nodeNames)
+	if err != nil {
+		nodelist = strings.Join(nodeNames, ",")
+	}
+	return nodelist
+}
+
+// getGresTypes returns the sorted GRES names derived by the NodeSets.
+//
+// https://slurm.schedmd.com/slurm.conf.html#OPT_GresTypes
+func getGresTypes(nodesetList *slinkyv1beta1.NodeSetList) []string {
+	names := []string{}
+	for _, nodeset := range nodesetList.Items {
+		for _, gres := range common.GetGres(&nodeset) {
+			names = append(names, gres.Name)
+		}
+	}
+	return structutils.SortedDedup(names)
+}
+
+// buildGresConf() returns a gres.conf containing the GRES derived by the
+// NodeSets, for the Slurm nodes of their replicas. GRES with a File are
+// configured explicitly, "gpu" GRES are otherwise detected with AutoDetect,
+// if configured, and the rest are configured by count.
+//
+// DaemonSet NodeSet nodes are not known ahead of time, hence not configured;
+// slurmd then registers their GRES by count only.
+//
+// https://slurm.schedmd.com/gres.conf.html
+func buildGresConf(nodesetList *slinkyv1beta1.NodeSetList) string {
+	conf := config.NewBuilder()
+
+	sort.Slice(nodesetList.Items, func(i, j int) bool {
+		return nodesetList.Items[i].Name < nodesetList.Items[j].Name
+	})
+	for _, nodeset := range nodesetList.Items {
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			continue
+		}
+		gresList := common.GetGres(&nodeset)
+		if len(gresList) == 0 {
+			continue
+		}
+		nodelist := getNodeList(&nodeset)
+		if nodelist == "" {
+			continue
+		}
+		autoDetect := nodeset.Spec.Gres.AutoDetect
+		autoDetected := false
+		for _, gres := range gresList {
+			gresLine := []string{
+				fmt.Sprintf("NodeName=%v", nodelist),
+				fmt.Sprintf("Name=%v", gres.Name),
+			}
+			if gres.Type != "" {
+				gresLine = append(gresLine, fmt.Sprintf("Type=%v", gres.Type))
+			}
+			switch {
+			case gres.File != "":
+				gresLine = append(gresLine, fmt.Sprintf("File=%v", gres.File))
+			case autoDetect != "" && gres.Name == "gpu":
+				autoDetected = true
+				continue
+			default:
+				gresLine = append(gresLine, fmt.Sprintf("Count=%d", gres.Count))
+			}
+			conf.AddProperty(config.NewPropertyRaw(strings.Join(gresLine, " ")))
+		}
+		if autoDetected {
+			autoDetectLine := []string{
+				fmt.Sprintf("NodeName=%v", nodelist),
+				fmt.Sprintf("AutoDetect=%v", autoDetect),
+			}
+			conf.AddProperty(config.NewPropertyRaw(strings.Join(autoDetectLine, " ")))
+		}
+	}
+
+	return conf.Build()
+}
+
+// buildPowerSaveScript returns the ResumeProgram and SuspendProgram script,
+// which notifies the slurm-operator endpoint, if any.
+func buildPowerSaveScript(endpoint string) string {
+	// The endpoint is rendered within single quotes.
+	endpoint = strings.ReplaceAll(endpoint, "'", `'\''`)
+	return strings.ReplaceAll(powerSaveScript, "@ENDPOINT@", endpoint)
+}
+
+func durationOrDefault(d metav1.Duration, def time.Duration) time.Duration {
+	if d.Duration <= 0 {
+		return def
+	}
+	return d.Duration
+}
+
 // https://slurm.schedmd.com/cgroup.conf.html
 func buildCgroupConf() string {
 	conf := config.NewBuilder()
@@ -5,11 +5,14 @@ package controllerbuilder
 
 import (
 	"math/rand/v2"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -300,6 +303,7 @@ func TestControllerBuilder_BuildControllerConfig(t *testing.T) {
 		c          client.Client
 		controller *slinkyv1beta1.Controller
 		wantLine   []string
+		wantFiles  []string
 		wantErr    bool
 	}{
 		{
@@ -313,6 +317,7 @@ func TestControllerBuilder_BuildControllerConfig(t *testing.T) {
 			},
 			wantLine: []string{
 				"SlurmctldHost=slurm-controller-0(slurm-controller-0.slurm-controller-internal.slurm)",
+				"RebootProgram=/bin/true",
 			},
 		},
 		{
@@ -335,6 +340,117 @@ func TestControllerBuilder_BuildControllerConfig(t *testing.T) {
 				"SlurmctldHost=slurm-controller-1(slurm-controller-1.slurm-controller-internal.slurm)",
 			},
 		},
+		{
+			name: "gres types",
+			c: fake.NewFakeClient(func() *slinkyv1beta1.NodeSet {
+				nodeset := newGresNodeSet("gpu", 1, slinkyv1beta1.NodeSetGres{Enabled: true}, corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("4"),
+				})
+				nodeset.Namespace = "slurm"
+				nodeset.Spec.ControllerRef.Name = "slurm"
+				return &nodeset
+			}()),
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "slurm",
+					Namespace: "slurm",
+				},
+			},
+			wantLine: []string{
+				"GresTypes=gpu",
+			},
+		},
+		{
+			name: "scheduling merged with extraConf",
+			c:    fake.NewFakeClient(),
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "slurm",
+					Namespace: "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					ExtraConf: "SchedulerParameters=bf_window=100,max_rpc_cnt=150",
+					Scheduling: slinkyv1beta1.ControllerScheduling{
+						SchedulerType:       "sched/backfill",
+						SchedulerParameters: []string{"bf_continue", "bf_window=4320"},
+					},
+				},
+			},
+			wantLine: []string{
+				"SchedulerType=sched/backfill",
+				"SchedulerParameters=bf_continue,bf_window=4320",
+				"SchedulerParameters=bf_continue,bf_window=4320,max_rpc_cnt=150",
+			},
+		},
+		{
+			name: "topology",
+			c: fake.NewFakeClient(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node0",
+					Labels: map[string]string{
+						corev1.LabelTopologyZone: "zone-a",
+					},
+				},
+			}),
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "slurm",
+					Namespace: "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					Topology: slinkyv1beta1.ControllerTopology{
+						Topologies: []slinkyv1beta1.ControllerTopologySpec{
+							{
+								Name: "topo-switch",
+								Tree: &slinkyv1beta1.ControllerTopologyTree{
+									SwitchLabels: []string{corev1.LabelTopologyZone},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantFiles: []string{
+				SlurmConfFile,
+				TopologyYamlFile,
+			},
+		},
+		{
+			name: "topology from configFileRefs",
+			c: fake.NewFakeClient(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "slurm-config",
+					Namespace: "slurm",
+				},
+				Data: map[string]string{
+					TopologyConfFile: "SwitchName=s0 Nodes=node0",
+				},
+			}),
+			controller: &slinkyv1beta1.Controller{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "slurm",
+					Namespace: "slurm",
+				},
+				Spec: slinkyv1beta1.ControllerSpec{
+					ConfigFileRefs: []corev1.LocalObjectReference{
+						{Name: "slurm-config"},
+					},
+					Topology: slinkyv1beta1.ControllerTopology{
+						Topologies: []slinkyv1beta1.ControllerTopologySpec{
+							{
+								Name: "topo-switch",
+								Tree: &slinkyv1beta1.ControllerTopologyTree{
+									SwitchLabels: []string{corev1.LabelTopologyZone},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantFiles: []string{
+				SlurmConfFile,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -352,6 +468,16 @@ func TestControllerBuilder_BuildControllerConfig(t *testing.T) {
 					t.Errorf("BuildControllerConfig() = %v \n want to find = %s", conf, host)
 				}
 			}
+			for _, file := range []string{TopologyYamlFile, TopologyConfFile} {
+				if _, ok := got.Data[file]; ok && !slices.Contains(tt.wantFiles, file) {
+					t.Errorf("BuildControllerConfig() unexpected file = %s", file)
+				}
+			}
+			for _, file := range tt.wantFiles {
+				if _, ok := got.Data[file]; !ok {
+					t.Errorf("BuildControllerConfig() missing file = %s", file)
+				}
+			}
 		})
 	}
 }
@@ -508,9 +634,10 @@ func TestBuilder_BuildControllerConfigExternal(t *testing.T) {
 
 func Test_buildNodeSetConf(t *testing.T) {
 	tests := []struct {
-		name        string
-		nodesetList *slinkyv1beta1.NodeSetList
-		want        string
+		name          string
+		nodesetList   *slinkyv1beta1.NodeSetList
+		partitionList *slinkyv1beta1.PartitionList
+		want          string
 	}{
 		{
 			name: "empty",
@@ -567,6 +694,158 @@ PartitionName=nodeset-1 Nodes=nodeset-1 MaxTime=UNLIMITED OverSubscribe=EXCLUSIV
 NodeSet=nodeset-2 Feature=nodeset-2
 PartitionName=nodeset-2 Nodes=nodeset-2 MaxTime=UNLIMITED PreemptMode=REQUEUE`,
 		},
+		{
+			name: "power saving",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "cloud",
+						},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							Replicas:  ptr.To[int32](3),
+							ExtraConf: "Weight=10 Features=gpu CPUs=4",
+							Partition: slinkyv1beta1.NodeSetPartition{
+								Enabled: true,
+								Config:  "MaxTime=UNLIMITED",
+							},
+							PowerSaving: slinkyv1beta1.NodeSetPowerSaving{
+								Enabled:     true,
+								SuspendTime: metav1.Duration{Duration: 5 * time.Minute},
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "empty",
+						},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							Replicas: ptr.To[int32](0),
+							PowerSaving: slinkyv1beta1.NodeSetPowerSaving{
+								Enabled: true,
+							},
+						},
+					},
+				},
+			},
+			want: `NodeName=cloud-[0-2] State=CLOUD Features=cloud,gpu Cpus=4 Weight=10
+NodeSet=cloud Feature=cloud
+PartitionName=cloud Nodes=cloud SuspendTime=300 MaxTime=UNLIMITED
+NodeSet=empty Feature=empty`,
+		},
+		{
+			name: "power saving with gres",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "cloud",
+						},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							Replicas:  ptr.To[int32](2),
+							ExtraConf: "Weight=10",
+							Gres: slinkyv1beta1.NodeSetGres{
+								Enabled: true,
+							},
+							Slurmd: slinkyv1beta1.ContainerWrapper{
+								Container: corev1.Container{
+									Resources: corev1.ResourceRequirements{
+										Limits: corev1.ResourceList{
+											"nvidia.com/gpu": resource.MustParse("2"),
+										},
+									},
+								},
+							},
+							PowerSaving: slinkyv1beta1.NodeSetPowerSaving{
+								Enabled: true,
+							},
+						},
+					},
+				},
+			},
+			want: `NodeName=cloud-[0-1] State=CLOUD Features=cloud Gres=gpu:2 Weight=10
+NodeSet=cloud Feature=cloud`,
+		},
+		{
+			name: "partitions",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "gpu-a",
+							Labels:    map[string]string{"gpu": "true"},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "gpu-b",
+							Labels:    map[string]string{"gpu": "true"},
+						},
+						Spec: slinkyv1beta1.NodeSetSpec{
+							Partition: slinkyv1beta1.NodeSetPartition{
+								Enabled: true,
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "cpu",
+						},
+					},
+				},
+			},
+			partitionList: &slinkyv1beta1.PartitionList{
+				Items: []slinkyv1beta1.Partition{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "gpu-all",
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							NodeSetSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"gpu": "true"},
+							},
+							State:         slinkyv1beta1.PartitionStateDrain,
+							MaxTime:       "1-00:00:00",
+							DefaultTime:   "60",
+							AllowAccounts: []string{"physics", "chemistry"},
+							PriorityTier:  ptr.To[int32](10),
+							OverSubscribe: "FORCE:2",
+							Config:        "PreemptMode=REQUEUE",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "all",
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							NodeSetSelector: &metav1.LabelSelector{},
+							State:           slinkyv1beta1.PartitionStateUp,
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "none",
+						},
+					},
+				},
+			},
+			want: `NodeSet=cpu Feature=cpu
+NodeSet=gpu-a Feature=gpu-a
+NodeSet=gpu-b Feature=gpu-b
+PartitionName=gpu-b Nodes=gpu-b 
+PartitionName=all Nodes=cpu,gpu-a,gpu-b State=UP
+PartitionName=gpu-all Nodes=gpu-a,gpu-b State=DRAIN MaxTime=1-00:00:00 DefaultTime=60 AllowAccounts=physics,chemistry PriorityTier=10 OverSubscribe=FORCE:2 PreemptMode=REQUEUE
+PartitionName=none`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -579,12 +858,189 @@ PartitionName=nodeset-2 Nodes=nodeset-2 MaxTime=UNLIMITED PreemptMode=REQUEUE`,
 					randomized[j] = tt.nodesetList.Items[idx[j]]
 				}
 
-				require.Equal(t, tt.want, buildNodeSetConf(tt.nodesetList))
+				require.Equal(t, tt.want, buildNodeSetConf(tt.nodesetList, tt.partitionList))
 			}
 		})
 	}
 }
 
+func newGresNodeSet(name string, replicas int32, gres slinkyv1beta1.NodeSetGres, limits corev1.ResourceList) slinkyv1beta1.NodeSet {
+	return slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			Replicas: ptr.To(replicas),
+			Gres:     gres,
+			Slurmd: slinkyv1beta1.ContainerWrapper{
+				Container: corev1.Container{
+					Resources: corev1.ResourceRequirements{
+						Limits: limits,
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_getGresTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		nodesetList *slinkyv1beta1.NodeSetList
+		want        []string
+	}{
+		{
+			name:        "empty",
+			nodesetList: &slinkyv1beta1.NodeSetList{},
+			want:        []string{},
+		},
+		{
+			name: "non-empty",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					newGresNodeSet("cpu", 1, slinkyv1beta1.NodeSetGres{Enabled: true}, nil),
+					newGresNodeSet("gpu-a", 1, slinkyv1beta1.NodeSetGres{Enabled: true}, corev1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("4"),
+					}),
+					newGresNodeSet("gpu-b", 1, slinkyv1beta1.NodeSetGres{
+						Enabled: true,
+						Resources: []slinkyv1beta1.NodeSetGresResource{
+							{ResourceName: "example.com/gpu", Name: "gpu"},
+							{ResourceName: "example.com/fpga", Name: "fpga"},
+						},
+					}, corev1.ResourceList{
+						"example.com/gpu":  resource.MustParse("1"),
+						"example.com/fpga": resource.MustParse("1"),
+					}),
+					newGresNodeSet("disabled", 1, slinkyv1beta1.NodeSetGres{}, corev1.ResourceList{
+						"example.com/mps": resource.MustParse("1"),
+					}),
+				},
+			},
+			want: []string{"fpga", "gpu"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getGresTypes(tt.nodesetList))
+		})
+	}
+}
+
+func Test_buildGresConf(t *testing.T) {
+	fakeResources := []slinkyv1beta1.NodeSetGresResource{
+		{ResourceName: "example.com/gpu-a", Name: "gpu", Type: "a", File: "/dev/gpu-a[0-1]"},
+		{ResourceName: "example.com/gpu-b", Name: "gpu", Type: "b"},
+		{ResourceName: "example.com/fpga", Name: "fpga"},
+	}
+	fakeLimits := corev1.ResourceList{
+		"example.com/gpu-a": resource.MustParse("2"),
+		"example.com/gpu-b": resource.MustParse("4"),
+		"example.com/fpga":  resource.MustParse("1"),
+	}
+	tests := []struct {
+		name        string
+		nodesetList *slinkyv1beta1.NodeSetList
+		want        string
+	}{
+		{
+			name:        "empty",
+			nodesetList: &slinkyv1beta1.NodeSetList{},
+			want:        "",
+		},
+		{
+			name: "without gres",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					newGresNodeSet("cpu", 2, slinkyv1beta1.NodeSetGres{Enabled: true}, nil),
+				},
+			},
+			want: "",
+		},
+		{
+			name: "count",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					newGresNodeSet("gpu", 2, slinkyv1beta1.NodeSetGres{Enabled: true}, corev1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("4"),
+					}),
+				},
+			},
+			want: "NodeName=gpu-[0-1] Name=gpu Count=4\n",
+		},
+		{
+			name: "autodetect",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					newGresNodeSet("gpu", 2, slinkyv1beta1.NodeSetGres{Enabled: true, AutoDetect: "nvidia"}, corev1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("4"),
+					}),
+				},
+			},
+			want: "NodeName=gpu-[0-1] AutoDetect=nvidia\n",
+		},
+		{
+			name: "mapped resources",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					newGresNodeSet("mixed", 3, slinkyv1beta1.NodeSetGres{
+						Enabled:    true,
+						Resources:  fakeResources,
+						AutoDetect: "nvml",
+					}, fakeLimits),
+					newGresNodeSet("fpga", 1, slinkyv1beta1.NodeSetGres{
+						Enabled:   true,
+						Resources: fakeResources,
+					}, corev1.ResourceList{
+						"example.com/fpga": resource.MustParse("2"),
+					}),
+				},
+			},
+			want: strings.Join([]string{
+				"NodeName=fpga-0 Name=fpga Count=2",
+				"NodeName=mixed-[0-2] Name=fpga Count=1",
+				"NodeName=mixed-[0-2] Name=gpu Type=a File=/dev/gpu-a[0-1]",
+				"NodeName=mixed-[0-2] AutoDetect=nvml",
+				"",
+			}, "\n"),
+		},
+		{
+			name: "skipped nodesets",
+			nodesetList: &slinkyv1beta1.NodeSetList{
+				Items: []slinkyv1beta1.NodeSet{
+					newGresNodeSet("disabled", 1, slinkyv1beta1.NodeSetGres{}, corev1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("4"),
+					}),
+					newGresNodeSet("empty", 0, slinkyv1beta1.NodeSetGres{Enabled: true}, corev1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("4"),
+					}),
+					func() slinkyv1beta1.NodeSet {
+						nodeset := newGresNodeSet("daemonset", 1, slinkyv1beta1.NodeSetGres{Enabled: true}, corev1.ResourceList{
+							"nvidia.com/gpu": resource.MustParse("4"),
+						})
+						nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+						return nodeset
+					}(),
+					func() slinkyv1beta1.NodeSet {
+						nodeset := newGresNodeSet("extraconf", 1, slinkyv1beta1.NodeSetGres{Enabled: true}, corev1.ResourceList{
+							"nvidia.com/gpu": resource.MustParse("4"),
+						})
+						nodeset.Spec.ExtraConf = "Gres=gpu:4"
+						return nodeset
+					}(),
+				},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, buildGresConf(tt.nodesetList))
+		})
+	}
+}
+
 func Test_buildPrologEpilogConf(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -710,3 +1166,168 @@ EpilogSlurmctld=/etc/slurm/epilog-slurmctld-2.sh`,
 		})
 	}
 }
+
+func Test_buildPowerSavingConf(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		want       string
+	}{
+		{
+			name:       "defaults",
+			controller: &slinkyv1beta1.Controller{},
+			want: `ResumeProgram=/etc/slurm/resume.sh
+SuspendProgram=/etc/slurm/suspend.sh
+ResumeTimeout=300
+SuspendTimeout=60`,
+		},
+		{
+			name: "timeouts",
+			controller: &slinkyv1beta1.Controller{
+				Spec: slinkyv1beta1.ControllerSpec{
+					PowerSaving: slinkyv1beta1.ControllerPowerSaving{
+						ResumeTimeout:  metav1.Duration{Duration: 10 * time.Minute},
+						SuspendTimeout: metav1.Duration{Duration: 90 * time.Second},
+					},
+				},
+			},
+			want: `ResumeProgram=/etc/slurm/resume.sh
+SuspendProgram=/etc/slurm/suspend.sh
+ResumeTimeout=600
+SuspendTimeout=90`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, buildPowerSavingConf(tt.controller))
+		})
+	}
+}
+
+func Test_buildSchedulingConf(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheduling slinkyv1beta1.ControllerScheduling
+		want       string
+	}{
+		{
+			name:       "empty",
+			scheduling: slinkyv1beta1.ControllerScheduling{},
+			want:       "",
+		},
+		{
+			name: "all",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				SchedulerType:        "sched/backfill",
+				SchedulerParameters:  []string{"bf_continue", "bf_window=4320"},
+				SelectType:           "select/cons_tres",
+				SelectTypeParameters: []string{"CR_Core_Memory"},
+				Priority: slinkyv1beta1.ControllerPriority{
+					Type:            "priority/multifactor",
+					WeightAge:       ptr.To[int32](1000),
+					WeightFairshare: ptr.To[int32](10000),
+					WeightQOS:       ptr.To[int32](0),
+					WeightTRES: map[string]int32{
+						"GRES/gpu": 3000,
+						"CPU":      1000,
+						"Mem":      2000,
+					},
+				},
+				Preemption: slinkyv1beta1.ControllerPreemption{
+					Type: "preempt/qos",
+					Mode: []string{"SUSPEND", "GANG"},
+				},
+				JobDefaults: slinkyv1beta1.ControllerJobDefaults{
+					DefMemPerCPU: ptr.To[int64](2048),
+					DefCpuPerGPU: ptr.To[int32](8),
+				},
+			},
+			want: `SchedulerType=sched/backfill
+SchedulerParameters=bf_continue,bf_window=4320
+SelectType=select/cons_tres
+SelectTypeParameters=CR_Core_Memory
+PriorityType=priority/multifactor
+PriorityWeightAge=1000
+PriorityWeightFairshare=10000
+PriorityWeightQOS=0
+PriorityWeightTRES=CPU=1000,GRES/gpu=3000,Mem=2000
+PreemptType=preempt/qos
+PreemptMode=SUSPEND,GANG
+DefMemPerCPU=2048
+DefCpuPerGPU=8`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, buildSchedulingConf(tt.scheduling))
+		})
+	}
+}
+
+func TestSchedulingConfKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheduling slinkyv1beta1.ControllerScheduling
+		want       []string
+	}{
+		{
+			name:       "empty",
+			scheduling: slinkyv1beta1.ControllerScheduling{},
+			want:       []string{},
+		},
+		{
+			name: "without merged list parameters",
+			scheduling: slinkyv1beta1.ControllerScheduling{
+				SchedulerType:       "sched/backfill",
+				SchedulerParameters: []string{"bf_continue"},
+				Priority: slinkyv1beta1.ControllerPriority{
+					WeightQOS: ptr.To[int32](0),
+				},
+				Preemption: slinkyv1beta1.ControllerPreemption{
+					Type: "preempt/qos",
+					Mode: []string{"SUSPEND", "GANG"},
+				},
+				JobDefaults: slinkyv1beta1.ControllerJobDefaults{
+					DefMemPerCPU: ptr.To[int64](2048),
+				},
+			},
+			want: []string{"SchedulerType", "PriorityWeightQOS", "PreemptType", "DefMemPerCPU"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, SchedulingConfKeys(tt.scheduling))
+		})
+	}
+}
+
+func Test_buildPowerSaveScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		want     string
+	}{
+		{
+			name:     "empty",
+			endpoint: "",
+			want:     "ENDPOINT=''",
+		},
+		{
+			name:     "endpoint",
+			endpoint: "http://slurm-operator.slinky:8082",
+			want:     "ENDPOINT='http://slurm-operator.slinky:8082'",
+		},
+		{
+			name:     "quoted",
+			endpoint: "http://foo'bar",
+			want:     `ENDPOINT='http://foo'\''bar'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPowerSaveScript(tt.endpoint)
+			require.Contains(t, got, tt.want)
+			require.NotContains(t, got, "@ENDPOINT@")
+		})
+	}
+}
@@ -0,0 +1,35 @@
+#!/usr/bin/env bash
+# SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+# SPDX-License-Identifier: Apache-2.0
+
+set -uo pipefail
+
+# Slurm ResumeProgram and SuspendProgram, invoked by slurmctld with a hostlist.
+#
+# The slurm-operator observes the node power states from Slurm, hence this only
+# notifies the slurm-operator to reconcile the NodeSets of the nodes sooner. The
+# notification is authenticated with a short-lived Slurm JWT of the SlurmUser.
+ACTION="$(basename "$0" .sh)"
+ENDPOINT='@ENDPOINT@'
+NODES="${1:-}"
+
+function main() {
+	echo "[$(date)] Power saving action '$ACTION' for nodes: $NODES"
+	if [ -z "$ENDPOINT" ]; then
+		return 0
+	fi
+	local token
+	token="$(scontrol token lifespan=60 2>/dev/null | sed -n 's/^SLURM_JWT=//p')"
+	if [ -z "$token" ]; then
+		echo "[$(date)] Failed to get Slurm auth token, continuing..."
+		return 0
+	fi
+	if ! curl --silent --show-error --fail --max-time 10 \
+		--request POST --data-urlencode "nodes=$NODES" \
+		--header "Authorization: Bearer $token" \
+		"${ENDPOINT%/}/powersave/$ACTION"; then
+		echo "[$(date)] Failed to notify '$ENDPOINT', continuing..."
+	fi
+	return 0
+}
+main
@@ -5,38 +5,33 @@
 set -euo pipefail
 
 SLURM_DIR="/etc/slurm"
+HASH_FILE="$SLURM_DIR/slurm-config.hash"
 INTERVAL="5"
 
-# Returns the canonical checksum hash of all Slurm configuration files.
-#
-# Avoids kubelet projected-volume directories (..data, ..YYYY_MM_DD_*).
+# Returns the hash of the mounted Slurm configuration.
 function getHash() {
-	find "$SLURM_DIR" -path '*/..*' -prune -o \( -type f -o -type l \) -exec sha256sum {} \; | sort -k2 | sha256sum
-}
-
-# Issues a cluster reconfigure request with retry.
-function reconfigure() {
-	echo "[$(date)] Reconfiguring Slurm..."
-	until scontrol reconfigure; do
-		echo "[$(date)] Failed to reconfigure, try again..."
-		sleep 2
-	done
-	echo "[$(date)] SUCCESS"
+	cat "$HASH_FILE" 2>/dev/null || true
 }
 
+# Reports the hash of the Slurm configuration which slurmctld can read, such
+# that the operator only reconfigures Slurm once the kubelet has mounted it.
+#
+# The hash is reported as the last field of the last log line, on start and
+# whenever the mounted configuration changes, which the operator reads back.
 function main() {
 	local lastHash=""
 	local newHash=""
 
-	echo "[$(date)] Start '$SLURM_DIR' polling"
+	echo "[$(date)] Start '$HASH_FILE' polling"
 	lastHash="$(getHash)"
+	echo "[$(date)] Slurm configuration mounted: $lastHash"
 	while true; do
+		sleep "$INTERVAL"
 		newHash="$(getHash)"
 		if [ "$newHash" != "$lastHash" ]; then
-			reconfigure
+			echo "[$(date)] Slurm configuration mounted: $newHash"
 			lastHash="$newHash"
 		fi
-		sleep "$INTERVAL"
 	done
 }
 main
@@ -195,28 +195,33 @@ func (b *WorkerBuilder) slurmdContainer(nodeset *slinkyv1beta1.NodeSet, controll
 
 	cpus, memory := b.getResourceLimits(&nodeset.Spec)
 
-	opts := common.ContainerOpts{
-		Base: corev1.Container{
-			Name: labels.WorkerApp,
-			Args: slurmdArgs(nodeset, controller),
-			Env: []corev1.EnvVar{
-				{
-					Name: "SLINKY_TOPOLOGY",
-					ValueFrom: &corev1.EnvVarSource{
-						FieldRef: &corev1.ObjectFieldSelector{
-							FieldPath: fmt.Sprintf("metadata.annotations['%s']", slinkyv1beta1.AnnotationNodeTopologySpec),
-						},
-					},
-				},
-				{
-					Name:  "POD_CPUS",
-					Value: strconv.FormatInt(cpus, 10),
-				},
-				{
-					Name:  "POD_MEMORY",
-					Value: strconv.FormatInt(memory, 10),
+	env := []corev1.EnvVar{
+		{
+			Name: "SLINKY_TOPOLOGY",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", slinkyv1beta1.AnnotationNodeTopologySpec),
 				},
 			},
+		},
+		{
+			Name:  "POD_CPUS",
+			Value: strconv.FormatInt(cpus, 10),
+		},
+		{
+			Name:  "POD_MEMORY",
+			Value: strconv.FormatInt(memory, 10),
+		},
+	}
+	if nodeset.Spec.CpuTopology.Enabled {
+		env = append(env, cpuSpecEnvVar())
+	}
+
+	opts := common.ContainerOpts{
+		Base: corev1.Container{
+			Name:  labels.WorkerApp,
+			Args:  slurmdArgs(nodeset, controller),
+			Env:   env,
 			Ports: ports,
 			StartupProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
@@ -277,45 +282,49 @@ func (b *WorkerBuilder) slurmdContainer(nodeset *slinkyv1beta1.NodeSet, controll
 }
 
 func slurmdArgs(nodeset *slinkyv1beta1.NodeSet, controller *slinkyv1beta1.Controller) []string {
+	// Power saving nodes are defined in slurm.conf as CLOUD nodes, hence they
+	// are not dynamic and cannot have their node config overridden.
+	if common.IsPowerSavingEnabled(nodeset) {
+		return common.ConfiglessArgs(controller)
+	}
 	args := []string{"-Z"}
 	args = append(args, common.ConfiglessArgs(controller)...)
 	args = append(args, slurmdConfArgs(nodeset)...)
 	return args
 }
 
-// ParseExtraConf parses a NodeSet's Spec.ExtraConf into a map of normalized keys
-// to their values. The expected shape is space-separated Key=Value pairs; each
-// value can be a comma-separated list (e.g. Features=a,b,c). Keys are title-cased
-// so "feature=a" and "Feature=a" both produce key "Feature".
-//
-// Tokens are split on whitespace, so leading, trailing, and repeated whitespace
-// are tolerated; any token without '=' returns an error. Exported so the NodeSet
-// validation webhook can reject malformed input with the same parser the worker
-// builder uses, keeping the accepted set identical at admission and at build time.
-func ParseExtraConf(extraConf string) (map[string][]string, error) {
-	out := make(map[string][]string, 10)
-	for item := range strings.FieldsSeq(extraConf) {
-		pair := strings.SplitN(item, "=", 2)
-		if len(pair) != 2 {
-			return nil, fmt.Errorf("malformed --conf item %q: expected Key=Value", item)
-		}
-		key := cases.Title(language.English).String(pair[0])
-		out[key] = append(out[key], strings.Split(pair[1], ",")...)
+func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
+	confMap := slurmdConfMap(nodeset)
+	if _, ok := confMap["Topology"]; !ok {
+		confMap["Topology"] = `'"$SLINKY_TOPOLOGY"'`
+	}
+	conf := joinConfMap(confMap)
+	if nodeset.Spec.CpuTopology.Enabled {
+		conf += fmt.Sprintf(` '"$%s"'`, cpuSpecEnv)
 	}
-	return out, nil
+	return []string{"--conf", fmt.Sprintf("'%s'", conf)}
 }
 
-func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
+// SlurmdNodeConf returns the node configuration which slurmd registers with via
+// --conf, excluding the parts that are only known within the pod (e.g. Topology).
+// It is used to create Slurm node records before the NodeSet pod exists.
+func SlurmdNodeConf(nodeset *slinkyv1beta1.NodeSet) string {
+	return joinConfMap(slurmdConfMap(nodeset))
+}
+
+func slurmdConfMap(nodeset *slinkyv1beta1.NodeSet) map[string]string {
 	confMap := map[string]string{
 		"Features": strings.Join(baselineFeatures(nodeset), ","),
-		"Topology": `'"$SLINKY_TOPOLOGY"'`,
+	}
+	if gres := common.GetGres(nodeset); len(gres) > 0 {
+		confMap["Gres"] = common.FormatGres(gres)
 	}
 
 	// The NodeSet webhook validates ExtraConf at admission, but it is a separate,
 	// optional deployment, so the manager cannot assume it ran. On a parse error,
 	// degrade to the baseline (matching baselineFeatures) instead of failing the
 	// NodeSet's reconcile.
-	if conf, err := ParseExtraConf(nodeset.Spec.ExtraConf); err == nil {
+	if conf, err := common.ParseExtraConf(nodeset.Spec.ExtraConf); err == nil {
 		for key, vals := range conf {
 			if key == "Feature" || key == "Features" {
 				continue
@@ -324,12 +333,16 @@ func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
 		}
 	}
 
+	return confMap
+}
+
+func joinConfMap(confMap map[string]string) string {
 	confList := make([]string, 0, len(confMap))
 	for key, val := range confMap {
 		confList = append(confList, fmt.Sprintf("%s=%s", key, val))
 	}
 	sort.Strings(confList)
-	return []string{"--conf", fmt.Sprintf("'%s'", strings.Join(confList, " "))}
+	return strings.Join(confList, " ")
 }
 
 // baselineFeatures returns the sorted, de-duplicated list of Slurm features baked
@@ -341,13 +354,64 @@ func slurmdConfArgs(nodeset *slinkyv1beta1.NodeSet) []string {
 // webhook rejects such input at admission when it is enabled.
 func baselineFeatures(nodeset *slinkyv1beta1.NodeSet) []string {
 	raw := []string{common.GetSlurmNodeSetName(nodeset)}
-	if conf, err := ParseExtraConf(nodeset.Spec.ExtraConf); err == nil {
+	if conf, err := common.ParseExtraConf(nodeset.Spec.ExtraConf); err == nil {
 		raw = append(raw, conf["Feature"]...)
 		raw = append(raw, conf["Features"]...)
 	}
 	return structutils.SortedDedup(raw)
 }
 
+// liveNodeConfKeys are the ExtraConf keys of Slurm node attributes which can be
+// updated on a registered Slurm node, hence without restarting slurmd.
+var liveNodeConfKeys = map[string]bool{
+	"Feature":  true,
+	"Features": true,
+	"Weight":   true,
+}
+
+// NodeAttributes are the Slurm node attributes of a NodeSet which can be
+// updated on a registered Slurm node.
+type NodeAttributes struct {
+	// Features are the baseline features of the Slurm node.
+	Features []string `json:"features,omitempty"`
+	// Weight is the scheduling weight of the Slurm node, or nil if unset.
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// GetNodeAttributes returns the Slurm node attributes of the NodeSet, which
+// slurmd registers with via --conf, that can be updated live.
+func GetNodeAttributes(nodeset *slinkyv1beta1.NodeSet) NodeAttributes {
+	attrs := NodeAttributes{
+		Features: baselineFeatures(nodeset),
+	}
+	if conf, err := common.ParseExtraConf(nodeset.Spec.ExtraConf); err == nil {
+		if vals := conf["Weight"]; len(vals) > 0 {
+			if weight, err := strconv.ParseInt(vals[len(vals)-1], 10, 32); err == nil {
+				attrs.Weight = ptr.To(int32(weight))
+			}
+		}
+	}
+	return attrs
+}
+
+// PodExtraConf returns the NodeSet's Spec.ExtraConf without the Slurm node
+// attributes which can be updated live, hence the part of it which can only
+// take effect by restarting slurmd. A malformed ExtraConf is returned as is.
+func PodExtraConf(extraConf string) string {
+	if _, err := common.ParseExtraConf(extraConf); err != nil {
+		return extraConf
+	}
+	items := []string{}
+	for item := range strings.FieldsSeq(extraConf) {
+		key, _, _ := strings.Cut(item, "=")
+		if liveNodeConfKeys[cases.Title(language.English).String(key)] {
+			continue
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, " ")
+}
+
 func (b *WorkerBuilder) getWorkerHashes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (map[string]string, error) {
 	sshConfig := &corev1.ConfigMap{}
 	sshConfigKey := nodeset.SshConfigKey()
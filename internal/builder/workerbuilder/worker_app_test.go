@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
 	"k8s.io/utils/set"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -343,36 +344,6 @@ func TestWorkerBuilder_getResourceLimits(t *testing.T) {
 	}
 }
 
-func TestParseExtraConf(t *testing.T) {
-	cases := []struct {
-		name    string
-		input   string
-		want    map[string][]string
-		wantErr bool
-	}{
-		{name: "empty", input: "", want: map[string][]string{}},
-		{name: "single", input: "Weight=10", want: map[string][]string{"Weight": {"10"}}},
-		{name: "multiple keys", input: "Feature=a Weight=10", want: map[string][]string{"Feature": {"a"}, "Weight": {"10"}}},
-		{name: "csv value splits", input: "Features=a,b,c", want: map[string][]string{"Features": {"a", "b", "c"}}},
-		{name: "case normalised", input: "feature=a", want: map[string][]string{"Feature": {"a"}}},
-		{name: "repeated and surrounding whitespace tolerated", input: "  Weight=5  Feature=a ", want: map[string][]string{"Weight": {"5"}, "Feature": {"a"}}},
-		{name: "missing equals", input: "Weight10", wantErr: true},
-		{name: "missing equals among valid", input: "Weight=5 nope Feature=a", wantErr: true},
-		{name: "Feature and Features stay distinct keys", input: "Feature=a Features=b", want: map[string][]string{"Feature": {"a"}, "Features": {"b"}}},
-	}
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			got, err := ParseExtraConf(tc.input)
-			if tc.wantErr {
-				require.Error(t, err)
-				return
-			}
-			require.NoError(t, err)
-			require.Equal(t, tc.want, got)
-		})
-	}
-}
-
 func TestBaselineFeatures(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -508,6 +479,17 @@ func TestSlurmdConfArgs(t *testing.T) {
 			},
 			want: []string{"--conf", `'Features=gpu Topology='"$SLINKY_TOPOLOGY"''`},
 		},
+		{
+			name: "cpu topology",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ExtraConf:   "Weight=10",
+					CpuTopology: slinkyv1beta1.NodeSetCpuTopology{Enabled: true},
+				},
+			},
+			want: []string{"--conf", `'Features=gpu Topology='"$SLINKY_TOPOLOGY"' Weight=10 '"$SLINKY_CPU_SPEC"''`},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -516,3 +498,160 @@ func TestSlurmdConfArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestSlurmdNodeConf(t *testing.T) {
+	cases := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    string
+	}{
+		{
+			name:    "name only",
+			nodeset: &slinkyv1beta1.NodeSet{ObjectMeta: metav1.ObjectMeta{Name: "gpu"}},
+			want:    "Features=gpu",
+		},
+		{
+			name: "feature and non-feature keys",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+				Spec:       slinkyv1beta1.NodeSetSpec{ExtraConf: "Weight=10 feature=a"},
+			},
+			want: "Features=a,gpu Weight=10",
+		},
+		{
+			name: "explicit topology key",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+				Spec:       slinkyv1beta1.NodeSetSpec{ExtraConf: "Topology=switch-topo:s1"},
+			},
+			want: "Features=gpu Topology=switch-topo:s1",
+		},
+		{
+			name: "derived gres",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ExtraConf: "Weight=10",
+					Gres:      slinkyv1beta1.NodeSetGres{Enabled: true},
+					Slurmd: slinkyv1beta1.ContainerWrapper{
+						Container: corev1.Container{
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									"nvidia.com/gpu": resource.MustParse("4"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: "Features=gpu Gres=gpu:4 Weight=10",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SlurmdNodeConf(tc.nodeset)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSlurmdArgs(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{ObjectMeta: metav1.ObjectMeta{Name: "slurm", Namespace: "slinky"}}
+	cases := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    []string
+	}{
+		{
+			name:    "dynamic node",
+			nodeset: &slinkyv1beta1.NodeSet{ObjectMeta: metav1.ObjectMeta{Name: "gpu"}},
+			want: append(append([]string{"-Z"}, common.ConfiglessArgs(controller)...),
+				"--conf", `'Features=gpu Topology='"$SLINKY_TOPOLOGY"''`),
+		},
+		{
+			name: "power saving node",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+				Spec: slinkyv1beta1.NodeSetSpec{
+					ExtraConf:   "Weight=10",
+					PowerSaving: slinkyv1beta1.NodeSetPowerSaving{Enabled: true},
+				},
+			},
+			want: common.ConfiglessArgs(controller),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, slurmdArgs(tc.nodeset, controller))
+		})
+	}
+}
+
+func TestGetNodeAttributes(t *testing.T) {
+	cases := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    NodeAttributes
+	}{
+		{
+			name:    "name only",
+			nodeset: &slinkyv1beta1.NodeSet{ObjectMeta: metav1.ObjectMeta{Name: "gpu"}},
+			want:    NodeAttributes{Features: []string{"gpu"}},
+		},
+		{
+			name: "features and weight",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+				Spec:       slinkyv1beta1.NodeSetSpec{ExtraConf: "weight=10 Features=a100 RealMemory=1024"},
+			},
+			want: NodeAttributes{Features: []string{"a100", "gpu"}, Weight: ptr.To[int32](10)},
+		},
+		{
+			name: "invalid weight",
+			nodeset: &slinkyv1beta1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+				Spec:       slinkyv1beta1.NodeSetSpec{ExtraConf: "Weight=heavy"},
+			},
+			want: NodeAttributes{Features: []string{"gpu"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, GetNodeAttributes(tc.nodeset))
+		})
+	}
+}
+
+func TestPodExtraConf(t *testing.T) {
+	cases := []struct {
+		name      string
+		extraConf string
+		want      string
+	}{
+		{
+			name:      "empty",
+			extraConf: "",
+			want:      "",
+		},
+		{
+			name:      "only node attributes",
+			extraConf: "Features=a100 weight=10",
+			want:      "",
+		},
+		{
+			name:      "mixed",
+			extraConf: "Feature=a100  RealMemory=1024 Weight=10 CpuSpecList=0",
+			want:      "RealMemory=1024 CpuSpecList=0",
+		},
+		{
+			name:      "malformed",
+			extraConf: "Features=a100 bad",
+			want:      "Features=a100 bad",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, PodExtraConf(tc.extraConf))
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package workerbuilder
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+)
+
+const (
+	// cpuSpecEnv is the slurmd environment variable of the pod CPU spec annotation.
+	cpuSpecEnv = "SLINKY_CPU_SPEC"
+
+	mebibyte = 1024 * 1024
+)
+
+// GetNodeCpuTopology returns the CPU topology and specialized resources which
+// the Slurm node of the NodeSet pod registers with, on the given node. Values
+// defined by the NodeSet Spec.ExtraConf are omitted, as those take precedence.
+func GetNodeCpuTopology(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, node *corev1.Node) slinkyv1beta1.NodeSetNodeCpuTopology {
+	out := slinkyv1beta1.NodeSetNodeCpuTopology{}
+	spec := nodeset.Spec.CpuTopology
+	if !spec.Enabled {
+		return out
+	}
+
+	nodeLabels := node.GetLabels()
+	getValue := func(value *int32, label, defaultLabel string) int32 {
+		if value != nil {
+			return *value
+		}
+		if label == "" {
+			label = defaultLabel
+		}
+		parsed, err := strconv.ParseInt(nodeLabels[label], 10, 32)
+		if err != nil || parsed <= 0 {
+			return 0
+		}
+		return int32(parsed)
+	}
+	sockets := getValue(spec.Sockets, spec.NodeLabels.Sockets, slinkyv1beta1.LabelNodeCpuSockets)
+	coresPerSocket := getValue(spec.CoresPerSocket, spec.NodeLabels.CoresPerSocket, slinkyv1beta1.LabelNodeCpuCoresPerSocket)
+	threadsPerCore := getValue(spec.ThreadsPerCore, spec.NodeLabels.ThreadsPerCore, slinkyv1beta1.LabelNodeCpuThreadsPerCore)
+
+	// A partial CPU topology would be inconsistent with the one slurmd detects.
+	if sockets > 0 && coresPerSocket > 0 && threadsPerCore > 0 {
+		out.Sockets = sockets
+		out.CoresPerSocket = coresPerSocket
+		out.ThreadsPerCore = threadsPerCore
+	}
+
+	if nodeset.Spec.OversubscribeNode {
+		cpus, memory := getPodResourceLimits(pod)
+		allocatable := node.Status.Allocatable
+		if cpus > 0 {
+			// Only whole CPUs of the node can be allocated by Slurm.
+			cpuGap := allocatable.Cpu().MilliValue()/1000 - cpus
+			out.CoreSpecCount = int32(max(cpuGap/int64(max(threadsPerCore, 1)), 0))
+		}
+		if memory > 0 {
+			memoryGap := allocatable.Memory().Value()/mebibyte - memory/mebibyte
+			out.MemSpecLimit = max(memoryGap, 0)
+		}
+	}
+
+	if conf, err := common.ParseExtraConf(nodeset.Spec.ExtraConf); err == nil {
+		if _, ok := conf["Sockets"]; ok {
+			out.Sockets = 0
+		}
+		if _, ok := conf["Corespersocket"]; ok {
+			out.CoresPerSocket = 0
+		}
+		if _, ok := conf["Threadspercore"]; ok {
+			out.ThreadsPerCore = 0
+		}
+		if _, ok := conf["Corespeccount"]; ok {
+			out.CoreSpecCount = 0
+		}
+		if _, ok := conf["Memspeclimit"]; ok {
+			out.MemSpecLimit = 0
+		}
+	}
+
+	return out
+}
+
+// getPodResourceLimits returns the CPU and memory limits of the slurmd container,
+// falling back to the pod-level resource limits. Returns zero if not set.
+func getPodResourceLimits(pod *corev1.Pod) (int64, int64) {
+	var cpus, memory int64
+	if pod.Spec.Resources != nil {
+		cpus = pod.Spec.Resources.Limits.Cpu().Value()
+		memory = pod.Spec.Resources.Limits.Memory().Value()
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != labels.WorkerApp {
+			continue
+		}
+		// Only override pod limits with container limits if the latter is non-zero.
+		if containerCpus := container.Resources.Limits.Cpu().Value(); containerCpus != 0 {
+			cpus = containerCpus
+		}
+		if containerMemory := container.Resources.Limits.Memory().Value(); containerMemory != 0 {
+			memory = containerMemory
+		}
+	}
+	return cpus, memory
+}
+
+// FormatNodeCpuTopology returns the slurmd --conf items of the CPU topology,
+// which is stored in the pod CPU spec annotation.
+func FormatNodeCpuTopology(topology slinkyv1beta1.NodeSetNodeCpuTopology) string {
+	confMap := map[string]string{}
+	if topology.Sockets > 0 {
+		confMap["Sockets"] = strconv.Itoa(int(topology.Sockets))
+	}
+	if topology.CoresPerSocket > 0 {
+		confMap["CoresPerSocket"] = strconv.Itoa(int(topology.CoresPerSocket))
+	}
+	if topology.ThreadsPerCore > 0 {
+		confMap["ThreadsPerCore"] = strconv.Itoa(int(topology.ThreadsPerCore))
+	}
+	if topology.CoreSpecCount > 0 {
+		confMap["CoreSpecCount"] = strconv.Itoa(int(topology.CoreSpecCount))
+	}
+	if topology.MemSpecLimit > 0 {
+		confMap["MemSpecLimit"] = strconv.FormatInt(topology.MemSpecLimit, 10)
+	}
+	return joinConfMap(confMap)
+}
+
+// ParseNodeCpuTopology parses the pod CPU spec annotation, as returned by
+// FormatNodeCpuTopology, into the CPU topology.
+func ParseNodeCpuTopology(cpuSpec string) (slinkyv1beta1.NodeSetNodeCpuTopology, error) {
+	out := slinkyv1beta1.NodeSetNodeCpuTopology{}
+	conf, err := common.ParseExtraConf(cpuSpec)
+	if err != nil {
+		return out, err
+	}
+	parse := func(key string) (int64, error) {
+		vals := conf[key]
+		if len(vals) == 0 {
+			return 0, nil
+		}
+		value, err := strconv.ParseInt(vals[len(vals)-1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("malformed %s: %w", key, err)
+		}
+		return value, nil
+	}
+	for key, field := range map[string]*int32{
+		"Sockets":        &out.Sockets,
+		"Corespersocket": &out.CoresPerSocket,
+		"Threadspercore": &out.ThreadsPerCore,
+		"Corespeccount":  &out.CoreSpecCount,
+	} {
+		value, err := parse(key)
+		if err != nil {
+			return out, err
+		}
+		*field = int32(value)
+	}
+	memSpecLimit, err := parse("Memspeclimit")
+	if err != nil {
+		return out, err
+	}
+	out.MemSpecLimit = memSpecLimit
+	return out, nil
+}
+
+// cpuSpecEnvVar returns the slurmd environment variable of the pod CPU spec annotation.
+func cpuSpecEnvVar() corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: cpuSpecEnv,
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fmt.Sprintf("metadata.annotations['%s']", slinkyv1beta1.AnnotationPodCpuSpec),
+			},
+		},
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package workerbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+)
+
+func TestGetNodeCpuTopology(t *testing.T) {
+	newNodeSet := func(cpuTopology slinkyv1beta1.NodeSetCpuTopology, oversubscribe bool, extraConf string) *slinkyv1beta1.NodeSet {
+		return &slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				CpuTopology:       cpuTopology,
+				OversubscribeNode: oversubscribe,
+				ExtraConf:         extraConf,
+			},
+		}
+	}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("16"),
+					corev1.ResourceMemory: resource.MustParse("32Gi"),
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: labels.WorkerApp,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("8"),
+						},
+					},
+				},
+				{
+					Name: "sidecar",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1"),
+						},
+					},
+				},
+			},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-0",
+			Labels: map[string]string{
+				slinkyv1beta1.LabelNodeCpuSockets:        "2",
+				slinkyv1beta1.LabelNodeCpuCoresPerSocket: "8",
+				slinkyv1beta1.LabelNodeCpuThreadsPerCore: "1",
+				"example.com/threads":                    "2",
+				"example.com/invalid":                    "two",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("15500m"),
+				corev1.ResourceMemory: resource.MustParse("40Gi"),
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    slinkyv1beta1.NodeSetNodeCpuTopology
+	}{
+		{
+			name:    "disabled",
+			nodeset: newNodeSet(slinkyv1beta1.NodeSetCpuTopology{}, true, ""),
+			want:    slinkyv1beta1.NodeSetNodeCpuTopology{},
+		},
+		{
+			name:    "default node labels",
+			nodeset: newNodeSet(slinkyv1beta1.NodeSetCpuTopology{Enabled: true}, false, ""),
+			want:    slinkyv1beta1.NodeSetNodeCpuTopology{Sockets: 2, CoresPerSocket: 8, ThreadsPerCore: 1},
+		},
+		{
+			name: "explicit values and node labels",
+			nodeset: newNodeSet(slinkyv1beta1.NodeSetCpuTopology{
+				Enabled: true,
+				Sockets: ptr.To[int32](1),
+				NodeLabels: slinkyv1beta1.NodeSetCpuTopologyLabels{
+					ThreadsPerCore: "example.com/threads",
+				},
+			}, false, ""),
+			want: slinkyv1beta1.NodeSetNodeCpuTopology{Sockets: 1, CoresPerSocket: 8, ThreadsPerCore: 2},
+		},
+		{
+			name: "partial topology is omitted",
+			nodeset: newNodeSet(slinkyv1beta1.NodeSetCpuTopology{
+				Enabled: true,
+				NodeLabels: slinkyv1beta1.NodeSetCpuTopologyLabels{
+					Sockets: "example.com/invalid",
+				},
+			}, false, ""),
+			want: slinkyv1beta1.NodeSetNodeCpuTopology{},
+		},
+		{
+			name:    "specialized resources when oversubscribed",
+			nodeset: newNodeSet(slinkyv1beta1.NodeSetCpuTopology{Enabled: true}, true, ""),
+			want: slinkyv1beta1.NodeSetNodeCpuTopology{
+				Sockets:        2,
+				CoresPerSocket: 8,
+				ThreadsPerCore: 1,
+				CoreSpecCount:  7,
+				MemSpecLimit:   8192,
+			},
+		},
+		{
+			name: "specialized cores with threads",
+			nodeset: newNodeSet(slinkyv1beta1.NodeSetCpuTopology{
+				Enabled:        true,
+				ThreadsPerCore: ptr.To[int32](2),
+			}, true, ""),
+			want: slinkyv1beta1.NodeSetNodeCpuTopology{
+				Sockets:        2,
+				CoresPerSocket: 8,
+				ThreadsPerCore: 2,
+				CoreSpecCount:  3,
+				MemSpecLimit:   8192,
+			},
+		},
+		{
+			name:    "extraConf takes precedence",
+			nodeset: newNodeSet(slinkyv1beta1.NodeSetCpuTopology{Enabled: true}, true, "sockets=1 MemSpecLimit=1024"),
+			want: slinkyv1beta1.NodeSetNodeCpuTopology{
+				CoresPerSocket: 8,
+				ThreadsPerCore: 1,
+				CoreSpecCount:  7,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetNodeCpuTopology(tt.nodeset, pod, node))
+		})
+	}
+}
+
+func TestFormatNodeCpuTopology(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology slinkyv1beta1.NodeSetNodeCpuTopology
+		want     string
+	}{
+		{
+			name:     "empty",
+			topology: slinkyv1beta1.NodeSetNodeCpuTopology{},
+			want:     "",
+		},
+		{
+			name: "full",
+			topology: slinkyv1beta1.NodeSetNodeCpuTopology{
+				Sockets:        2,
+				CoresPerSocket: 8,
+				ThreadsPerCore: 2,
+				CoreSpecCount:  1,
+				MemSpecLimit:   2048,
+			},
+			want: "CoreSpecCount=1 CoresPerSocket=8 MemSpecLimit=2048 Sockets=2 ThreadsPerCore=2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatNodeCpuTopology(tt.topology)
+			require.Equal(t, tt.want, got)
+
+			parsed, err := ParseNodeCpuTopology(got)
+			require.NoError(t, err)
+			require.Equal(t, tt.topology, parsed)
+		})
+	}
+}
+
+func TestParseNodeCpuTopology(t *testing.T) {
+	tests := []struct {
+		name    string
+		cpuSpec string
+		want    slinkyv1beta1.NodeSetNodeCpuTopology
+		wantErr bool
+	}{
+		{
+			name:    "case insensitive keys",
+			cpuSpec: "sockets=2 corespersocket=8",
+			want:    slinkyv1beta1.NodeSetNodeCpuTopology{Sockets: 2, CoresPerSocket: 8},
+		},
+		{
+			name:    "malformed item",
+			cpuSpec: "Sockets",
+			wantErr: true,
+		},
+		{
+			name:    "malformed value",
+			cpuSpec: "Sockets=two",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNodeCpuTopology(tt.cpuSpec)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package workerbuilder
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+)
+
+const (
+	// featureLabelKey and featureLabelValue are the placeholders of a feature
+	// label template.
+	featureLabelKey   = "{key}"
+	featureLabelValue = "{value}"
+
+	// invalidFeatureChars delimit Slurm node features and feature expressions.
+	invalidFeatureChars = ",&| \t\n"
+)
+
+// GetNodeLabelFeatures returns the Slurm node features of the NodeSet pod
+// derived from the labels of the Kubernetes node, sorted and deduplicated.
+// The features are not namespaced by NodeFeaturePrefix.
+func GetNodeLabelFeatures(nodeset *slinkyv1beta1.NodeSet, node *corev1.Node) []string {
+	if len(nodeset.Spec.FeatureLabels) == 0 {
+		return nil
+	}
+
+	nodeLabels := node.GetLabels()
+	features := make([]string, 0, len(nodeset.Spec.FeatureLabels))
+	for _, featureLabel := range nodeset.Spec.FeatureLabels {
+		value, ok := nodeLabels[featureLabel.Key]
+		if !ok {
+			continue
+		}
+		template := featureLabel.Template
+		if template == "" {
+			template = featureLabelValue
+		}
+		feature := strings.NewReplacer(
+			featureLabelKey, featureLabel.Key,
+			featureLabelValue, value,
+		).Replace(template)
+		features = append(features, sanitizeFeature(feature))
+	}
+	return structutils.SortedDedup(features)
+}
+
+// sanitizeFeature replaces the characters not allowed in a Slurm node feature.
+func sanitizeFeature(feature string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(invalidFeatureChars, r) {
+			return '_'
+		}
+		return r
+	}, feature)
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package workerbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestGetNodeLabelFeatures(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+			Labels: map[string]string{
+				corev1.LabelInstanceTypeStable: "p5.48xlarge",
+				"nvidia.com/gpu.product":       "NVIDIA-H100-80GB-HBM3",
+				"example.com/ib":               "",
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		featureLabels []slinkyv1beta1.NodeSetFeatureLabel
+		want          []string
+	}{
+		{
+			name: "empty",
+			want: nil,
+		},
+		{
+			name: "default template",
+			featureLabels: []slinkyv1beta1.NodeSetFeatureLabel{
+				{Key: corev1.LabelInstanceTypeStable},
+			},
+			want: []string{"p5.48xlarge"},
+		},
+		{
+			name: "templates",
+			featureLabels: []slinkyv1beta1.NodeSetFeatureLabel{
+				{Key: "nvidia.com/gpu.product", Template: "gpu_{value}"},
+				{Key: "example.com/ib", Template: "{key}"},
+				{Key: corev1.LabelInstanceTypeStable, Template: "instance_{value}"},
+			},
+			want: []string{"example.com/ib", "gpu_NVIDIA-H100-80GB-HBM3", "instance_p5.48xlarge"},
+		},
+		{
+			name: "missing label",
+			featureLabels: []slinkyv1beta1.NodeSetFeatureLabel{
+				{Key: "example.com/missing", Template: "missing"},
+			},
+			want: []string{},
+		},
+		{
+			name: "empty label value",
+			featureLabels: []slinkyv1beta1.NodeSetFeatureLabel{
+				{Key: "example.com/ib"},
+			},
+			want: []string{},
+		},
+		{
+			name: "deduplicated",
+			featureLabels: []slinkyv1beta1.NodeSetFeatureLabel{
+				{Key: "nvidia.com/gpu.product", Template: "gpu"},
+				{Key: corev1.LabelInstanceTypeStable, Template: "gpu"},
+			},
+			want: []string{"gpu"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := &slinkyv1beta1.NodeSet{
+				Spec: slinkyv1beta1.NodeSetSpec{
+					FeatureLabels: tt.featureLabels,
+				},
+			}
+			got := GetNodeLabelFeatures(nodeset, node)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_sanitizeFeature(t *testing.T) {
+	tests := []struct {
+		name    string
+		feature string
+		want    string
+	}{
+		{
+			name:    "valid",
+			feature: "gpu_h100",
+			want:    "gpu_h100",
+		},
+		{
+			name:    "delimiters",
+			feature: "a,b&c|d e",
+			want:    "a_b_c_d_e",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeFeature(tt.feature); got != tt.want {
+				t.Errorf("sanitizeFeature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package account
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
+)
+
+// Sync implements control logic for synchronizing an Account.
+func (r *AccountReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	return r.syncer().Sync(ctx, req)
+}
+
+// syncer returns the Syncer of the Account into the Slurm database.
+func (r *AccountReconciler) syncer() *slurmdb.Syncer[*slinkyv1beta1.Account] {
+	return &slurmdb.Syncer[*slinkyv1beta1.Account]{
+		Client:        r.Client,
+		RefResolver:   r.refResolver,
+		DurationStore: durationStore,
+
+		Kind:      "Account",
+		Finalizer: slinkyv1beta1.FinalizerAccount,
+
+		NewObject: func() *slinkyv1beta1.Account {
+			return &slinkyv1beta1.Account{}
+		},
+		SlurmdbRef: func(account *slinkyv1beta1.Account) slinkyv1beta1.SlurmdbRef {
+			return account.Spec.SlurmdbRef
+		},
+		Conditions: func(account *slinkyv1beta1.Account) *[]metav1.Condition {
+			return &account.Status.Conditions
+		},
+		StatusFn: syncAccountStatus,
+
+		SyncFn:   r.slurmControl.SyncAccount,
+		DeleteFn: r.slurmControl.DeleteAccount,
+	}
+}
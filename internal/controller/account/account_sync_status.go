@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package account
+
+import (
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
+)
+
+// syncAccountStatus sets the status of the Account from the result of the sync.
+func syncAccountStatus(controllers []slinkyv1beta1.Controller, account *slinkyv1beta1.Account, syncErr error) {
+	// The clusters are only known to be associated once synced.
+	if syncErr == nil {
+		account.Status.Clusters = slurmdb.ClusterNames(controllers)
+	}
+}
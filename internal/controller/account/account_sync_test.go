@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/account/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func init() {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+}
+
+type fakeSlurmControl struct {
+	err error
+
+	synced  []string
+	deleted bool
+}
+
+func (f *fakeSlurmControl) SyncAccount(_ context.Context, controllers []slinkyv1beta1.Controller, _ *slinkyv1beta1.Account) error {
+	for _, controller := range controllers {
+		f.synced = append(f.synced, controller.Name)
+	}
+	return f.err
+}
+
+func (f *fakeSlurmControl) DeleteAccount(context.Context, []slinkyv1beta1.Controller, *slinkyv1beta1.Account) error {
+	f.deleted = true
+	return f.err
+}
+
+var _ slurmcontrol.SlurmControlInterface = &fakeSlurmControl{}
+
+func newAccount(deleting bool) *slinkyv1beta1.Account {
+	out := &slinkyv1beta1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "physics",
+		},
+		Spec: slinkyv1beta1.AccountSpec{
+			SlurmdbRef: slinkyv1beta1.SlurmdbRef{
+				AccountingRef: &corev1.LocalObjectReference{Name: "slurmdbd"},
+			},
+		},
+	}
+	if deleting {
+		out.Finalizers = []string{slinkyv1beta1.FinalizerAccount}
+	}
+	return out
+}
+
+// The reconcile flow is covered by the slurmdb package, only its wiring to the
+// Account is tested here.
+func TestAccountReconciler_Sync(t *testing.T) {
+	tests := []struct {
+		name          string
+		deleting      bool
+		err           error
+		wantSynced    []string
+		wantDeleted   bool
+		wantClusters  []string
+		wantCondition metav1.ConditionStatus
+		wantErr       bool
+	}{
+		{
+			name:          "Sync",
+			wantSynced:    []string{"slurm-a", "slurm-b"},
+			wantClusters:  []string{"default_slurm-a", "default_slurm-b"},
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name:          "Sync error",
+			err:           errors.New("internal error"),
+			wantSynced:    []string{"slurm-a", "slurm-b"},
+			wantCondition: metav1.ConditionFalse,
+			wantErr:       true,
+		},
+		{
+			name:        "Delete",
+			deleting:    true,
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newAccount(tt.deleting)
+			c := fake.NewClientBuilder().
+				WithObjects(testutils.NewSlurmdbObjects("slurmdbd", "slurm-a", "slurm-b")...).
+				WithObjects(account).
+				WithStatusSubresource(&slinkyv1beta1.Account{}).
+				Build()
+			slurmControl := &fakeSlurmControl{err: tt.err}
+			r := &AccountReconciler{
+				Client:       c,
+				refResolver:  refresolver.New(c),
+				slurmControl: slurmControl,
+			}
+
+			req := client.ObjectKeyFromObject(account)
+			if tt.deleting {
+				require.NoError(t, c.Delete(context.TODO(), account))
+			}
+			err := r.Sync(context.TODO(), reconcile.Request{NamespacedName: req})
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.wantSynced, slurmControl.synced)
+			require.Equal(t, tt.wantDeleted, slurmControl.deleted)
+
+			got := &slinkyv1beta1.Account{}
+			err = c.Get(context.TODO(), req, got)
+			if tt.deleting {
+				require.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, got.Finalizers, slinkyv1beta1.FinalizerAccount)
+			require.Equal(t, tt.wantClusters, got.Status.Clusters)
+			condition := meta.FindStatusCondition(got.Status.Conditions, slurmconditions.SlurmdbConditionSynced)
+			require.NotNil(t, condition)
+			require.Equal(t, tt.wantCondition, condition.Status)
+		})
+	}
+}
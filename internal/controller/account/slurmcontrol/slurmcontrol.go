@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmerrors "github.com/SlinkyProject/slurm-client/pkg/errors"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
+	slurmdbcontrol "github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+)
+
+var ErrNoSlurmClient = slurmdb.ErrNoSlurmClient
+
+type SlurmControlInterface interface {
+	// SyncAccount creates or updates the Slurm account, and its association in
+	// the Slurm cluster of each controller.
+	SyncAccount(ctx context.Context, controllers []slinkyv1beta1.Controller, account *slinkyv1beta1.Account) error
+	// DeleteAccount deletes the Slurm account, if it exists.
+	DeleteAccount(ctx context.Context, controllers []slinkyv1beta1.Controller, account *slinkyv1beta1.Account) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// SyncAccount implements SlurmControlInterface.
+func (r *realSlurmControl) SyncAccount(ctx context.Context, controllers []slinkyv1beta1.Controller, account *slinkyv1beta1.Account) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := slurmdbcontrol.LookupClient(r.clientMap, controllers)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controllers, cannot do SyncAccount()")
+		return ErrNoSlurmClient
+	}
+
+	if err := syncAccount(ctx, slurmClient, slurmdbutils.NewAccount(account)); err != nil {
+		return err
+	}
+
+	assocs, err := slurmdbcontrol.ListAssocs(ctx, slurmClient)
+	if err != nil {
+		return err
+	}
+	for _, controller := range controllers {
+		newAssoc := slurmdbutils.NewAccountAssoc(account, controller.ClusterName())
+		if err := slurmdbcontrol.SyncAssoc(ctx, slurmClient, assocs, newAssoc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteAccount implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteAccount(ctx context.Context, controllers []slinkyv1beta1.Controller, account *slinkyv1beta1.Account) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := slurmdbcontrol.LookupClient(r.clientMap, controllers)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controllers, cannot do DeleteAccount()")
+		return ErrNoSlurmClient
+	}
+
+	name := account.AccountName()
+	accountInfo := &slurmtypes.V0044Account{}
+	if err := slurmClient.Get(ctx, slurmobject.ObjectKey(name), accountInfo); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := slurmClient.Delete(ctx, accountInfo); err != nil && !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+		return fmt.Errorf("DeleteAccount() failed to Delete AccountName=%s with error=%w", name, err)
+	}
+
+	return nil
+}
+
+// syncAccount creates the Slurm account, or updates it when it drifted.
+func syncAccount(ctx context.Context, slurmClient slurmclient.Client, newAccount slurmdbutils.Account) error {
+	req := slurmapi.V0044Account{}
+	if err := slurmdbutils.Convert(newAccount, &req); err != nil {
+		return err
+	}
+
+	accountInfo := &slurmtypes.V0044Account{}
+	if err := slurmClient.Get(ctx, slurmobject.ObjectKey(newAccount.Name), accountInfo); err != nil {
+		if !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return err
+		}
+		accountInfo = &slurmtypes.V0044Account{V0044Account: req}
+		if err := slurmClient.Create(ctx, accountInfo, req); err != nil {
+			return fmt.Errorf("SyncAccount() failed to Create AccountName=%s with error=%w", newAccount.Name, err)
+		}
+		return nil
+	}
+
+	oldAccount := slurmdbutils.Account{}
+	if err := slurmdbutils.Convert(accountInfo, &oldAccount); err != nil {
+		return err
+	}
+	if slurmdbutils.IsAccountMatch(oldAccount, newAccount) {
+		return nil
+	}
+
+	if err := slurmClient.Update(ctx, accountInfo, req); err != nil {
+		return fmt.Errorf("SyncAccount() failed to Update AccountName=%s with error=%w", newAccount.Name, err)
+	}
+
+	return nil
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newController() slinkyv1beta1.Controller {
+	return slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+}
+
+func newAccount() *slinkyv1beta1.Account {
+	return &slinkyv1beta1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "physics",
+		},
+		Spec: slinkyv1beta1.AccountSpec{
+			Fairshare: ptr.To[int32](10),
+		},
+	}
+}
+
+func newAccountInfo(account slurmdbutils.Account) *types.V0044Account {
+	out := &types.V0044Account{}
+	_ = slurmdbutils.Convert(account, out)
+	return out
+}
+
+func newAssocInfo(assoc slurmdbutils.Assoc) types.V0044Assoc {
+	out := types.V0044Assoc{}
+	_ = slurmdbutils.Convert(assoc, &out)
+	return out
+}
+
+func Test_realSlurmControl_SyncAccount(t *testing.T) {
+	controller := newController()
+	account := newAccount()
+	tests := []struct {
+		name       string
+		existing   *types.V0044Account
+		assocs     []types.V0044Assoc
+		wantCreate int
+		wantUpdate int
+	}{
+		{
+			name:       "Create",
+			wantCreate: 2,
+		},
+		{
+			name:     "Unchanged",
+			existing: newAccountInfo(slurmdbutils.NewAccount(account)),
+			assocs: []types.V0044Assoc{
+				newAssocInfo(slurmdbutils.NewAccountAssoc(account, controller.ClusterName())),
+			},
+		},
+		{
+			name: "Drift",
+			existing: newAccountInfo(slurmdbutils.Account{
+				Name:         "physics",
+				Description:  "changed",
+				Organization: "root",
+			}),
+			assocs: []types.V0044Assoc{
+				newAssocInfo(slurmdbutils.Assoc{
+					Account:       "physics",
+					Cluster:       controller.ClusterName(),
+					ParentAccount: "root",
+					SharesRaw:     ptr.To[int32](1),
+				}),
+			},
+			wantUpdate: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, updated int
+			builder := fake.NewClientBuilder().
+				WithLists(&types.V0044AssocList{Items: tt.assocs}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(context.Context, object.Object, any, ...client.CreateOption) error {
+						created++
+						return nil
+					},
+					Update: func(context.Context, object.Object, any, ...client.UpdateOption) error {
+						updated++
+						return nil
+					},
+				})
+			if tt.existing != nil {
+				builder = builder.WithObjects(tt.existing)
+			}
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, builder.Build()))
+			err := r.SyncAccount(context.TODO(), []slinkyv1beta1.Controller{controller}, account)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCreate, created)
+			require.Equal(t, tt.wantUpdate, updated)
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteAccount(t *testing.T) {
+	controller := newController()
+	account := newAccount()
+	var deleted bool
+	sclient := fake.NewClientBuilder().
+		WithObjects(newAccountInfo(slurmdbutils.NewAccount(account))).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(context.Context, object.Object, ...client.DeleteOption) error {
+				deleted = true
+				return nil
+			},
+		}).
+		Build()
+	r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+	require.NoError(t, r.DeleteAccount(context.TODO(), []slinkyv1beta1.Controller{controller}, account))
+	require.True(t, deleted)
+
+	// Deleting an absent account is a no-op.
+	r = NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, fake.NewFakeClient()))
+	require.NoError(t, r.DeleteAccount(context.TODO(), []slinkyv1beta1.Controller{controller}, account))
+}
+
+func Test_realSlurmControl_NoClient(t *testing.T) {
+	controllers := []slinkyv1beta1.Controller{newController()}
+	account := newAccount()
+	r := NewSlurmControl(testutils.NewClientMap("other", corev1.NamespaceDefault, fake.NewFakeClient()))
+	err := r.SyncAccount(context.TODO(), controllers, account)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+	err = r.DeleteAccount(context.TODO(), controllers, account)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+}
@@ -0,0 +1,380 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	builder "github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/controller/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	ConfigRolloutReason = "ConfigRollout"
+
+	ConfigReconfiguredReason       = "Reconfigured"
+	ConfigRestartedReason          = "Restarted"
+	ConfigReconfigurePendingReason = "ReconfigurePending"
+	ConfigRestartingReason         = "Restarting"
+
+	// configFileRefsKey is the change key of the referenced ConfigMaps.
+	configFileRefsKey = "configFileRefs"
+	// maxChangeKeys is the number of changed keys listed in events and conditions.
+	maxChangeKeys = 10
+)
+
+// seedRestartHashes records the restart hashes of the Slurm configuration which
+// the pods run, unless already recorded, such that they are only restarted once
+// restart keys change. Otherwise, the restart hashes would restart the pods on
+// upgrade of the operator, or when inplaceReconfigure is enabled.
+func seedRestartHashes(
+	controller *slinkyv1beta1.Controller,
+	oldConfig, newConfig *corev1.ConfigMap,
+) {
+	if !controller.Spec.InplaceReconfigure || controller.Spec.External {
+		return
+	}
+
+	config := newConfig
+	if oldConfig != nil {
+		config = oldConfig
+	}
+	slurmConf := config.Data[builder.SlurmConfFile]
+	seeds := map[string]string{
+		common.AnnotationSlurmctldRestartHashSeed: common.SlurmctldRestartHash(slurmConf),
+		common.AnnotationSlurmdRestartHashSeed:    common.SlurmdRestartHash(slurmConf),
+	}
+	for key, seed := range seeds {
+		if oldConfig != nil && oldConfig.Annotations[key] != "" {
+			continue
+		}
+		if newConfig.Annotations == nil {
+			newConfig.Annotations = make(map[string]string)
+		}
+		newConfig.Annotations[key] = seed
+	}
+}
+
+// recordConfigChanges diffs the old and new Controller ConfigMap, and records
+// how the changes are rolled out on the ConfigApplied condition. Returns true
+// when there are changes to roll out.
+//
+// Changes of restart keys are rolled out by the pod-template hashes, all other
+// changes are reconfigured by syncConfigRollout.
+func (r *ControllerReconciler) recordConfigChanges(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	oldConfig, newConfig *corev1.ConfigMap,
+) bool {
+	logger := log.FromContext(ctx)
+
+	if oldConfig == nil || !controller.Spec.InplaceReconfigure || controller.Spec.External {
+		return false
+	}
+
+	changes := diffControllerConfig(oldConfig, newConfig)
+	if len(changes) == 0 {
+		return false
+	}
+	rollout := common.ClassifyConfigChanges(changes)
+
+	reason := ConfigReconfigurePendingReason
+	if rollout != common.ConfigRolloutReconfigure {
+		reason = ConfigRestartingReason
+	}
+	// Do not lose a pending restart to a later change.
+	if condition := meta.FindStatusCondition(controller.Status.Conditions, slurmconditions.ControllerConditionConfigApplied); condition != nil &&
+		condition.Status == metav1.ConditionFalse && condition.Reason == ConfigRestartingReason {
+		reason = ConfigRestartingReason
+	}
+
+	keys := formatChangeKeys(changes)
+	logger.Info("Slurm configuration changed", "rollout", rollout, "keys", keys)
+	r.eventRecorder.Eventf(controller, nil, corev1.EventTypeNormal, ConfigRolloutReason, string(rollout),
+		"Rolling out Slurm configuration changes by %s: %s", rollout, keys)
+
+	// The transition time is that of the latest change, slurmctld pods started
+	// since have it mounted.
+	meta.RemoveStatusCondition(&controller.Status.Conditions, slurmconditions.ControllerConditionConfigApplied)
+	meta.SetStatusCondition(&controller.Status.Conditions, metav1.Condition{
+		Type:               slurmconditions.ControllerConditionConfigApplied,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: controller.Generation,
+		Reason:             reason,
+		Message:            fmt.Sprintf("%s: %s", rollout, keys),
+	})
+
+	return true
+}
+
+// syncConfigRollout reconfigures Slurm once the pending config changes have
+// been rolled out to slurmctld, and slurmctld can read them.
+func (r *ControllerReconciler) syncConfigRollout(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+) error {
+	logger := log.FromContext(ctx)
+
+	if !controller.Spec.InplaceReconfigure || controller.Spec.External {
+		meta.RemoveStatusCondition(&controller.Status.Conditions, slurmconditions.ControllerConditionConfigApplied)
+		return nil
+	}
+
+	condition := meta.FindStatusCondition(controller.Status.Conditions, slurmconditions.ControllerConditionConfigApplied)
+	if condition == nil || condition.Status == metav1.ConditionTrue {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, controller.ConfigKey(), configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	doneReason := ConfigReconfiguredReason
+	if condition.Reason == ConfigRestartingReason {
+		restarted, err := r.isSlurmctldRestarted(ctx, controller, configMap)
+		if err != nil {
+			return err
+		}
+		if !restarted {
+			logger.V(1).Info("Waiting for slurmctld to restart before reconfigure")
+			durationStore.Push(objectutils.KeyFunc(controller), 5*time.Second)
+			return nil
+		}
+		if err := r.syncSlurmdRestartHash(ctx, configMap); err != nil {
+			return err
+		}
+		doneReason = ConfigRestartedReason
+	}
+
+	mounted, err := r.isSlurmConfigMounted(ctx, controller, configMap)
+	if err != nil {
+		return err
+	}
+	if !mounted {
+		logger.V(1).Info("Waiting for slurmctld to mount the Slurm configuration before reconfigure")
+		durationStore.Push(objectutils.KeyFunc(controller), 5*time.Second)
+		return nil
+	}
+
+	if err := r.slurmControl.Reconfigure(ctx, controller); err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			logger.V(1).Info("Waiting for Slurm client before reconfigure")
+			durationStore.Push(objectutils.KeyFunc(controller), 5*time.Second)
+			return nil
+		}
+		// slurmctld was restarted, only the reconfigure remains.
+		condition.Reason = ConfigReconfigurePendingReason
+		r.eventRecorder.Eventf(controller, nil, corev1.EventTypeWarning, ConfigRolloutReason, "Reconfigure",
+			"Failed to reconfigure Slurm: %v", err)
+		return fmt.Errorf("failed to reconfigure: %w", err)
+	}
+
+	r.eventRecorder.Eventf(controller, nil, corev1.EventTypeNormal, ConfigRolloutReason, "Reconfigure",
+		"Rolled out Slurm configuration changes (%s)", condition.Message)
+
+	meta.SetStatusCondition(&controller.Status.Conditions, metav1.Condition{
+		Type:               slurmconditions.ControllerConditionConfigApplied,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: controller.Generation,
+		Reason:             doneReason,
+		Message:            condition.Message,
+	})
+
+	return nil
+}
+
+// isSlurmctldRestarted returns true when the slurmctld StatefulSet was rolled
+// out with the current restart hash.
+func (r *ControllerReconciler) isSlurmctldRestarted(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	configMap *corev1.ConfigMap,
+) (bool, error) {
+	statefulset := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, controller.Key(), statefulset); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// The cached StatefulSet may not have the updated pod template yet.
+	hash := builder.SlurmctldRestartHash(configMap)
+	if statefulset.Spec.Template.Annotations[common.AnnotationSlurmctldRestartHash] != hash {
+		return false, nil
+	}
+
+	replicas := ptr.Deref(statefulset.Spec.Replicas, 1)
+	status := statefulset.Status
+	return status.ObservedGeneration >= statefulset.Generation &&
+		status.CurrentRevision == status.UpdateRevision &&
+		status.UpdatedReplicas == replicas &&
+		status.ReadyReplicas == replicas, nil
+}
+
+// syncSlurmdRestartHash publishes the slurmd restart hash on the Controller
+// ConfigMap, once slurmctld has restarted. The NodeSets then restart slurmd by
+// their update strategy, such that slurmd is restarted after slurmctld.
+func (r *ControllerReconciler) syncSlurmdRestartHash(
+	ctx context.Context,
+	configMap *corev1.ConfigMap,
+) error {
+	hash := common.RestartHash(
+		common.SlurmdRestartHash(configMap.Data[builder.SlurmConfFile]),
+		configMap.Annotations[common.AnnotationSlurmdRestartHashSeed],
+	)
+	mutateFn := func(configMap *corev1.ConfigMap) error {
+		if hash == "" {
+			delete(configMap.Annotations, common.AnnotationSlurmdRestartHash)
+			return nil
+		}
+		if configMap.Annotations == nil {
+			configMap.Annotations = make(map[string]string)
+		}
+		configMap.Annotations[common.AnnotationSlurmdRestartHash] = hash
+		return nil
+	}
+	if err := objectutils.PatchObject(r.Client, ctx, configMap, mutateFn); err != nil {
+		return fmt.Errorf("failed to patch object (%s): %w", klog.KObj(configMap), err)
+	}
+	return nil
+}
+
+// isSlurmConfigMounted returns true when all slurmctld pods have mounted the
+// Slurm configuration of the ConfigMap, as the kubelet only eventually updates
+// it. The running reconfigure sidecar logs the mounted configuration hash on
+// start and whenever it changes, hence its last log line reports it.
+func (r *ControllerReconciler) isSlurmConfigMounted(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	configMap *corev1.ConfigMap,
+) (bool, error) {
+	hash := configMap.Data[builder.SlurmConfigHashFile]
+	if hash == "" {
+		return false, nil
+	}
+
+	podList := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(controller.Namespace),
+		client.MatchingLabels(labels.NewBuilder().WithControllerSelectorLabels(controller).Build()),
+	}
+	if err := r.List(ctx, podList, listOpts...); err != nil {
+		return false, err
+	}
+
+	mounted := 0
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		idx := slices.IndexFunc(pod.Status.InitContainerStatuses, func(status corev1.ContainerStatus) bool {
+			return status.Name == builder.ReconfigureContainer
+		})
+		if idx < 0 || pod.Status.InitContainerStatuses[idx].State.Running == nil {
+			return false, nil
+		}
+		line, err := r.lastLogLine(ctx, pod, builder.ReconfigureContainer)
+		if err != nil {
+			return false, fmt.Errorf("failed to get logs of pod (%s): %w", klog.KObj(pod), err)
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[len(fields)-1] != hash {
+			return false, nil
+		}
+		mounted++
+	}
+
+	return mounted > 0, nil
+}
+
+// lastLogLineFunc returns the last log line of the pod container.
+type lastLogLineFunc func(ctx context.Context, pod *corev1.Pod, container string) (string, error)
+
+// newLastLogLineFunc returns a lastLogLineFunc which reads the pod log through
+// the Kubernetes API.
+func newLastLogLineFunc(clientset kubernetes.Interface) lastLogLineFunc {
+	return func(ctx context.Context, pod *corev1.Pod, container string) (string, error) {
+		opts := &corev1.PodLogOptions{
+			Container: container,
+			TailLines: ptr.To[int64](1),
+		}
+		out, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).DoRaw(ctx)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+}
+
+// diffControllerConfig returns the changes between the old and new Controller
+// ConfigMap. The `slurm.conf` is diffed by key, other files as a whole.
+func diffControllerConfig(oldConfig, newConfig *corev1.ConfigMap) []config.Change {
+	changes := []config.Change{}
+
+	oldSlurmConf := oldConfig.Data[builder.SlurmConfFile]
+	newSlurmConf := newConfig.Data[builder.SlurmConfFile]
+	if slurmConfChanges, err := config.Diff(oldSlurmConf, newSlurmConf); err == nil {
+		changes = append(changes, slurmConfChanges...)
+	} else if oldSlurmConf != newSlurmConf {
+		changes = append(changes, config.Change{Key: builder.SlurmConfFile, Old: oldSlurmConf, New: newSlurmConf})
+	}
+
+	files := set.KeySet(oldConfig.Data).Union(set.KeySet(newConfig.Data))
+	files.Delete(builder.SlurmConfFile, builder.SlurmConfigHashFile)
+	for _, file := range files.SortedList() {
+		if oldConfig.Data[file] != newConfig.Data[file] {
+			changes = append(changes, config.Change{Key: file, Old: oldConfig.Data[file], New: newConfig.Data[file]})
+		}
+	}
+
+	oldRefsHash := oldConfig.Annotations[builder.AnnotationConfigRefsHash]
+	newRefsHash := newConfig.Annotations[builder.AnnotationConfigRefsHash]
+	if oldRefsHash != newRefsHash {
+		changes = append(changes, config.Change{Key: configFileRefsKey, Old: oldRefsHash, New: newRefsHash})
+	}
+
+	return changes
+}
+
+// formatChangeKeys returns the changed keys, truncated to maxChangeKeys.
+func formatChangeKeys(changes []config.Change) string {
+	keys := make([]string, 0, len(changes))
+	for _, change := range changes {
+		keys = append(keys, change.Key)
+	}
+	if len(keys) > maxChangeKeys {
+		more := len(keys) - maxChangeKeys
+		keys = append(keys[:maxChangeKeys], fmt.Sprintf("and %d more", more))
+	}
+	return strings.Join(keys, ", ")
+}
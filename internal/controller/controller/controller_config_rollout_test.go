@@ -0,0 +1,482 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	builder "github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/controller/slurmcontrol"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func newRolloutController(inplaceReconfigure bool, conditions ...metav1.Condition) *slinkyv1beta1.Controller {
+	return &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			InplaceReconfigure: inplaceReconfigure,
+		},
+		Status: slinkyv1beta1.ControllerStatus{
+			Conditions: conditions,
+		},
+	}
+}
+
+const (
+	// rolloutSeedConf is the Slurm configuration the restart hashes are seeded from.
+	rolloutSeedConf = "ClusterName=slurm\nSelectType=select/cons_tres\nTaskPlugin=task/cgroup"
+	// rolloutConfigHash is the Slurm configuration hash of newRolloutConfig.
+	rolloutConfigHash = "config-hash"
+)
+
+func newRolloutConfig(controller *slinkyv1beta1.Controller, slurmConf string, data map[string]string) *corev1.ConfigMap {
+	out := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controller.ConfigKey().Namespace,
+			Name:      controller.ConfigKey().Name,
+			Annotations: map[string]string{
+				common.AnnotationSlurmctldRestartHashSeed: common.SlurmctldRestartHash(rolloutSeedConf),
+				common.AnnotationSlurmdRestartHashSeed:    common.SlurmdRestartHash(rolloutSeedConf),
+			},
+		},
+		Data: map[string]string{
+			builder.SlurmConfFile:       slurmConf,
+			builder.SlurmConfigHashFile: rolloutConfigHash,
+		},
+	}
+	for k, v := range data {
+		out.Data[k] = v
+	}
+	return out
+}
+
+// newRolloutPod returns a slurmctld pod, whose reconfigure sidecar has the state.
+func newRolloutPod(controller *slinkyv1beta1.Controller, state corev1.ContainerState) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: controller.Namespace,
+			Name:      controller.PodName(0),
+			Labels:    labels.NewBuilder().WithControllerSelectorLabels(controller).Build(),
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: builder.ReconfigureContainer, State: state},
+			},
+		},
+	}
+}
+
+var (
+	runningState = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	waitingState = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+)
+
+// reportedLog returns the last log line of a reconfigure sidecar which reported the hash.
+func reportedLog(hash string) string {
+	return "[Sun Oct 18 12:00:00 UTC 2026] Slurm configuration mounted: " + hash
+}
+
+func Test_seedRestartHashes(t *testing.T) {
+	const slurmConf = "ClusterName=slurm\nSelectType=select/linear\nTaskPlugin=task/affinity"
+	newConfig := func(slurmConf string, annotations map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Data:       map[string]string{builder.SlurmConfFile: slurmConf},
+		}
+	}
+	tests := []struct {
+		name       string
+		controller *slinkyv1beta1.Controller
+		oldConfig  *corev1.ConfigMap
+		want       map[string]string
+	}{
+		{
+			name:       "Without inplace reconfigure",
+			controller: newRolloutController(false),
+			oldConfig:  newConfig(rolloutSeedConf, nil),
+		},
+		{
+			name:       "Initial config",
+			controller: newRolloutController(true),
+			want: map[string]string{
+				common.AnnotationSlurmctldRestartHashSeed: common.SlurmctldRestartHash(slurmConf),
+				common.AnnotationSlurmdRestartHashSeed:    common.SlurmdRestartHash(slurmConf),
+			},
+		},
+		{
+			name:       "Seeded from the running config",
+			controller: newRolloutController(true),
+			oldConfig:  newConfig(rolloutSeedConf, nil),
+			want: map[string]string{
+				common.AnnotationSlurmctldRestartHashSeed: common.SlurmctldRestartHash(rolloutSeedConf),
+				common.AnnotationSlurmdRestartHashSeed:    common.SlurmdRestartHash(rolloutSeedConf),
+			},
+		},
+		{
+			name:       "Already seeded",
+			controller: newRolloutController(true),
+			oldConfig: newConfig(rolloutSeedConf, map[string]string{
+				common.AnnotationSlurmctldRestartHashSeed: "foo",
+				common.AnnotationSlurmdRestartHashSeed:    "bar",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newConfig := newConfig(slurmConf, nil)
+
+			seedRestartHashes(tt.controller, tt.oldConfig, newConfig)
+
+			require.Equal(t, tt.want, newConfig.Annotations)
+		})
+	}
+}
+
+func TestControllerReconciler_recordConfigChanges(t *testing.T) {
+	const slurmConf = "ClusterName=slurm\nSelectType=select/cons_tres\nDebugFlags=Steps"
+	tests := []struct {
+		name        string
+		controller  *slinkyv1beta1.Controller
+		oldConfig   *corev1.ConfigMap
+		slurmConf   string
+		data        map[string]string
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name:       "Initial config",
+			controller: newRolloutController(true),
+			slurmConf:  slurmConf,
+		},
+		{
+			name:       "Without inplace reconfigure",
+			controller: newRolloutController(false),
+			oldConfig:  newRolloutConfig(newRolloutController(false), slurmConf, nil),
+			slurmConf:  "ClusterName=slurm\nSelectType=select/cons_tres\nDebugFlags=Gres",
+		},
+		{
+			name:       "No changes",
+			controller: newRolloutController(true),
+			oldConfig:  newRolloutConfig(newRolloutController(true), slurmConf, nil),
+			slurmConf:  slurmConf,
+		},
+		{
+			name:        "Live keys",
+			controller:  newRolloutController(true),
+			oldConfig:   newRolloutConfig(newRolloutController(true), slurmConf, nil),
+			slurmConf:   "ClusterName=slurm\nSelectType=select/cons_tres\nDebugFlags=Gres",
+			data:        map[string]string{"gres.conf": "AutoDetect=nvidia"},
+			wantReason:  ConfigReconfigurePendingReason,
+			wantMessage: "Reconfigure: DebugFlags, gres.conf",
+		},
+		{
+			name:        "Restart keys",
+			controller:  newRolloutController(true),
+			oldConfig:   newRolloutConfig(newRolloutController(true), slurmConf, nil),
+			slurmConf:   "ClusterName=slurm\nSelectType=select/linear\nDebugFlags=Steps",
+			wantReason:  ConfigRestartingReason,
+			wantMessage: "RestartSlurmctld: SelectType",
+		},
+		{
+			name: "Pending restart is kept",
+			controller: newRolloutController(true, metav1.Condition{
+				Type:   slurmconditions.ControllerConditionConfigApplied,
+				Status: metav1.ConditionFalse,
+				Reason: ConfigRestartingReason,
+			}),
+			oldConfig:   newRolloutConfig(newRolloutController(true), slurmConf, nil),
+			slurmConf:   "ClusterName=slurm\nSelectType=select/cons_tres\nDebugFlags=Gres",
+			wantReason:  ConfigRestartingReason,
+			wantMessage: "Reconfigure: DebugFlags",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ControllerReconciler{
+				eventRecorder: events.NewFakeRecorder(10),
+			}
+			newConfig := newRolloutConfig(tt.controller, tt.slurmConf, tt.data)
+
+			r.recordConfigChanges(t.Context(), tt.controller, tt.oldConfig, newConfig)
+
+			condition := meta.FindStatusCondition(tt.controller.Status.Conditions, slurmconditions.ControllerConditionConfigApplied)
+			if tt.wantReason == "" {
+				require.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			require.Equal(t, metav1.ConditionFalse, condition.Status)
+			require.Equal(t, tt.wantReason, condition.Reason)
+			require.Equal(t, tt.wantMessage, condition.Message)
+		})
+	}
+}
+
+func TestControllerReconciler_syncConfigRollout(t *testing.T) {
+	const slurmConf = "ClusterName=slurm\nSelectType=select/linear\nTaskPlugin=task/cgroup"
+	newCondition := func(reason string) metav1.Condition {
+		return metav1.Condition{
+			Type:               slurmconditions.ControllerConditionConfigApplied,
+			Status:             metav1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			Reason:             reason,
+			Message:            "RestartSlurmctld: SelectType",
+		}
+	}
+	newStatefulSet := func(controller *slinkyv1beta1.Controller, hash string, rolledOut bool) *appsv1.StatefulSet {
+		out := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  controller.Key().Namespace,
+				Name:       controller.Key().Name,
+				Generation: 1,
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To[int32](1),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							common.AnnotationSlurmctldRestartHash: hash,
+						},
+					},
+				},
+			},
+			Status: appsv1.StatefulSetStatus{
+				CurrentRevision: "1",
+				UpdateRevision:  "2",
+			},
+		}
+		if rolledOut {
+			out.Status = appsv1.StatefulSetStatus{
+				ObservedGeneration: 1,
+				CurrentRevision:    "2",
+				UpdateRevision:     "2",
+				UpdatedReplicas:    1,
+				ReadyReplicas:      1,
+			}
+		}
+		return out
+	}
+	controller := newRolloutController(true)
+	config := newRolloutConfig(controller, slurmConf, nil)
+	mountedPod := newRolloutPod(controller, runningState)
+	mountedLog := reportedLog(rolloutConfigHash)
+	tests := []struct {
+		name             string
+		controller       *slinkyv1beta1.Controller
+		objs             []client.Object
+		log              string
+		logErr           error
+		reconfigureErr   error
+		wantReconfigures int
+		wantStatus       metav1.ConditionStatus
+		wantReason       string
+		wantErr          bool
+	}{
+		{
+			name:       "Nothing pending",
+			controller: newRolloutController(true),
+		},
+		{
+			name:       "Condition is removed without inplace reconfigure",
+			controller: newRolloutController(false, newCondition(ConfigReconfigurePendingReason)),
+		},
+		{
+			name:             "Reconfigure",
+			controller:       newRolloutController(true, newCondition(ConfigReconfigurePendingReason)),
+			objs:             []client.Object{config, mountedPod},
+			log:              mountedLog,
+			wantReconfigures: 1,
+			wantStatus:       metav1.ConditionTrue,
+			wantReason:       ConfigReconfiguredReason,
+		},
+		{
+			name:       "Wait for slurmctld to mount the config",
+			controller: newRolloutController(true, newCondition(ConfigReconfigurePendingReason)),
+			objs:       []client.Object{config, mountedPod},
+			log:        reportedLog("stale"),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ConfigReconfigurePendingReason,
+		},
+		{
+			name:       "Wait for the reconfigure sidecar to report",
+			controller: newRolloutController(true, newCondition(ConfigReconfigurePendingReason)),
+			objs:       []client.Object{config, mountedPod},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ConfigReconfigurePendingReason,
+		},
+		{
+			name:       "Wait for the reconfigure sidecar to run",
+			controller: newRolloutController(true, newCondition(ConfigReconfigurePendingReason)),
+			objs: []client.Object{
+				config,
+				newRolloutPod(controller, waitingState),
+			},
+			log:        mountedLog,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ConfigReconfigurePendingReason,
+		},
+		{
+			name:       "Log error",
+			controller: newRolloutController(true, newCondition(ConfigReconfigurePendingReason)),
+			objs:       []client.Object{config, mountedPod},
+			logErr:     errors.New("internal error"),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ConfigReconfigurePendingReason,
+			wantErr:    true,
+		},
+		{
+			name:       "Wait for slurmctld pods",
+			controller: newRolloutController(true, newCondition(ConfigReconfigurePendingReason)),
+			objs:       []client.Object{config},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ConfigReconfigurePendingReason,
+		},
+		{
+			name:       "Wait for slurmctld restart",
+			controller: newRolloutController(true, newCondition(ConfigRestartingReason)),
+			objs: []client.Object{
+				config,
+				newStatefulSet(controller, common.SlurmctldRestartHash(slurmConf), false),
+				mountedPod,
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ConfigRestartingReason,
+		},
+		{
+			name:       "Wait for updated pod template",
+			controller: newRolloutController(true, newCondition(ConfigRestartingReason)),
+			objs: []client.Object{
+				config,
+				newStatefulSet(controller, "stale", true),
+				mountedPod,
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ConfigRestartingReason,
+		},
+		{
+			name:       "Reconfigure after slurmctld restart",
+			controller: newRolloutController(true, newCondition(ConfigRestartingReason)),
+			objs: []client.Object{
+				config,
+				newStatefulSet(controller, common.SlurmctldRestartHash(slurmConf), true),
+				mountedPod,
+			},
+			log:              mountedLog,
+			wantReconfigures: 1,
+			wantStatus:       metav1.ConditionTrue,
+			wantReason:       ConfigRestartedReason,
+		},
+		{
+			name:             "No Slurm client",
+			controller:       newRolloutController(true, newCondition(ConfigReconfigurePendingReason)),
+			objs:             []client.Object{config, mountedPod},
+			log:              mountedLog,
+			reconfigureErr:   slurmcontrol.ErrNoSlurmClient,
+			wantReconfigures: 1,
+			wantStatus:       metav1.ConditionFalse,
+			wantReason:       ConfigReconfigurePendingReason,
+		},
+		{
+			name:       "Reconfigure error",
+			controller: newRolloutController(true, newCondition(ConfigRestartingReason)),
+			objs: []client.Object{
+				config,
+				newStatefulSet(controller, common.SlurmctldRestartHash(slurmConf), true),
+				mountedPod,
+			},
+			log:              mountedLog,
+			reconfigureErr:   errors.New("internal error"),
+			wantReconfigures: 1,
+			wantStatus:       metav1.ConditionFalse,
+			wantReason:       ConfigReconfigurePendingReason,
+			wantErr:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconfigures := 0
+			r := &ControllerReconciler{
+				Client:        fake.NewClientBuilder().WithObjects(tt.objs...).Build(),
+				eventRecorder: events.NewFakeRecorder(10),
+				slurmControl: fakeSlurmControl{
+					reconfigures:   &reconfigures,
+					reconfigureErr: tt.reconfigureErr,
+				},
+				lastLogLine: func(ctx context.Context, pod *corev1.Pod, container string) (string, error) {
+					return tt.log, tt.logErr
+				},
+			}
+
+			err := r.syncConfigRollout(t.Context(), tt.controller)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantReconfigures, reconfigures)
+
+			condition := meta.FindStatusCondition(tt.controller.Status.Conditions, slurmconditions.ControllerConditionConfigApplied)
+			if tt.wantReason == "" {
+				require.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			require.Equal(t, tt.wantStatus, condition.Status)
+			require.Equal(t, tt.wantReason, condition.Reason)
+		})
+	}
+}
+
+func TestControllerReconciler_syncSlurmdRestartHash(t *testing.T) {
+	tests := []struct {
+		name      string
+		slurmConf string
+		want      string
+	}{
+		{
+			name:      "slurmd restart keys are unchanged",
+			slurmConf: "ClusterName=slurm\nSelectType=select/linear\nTaskPlugin=task/cgroup",
+		},
+		{
+			name:      "slurmd restart keys changed",
+			slurmConf: "ClusterName=slurm\nSelectType=select/cons_tres\nTaskPlugin=task/affinity",
+			want:      common.SlurmdRestartHash("ClusterName=slurm\nSelectType=select/cons_tres\nTaskPlugin=task/affinity"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := newRolloutController(true)
+			config := newRolloutConfig(controller, tt.slurmConf, nil)
+			c := fake.NewClientBuilder().WithObjects(config).Build()
+			r := &ControllerReconciler{
+				Client: c,
+			}
+
+			err := r.syncSlurmdRestartHash(t.Context(), config)
+			require.NoError(t, err)
+
+			got := &corev1.ConfigMap{}
+			require.NoError(t, c.Get(t.Context(), controller.ConfigKey(), got))
+			require.Equal(t, tt.want, got.Annotations[common.AnnotationSlurmdRestartHash])
+		})
+	}
+}
@@ -51,6 +51,10 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 		durationStore.Push(key, 30*time.Second)
 	}
 
+	// Slurm cannot be reconfigured with the config changes written by the same
+	// sync, as slurmctld has yet to mount them.
+	configChanged := false
+
 	steps := []syncsteps.Step[*slinkyv1beta1.Controller]{
 		{
 			Name: "Internal Service",
@@ -97,9 +101,18 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 				if err != nil {
 					return fmt.Errorf("failed to build: %w", err)
 				}
+				oldConfig := &corev1.ConfigMap{}
+				if err := r.Get(ctx, client.ObjectKeyFromObject(object), oldConfig); err != nil {
+					if !apierrors.IsNotFound(err) {
+						return fmt.Errorf("failed to get object (%s): %w", klog.KObj(object), err)
+					}
+					oldConfig = nil
+				}
+				seedRestartHashes(controller, oldConfig, object)
 				if err := objectutils.SyncObject(r.Client, ctx, r.eventRecorder, controller, object, true); err != nil {
 					return fmt.Errorf("failed to sync object (%s): %w", klog.KObj(object), err)
 				}
+				configChanged = r.recordConfigChanges(ctx, controller, oldConfig, object)
 				return nil
 			},
 		},
@@ -147,6 +160,16 @@ func (r *ControllerReconciler) Sync(ctx context.Context, req reconcile.Request)
 				return nil
 			},
 		},
+		{
+			Name: "Config Rollout",
+			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+				if configChanged {
+					durationStore.Push(key, 5*time.Second)
+					return nil
+				}
+				return r.syncConfigRollout(ctx, controller)
+			},
+		},
 		{
 			Name: "ServiceMonitor",
 			SyncFn: func(ctx context.Context, controller *slinkyv1beta1.Controller) error {
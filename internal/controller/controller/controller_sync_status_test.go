@@ -22,12 +22,22 @@ import (
 type fakeSlurmControl struct {
 	pings []slurmcontrol.ControllerPing
 	err   error
+
+	reconfigures   *int
+	reconfigureErr error
 }
 
 func (f fakeSlurmControl) GetActiveHAController(context.Context, *slinkyv1beta1.Controller) ([]slurmcontrol.ControllerPing, error) {
 	return f.pings, f.err
 }
 
+func (f fakeSlurmControl) Reconfigure(context.Context, *slinkyv1beta1.Controller) error {
+	if f.reconfigures != nil {
+		*f.reconfigures++
+	}
+	return f.reconfigureErr
+}
+
 func TestControllerReconciler_syncHAStatus(t *testing.T) {
 	newController := func(external bool) *slinkyv1beta1.Controller {
 		return &slinkyv1beta1.Controller{
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+func NewNodeEventHandler(reader client.Reader) *NodeEventHandler {
+	return &NodeEventHandler{
+		Reader: reader,
+	}
+}
+
+var _ handler.EventHandler = &NodeEventHandler{}
+
+// NodeEventHandler enqueues the Controllers which generate their Slurm topology
+// from Kubernetes node labels.
+type NodeEventHandler struct {
+	client.Reader
+}
+
+func (e *NodeEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, q)
+}
+
+func (e *NodeEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	oldNode, ok := evt.ObjectOld.(*corev1.Node)
+	if !ok {
+		return
+	}
+	newNode, ok := evt.ObjectNew.(*corev1.Node)
+	if !ok {
+		return
+	}
+	if apiequality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) {
+		return
+	}
+	e.enqueueRequest(ctx, q)
+}
+
+func (e *NodeEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, q)
+}
+
+func (e *NodeEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+func (e *NodeEventHandler) enqueueRequest(
+	ctx context.Context,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	logger := log.FromContext(ctx)
+
+	controllerList := &slinkyv1beta1.ControllerList{}
+	if err := e.List(ctx, controllerList); err != nil {
+		logger.Error(err, "failed to list controller CRs")
+	}
+
+	for _, controller := range controllerList.Items {
+		if len(controller.Spec.Topology.Topologies) == 0 {
+			continue
+		}
+		objectutils.EnqueueRequest(q, &controller)
+	}
+}
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newTopologyController(name string) *slinkyv1beta1.Controller {
+	controller := testutils.NewController(name, testutils.NewSlurmKeyRef(name), testutils.NewJwtKeyRef(name), nil)
+	controller.Spec.Topology = slinkyv1beta1.ControllerTopology{
+		Topologies: []slinkyv1beta1.ControllerTopologySpec{
+			{
+				Name: "topo-switch",
+				Tree: &slinkyv1beta1.ControllerTopologyTree{
+					SwitchLabels: []string{corev1.LabelTopologyZone},
+				},
+			},
+		},
+	}
+	return controller
+}
+
+func Test_NodeEventHandler_Create(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+		},
+	}
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "No topology",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					testutils.NewController("slurm", testutils.NewSlurmKeyRef("slurm"), testutils.NewJwtKeyRef("slurm"), nil),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: node,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "Topology",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					newTopologyController("slurm"),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: node,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewNodeEventHandler(tt.fields.Reader)
+			h.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			require.Equal(t, tt.want, tt.args.q.Len())
+		})
+	}
+}
+
+func Test_NodeEventHandler_Update(t *testing.T) {
+	oldNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+		},
+	}
+	newNode := oldNode.DeepCopy()
+	newNode.Labels = map[string]string{
+		corev1.LabelTopologyZone: "zone-a",
+	}
+	annotatedNode := oldNode.DeepCopy()
+	annotatedNode.Annotations = map[string]string{
+		"foo": "bar",
+	}
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Labels changed",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					newTopologyController("slurm"),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: oldNode,
+					ObjectNew: newNode,
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Labels unchanged",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					newTopologyController("slurm"),
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: oldNode,
+					ObjectNew: annotatedNode,
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewNodeEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			require.Equal(t, tt.want, tt.args.q.Len())
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
 	slurmerrors "github.com/SlinkyProject/slurm-client/pkg/errors"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
 	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
@@ -24,6 +25,8 @@ var ErrNoSlurmClient = errors.New("NoSlurmClient")
 type SlurmControlInterface interface {
 	// GetActiveHAController returns a list of controller pings.
 	GetActiveHAController(ctx context.Context, controller *slinkyv1beta1.Controller) ([]ControllerPing, error)
+	// Reconfigure requests the Slurm daemons to reload their configuration.
+	Reconfigure(ctx context.Context, controller *slinkyv1beta1.Controller) error
 }
 
 // realSlurmControl is the default implementation of SlurmControlInterface.
@@ -73,6 +76,24 @@ func (r *realSlurmControl) GetActiveHAController(ctx context.Context, controller
 	return controllerPings, nil
 }
 
+// Reconfigure implements SlurmControlInterface.
+func (r *realSlurmControl) Reconfigure(ctx context.Context, controller *slinkyv1beta1.Controller) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do Reconfigure()")
+		return ErrNoSlurmClient
+	}
+
+	reconfigure := &slurmtypes.V0044Reconfigure{}
+	if err := slurmClient.Get(ctx, slurmobject.ObjectKey(controller.ClusterName()), reconfigure); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *realSlurmControl) lookupClient(controller *slinkyv1beta1.Controller) slurmclient.Client {
 	key := ktypes.NamespacedName{
 		Namespace: controller.Namespace,
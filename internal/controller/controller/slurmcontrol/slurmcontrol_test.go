@@ -4,6 +4,8 @@
 package slurmcontrol
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -13,6 +15,8 @@ import (
 	api "github.com/SlinkyProject/slurm-client/api/v0044"
 	"github.com/SlinkyProject/slurm-client/pkg/client"
 	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
 	"github.com/SlinkyProject/slurm-client/pkg/types"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
@@ -131,6 +135,57 @@ func Test_realSlurmControl_GetActiveHAController(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_Reconfigure(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "test",
+		},
+	}
+	tests := []struct {
+		name         string
+		getErr       error
+		wantErr      bool
+		wantReconfig bool
+	}{
+		{
+			name:         "reconfigured",
+			wantReconfig: true,
+		},
+		{
+			name:         "failed",
+			getErr:       errors.New("slurmctld is not responding"),
+			wantErr:      true,
+			wantReconfig: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reconfigured bool
+			sclient := fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(_ context.Context, _ object.ObjectKey, obj object.Object, _ ...client.GetOption) error {
+						if _, ok := obj.(*types.V0044Reconfigure); ok {
+							reconfigured = true
+						}
+						return tt.getErr
+					},
+				}).
+				Build()
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+			err := r.Reconfigure(t.Context(), controller)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.wantReconfig, reconfigured)
+		})
+	}
+
+	t.Run("no client", func(t *testing.T) {
+		r := NewSlurmControl(testutils.NewClientMap("other", controller.Namespace, fake.NewFakeClient()))
+		err := r.Reconfigure(t.Context(), controller)
+		require.ErrorIs(t, err, ErrNoSlurmClient)
+	})
+}
+
 func newPing(hostname string, isPrimary, isResponding bool) api.V0044ControllerPing {
 	ping := api.V0044ControllerPing{
 		Hostname:   new(hostname),
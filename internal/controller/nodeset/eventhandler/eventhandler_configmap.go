@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+func NewConfigMapEventHandler(reader client.Reader) *ConfigMapEventHandler {
+	return &ConfigMapEventHandler{
+		Reader:      reader,
+		refResolver: refresolver.New(reader),
+	}
+}
+
+var _ handler.EventHandler = &ConfigMapEventHandler{}
+
+// ConfigMapEventHandler enqueues the NodeSets of a Controller when the slurmd
+// restart hash of the Controller ConfigMap changes.
+type ConfigMapEventHandler struct {
+	client.Reader
+	refResolver *refresolver.RefResolver
+}
+
+func (e *ConfigMapEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *ConfigMapEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	oldHash := evt.ObjectOld.GetAnnotations()[common.AnnotationSlurmdRestartHash]
+	newHash := evt.ObjectNew.GetAnnotations()[common.AnnotationSlurmdRestartHash]
+	if oldHash == newHash {
+		return
+	}
+	e.enqueueRequest(ctx, evt.ObjectNew, q)
+}
+
+func (e *ConfigMapEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *ConfigMapEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+func (e *ConfigMapEventHandler) enqueueRequest(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	logger := log.FromContext(ctx)
+
+	configMap, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+	configMapKey := client.ObjectKeyFromObject(configMap)
+
+	controllerList := &slinkyv1beta1.ControllerList{}
+	if err := e.List(ctx, controllerList, client.InNamespace(configMap.Namespace)); err != nil {
+		logger.Error(err, "failed to list controller CRs")
+		return
+	}
+
+	for _, controller := range controllerList.Items {
+		if configMapKey.String() != controller.ConfigKey().String() {
+			continue
+		}
+
+		nodesetList, err := e.refResolver.GetNodeSetsForController(ctx, &controller)
+		if err != nil {
+			logger.Error(err, "failed to list NodeSet CRs")
+			continue
+		}
+
+		for _, nodeset := range nodesetList.Items {
+			objectutils.EnqueueRequest(q, &nodeset)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newConfigMap(key client.ObjectKey, restartHash string) *corev1.ConfigMap {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+		},
+	}
+	if restartHash != "" {
+		configMap.Annotations = map[string]string{
+			common.AnnotationSlurmdRestartHash: restartHash,
+		}
+	}
+	return configMap
+}
+
+func Test_ConfigMapEventHandler_Create(t *testing.T) {
+	name := "slurm"
+	slurmKeyRef := testutils.NewSlurmKeyRef(name)
+	jwtKeyRef := testutils.NewJwtKeyRef(name)
+	controller := testutils.NewController(name, slurmKeyRef, jwtKeyRef, nil)
+	nodeset := testutils.NewNodeset(name, controller, 2)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Controller config",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					controller,
+					nodeset,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newConfigMap(controller.ConfigKey(), ""),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Other config",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					controller,
+					nodeset,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newConfigMap(client.ObjectKey{Namespace: controller.Namespace, Name: "foo"}, ""),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewConfigMapEventHandler(tt.fields.Reader)
+			h.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			require.Equal(t, tt.want, tt.args.q.Len())
+		})
+	}
+}
+
+func Test_ConfigMapEventHandler_Delete(t *testing.T) {
+	name := "slurm"
+	slurmKeyRef := testutils.NewSlurmKeyRef(name)
+	jwtKeyRef := testutils.NewJwtKeyRef(name)
+	controller := testutils.NewController(name, slurmKeyRef, jwtKeyRef, nil)
+	nodeset := testutils.NewNodeset(name, controller, 2)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.DeleteEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Controller config",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					controller,
+					nodeset,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{
+					Object: newConfigMap(controller.ConfigKey(), "foo"),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Not a ConfigMap",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					controller,
+					nodeset,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{
+					Object: &slinkyv1beta1.Controller{},
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewConfigMapEventHandler(tt.fields.Reader)
+			h.Delete(tt.args.ctx, tt.args.evt, tt.args.q)
+			require.Equal(t, tt.want, tt.args.q.Len())
+		})
+	}
+}
+
+func Test_ConfigMapEventHandler_Update(t *testing.T) {
+	name := "slurm"
+	slurmKeyRef := testutils.NewSlurmKeyRef(name)
+	jwtKeyRef := testutils.NewJwtKeyRef(name)
+	controller := testutils.NewController(name, slurmKeyRef, jwtKeyRef, nil)
+	nodeset := testutils.NewNodeset(name, controller, 2)
+	type fields struct {
+		Reader client.Reader
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "Restart hash changed",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					controller,
+					nodeset,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newConfigMap(controller.ConfigKey(), ""),
+					ObjectNew: newConfigMap(controller.ConfigKey(), "foo"),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Restart hash unchanged",
+			fields: fields{
+				Reader: fake.NewFakeClient(
+					controller,
+					nodeset,
+				),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newConfigMap(controller.ConfigKey(), "foo"),
+					ObjectNew: newConfigMap(controller.ConfigKey(), "foo"),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewConfigMapEventHandler(tt.fields.Reader)
+			h.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			require.Equal(t, tt.want, tt.args.q.Len())
+		})
+	}
+}
@@ -76,14 +76,33 @@ func (h *NodeEventHandler) Update(
 		return
 	}
 
-	// Detect node cordoning/uncordoning or metadata changed.
+	// Detect node cordoning/uncordoning, metadata, or condition status changed.
 	if oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
 		!apiequality.Semantic.DeepEqual(oldNode.Annotations, newNode.Annotations) ||
-		!apiequality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) {
+		!apiequality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		isNodeConditionStatusChanged(oldNode, newNode) {
 		h.enqueueNodeSetsForNode(ctx, newNode, q)
 	}
 }
 
+// isNodeConditionStatusChanged returns true if a node condition was added,
+// removed, or changed status. Heartbeat updates are ignored.
+func isNodeConditionStatusChanged(oldNode, newNode *corev1.Node) bool {
+	if len(oldNode.Status.Conditions) != len(newNode.Status.Conditions) {
+		return true
+	}
+	oldStatus := make(map[corev1.NodeConditionType]corev1.ConditionStatus, len(oldNode.Status.Conditions))
+	for _, condition := range oldNode.Status.Conditions {
+		oldStatus[condition.Type] = condition.Status
+	}
+	for _, condition := range newNode.Status.Conditions {
+		if status, ok := oldStatus[condition.Type]; !ok || status != condition.Status {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *NodeEventHandler) enqueueNodeSetsForNode(
 	ctx context.Context,
 	node *corev1.Node,
@@ -186,6 +186,46 @@ func Test_NodeEventHandler_Update(t *testing.T) {
 			},
 			want: 1, // Should enqueue 1 NodeSet for reconciliation
 		},
+		{
+			name: "Node condition status changed - should enqueue NodeSet",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(
+					nodeset,
+					newNodeSetPod(cl, nodeset, 0, "test-node"),
+				).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNodeWithCondition("test-node", "GPUUnhealthy", corev1.ConditionFalse),
+					ObjectNew: newNodeWithCondition("test-node", "GPUUnhealthy", corev1.ConditionTrue),
+				},
+				q: newQueue(),
+			},
+			want: 1,
+		},
+		{
+			name: "Node condition heartbeat - should not enqueue",
+			fields: fields{
+				Reader: indexes.NewFakeClientBuilderWithIndexes(
+					nodeset,
+					newNodeSetPod(cl, nodeset, 0, "test-node"),
+				).Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNodeWithCondition("test-node", "GPUUnhealthy", corev1.ConditionTrue),
+					ObjectNew: func() *corev1.Node {
+						node := newNodeWithCondition("test-node", "GPUUnhealthy", corev1.ConditionTrue)
+						node.Status.Conditions[0].LastHeartbeatTime = metav1.Now()
+						return node
+					}(),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
 		{
 			name: "No cordon change - should not enqueue",
 			fields: fields{
@@ -244,3 +284,11 @@ func newNode(name string, unschedulable bool) *corev1.Node {
 	}
 	return node
 }
+
+func newNodeWithCondition(name string, conditionType corev1.NodeConditionType, status corev1.ConditionStatus) *corev1.Node {
+	node := newNode(name, false)
+	node.Status.Conditions = []corev1.NodeCondition{
+		{Type: conditionType, Status: status},
+	}
+	return node
+}
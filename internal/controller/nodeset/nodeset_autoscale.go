@@ -0,0 +1,279 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+var (
+	autoscalers = newAutoscalerStore()
+)
+
+// autoscaleRecommendation is a replica recommendation at a point in time.
+type autoscaleRecommendation struct {
+	replicas  int32
+	timestamp time.Time
+}
+
+// autoscalerState is the in-memory state of a NodeSet autoscaler.
+type autoscalerState struct {
+	// observed is the first recommendation since the operator started, the
+	// replicas are not scaled down below it until a full scale-down window.
+	observed        autoscaleRecommendation
+	recommendations []autoscaleRecommendation
+	demand          slurmcontrol.SlurmJobDemand
+	desired         int32
+	lastScaleTime   time.Time
+}
+
+// autoscalerStore tracks the autoscaler state of NodeSets by key.
+type autoscalerStore struct {
+	lock   sync.Mutex
+	states map[string]*autoscalerState
+}
+
+func newAutoscalerStore() *autoscalerStore {
+	return &autoscalerStore{
+		states: make(map[string]*autoscalerState),
+	}
+}
+
+// Get returns a copy of the autoscaler state for the key.
+func (s *autoscalerStore) Get(key string) (autoscalerState, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state, ok := s.states[key]
+	if !ok {
+		return autoscalerState{}, false
+	}
+	return *state, true
+}
+
+// Delete removes the autoscaler state for the key.
+func (s *autoscalerStore) Delete(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.states, key)
+}
+
+// Stabilize records the recommendation and returns the stabilized replica
+// count. Scale-up is limited to the lowest recommendation within the scale-up
+// window and scale-down is limited to the highest recommendation within the
+// scale-down window, such that only sustained demand changes the replicas.
+// The history is kept in memory, so after an operator restart the current
+// replicas are held until a full scale-down window has been observed.
+func (s *autoscalerStore) Stabilize(
+	key string,
+	current, recommended int32,
+	demand slurmcontrol.SlurmJobDemand,
+	upWindow, downWindow time.Duration,
+	now time.Time,
+) int32 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	state, ok := s.states[key]
+	if !ok {
+		state = &autoscalerState{
+			observed: autoscaleRecommendation{
+				replicas:  current,
+				timestamp: now,
+			},
+		}
+		s.states[key] = state
+	}
+
+	cutoff := now.Add(-max(upWindow, downWindow))
+	recommendations := make([]autoscaleRecommendation, 0, len(state.recommendations)+1)
+	for _, rec := range state.recommendations {
+		if rec.timestamp.After(cutoff) {
+			recommendations = append(recommendations, rec)
+		}
+	}
+	recommendations = append(recommendations, autoscaleRecommendation{
+		replicas:  recommended,
+		timestamp: now,
+	})
+	state.recommendations = recommendations
+	state.demand = demand
+
+	upLimit := recommended
+	downLimit := recommended
+	for _, rec := range recommendations {
+		if !rec.timestamp.Before(now.Add(-upWindow)) {
+			upLimit = min(upLimit, rec.replicas)
+		}
+		if !rec.timestamp.Before(now.Add(-downWindow)) {
+			downLimit = max(downLimit, rec.replicas)
+		}
+	}
+	if now.Sub(state.observed.timestamp) < downWindow {
+		downLimit = max(downLimit, state.observed.replicas)
+	}
+
+	desired := current
+	switch {
+	case current < upLimit:
+		desired = upLimit
+	case current > downLimit:
+		desired = downLimit
+	}
+	state.desired = desired
+
+	return desired
+}
+
+// MarkScaled records that the autoscaler changed the replicas.
+func (s *autoscalerStore) MarkScaled(key string, now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if state, ok := s.states[key]; ok {
+		state.lastScaleTime = now
+	}
+}
+
+// autoscalePartitions returns the Slurm partitions whose jobs count towards
+// the demand of the NodeSet.
+func autoscalePartitions(nodeset *slinkyv1beta1.NodeSet) []string {
+	if len(nodeset.Spec.Autoscaling.Partitions) > 0 {
+		return nodeset.Spec.Autoscaling.Partitions
+	}
+	if nodeset.Spec.Partition.Enabled {
+		return []string{common.GetSlurmNodeSetName(nodeset)}
+	}
+	return nil
+}
+
+// countBusyNodes returns the number of Slurm nodes which are doing work.
+func countBusyNodes(nodeStatus slurmcontrol.SlurmNodeStatus) int32 {
+	busy := int32(0)
+	for _, conditions := range nodeStatus.NodeStates {
+		status := &corev1.PodStatus{Conditions: conditions}
+		if slurmconditions.IsNodeBusy(status) {
+			busy++
+		}
+	}
+	return busy
+}
+
+// calculateAutoscaleReplicas returns the number of replicas required to run
+// the busy Slurm nodes and the pending Slurm jobs, bounded by min/max replicas.
+func calculateAutoscaleReplicas(
+	nodeset *slinkyv1beta1.NodeSet,
+	busy int32,
+	demand slurmcontrol.SlurmJobDemand,
+) int32 {
+	autoscaling := nodeset.Spec.Autoscaling
+	replicas := busy + demand.PendingNodes
+	return mathutils.Clamp(replicas, autoscaling.MinReplicas, max(autoscaling.MinReplicas, autoscaling.MaxReplicas))
+}
+
+// syncAutoscale handles scaling the NodeSet replicas based on the Slurm job queue.
+// Replicas are never scaled below the number of busy Slurm nodes, and scale-in
+// condemns idle Slurm nodes before busy ones, such that only IDLE nodes are
+// condemned and drained by processCondemned.
+func (r *NodeSetReconciler) syncAutoscale(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	jobs *slurmcontrol.SlurmJobs,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+
+	autoscaling := nodeset.Spec.Autoscaling
+	if !autoscaling.Enabled || nodeset.Spec.ScalingMode != slinkyv1beta1.ScalingModeStatefulset {
+		autoscalers.Delete(key)
+		return nil
+	}
+
+	nodeStatus, err := r.slurmControl.CalculateNodeStatus(ctx, nodeset, pods, jobs)
+	if err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return nil
+		}
+		return err
+	}
+	demand := jobs.CalculateDemand(autoscalePartitions(nodeset))
+
+	now := time.Now()
+	busy := countBusyNodes(nodeStatus)
+	current := ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas)
+	recommended := calculateAutoscaleReplicas(nodeset, busy, demand)
+	desired := autoscalers.Stabilize(key, current, recommended, demand,
+		autoscaling.ScaleUpStabilizationWindow.Duration,
+		autoscaling.ScaleDownStabilizationWindow.Duration,
+		now)
+	if desired < current {
+		// Only scale-down IDLE nodes, busy nodes must remain.
+		desired = max(desired, min(busy, current))
+	}
+
+	logger.V(1).Info("NodeSet autoscale recommendation",
+		"current", current, "recommended", recommended, "desired", desired,
+		"busy", busy, "pendingJobs", demand.PendingJobs, "pendingNodes", demand.PendingNodes)
+	if desired == current {
+		return nil
+	}
+
+	mutateFn := func(nodeset *slinkyv1beta1.NodeSet) error {
+		nodeset.Spec.Replicas = ptr.To(desired)
+		return nil
+	}
+	if err := objectutils.PatchObject(r.Client, ctx, nodeset, mutateFn); err != nil {
+		return err
+	}
+	// The patch response replaces the object, restore the defaults for subsequent steps.
+	defaults.SetNodeSetDefaults(nodeset)
+	autoscalers.MarkScaled(key, now)
+
+	r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, AutoscalingReason, "Autoscale",
+		"Scaling replicas from %d to %d (busy nodes: %d, pending jobs: %d, pending nodes: %d)",
+		current, desired, busy, demand.PendingJobs, demand.PendingNodes)
+
+	return nil
+}
+
+// calculateAutoscalingStatus returns the observed state of the NodeSet autoscaler.
+func calculateAutoscalingStatus(nodeset *slinkyv1beta1.NodeSet) *slinkyv1beta1.NodeSetAutoscalingStatus {
+	if !nodeset.Spec.Autoscaling.Enabled || nodeset.Spec.ScalingMode != slinkyv1beta1.ScalingModeStatefulset {
+		return nil
+	}
+
+	state, ok := autoscalers.Get(objectutils.KeyFunc(nodeset))
+	if !ok {
+		return nodeset.Status.Autoscaling
+	}
+
+	status := &slinkyv1beta1.NodeSetAutoscalingStatus{
+		DesiredReplicas: state.desired,
+		PendingJobs:     state.demand.PendingJobs,
+		PendingNodes:    state.demand.PendingNodes,
+		RunningJobs:     state.demand.RunningJobs,
+	}
+	if !state.lastScaleTime.IsZero() {
+		status.LastScaleTime = ptr.To(metav1.NewTime(state.lastScaleTime.Truncate(time.Second)))
+	} else if nodeset.Status.Autoscaling != nil {
+		status.LastScaleTime = nodeset.Status.Autoscaling.LastScaleTime
+	}
+
+	return status
+}
@@ -0,0 +1,356 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+func Test_autoscalerStore_Stabilize(t *testing.T) {
+	now := time.Now()
+	type history struct {
+		replicas int32
+		age      time.Duration
+	}
+	tests := []struct {
+		name        string
+		history     []history
+		current     int32
+		recommended int32
+		want        int32
+	}{
+		{
+			name:        "No history, scale up",
+			current:     1,
+			recommended: 3,
+			want:        3,
+		},
+		{
+			name:        "No history, scale down is held for the scale-down window",
+			current:     3,
+			recommended: 1,
+			want:        3,
+		},
+		{
+			name: "Scale down after a full scale-down window was observed",
+			history: []history{
+				{replicas: 3, age: 11 * time.Minute},
+				{replicas: 1, age: 5 * time.Minute},
+			},
+			current:     3,
+			recommended: 1,
+			want:        1,
+		},
+		{
+			name: "Scale up is limited by the scale-up window",
+			history: []history{
+				{replicas: 2, age: 30 * time.Second},
+				{replicas: 4, age: 10 * time.Second},
+			},
+			current:     1,
+			recommended: 5,
+			want:        2,
+		},
+		{
+			name: "Scale up ignores recommendations outside the scale-up window",
+			history: []history{
+				{replicas: 1, age: 2 * time.Minute},
+				{replicas: 4, age: 30 * time.Second},
+			},
+			current:     1,
+			recommended: 5,
+			want:        4,
+		},
+		{
+			name: "Scale down is limited by the scale-down window",
+			history: []history{
+				{replicas: 3, age: 5 * time.Minute},
+				{replicas: 2, age: 1 * time.Minute},
+			},
+			current:     4,
+			recommended: 0,
+			want:        3,
+		},
+		{
+			name: "Scale down ignores recommendations outside the scale-down window",
+			history: []history{
+				{replicas: 4, age: 20 * time.Minute},
+				{replicas: 2, age: 1 * time.Minute},
+			},
+			current:     4,
+			recommended: 0,
+			want:        2,
+		},
+		{
+			name: "Unchanged within bounds",
+			history: []history{
+				{replicas: 1, age: 30 * time.Second},
+				{replicas: 4, age: 5 * time.Minute},
+			},
+			current:     2,
+			recommended: 3,
+			want:        2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAutoscalerStore()
+			key := "default/foo"
+			for i := len(tt.history) - 1; i >= 0; i-- {
+				h := tt.history[i]
+				_ = s.Stabilize(key, h.replicas, h.replicas, slurmcontrol.SlurmJobDemand{},
+					time.Minute, 10*time.Minute, now.Add(-h.age))
+			}
+			got := s.Stabilize(key, tt.current, tt.recommended, slurmcontrol.SlurmJobDemand{},
+				time.Minute, 10*time.Minute, now)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_calculateAutoscaleReplicas(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 1)
+	nodeset.Spec.Autoscaling = slinkyv1beta1.NodeSetAutoscaling{
+		Enabled:     true,
+		MinReplicas: 1,
+		MaxReplicas: 4,
+	}
+	tests := []struct {
+		name   string
+		busy   int32
+		demand slurmcontrol.SlurmJobDemand
+		want   int32
+	}{
+		{
+			name: "No demand",
+			want: 1,
+		},
+		{
+			name:   "Busy and pending",
+			busy:   1,
+			demand: slurmcontrol.SlurmJobDemand{PendingJobs: 1, PendingNodes: 2},
+			want:   3,
+		},
+		{
+			name:   "Bounded by maxReplicas",
+			busy:   2,
+			demand: slurmcontrol.SlurmJobDemand{PendingJobs: 2, PendingNodes: 8},
+			want:   4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateAutoscaleReplicas(nodeset, tt.busy, tt.demand)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_autoscalePartitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		want    []string
+	}{
+		{
+			name:    "No partition",
+			nodeset: newNodeSet("foo", "slurm", 1),
+			want:    nil,
+		},
+		{
+			name: "NodeSet partition",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", "slurm", 1)
+				nodeset.Spec.Partition.Enabled = true
+				return nodeset
+			}(),
+			want: []string{"foo"},
+		},
+		{
+			name: "Explicit partitions",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", "slurm", 1)
+				nodeset.Spec.Partition.Enabled = true
+				nodeset.Spec.Autoscaling.Partitions = []string{"all", "gpu"}
+				return nodeset
+			}(),
+			want: []string{"all", "gpu"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := autoscalePartitions(tt.nodeset)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncAutoscale(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	newAutoscaledNodeSet := func(replicas int32) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, replicas)
+		nodeset.Spec.Partition.Enabled = true
+		nodeset.Spec.Autoscaling = slinkyv1beta1.NodeSetAutoscaling{
+			Enabled:     true,
+			MinReplicas: 0,
+			MaxReplicas: 4,
+		}
+		return nodeset
+	}
+	newSlurmNode := func(pod *corev1.Pod, state slurmapi.V0044NodeState) slurmtypes.V0044Node {
+		return slurmtypes.V0044Node{
+			V0044Node: slurmapi.V0044Node{
+				Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+				State: ptr.To([]slurmapi.V0044NodeState{state}),
+			},
+		}
+	}
+	newPendingJob := func(id, nodes int32) slurmtypes.V0044JobInfo {
+		return slurmtypes.V0044JobInfo{
+			V0044JobInfo: slurmapi.V0044JobInfo{
+				JobId:       ptr.To(id),
+				JobState:    ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStatePENDING}),
+				Partition:   ptr.To("foo"),
+				StateReason: ptr.To("Resources"),
+				NodeCount:   ptr.To(slurmapi.V0044Uint32NoValStruct{Number: ptr.To(nodes)}),
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		nodeset      *slinkyv1beta1.NodeSet
+		pods         func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod
+		clientMap    func(pods []*corev1.Pod) *clientmap.ClientMap
+		wantReplicas int32
+		wantErr      bool
+	}{
+		{
+			name:    "Disabled",
+			nodeset: newNodeSet("foo", controller.Name, 1),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return nil
+			},
+			clientMap: func(pods []*corev1.Pod) *clientmap.ClientMap {
+				jobList := &slurmtypes.V0044JobInfoList{
+					Items: []slurmtypes.V0044JobInfo{newPendingJob(1, 2)},
+				}
+				return newClientMap(controller.Name, newFakeClientList(sinterceptor.Funcs{}, jobList))
+			},
+			wantReplicas: 1,
+		},
+		{
+			name:    "No client",
+			nodeset: newAutoscaledNodeSet(1),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return nil
+			},
+			clientMap: func(pods []*corev1.Pod) *clientmap.ClientMap {
+				return clientmap.NewClientMap()
+			},
+			wantReplicas: 1,
+		},
+		{
+			name:    "Scale up for pending jobs",
+			nodeset: newAutoscaledNodeSet(1),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")),
+				}
+			},
+			clientMap: func(pods []*corev1.Pod) *clientmap.ClientMap {
+				nodeList := &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{newSlurmNode(pods[0], slurmapi.V0044NodeStateALLOCATED)},
+				}
+				jobList := &slurmtypes.V0044JobInfoList{
+					Items: []slurmtypes.V0044JobInfo{newPendingJob(1, 2)},
+				}
+				return newClientMap(controller.Name, newFakeClientList(sinterceptor.Funcs{}, nodeList, jobList))
+			},
+			wantReplicas: 3,
+		},
+		{
+			name:    "Scale up bounded by maxReplicas",
+			nodeset: newAutoscaledNodeSet(1),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return nil
+			},
+			clientMap: func(pods []*corev1.Pod) *clientmap.ClientMap {
+				jobList := &slurmtypes.V0044JobInfoList{
+					Items: []slurmtypes.V0044JobInfo{newPendingJob(1, 8)},
+				}
+				return newClientMap(controller.Name, newFakeClientList(sinterceptor.Funcs{}, jobList))
+			},
+			wantReplicas: 4,
+		},
+		{
+			name:    "Scale down only idle nodes",
+			nodeset: newAutoscaledNodeSet(3),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")),
+					makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")),
+					makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 2, "")),
+				}
+			},
+			clientMap: func(pods []*corev1.Pod) *clientmap.ClientMap {
+				nodeList := &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{
+						newSlurmNode(pods[0], slurmapi.V0044NodeStateIDLE),
+						newSlurmNode(pods[1], slurmapi.V0044NodeStateMIXED),
+						newSlurmNode(pods[2], slurmapi.V0044NodeStateIDLE),
+					},
+				}
+				return newClientMap(controller.Name, newFakeClientList(sinterceptor.Funcs{}, nodeList, &slurmtypes.V0044JobInfoList{}))
+			},
+			wantReplicas: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			autoscalers.Delete(objectutils.KeyFunc(tt.nodeset))
+			pods := tt.pods(tt.nodeset)
+			c := fake.NewFakeClient(tt.nodeset.DeepCopy())
+			r := newNodeSetController(c, tt.clientMap(pods))
+			nodeset := tt.nodeset.DeepCopy()
+			jobs, _ := r.slurmControl.ListJobs(ctx, nodeset)
+			gotErr := r.syncAutoscale(ctx, nodeset, pods, jobs)
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+
+			checkNodeSet := &slinkyv1beta1.NodeSet{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tt.nodeset), checkNodeSet))
+			require.Equal(t, tt.wantReplicas, ptr.Deref(checkNodeSet.Spec.Replicas, 0))
+			require.Equal(t, tt.wantReplicas, ptr.Deref(nodeset.Spec.Replicas, 0))
+		})
+	}
+}
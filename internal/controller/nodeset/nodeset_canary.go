@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	daemonutils "k8s.io/kubernetes/pkg/controller/daemon/util"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
+)
+
+// isRolloutHeld returns true if the rolling update of the NodeSet holds any pods
+// at the current revision, by being paused, partitioned, or limited to canary nodes.
+func isRolloutHeld(nodeset *slinkyv1beta1.NodeSet) bool {
+	switch nodeset.Spec.UpdateStrategy.Type {
+	case slinkyv1beta1.ScheduledUpdateNodeSetStrategyType, slinkyv1beta1.OnDeleteNodeSetStrategyType:
+		return false
+	}
+	rollingUpdate := nodeset.Spec.UpdateStrategy.RollingUpdate
+	return rollingUpdate.Paused || getUpdatePartition(nodeset) > 0 || getCanarySelector(nodeset) != nil
+}
+
+// getUpdatePartition returns the ordinal below which pods are not updated.
+func getUpdatePartition(nodeset *slinkyv1beta1.NodeSet) int {
+	if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+		return 0
+	}
+	return int(max(ptr.Deref(nodeset.Spec.UpdateStrategy.RollingUpdate.Partition, 0), 0))
+}
+
+// getCanarySelector returns the selector of the Kubernetes nodes whose pods are
+// updated, or nil if pods on all nodes are updated.
+func getCanarySelector(nodeset *slinkyv1beta1.NodeSet) *metav1.LabelSelector {
+	if nodeset.Spec.ScalingMode != slinkyv1beta1.ScalingModeDaemonset {
+		return nil
+	}
+	return nodeset.Spec.UpdateStrategy.RollingUpdate.CanarySelector
+}
+
+// isPodUpdateEligible returns true if the rolling update may update the pod,
+// given the update is not paused, the pod ordinal is not below the partition,
+// and the Kubernetes node of the pod is selected by the canary selector.
+func (r *NodeSetReconciler) isPodUpdateEligible(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+) (bool, error) {
+	if !isRolloutHeld(nodeset) {
+		return true, nil
+	}
+	if nodeset.Spec.UpdateStrategy.RollingUpdate.Paused {
+		return false, nil
+	}
+
+	if nodeset.Spec.ScalingMode != slinkyv1beta1.ScalingModeDaemonset {
+		return nodesetutils.GetOrdinal(pod) >= getUpdatePartition(nodeset), nil
+	}
+
+	canarySelector := getCanarySelector(nodeset)
+	if canarySelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(canarySelector)
+	if err != nil {
+		return false, err
+	}
+	nodeName, err := daemonutils.GetTargetNodeName(pod)
+	if err != nil {
+		return false, err
+	}
+	node := &corev1.Node{}
+	if err := r.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+		return false, err
+	}
+	return selector.Matches(k8slabels.Set(node.Labels)), nil
+}
+
+// splitHeldPods returns the old pods which the rolling update may update, and
+// the old pods which are held at their revision.
+func (r *NodeSetReconciler) splitHeldPods(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	oldPods []*corev1.Pod,
+) (eligiblePods, heldPods []*corev1.Pod) {
+	logger := log.FromContext(ctx)
+
+	if !isRolloutHeld(nodeset) {
+		return oldPods, nil
+	}
+
+	for _, pod := range oldPods {
+		eligible, err := r.isPodUpdateEligible(ctx, nodeset, pod)
+		if err != nil {
+			logger.Error(err, "failed to determine if pod is eligible for update", "pod", klog.KObj(pod))
+		}
+		if eligible {
+			eligiblePods = append(eligiblePods, pod)
+		} else {
+			heldPods = append(heldPods, pod)
+		}
+	}
+
+	logger.V(1).Info("calculated held pods for update",
+		"eligiblePods", len(eligiblePods),
+		"heldPods", len(heldPods))
+	return eligiblePods, heldPods
+}
+
+// getCurrentRevisionNodeSet returns the NodeSet restored to its current revision,
+// and the hash of the current revision. It returns nil when the current revision
+// is the update revision, or is no longer in the revision history.
+func (r *NodeSetReconciler) getCurrentRevisionNodeSet(
+	nodeset *slinkyv1beta1.NodeSet,
+	hash string,
+) (*slinkyv1beta1.NodeSet, string, error) {
+	currentHash := nodeset.Status.CurrentRevision
+	if currentHash == "" || currentHash == hash {
+		return nil, "", nil
+	}
+
+	revisions, err := r.listRevisions(nodeset)
+	if err != nil {
+		return nil, "", err
+	}
+	var currentRevision *appsv1.ControllerRevision
+	for _, revision := range revisions {
+		if historycontrol.GetRevision(revision.GetLabels()) == currentHash {
+			currentRevision = revision
+			break
+		}
+	}
+	if currentRevision == nil {
+		return nil, "", nil
+	}
+
+	currentNodeSet, err := applyRevision(nodeset, currentRevision)
+	if err != nil {
+		return nil, "", err
+	}
+	return currentNodeSet, currentHash, nil
+}
+
+// holdNewPods replaces the pods to be created, which the rolling update may not
+// update, with pods at the current revision. Hence pods which are held at the
+// current revision are also recreated at it.
+func (r *NodeSetReconciler) holdNewPods(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	podsToCreate []*corev1.Pod,
+	hash string,
+) ([]*corev1.Pod, error) {
+	logger := log.FromContext(ctx)
+
+	if !isRolloutHeld(nodeset) {
+		return podsToCreate, nil
+	}
+
+	currentNodeSet, currentHash, err := r.getCurrentRevisionNodeSet(nodeset, hash)
+	if err != nil {
+		return nil, err
+	}
+	if currentNodeSet == nil {
+		return podsToCreate, nil
+	}
+
+	for i, pod := range podsToCreate {
+		eligible, err := r.isPodUpdateEligible(ctx, nodeset, pod)
+		if err != nil {
+			logger.Error(err, "failed to determine if pod is eligible for update", "pod", klog.KObj(pod))
+		}
+		if eligible {
+			continue
+		}
+		var heldPod *corev1.Pod
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			nodeName, err := daemonutils.GetTargetNodeName(pod)
+			if err != nil {
+				return nil, err
+			}
+			heldPod, err = r.newNodeSetPodDaemon(r.Client, ctx, currentNodeSet, nodeName, currentHash)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			heldPod, err = r.newNodeSetPodOrdinal(r.Client, ctx, currentNodeSet, nodesetutils.GetOrdinal(pod), currentHash)
+			if err != nil {
+				return nil, err
+			}
+		}
+		podsToCreate[i] = heldPod
+	}
+
+	return podsToCreate, nil
+}
+
+// calculateRevisionStatus returns the current revision, update revision, and
+// number of current replicas. The current revision becomes the update revision
+// once no pods remain at any other revision.
+func calculateRevisionStatus(
+	pods []*corev1.Pod,
+	currentRevision, updateRevision *appsv1.ControllerRevision,
+	replicaStatus replicaStatus,
+) (currentHash, updateHash string, currentReplicas int32) {
+	currentHash = historycontrol.GetRevision(currentRevision.GetLabels())
+	updateHash = historycontrol.GetRevision(updateRevision.GetLabels())
+	currentReplicas = replicaStatus.Current
+
+	if _, oldPods := findUpdatedPods(pods, updateHash); len(oldPods) == 0 {
+		currentHash = updateHash
+		currentReplicas = replicaStatus.Updated
+	}
+
+	return currentHash, updateHash, currentReplicas
+}
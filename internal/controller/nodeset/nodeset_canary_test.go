@@ -0,0 +1,317 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
+)
+
+func newCanaryNode(name string, nodeLabels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: nodeLabels,
+		},
+	}
+}
+
+func Test_isRolloutHeld(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodeset func() *slinkyv1beta1.NodeSet
+		want    bool
+	}{
+		{
+			name: "Not held",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				return newNodeSet("foo", "slurm", 4)
+			},
+			want: false,
+		},
+		{
+			name: "Paused",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", "slurm", 4)
+				nodeset.Spec.UpdateStrategy.RollingUpdate.Paused = true
+				return nodeset
+			},
+			want: true,
+		},
+		{
+			name: "Partition",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", "slurm", 4)
+				nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](2)
+				return nodeset
+			},
+			want: true,
+		},
+		{
+			name: "Partition in DaemonSet mode",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", "slurm", 4)
+				nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+				nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](2)
+				return nodeset
+			},
+			want: false,
+		},
+		{
+			name: "Canary selector in DaemonSet mode",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", "slurm", 4)
+				nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+				nodeset.Spec.UpdateStrategy.RollingUpdate.CanarySelector = &metav1.LabelSelector{}
+				return nodeset
+			},
+			want: true,
+		},
+		{
+			name: "OnDelete strategy",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", "slurm", 4)
+				nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.OnDeleteNodeSetStrategyType
+				nodeset.Spec.UpdateStrategy.RollingUpdate.Paused = true
+				return nodeset
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isRolloutHeld(tt.nodeset()))
+		})
+	}
+}
+
+func TestNodeSetReconciler_splitHeldPods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{ObjectMeta: metav1.ObjectMeta{Name: "slurm"}}
+	canaryNode := newCanaryNode("node-0", map[string]string{"canary": "true"})
+	otherNode := newCanaryNode("node-1", nil)
+	newStatefulSetPods := func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+		pods := []*corev1.Pod{}
+		for i := range 4 {
+			pods = append(pods, nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, i, surgeOldHash))
+		}
+		return pods
+	}
+	newDaemonSetPods := func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+		pods := []*corev1.Pod{}
+		for i, node := range []*corev1.Node{canaryNode, otherNode} {
+			pod := nodesetutils.NewNodeSetDaemonSetPod(fake.NewFakeClient(), nodeset, controller, node.Name, "", surgeOldHash)
+			pod.Name = nodesetutils.GetOrdinalPodName(nodeset, i)
+			pods = append(pods, pod)
+		}
+		return pods
+	}
+	tests := []struct {
+		name         string
+		nodeset      func() *slinkyv1beta1.NodeSet
+		pods         func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod
+		wantEligible []string
+		wantHeld     []string
+	}{
+		{
+			name: "Not held",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				return newNodeSet("foo", controller.Name, 4)
+			},
+			pods:         newStatefulSetPods,
+			wantEligible: []string{"foo-0", "foo-1", "foo-2", "foo-3"},
+			wantHeld:     []string{},
+		},
+		{
+			name: "Partition",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", controller.Name, 4)
+				nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](2)
+				return nodeset
+			},
+			pods:         newStatefulSetPods,
+			wantEligible: []string{"foo-2", "foo-3"},
+			wantHeld:     []string{"foo-0", "foo-1"},
+		},
+		{
+			name: "Paused",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", controller.Name, 4)
+				nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](2)
+				nodeset.Spec.UpdateStrategy.RollingUpdate.Paused = true
+				return nodeset
+			},
+			pods:         newStatefulSetPods,
+			wantEligible: []string{},
+			wantHeld:     []string{"foo-0", "foo-1", "foo-2", "foo-3"},
+		},
+		{
+			name: "Canary selector",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("foo", controller.Name, 0)
+				nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+				nodeset.Spec.UpdateStrategy.RollingUpdate.CanarySelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"canary": "true"},
+				}
+				return nodeset
+			},
+			pods:         newDaemonSetPods,
+			wantEligible: []string{"foo-0"},
+			wantHeld:     []string{"foo-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			nodeset := tt.nodeset()
+			c := fake.NewFakeClient(canaryNode.DeepCopy(), otherNode.DeepCopy())
+			r := newNodeSetController(c, clientmap.NewClientMap())
+
+			eligiblePods, heldPods := r.splitHeldPods(ctx, nodeset, tt.pods(nodeset))
+			gotEligible := []string{}
+			for _, pod := range eligiblePods {
+				gotEligible = append(gotEligible, pod.Name)
+			}
+			gotHeld := []string{}
+			for _, pod := range heldPods {
+				gotHeld = append(gotHeld, pod.Name)
+			}
+			require.Equal(t, tt.wantEligible, gotEligible)
+			require.Equal(t, tt.wantHeld, gotHeld)
+		})
+	}
+}
+
+func TestNodeSetReconciler_holdNewPods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	newCurrentRevision := func(nodeset *slinkyv1beta1.NodeSet) *appsv1.ControllerRevision {
+		currentNodeSet := nodeset.DeepCopy()
+		currentNodeSet.Spec.Slurmd.Image = "slurmd:old"
+		revision, err := newRevision(currentNodeSet, "", 1, ptr.To[int32](0))
+		require.NoError(t, err)
+		maps.Copy(revision.Labels, labels.NewBuilder().WithWorkerSelectorLabels(nodeset).Build())
+		return revision
+	}
+	tests := []struct {
+		name        string
+		partition   int32
+		wantCurrent []bool
+	}{
+		{
+			name:        "Not held",
+			partition:   0,
+			wantCurrent: []bool{false, false},
+		},
+		{
+			name:        "Recreate pods below the partition at the current revision",
+			partition:   2,
+			wantCurrent: []bool{true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			nodeset := newNodeSet("foo", controller.Name, 4)
+			nodeset.Spec.Slurmd.Image = "slurmd:new"
+			nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To(tt.partition)
+			revision := newCurrentRevision(nodeset)
+			currentHash := historycontrol.GetRevision(revision.Labels)
+			nodeset.Status.CurrentRevision = currentHash
+
+			objs := []runtime.Object{nodeset.DeepCopy(), controller.DeepCopy(), revision}
+			c := fake.NewFakeClient(objs...)
+			r := newNodeSetController(c, clientmap.NewClientMap())
+
+			podsToCreate := []*corev1.Pod{
+				nodesetutils.NewNodeSetStatefulSetPod(c, nodeset, controller, 0, surgeNewHash),
+				nodesetutils.NewNodeSetStatefulSetPod(c, nodeset, controller, 3, surgeNewHash),
+			}
+			got, err := r.holdNewPods(ctx, nodeset, podsToCreate, surgeNewHash)
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.wantCurrent))
+			for i, wantCurrent := range tt.wantCurrent {
+				wantHash, wantImage := surgeNewHash, "slurmd:new"
+				if wantCurrent {
+					wantHash, wantImage = currentHash, "slurmd:old"
+				}
+				require.Equal(t, podsToCreate[i].Name, got[i].Name)
+				require.Equal(t, wantHash, historycontrol.GetRevision(got[i].Labels))
+				images := []string{}
+				for _, container := range got[i].Spec.Containers {
+					images = append(images, container.Image)
+				}
+				require.Contains(t, images, wantImage)
+			}
+		})
+	}
+}
+
+func Test_calculateRevisionStatus(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{ObjectMeta: metav1.ObjectMeta{Name: "slurm"}}
+	nodeset := newNodeSet("foo", controller.Name, 2)
+	newRevisionWithHash := func(hash string) *appsv1.ControllerRevision {
+		revision := &appsv1.ControllerRevision{}
+		revision.Labels = map[string]string{}
+		historycontrol.SetRevision(revision.Labels, hash)
+		return revision
+	}
+	currentRevision := newRevisionWithHash(surgeOldHash)
+	updateRevision := newRevisionWithHash(surgeNewHash)
+
+	tests := []struct {
+		name                string
+		pods                []*corev1.Pod
+		status              replicaStatus
+		wantCurrentHash     string
+		wantCurrentReplicas int32
+	}{
+		{
+			name: "Rollout in progress",
+			pods: []*corev1.Pod{
+				newSurgePod(nodeset, controller, 0, surgeOldHash, true),
+				newSurgePod(nodeset, controller, 1, surgeNewHash, true),
+			},
+			status:              replicaStatus{Current: 1, Updated: 1},
+			wantCurrentHash:     surgeOldHash,
+			wantCurrentReplicas: 1,
+		},
+		{
+			name: "Rollout complete",
+			pods: []*corev1.Pod{
+				newSurgePod(nodeset, controller, 0, surgeNewHash, true),
+				newSurgePod(nodeset, controller, 1, surgeNewHash, true),
+			},
+			status:              replicaStatus{Current: 0, Updated: 2},
+			wantCurrentHash:     surgeNewHash,
+			wantCurrentReplicas: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currentHash, updateHash, currentReplicas := calculateRevisionStatus(tt.pods, currentRevision, updateRevision, tt.status)
+			require.Equal(t, tt.wantCurrentHash, currentHash)
+			require.Equal(t, surgeNewHash, updateHash)
+			require.Equal(t, tt.wantCurrentReplicas, currentReplicas)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+)
+
+// syncConditionDrain enforces the NodeSet ConditionDrainPolicy. The Slurm node of
+// a pod is drained while the Kubernetes node of the pod has any of the policy
+// conditions, for at least their DrainAfter, and undrained once all of them have
+// cleared, for at least their UndrainAfter.
+//
+// The drained condition types are recorded on the pod, such that syncCordon does
+// not undrain the Slurm node in the meantime. While the pod or its Kubernetes node
+// is cordoned, the Slurm node drain is left to syncCordon.
+func (r *NodeSetReconciler) syncConditionDrain(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+	key := objectutils.KeyFunc(nodeset)
+	now := time.Now()
+
+	syncConditionDrainFn := func(i int) error {
+		pod := pods[i]
+
+		if podutils.IsTerminating(pod) {
+			return nil
+		}
+
+		var node *corev1.Node
+		if pod.Spec.NodeName != "" {
+			node = &corev1.Node{}
+			nodeKey := types.NamespacedName{Name: pod.Spec.NodeName}
+			if err := r.Get(ctx, nodeKey, node); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return err
+				}
+				node = nil
+			}
+		}
+
+		drained := getPodConditionDrain(pod)
+		held, requeueAfter := getHeldDrainConditions(nodeset, node, drained, now)
+		if requeueAfter > 0 {
+			durationStore.Push(key, requeueAfter)
+		}
+		if len(held) == 0 && drained.Len() == 0 {
+			return nil
+		}
+
+		slurmNodeIsUnresponsive, err := r.slurmControl.IsNodeDownForUnresponsive(ctx, nodeset, pod)
+		if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return err
+		}
+		ourReason, err := r.slurmControl.IsNodeReasonOurs(ctx, nodeset, pod)
+		if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return err
+		}
+		// If Slurm node was externally set into a state, preserve it
+		if !ourReason || slurmNodeIsUnresponsive {
+			return nil
+		}
+
+		// The Slurm node drain of a cordoned pod is managed by syncCordon.
+		isCordoned := podutils.IsPodCordon(pod) ||
+			(node != nil && node.Spec.Unschedulable && !isNodeCordonedForSlurmUnhealthy(node))
+
+		if len(held) > 0 {
+			heldTypes := make([]string, 0, len(held))
+			for _, condition := range held {
+				heldTypes = append(heldTypes, string(condition.Type))
+			}
+			if !drained.Equal(set.New(heldTypes...)) {
+				logger.Info("Kubernetes node has drain conditions, draining Slurm node",
+					"pod", klog.KObj(pod), "node", pod.Spec.NodeName, "conditions", heldTypes)
+				r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeWarning, NodeConditionDrainReason, "Drain",
+					"Draining Slurm node of Pod %s: Kubernetes node %s has conditions %s",
+					klog.KObj(pod), pod.Spec.NodeName, strings.Join(heldTypes, ","))
+				if err := r.setPodConditionDrain(ctx, pod, heldTypes); err != nil {
+					return err
+				}
+			}
+			if isCordoned {
+				return nil
+			}
+			reason := formatConditionDrainReason(pod.Spec.NodeName, held)
+			if err := r.slurmControl.MakeNodeDrain(ctx, nodeset, pod, reason, true); err != nil &&
+				!errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+				return err
+			}
+			return nil
+		}
+
+		logger.Info("Kubernetes node drain conditions cleared, undraining Slurm node",
+			"pod", klog.KObj(pod), "node", pod.Spec.NodeName, "conditions", drained.SortedList())
+		r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeNormal, NodeConditionUndrainReason, "Undrain",
+			"Undraining Slurm node of Pod %s: Kubernetes node %s conditions %s cleared",
+			klog.KObj(pod), pod.Spec.NodeName, strings.Join(drained.SortedList(), ","))
+		if err := r.setPodConditionDrain(ctx, pod, nil); err != nil {
+			return err
+		}
+		if isCordoned {
+			return nil
+		}
+		if err := r.slurmControl.MakeNodeUndrain(ctx, nodeset, pod, ""); err != nil &&
+			!errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return err
+		}
+
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncConditionDrainFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getHeldDrainConditions returns the Kubernetes node conditions which hold the
+// Slurm node drained, and the duration after which that may change.
+//
+// A condition with the policy Status holds the Slurm node drained once it had the
+// status for DrainAfter, or immediately if it was already drained by it. A drained
+// condition which cleared keeps holding the Slurm node drained for UndrainAfter.
+func getHeldDrainConditions(
+	nodeset *slinkyv1beta1.NodeSet,
+	node *corev1.Node,
+	drained set.Set[string],
+	now time.Time,
+) ([]corev1.NodeCondition, time.Duration) {
+	if node == nil {
+		return nil, 0
+	}
+
+	var held []corev1.NodeCondition
+	var requeueAfter time.Duration
+	requeue := func(d time.Duration) {
+		if requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+	for _, drainCondition := range nodeset.Spec.ConditionDrainPolicy.Conditions {
+		idx := slices.IndexFunc(node.Status.Conditions, func(c corev1.NodeCondition) bool {
+			return c.Type == drainCondition.Type
+		})
+		if idx < 0 {
+			continue
+		}
+		condition := node.Status.Conditions[idx]
+		isDrained := drained.Has(string(condition.Type))
+
+		if condition.Status == getDrainConditionStatus(drainCondition) {
+			if !isDrained {
+				remaining := condition.LastTransitionTime.Add(drainCondition.DrainAfter.Duration).Sub(now)
+				if remaining > 0 {
+					requeue(remaining)
+					continue
+				}
+			}
+			held = append(held, condition)
+			continue
+		}
+
+		if isDrained {
+			remaining := condition.LastTransitionTime.Add(drainCondition.UndrainAfter.Duration).Sub(now)
+			if remaining > 0 {
+				requeue(remaining)
+				held = append(held, condition)
+			}
+		}
+	}
+
+	return held, requeueAfter
+}
+
+// getDrainConditionStatus returns the condition Status which drains the Slurm node.
+func getDrainConditionStatus(drainCondition slinkyv1beta1.NodeSetDrainCondition) corev1.ConditionStatus {
+	if drainCondition.Status == "" {
+		return defaults.DefaultNodeSetDrainConditionStatus
+	}
+	return drainCondition.Status
+}
+
+// formatConditionDrainReason returns the Slurm node drain reason for the
+// Kubernetes node conditions.
+func formatConditionDrainReason(nodeName string, conditions []corev1.NodeCondition) string {
+	reasons := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		reasons = append(reasons, fmt.Sprintf("%s=%s (%s: %s)",
+			condition.Type, condition.Status, condition.Reason, condition.Message))
+	}
+	return sanitizeSlurmReason(fmt.Sprintf("Node (%s) has conditions %s",
+		nodeName, strings.Join(reasons, "; ")))
+}
+
+// getPodConditionDrain returns the Kubernetes node condition types which the
+// Slurm node of the pod was drained for.
+func getPodConditionDrain(pod *corev1.Pod) set.Set[string] {
+	value := pod.Annotations[slinkyv1beta1.AnnotationPodConditionDrain]
+	if value == "" {
+		return set.New[string]()
+	}
+	return set.New(strings.Split(value, ",")...)
+}
+
+// isPodConditionDrain returns true if the Slurm node of the pod was drained by
+// the ConditionDrainPolicy.
+func isPodConditionDrain(pod *corev1.Pod) bool {
+	return pod.Annotations[slinkyv1beta1.AnnotationPodConditionDrain] != ""
+}
+
+// setPodConditionDrain records the Kubernetes node condition types which the
+// Slurm node of the pod is drained for, or removes the record if there are none.
+func (r *NodeSetReconciler) setPodConditionDrain(ctx context.Context, pod *corev1.Pod, conditionTypes []string) error {
+	mutateFn := func(pod *corev1.Pod) error {
+		if len(conditionTypes) == 0 {
+			delete(pod.Annotations, slinkyv1beta1.AnnotationPodConditionDrain)
+			return nil
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[slinkyv1beta1.AnnotationPodConditionDrain] = strings.Join(conditionTypes, ",")
+		return nil
+	}
+	if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+)
+
+func TestNodeSetReconciler_syncConditionDrain(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	nodeset.Spec.ConditionDrainPolicy.Conditions = []slinkyv1beta1.NodeSetDrainCondition{
+		{
+			Type:         "GPUUnhealthy",
+			Status:       corev1.ConditionTrue,
+			DrainAfter:   metav1.Duration{Duration: time.Minute},
+			UndrainAfter: metav1.Duration{Duration: 10 * time.Minute},
+		},
+	}
+	newPod := func(conditionDrain string) *corev1.Pod {
+		pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+		pod.Spec.NodeName = "node-0"
+		if conditionDrain != "" {
+			if pod.Annotations == nil {
+				pod.Annotations = make(map[string]string)
+			}
+			pod.Annotations[slinkyv1beta1.AnnotationPodConditionDrain] = conditionDrain
+		}
+		return pod
+	}
+	newNode := func(status corev1.ConditionStatus, since time.Duration) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-0",
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:               "GPUUnhealthy",
+						Status:             status,
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+						Reason:             "XidError",
+						Message:            "GPU has fallen off the bus",
+					},
+				},
+			},
+		}
+	}
+	newSlurmNodeList := func(reason string, states ...slurmapi.V0044NodeState) *slurmtypes.V0044NodeList {
+		node := slurmtypes.V0044Node{V0044Node: slurmapi.V0044Node{
+			Name:  ptr.To("foo-0"),
+			State: ptr.To(states),
+		}}
+		if reason != "" {
+			node.Reason = ptr.To(reason)
+		}
+		return &slurmtypes.V0044NodeList{Items: []slurmtypes.V0044Node{node}}
+	}
+	idleNodeList := newSlurmNodeList("", slurmapi.V0044NodeStateIDLE)
+	drainNodeList := newSlurmNodeList("slurm-operator: Node (node-0) has conditions GPUUnhealthy=True",
+		slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN)
+
+	tests := []struct {
+		name               string
+		pod                *corev1.Pod
+		node               *corev1.Node
+		slurmNodeList      *slurmtypes.V0044NodeList
+		wantConditionDrain string
+		wantSlurmDrain     bool
+		wantReasonSub      string
+	}{
+		{
+			name:          "Condition not present",
+			pod:           newPod(""),
+			node:          &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-0"}},
+			slurmNodeList: idleNodeList,
+		},
+		{
+			name:          "Condition within DrainAfter",
+			pod:           newPod(""),
+			node:          newNode(corev1.ConditionTrue, 10*time.Second),
+			slurmNodeList: idleNodeList,
+		},
+		{
+			name:               "Condition after DrainAfter",
+			pod:                newPod(""),
+			node:               newNode(corev1.ConditionTrue, 2*time.Minute),
+			slurmNodeList:      idleNodeList,
+			wantConditionDrain: "GPUUnhealthy",
+			wantSlurmDrain:     true,
+			wantReasonSub:      "GPUUnhealthy=True (XidError: GPU has fallen off the bus)",
+		},
+		{
+			name:               "Condition cleared within UndrainAfter",
+			pod:                newPod("GPUUnhealthy"),
+			node:               newNode(corev1.ConditionFalse, time.Minute),
+			slurmNodeList:      drainNodeList,
+			wantConditionDrain: "GPUUnhealthy",
+			wantSlurmDrain:     true,
+		},
+		{
+			name:          "Condition cleared after UndrainAfter",
+			pod:           newPod("GPUUnhealthy"),
+			node:          newNode(corev1.ConditionFalse, time.Hour),
+			slurmNodeList: drainNodeList,
+		},
+		{
+			name:          "Condition removed",
+			pod:           newPod("GPUUnhealthy"),
+			node:          &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-0"}},
+			slurmNodeList: drainNodeList,
+		},
+		{
+			name:           "External Slurm reason",
+			pod:            newPod(""),
+			node:           newNode(corev1.ConditionTrue, time.Hour),
+			slurmNodeList:  newSlurmNodeList("manual drain", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN),
+			wantSlurmDrain: true,
+			wantReasonSub:  "manual drain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewFakeClient(nodeset.DeepCopy(), tt.pod.DeepCopy(), tt.node.DeepCopy())
+			sclient := newFakeClientList(sinterceptor.Funcs{}, tt.slurmNodeList)
+			r := newNodeSetController(c, newClientMap(controller.Name, sclient))
+
+			err := r.syncConditionDrain(ctx, nodeset, []*corev1.Pod{tt.pod})
+			require.NoError(t, err)
+
+			gotPod := &corev1.Pod{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tt.pod), gotPod))
+			require.Equal(t, tt.wantConditionDrain, gotPod.Annotations[slinkyv1beta1.AnnotationPodConditionDrain])
+
+			gotNode := &slurmtypes.V0044Node{}
+			mapKey := types.NamespacedName{
+				Namespace: nodeset.Namespace,
+				Name:      nodeset.Spec.ControllerRef.Name,
+			}
+			sc := r.ClientMap.Get(mapKey)
+			require.NotNil(t, sc)
+			require.NoError(t, sc.Get(ctx, slurmclient.ObjectKey("foo-0"), gotNode))
+			require.Equal(t, tt.wantSlurmDrain, gotNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDRAIN))
+			if tt.wantReasonSub != "" {
+				reason := ptr.Deref(gotNode.Reason, "")
+				require.True(t, strings.Contains(reason, tt.wantReasonSub), "Slurm node Reason = %q, want substring %q", reason, tt.wantReasonSub)
+			}
+		})
+	}
+}
+
+func Test_getHeldDrainConditions(t *testing.T) {
+	now := time.Now()
+	nodeset := &slinkyv1beta1.NodeSet{}
+	nodeset.Spec.ConditionDrainPolicy.Conditions = []slinkyv1beta1.NodeSetDrainCondition{
+		{Type: "GPUUnhealthy", DrainAfter: metav1.Duration{Duration: time.Minute}},
+		{Type: corev1.NodeReady, Status: corev1.ConditionFalse, UndrainAfter: metav1.Duration{Duration: time.Minute}},
+	}
+	newNode := func(conditions ...corev1.NodeCondition) *corev1.Node {
+		return &corev1.Node{Status: corev1.NodeStatus{Conditions: conditions}}
+	}
+	newCondition := func(conditionType corev1.NodeConditionType, status corev1.ConditionStatus, since time.Duration) corev1.NodeCondition {
+		return corev1.NodeCondition{
+			Type:               conditionType,
+			Status:             status,
+			LastTransitionTime: metav1.NewTime(now.Add(-since)),
+		}
+	}
+	tests := []struct {
+		name             string
+		node             *corev1.Node
+		drained          set.Set[string]
+		wantHeld         []corev1.NodeConditionType
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:    "No node",
+			drained: set.New[string](),
+		},
+		{
+			name:             "Default status within DrainAfter",
+			node:             newNode(newCondition("GPUUnhealthy", corev1.ConditionTrue, 20*time.Second)),
+			drained:          set.New[string](),
+			wantRequeueAfter: 40 * time.Second,
+		},
+		{
+			name:     "Default status within DrainAfter when drained",
+			node:     newNode(newCondition("GPUUnhealthy", corev1.ConditionTrue, 20*time.Second)),
+			drained:  set.New("GPUUnhealthy"),
+			wantHeld: []corev1.NodeConditionType{"GPUUnhealthy"},
+		},
+		{
+			name: "Multiple conditions",
+			node: newNode(
+				newCondition("GPUUnhealthy", corev1.ConditionTrue, time.Hour),
+				newCondition(corev1.NodeReady, corev1.ConditionFalse, 0),
+			),
+			drained:  set.New[string](),
+			wantHeld: []corev1.NodeConditionType{"GPUUnhealthy", corev1.NodeReady},
+		},
+		{
+			name:             "Cleared within UndrainAfter",
+			node:             newNode(newCondition(corev1.NodeReady, corev1.ConditionTrue, 15*time.Second)),
+			drained:          set.New(string(corev1.NodeReady)),
+			wantHeld:         []corev1.NodeConditionType{corev1.NodeReady},
+			wantRequeueAfter: 45 * time.Second,
+		},
+		{
+			name:    "Cleared after UndrainAfter",
+			node:    newNode(newCondition(corev1.NodeReady, corev1.ConditionTrue, time.Hour)),
+			drained: set.New(string(corev1.NodeReady)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			held, requeueAfter := getHeldDrainConditions(nodeset, tt.node, tt.drained, now)
+			var gotHeld []corev1.NodeConditionType
+			for _, condition := range held {
+				gotHeld = append(gotHeld, condition.Type)
+			}
+			require.Equal(t, tt.wantHeld, gotHeld)
+			require.Equal(t, tt.wantRequeueAfter, requeueAfter)
+		})
+	}
+}
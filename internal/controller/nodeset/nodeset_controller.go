@@ -18,8 +18,11 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	builder "github.com/SlinkyProject/slurm-operator/internal/builder/workerbuilder"
@@ -58,12 +61,30 @@ const (
 	SyncFinalizerFailedReason = "SyncFinalizerFailed"
 	// NodeCordonReason is added to an event when a pod is cordoned due to its Kubernetes node being cordoned.
 	NodeCordonReason = "NodeCordon"
+	// NodeConditionDrainReason is added to an event when a Slurm node is drained due to its Kubernetes node conditions.
+	NodeConditionDrainReason = "NodeConditionDrain"
+	// NodeConditionUndrainReason is added to an event when a Slurm node is undrained because its Kubernetes node conditions cleared.
+	NodeConditionUndrainReason = "NodeConditionUndrain"
 	// SlurmNodeNotRegisteredReason is added to an event when a pod is deleted because its Slurm node is not registered.
 	SlurmNodeNotRegisteredReason = "SlurmNodeNotRegistered"
 	// DefunctSlurmNodePrunedReason is added to an event when a defunct Slurm node is pruned.
 	DefunctSlurmNodePrunedReason = "DefunctSlurmNodePruned"
 	// RollingUpdateReason is added to an event when pods are being replaced during a rolling update.
 	RollingUpdateReason = "RollingUpdate"
+	// InPlaceUpdateReason is added to an event when pods are updated in place with Slurm node attributes.
+	InPlaceUpdateReason = "InPlaceUpdate"
+	// AutoscalingReason is added to an event when the autoscaler changes the replicas.
+	AutoscalingReason = "Autoscaling"
+	// RemediationReason is added to an event when a pod is replaced because its Slurm node has persistently failed.
+	RemediationReason = "Remediation"
+	// SlurmNodeRebootReason is added to an event when a pod is replaced, or its Slurm node returned to service, for a Slurm node reboot.
+	SlurmNodeRebootReason = "SlurmNodeReboot"
+	// PowerSavingReason is added to an event when pods are created or deleted for Slurm power saving.
+	PowerSavingReason = "PowerSaving"
+	// SlurmNodePreRegisteredReason is added to an event when FUTURE Slurm nodes are created or deleted.
+	SlurmNodePreRegisteredReason = "SlurmNodePreRegistered"
+	// DrainEscalationReason is added to an event when a Slurm node did not drain within the DrainPolicy MaxDrainDuration.
+	DrainEscalationReason = "DrainEscalation"
 	// ControllerRefFailedReason is added to an event when the referenced Controller CR cannot be fetched.
 	ControllerRefFailedReason = "ControllerRefFailed"
 )
@@ -75,6 +96,8 @@ func init() {
 var (
 	maxConcurrentReconciles = 1
 
+	powerSaveEventsBufferSize = 100
+
 	// this is a short cut for any sub-functions to notify the reconcile how long to wait to requeue
 	durationStore = durationstore.NewDurationStore(durationstore.Greater)
 
@@ -98,18 +121,23 @@ type NodeSetReconciler struct {
 	historyControl historycontrol.HistoryControlInterface
 	eventRecorder  events.EventRecorder
 	expectations   *kubecontroller.UIDTrackingControllerExpectations
+
+	powerSaveEvents chan event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets/finalizers,verbs=update
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=nodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
@@ -167,6 +195,8 @@ func (r *NodeSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&corev1.Node{}, eventhandler.NewNodeEventHandler(r.Client)).
 		Watches(&slinkyv1beta1.Controller{}, eventhandler.NewControllerEventHandler(r.Client)).
 		Watches(&corev1.Secret{}, eventhandler.NewSecretEventHandler(r.Client)).
+		Watches(&corev1.ConfigMap{}, eventhandler.NewConfigMapEventHandler(r.Client)).
+		WatchesRawSource(source.Channel(r.powerSaveEvents, &handler.EnqueueRequestForObject{})).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles,
 		}).
@@ -194,5 +224,7 @@ func NewReconciler(c client.Client, cm *clientmap.ClientMap, propagatedNodeCondi
 		slurmControl:   slurmcontrol.NewSlurmControl(cm),
 		eventRecorder:  er,
 		expectations:   kubecontroller.NewUIDTrackingControllerExpectations(kubecontroller.NewControllerExpectations()),
+
+		powerSaveEvents: make(chan event.GenericEvent, powerSaveEventsBufferSize),
 	}
 }
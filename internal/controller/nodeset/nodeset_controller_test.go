@@ -40,16 +40,23 @@ func newFakeClientList(interceptorFuncs interceptor.Funcs, initObjLists ...objec
 				switch stateReq {
 				case slurmapi.V0044UpdateNodeMsgStateUNDRAIN:
 					stateSet.Delete(slurmapi.V0044NodeStateDRAIN)
+				case slurmapi.V0044UpdateNodeMsgStateRESUME:
+					stateSet.Delete(slurmapi.V0044NodeStateDOWN, slurmapi.V0044NodeStateDRAIN,
+						slurmapi.V0044NodeStateREBOOTREQUESTED, slurmapi.V0044NodeStateREBOOTISSUED)
 				default:
 					stateSet.Insert(slurmapi.V0044NodeState(stateReq))
 				}
 			}
 			o.State = ptr.To(stateSet.UnsortedList())
 			o.Comment = r.Comment
+			o.Extra = r.Extra
 			o.Reason = r.Reason
 			o.Topology = r.TopologyStr
 			o.Features = r.Features
 			o.ActiveFeatures = r.FeaturesAct
+			if r.Weight != nil {
+				o.Weight = r.Weight.Number
+			}
 		default:
 			return errors.New("failed to cast slurm object")
 		}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	builder "github.com/SlinkyProject/slurm-operator/internal/builder/workerbuilder"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+)
+
+// calculateNodeCpuTopology returns the CPU topology which the Slurm nodes of the
+// pods were registered with, by Slurm node name, as recorded on the pods by the
+// pod binding webhook.
+func calculateNodeCpuTopology(nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) map[string]slinkyv1beta1.NodeSetNodeCpuTopology {
+	if !nodeset.Spec.CpuTopology.Enabled {
+		return nil
+	}
+
+	out := make(map[string]slinkyv1beta1.NodeSetNodeCpuTopology)
+	for _, pod := range pods {
+		cpuSpec, ok := pod.Annotations[slinkyv1beta1.AnnotationPodCpuSpec]
+		if !ok {
+			continue
+		}
+		topology, err := builder.ParseNodeCpuTopology(cpuSpec)
+		if err != nil {
+			continue
+		}
+		out[nodesetutils.GetSlurmNodeName(pod)] = topology
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
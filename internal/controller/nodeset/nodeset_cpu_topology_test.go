@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func Test_calculateNodeCpuTopology(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{ObjectMeta: metav1.ObjectMeta{Name: "slurm"}}
+	newPod := func(nodeset *slinkyv1beta1.NodeSet, ordinal int, cpuSpec string) *corev1.Pod {
+		pod := newNodeSetPodWithStatus(nodeset, controller, ordinal, corev1.PodRunning, nil)
+		if cpuSpec != "" {
+			pod.Annotations[slinkyv1beta1.AnnotationPodCpuSpec] = cpuSpec
+		}
+		return pod
+	}
+	enabled := newNodeSet("foo", controller.Name, 3)
+	enabled.Spec.CpuTopology.Enabled = true
+	disabled := newNodeSet("foo", controller.Name, 1)
+
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		pods    []*corev1.Pod
+		want    map[string]slinkyv1beta1.NodeSetNodeCpuTopology
+	}{
+		{
+			name:    "disabled",
+			nodeset: disabled,
+			pods: []*corev1.Pod{
+				newPod(disabled, 0, "CoresPerSocket=4 Sockets=2 ThreadsPerCore=2"),
+			},
+			want: nil,
+		},
+		{
+			name:    "no annotations",
+			nodeset: enabled,
+			pods: []*corev1.Pod{
+				newPod(enabled, 0, ""),
+			},
+			want: nil,
+		},
+		{
+			name:    "annotated pods",
+			nodeset: enabled,
+			pods: []*corev1.Pod{
+				newPod(enabled, 0, "CoreSpecCount=1 CoresPerSocket=4 MemSpecLimit=2048 Sockets=2 ThreadsPerCore=2"),
+				newPod(enabled, 1, "CoresPerSocket=8 Sockets=1 ThreadsPerCore=1"),
+				newPod(enabled, 2, "malformed"),
+			},
+			want: map[string]slinkyv1beta1.NodeSetNodeCpuTopology{
+				"foo-0": {Sockets: 2, CoresPerSocket: 4, ThreadsPerCore: 2, CoreSpecCount: 1, MemSpecLimit: 2048},
+				"foo-1": {Sockets: 1, CoresPerSocket: 8, ThreadsPerCore: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, calculateNodeCpuTopology(tt.nodeset, tt.pods))
+		})
+	}
+}
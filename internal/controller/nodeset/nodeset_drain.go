@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// syncDrainPolicy enforces the NodeSet DrainPolicy on cordoned pods, whose Slurm
+// node is draining for scale-in, rolling update, or cordon. When a Slurm node has
+// not drained within the MaxDrainDuration, and its running jobs would not end by
+// then, the EscalationAction is taken on its running jobs.
+func (r *NodeSetReconciler) syncDrainPolicy(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	maxDrainDuration := nodeset.Spec.DrainPolicy.MaxDrainDuration.Duration
+	if maxDrainDuration <= 0 {
+		return nil
+	}
+
+	nodeDeadlines, err := r.slurmControl.GetNodeDeadlines(ctx, nodeset, pods)
+	if err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			// Cannot determine if the Slurm nodes have drained at this time.
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	syncDrainPolicyFn := func(i int) error {
+		pod := pods[i]
+
+		if !podutils.IsPodCordon(pod) || podutils.IsTerminating(pod) {
+			return nil
+		}
+
+		drainStart, ok := getPodDrainStart(pod)
+		if !ok {
+			mutateFn := func(pod *corev1.Pod) error {
+				pod.Annotations[slinkyv1beta1.AnnotationPodDrainStart] = now.Format(time.RFC3339)
+				return nil
+			}
+			if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
+				if apierrors.IsNotFound(err) {
+					return nil
+				}
+				return err
+			}
+			return nil
+		}
+
+		if isPodDrainEscalated(pod) {
+			return nil
+		}
+
+		drainDeadline := drainStart.Add(maxDrainDuration)
+		if now.Before(drainDeadline) {
+			return nil
+		}
+		// Running jobs which end by the drain deadline are allowed to complete.
+		jobDeadline := nodeDeadlines.Peek(nodesetutils.GetSlurmNodeName(pod))
+		if jobDeadline.IsZero() || !jobDeadline.After(drainDeadline) {
+			return nil
+		}
+
+		isDrained, err := r.slurmControl.IsNodeDrained(ctx, nodeset, pod)
+		if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return err
+		}
+		if isDrained {
+			return nil
+		}
+
+		return r.escalatePodDrain(ctx, nodeset, pod)
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncDrainPolicyFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// escalatePodDrain takes the DrainPolicy EscalationAction on the running jobs of
+// the Slurm node of the pod, and records the escalated jobs on the pod.
+func (r *NodeSetReconciler) escalatePodDrain(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+
+	jobIDs, err := r.slurmControl.GetRunningJobsForPod(ctx, nodeset, pod)
+	if err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return nil
+		}
+		return err
+	}
+	if len(jobIDs) == 0 {
+		return nil
+	}
+
+	action := getDrainEscalationAction(nodeset)
+	maxDrainDuration := nodeset.Spec.DrainPolicy.MaxDrainDuration.Duration
+	slurmNodeName := nodesetutils.GetSlurmNodeName(pod)
+	jobs := formatJobIDs(jobIDs)
+
+	logger.Info("Escalating drain of Slurm node",
+		"pod", klog.KObj(pod), "node", slurmNodeName, "action", action, "jobs", jobs)
+	switch action {
+	case slinkyv1beta1.DrainEscalationActionRequeue:
+		reason := fmt.Sprintf("Drain exceeded %s, requeue jobs", maxDrainDuration)
+		if err := r.slurmControl.MakeNodeDown(ctx, nodeset, pod, reason); err != nil &&
+			!errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return err
+		}
+	case slinkyv1beta1.DrainEscalationActionCancel:
+		if err := r.slurmControl.CancelJobs(ctx, nodeset, jobIDs); err != nil &&
+			!errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return err
+		}
+	}
+	r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeWarning, DrainEscalationReason, string(action),
+		"Slurm node %s did not drain within %s, escalation action %s taken on jobs: %s",
+		slurmNodeName, maxDrainDuration, action, jobs)
+
+	mutateFn := func(pod *corev1.Pod) error {
+		pod.Annotations[slinkyv1beta1.AnnotationPodDrainEscalated] = jobs
+		return nil
+	}
+	if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// calculateDrainEscalatedCondition returns the DrainEscalated condition naming the
+// pods and jobs on which the DrainPolicy EscalationAction was taken, if any.
+func calculateDrainEscalatedCondition(nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) *metav1.Condition {
+	escalated := []string{}
+	for _, pod := range pods {
+		if !isPodDrainEscalated(pod) {
+			continue
+		}
+		jobs := pod.Annotations[slinkyv1beta1.AnnotationPodDrainEscalated]
+		escalated = append(escalated, fmt.Sprintf("%s (jobs: %s)", pod.Name, jobs))
+	}
+	if len(escalated) == 0 {
+		return nil
+	}
+	slices.Sort(escalated)
+
+	return &metav1.Condition{
+		Type:               slurmconditions.NodeSetConditionDrainEscalated,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: nodeset.Generation,
+		Reason:             string(getDrainEscalationAction(nodeset)),
+		Message:            "Drain exceeded maxDrainDuration for pods: " + strings.Join(escalated, ", "),
+	}
+}
+
+// getPodDrainStart returns when the NodeSet started to drain the Slurm node of the pod.
+func getPodDrainStart(pod *corev1.Pod) (time.Time, bool) {
+	value, ok := pod.Annotations[slinkyv1beta1.AnnotationPodDrainStart]
+	if !ok {
+		return time.Time{}, false
+	}
+	drainStart, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return drainStart, true
+}
+
+// isPodDrainEscalated returns true if the DrainPolicy EscalationAction was taken on the pod.
+func isPodDrainEscalated(pod *corev1.Pod) bool {
+	return pod.Annotations[slinkyv1beta1.AnnotationPodDrainEscalated] != ""
+}
+
+// getDrainEscalationAction returns the DrainPolicy EscalationAction of the NodeSet.
+func getDrainEscalationAction(nodeset *slinkyv1beta1.NodeSet) slinkyv1beta1.DrainEscalationActionType {
+	if nodeset.Spec.DrainPolicy.EscalationAction == "" {
+		return defaults.DefaultNodeSetDrainEscalationAction
+	}
+	return nodeset.Spec.DrainPolicy.EscalationAction
+}
+
+func formatJobIDs(jobIDs []int32) string {
+	ids := make([]string, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		ids = append(ids, strconv.Itoa(int(jobID)))
+	}
+	return strings.Join(ids, ",")
+}
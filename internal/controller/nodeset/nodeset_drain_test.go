@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func TestNodeSetReconciler_syncDrainPolicy(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	now := time.Now()
+	newDrainNodeSet := func(maxDrainDuration time.Duration, action slinkyv1beta1.DrainEscalationActionType) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, 1)
+		nodeset.Spec.DrainPolicy.MaxDrainDuration = metav1.Duration{Duration: maxDrainDuration}
+		nodeset.Spec.DrainPolicy.EscalationAction = action
+		return nodeset
+	}
+	newPod := func(nodeset *slinkyv1beta1.NodeSet, annotations map[string]string) *corev1.Pod {
+		pod := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, ""))
+		for k, v := range annotations {
+			pod.Annotations[k] = v
+		}
+		return pod
+	}
+	drainingSince := func(d time.Duration) map[string]string {
+		return map[string]string{
+			slinkyv1beta1.AnnotationPodCordon:     "true",
+			slinkyv1beta1.AnnotationPodDrainStart: now.Add(-d).Format(time.RFC3339),
+		}
+	}
+	nodeList := &slurmtypes.V0044NodeList{
+		Items: []slurmtypes.V0044Node{
+			{V0044Node: slurmapi.V0044Node{
+				Name:  ptr.To("foo-0"),
+				State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateALLOCATED, slurmapi.V0044NodeStateDRAIN}),
+			}},
+		},
+	}
+	newJobList := func(timeLimit time.Duration) *slurmtypes.V0044JobInfoList {
+		return &slurmtypes.V0044JobInfoList{
+			Items: []slurmtypes.V0044JobInfo{
+				{V0044JobInfo: slurmapi.V0044JobInfo{
+					JobId:     ptr.To[int32](1),
+					JobState:  ptr.To([]slurmapi.V0044JobInfoJobState{slurmapi.V0044JobInfoJobStateRUNNING}),
+					Nodes:     ptr.To("foo-0"),
+					StartTime: ptr.To(slurmapi.V0044Uint64NoValStruct{Number: ptr.To(now.Add(-2 * time.Hour).Unix())}),
+					TimeLimit: ptr.To(slurmapi.V0044Uint32NoValStruct{Number: ptr.To(int32(timeLimit.Minutes()))}),
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		nodeset           *slinkyv1beta1.NodeSet
+		annotations       map[string]string
+		jobList           *slurmtypes.V0044JobInfoList
+		wantDrainStart    bool
+		wantEscalated     string
+		wantCancelledJobs []int32
+		wantNodeDown      bool
+	}{
+		{
+			name:    "Unbounded drain",
+			nodeset: newDrainNodeSet(0, ""),
+			annotations: map[string]string{
+				slinkyv1beta1.AnnotationPodCordon: "true",
+			},
+			jobList:        newJobList(24 * time.Hour),
+			wantDrainStart: false,
+		},
+		{
+			name:           "Not cordoned",
+			nodeset:        newDrainNodeSet(time.Hour, slinkyv1beta1.DrainEscalationActionCancel),
+			jobList:        newJobList(24 * time.Hour),
+			wantDrainStart: false,
+		},
+		{
+			name:    "Record drain start",
+			nodeset: newDrainNodeSet(time.Hour, slinkyv1beta1.DrainEscalationActionCancel),
+			annotations: map[string]string{
+				slinkyv1beta1.AnnotationPodCordon: "true",
+			},
+			jobList:        newJobList(24 * time.Hour),
+			wantDrainStart: true,
+		},
+		{
+			name:           "Within max drain duration",
+			nodeset:        newDrainNodeSet(time.Hour, slinkyv1beta1.DrainEscalationActionCancel),
+			annotations:    drainingSince(30 * time.Minute),
+			jobList:        newJobList(24 * time.Hour),
+			wantDrainStart: true,
+		},
+		{
+			name:           "Jobs end by the drain deadline",
+			nodeset:        newDrainNodeSet(time.Hour, slinkyv1beta1.DrainEscalationActionCancel),
+			annotations:    drainingSince(2 * time.Hour),
+			jobList:        newJobList(time.Hour),
+			wantDrainStart: true,
+		},
+		{
+			name:           "Escalate with wait",
+			nodeset:        newDrainNodeSet(time.Hour, slinkyv1beta1.DrainEscalationActionWait),
+			annotations:    drainingSince(2 * time.Hour),
+			jobList:        newJobList(24 * time.Hour),
+			wantDrainStart: true,
+			wantEscalated:  "1",
+		},
+		{
+			name:              "Escalate with cancel",
+			nodeset:           newDrainNodeSet(time.Hour, slinkyv1beta1.DrainEscalationActionCancel),
+			annotations:       drainingSince(2 * time.Hour),
+			jobList:           newJobList(24 * time.Hour),
+			wantDrainStart:    true,
+			wantEscalated:     "1",
+			wantCancelledJobs: []int32{1},
+		},
+		{
+			name:           "Escalate with requeue",
+			nodeset:        newDrainNodeSet(time.Hour, slinkyv1beta1.DrainEscalationActionRequeue),
+			annotations:    drainingSince(2 * time.Hour),
+			jobList:        newJobList(24 * time.Hour),
+			wantDrainStart: true,
+			wantEscalated:  "1",
+			wantNodeDown:   true,
+		},
+		{
+			name:    "Already escalated",
+			nodeset: newDrainNodeSet(time.Hour, slinkyv1beta1.DrainEscalationActionCancel),
+			annotations: func() map[string]string {
+				annotations := drainingSince(2 * time.Hour)
+				annotations[slinkyv1beta1.AnnotationPodDrainEscalated] = "1"
+				return annotations
+			}(),
+			jobList:        newJobList(24 * time.Hour),
+			wantDrainStart: true,
+			wantEscalated:  "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pod := newPod(tt.nodeset, tt.annotations)
+			c := fake.NewFakeClient(tt.nodeset.DeepCopy(), pod.DeepCopy())
+
+			gotCancelledJobs := []int32{}
+			sclient := newFakeClientList(sinterceptor.Funcs{
+				Delete: func(_ context.Context, obj slurmobject.Object, _ ...slurmclient.DeleteOption) error {
+					if job, ok := obj.(*slurmtypes.V0044JobInfo); ok {
+						gotCancelledJobs = append(gotCancelledJobs, ptr.Deref(job.JobId, 0))
+					}
+					return nil
+				},
+			}, nodeList.DeepCopy(), tt.jobList)
+			r := newNodeSetController(c, newClientMap(controller.Name, sclient))
+
+			err := r.syncDrainPolicy(ctx, tt.nodeset, []*corev1.Pod{pod})
+			require.NoError(t, err)
+
+			gotPod := &corev1.Pod{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), gotPod))
+			_, gotDrainStart := gotPod.Annotations[slinkyv1beta1.AnnotationPodDrainStart]
+			require.Equal(t, tt.wantDrainStart, gotDrainStart)
+			require.Equal(t, tt.wantEscalated, gotPod.Annotations[slinkyv1beta1.AnnotationPodDrainEscalated])
+
+			if tt.wantCancelledJobs == nil {
+				tt.wantCancelledJobs = []int32{}
+			}
+			require.Equal(t, tt.wantCancelledJobs, gotCancelledJobs)
+
+			gotNode := &slurmtypes.V0044Node{}
+			require.NoError(t, sclient.Get(ctx, "foo-0", gotNode))
+			require.Equal(t, tt.wantNodeDown, gotNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN))
+		})
+	}
+}
+
+func Test_calculateDrainEscalatedCondition(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 2)
+	nodeset.Spec.DrainPolicy.MaxDrainDuration = metav1.Duration{Duration: time.Hour}
+	nodeset.Spec.DrainPolicy.EscalationAction = slinkyv1beta1.DrainEscalationActionCancel
+	newPod := func(ordinal int, jobs string) *corev1.Pod {
+		pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, &slinkyv1beta1.Controller{}, ordinal, "")
+		if jobs != "" {
+			pod.Annotations[slinkyv1beta1.AnnotationPodDrainEscalated] = jobs
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name string
+		pods []*corev1.Pod
+		want *metav1.Condition
+	}{
+		{
+			name: "Not escalated",
+			pods: []*corev1.Pod{newPod(0, ""), newPod(1, "")},
+			want: nil,
+		},
+		{
+			name: "Escalated",
+			pods: []*corev1.Pod{newPod(1, "3"), newPod(0, "1,2")},
+			want: &metav1.Condition{
+				Type:    slurmconditions.NodeSetConditionDrainEscalated,
+				Status:  metav1.ConditionTrue,
+				Reason:  string(slinkyv1beta1.DrainEscalationActionCancel),
+				Message: "Drain exceeded maxDrainDuration for pods: foo-0 (jobs: 1,2), foo-1 (jobs: 3)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateDrainEscalatedCondition(nodeset, tt.pods)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
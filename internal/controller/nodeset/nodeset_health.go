@@ -0,0 +1,386 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	SlurmNodeUnhealthyReason = "SlurmNodeUnhealthy"
+	SlurmNodeHealthyReason   = "SlurmNodeHealthy"
+)
+
+// syncNodeHealth enforces the NodeSet NodeHealthPolicy. When the Slurm node of a
+// pod was set DOWN or DRAIN outside of the operator, the Kubernetes node of the
+// pod gets the SlurmNodeUnhealthy condition and the policy Action. Once Slurm
+// clears the state, or the policy is disabled, they are removed again.
+//
+// A Kubernetes node may run pods of several NodeSets, hence the marks of a node
+// are always computed from all NodeSet pods on it, such that NodeSets do not
+// overwrite each other. The cordon is recorded in an annotation, which syncCordon
+// ignores, so that it is not propagated back into a Slurm drain.
+func (r *NodeSetReconciler) syncNodeHealth(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	nodeNames := sets.New[string]()
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			// Skip if Pod has not been allocated to a Node.
+			continue
+		}
+		nodeNames.Insert(pod.Spec.NodeName)
+	}
+	nodeNameList := sets.List(nodeNames)
+
+	syncNodeHealthFn := func(i int) error {
+		node := &corev1.Node{}
+		nodeKey := types.NamespacedName{Name: nodeNameList[i]}
+		if err := r.Get(ctx, nodeKey, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		if !nodeset.Spec.NodeHealthPolicy.Enabled && !hasNodeHealthMarks(node) {
+			// Nothing to enforce or remove on behalf of this NodeSet.
+			return nil
+		}
+
+		unhealthy, err := r.getUnhealthySlurmNodes(ctx, nodeset, node)
+		if err != nil {
+			if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+				// Cannot determine the Slurm node health at this time.
+				return nil
+			}
+			return err
+		}
+
+		return r.syncNodeHealthMarks(ctx, nodeset, node, unhealthy)
+	}
+	if _, err := utils.SlowStartBatch(len(nodeNameList), utils.SlowStartInitialBatchSize, syncNodeHealthFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// unhealthySlurmNode is a Slurm node, of a NodeSet pod on a Kubernetes node, which
+// is unhealthy according to the NodeHealthPolicy of its NodeSet.
+type unhealthySlurmNode struct {
+	name   string
+	reason string
+	action slinkyv1beta1.NodeHealthActionType
+}
+
+// getUnhealthySlurmNodes returns the unhealthy Slurm nodes of all NodeSet pods on
+// the Kubernetes node, sorted by name. Pods of NodeSets with a disabled
+// NodeHealthPolicy are never unhealthy.
+func (r *NodeSetReconciler) getUnhealthySlurmNodes(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	node *corev1.Node,
+) ([]unhealthySlurmNode, error) {
+	podList := &corev1.PodList{}
+	opts := []client.ListOption{
+		client.MatchingFields{
+			"spec.nodeName": node.Name,
+		},
+	}
+	if err := r.List(ctx, podList, opts...); err != nil {
+		return nil, err
+	}
+
+	owners := make(map[types.UID]*slinkyv1beta1.NodeSet)
+	unhealthy := []unhealthySlurmNode{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		controllerRef := metav1.GetControllerOf(pod)
+		if controllerRef == nil || controllerRef.Kind != slinkyv1beta1.NodeSetKind {
+			continue
+		}
+		owner, ok := owners[controllerRef.UID]
+		if !ok {
+			var err error
+			owner, err = r.getPodNodeSet(ctx, nodeset, pod.Namespace, controllerRef)
+			if err != nil {
+				return nil, err
+			}
+			owners[controllerRef.UID] = owner
+		}
+		if owner == nil || !owner.Spec.NodeHealthPolicy.Enabled {
+			continue
+		}
+
+		reason, err := r.slurmControl.GetNodeUnhealthyReason(ctx, owner, pod)
+		if err != nil {
+			return nil, err
+		}
+		if reason == "" {
+			continue
+		}
+		unhealthy = append(unhealthy, unhealthySlurmNode{
+			name:   nodesetutils.GetSlurmNodeName(pod),
+			reason: reason,
+			action: getNodeHealthAction(owner),
+		})
+	}
+	slices.SortFunc(unhealthy, func(a, b unhealthySlurmNode) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return unhealthy, nil
+}
+
+// getPodNodeSet returns the NodeSet of the controllerRef, or nil if it no longer
+// exists.
+func (r *NodeSetReconciler) getPodNodeSet(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	namespace string,
+	controllerRef *metav1.OwnerReference,
+) (*slinkyv1beta1.NodeSet, error) {
+	if namespace == nodeset.Namespace && controllerRef.Name == nodeset.Name {
+		if controllerRef.UID != nodeset.UID {
+			return nil, nil
+		}
+		return nodeset, nil
+	}
+
+	owner := &slinkyv1beta1.NodeSet{}
+	key := types.NamespacedName{Namespace: namespace, Name: controllerRef.Name}
+	if err := r.Get(ctx, key, owner); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if owner.UID != controllerRef.UID {
+		return nil, nil
+	}
+	return owner, nil
+}
+
+// syncNodeHealthMarks reconciles the condition, taint, and cordon of the Kubernetes
+// node for all of its unhealthy Slurm nodes. No unhealthy Slurm nodes means the
+// marks are removed.
+func (r *NodeSetReconciler) syncNodeHealthMarks(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	node *corev1.Node,
+	unhealthy []unhealthySlurmNode,
+) error {
+	logger := log.FromContext(ctx)
+
+	taintFor := ""
+	cordonFor := ""
+	for _, u := range unhealthy {
+		switch {
+		case u.action == slinkyv1beta1.NodeHealthActionTaint && taintFor == "":
+			taintFor = u.name
+		case u.action == slinkyv1beta1.NodeHealthActionCordon && cordonFor == "":
+			cordonFor = u.name
+		}
+	}
+
+	newNode := node.DeepCopy()
+	setNodeUnhealthyCondition(newNode, unhealthy)
+	setNodeUnhealthyTaint(newNode, taintFor)
+	setNodeUnhealthyCordon(newNode, cordonFor)
+
+	if !apiequality.Semantic.DeepEqual(node.ObjectMeta, newNode.ObjectMeta) ||
+		!apiequality.Semantic.DeepEqual(node.Spec, newNode.Spec) {
+		if len(unhealthy) > 0 {
+			message := nodeUnhealthyMessage(unhealthy)
+			logger.Info("Slurm node is unhealthy, marking Kubernetes node",
+				"node", klog.KObj(node), "message", message, "taint", taintFor, "cordon", cordonFor)
+			r.eventRecorder.Eventf(nodeset, node, corev1.EventTypeWarning, SlurmNodeUnhealthyReason, "Mark",
+				"%s", message)
+		} else {
+			logger.Info("Slurm nodes are healthy, unmarking Kubernetes node",
+				"node", klog.KObj(node))
+			r.eventRecorder.Eventf(nodeset, node, corev1.EventTypeNormal, SlurmNodeHealthyReason, "Unmark",
+				"Slurm nodes on node %s are healthy", node.Name)
+		}
+		toPatch := newNode.DeepCopy()
+		toPatch.Status = node.Status
+		patch := client.StrategicMergeFrom(node)
+		if err := r.Patch(ctx, toPatch, patch); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to patch node %s: %w", node.Name, err)
+		}
+	}
+
+	if !apiequality.Semantic.DeepEqual(node.Status.Conditions, newNode.Status.Conditions) {
+		toPatch := node.DeepCopy()
+		toPatch.Status.Conditions = newNode.Status.Conditions
+		patch := client.StrategicMergeFrom(node)
+		if err := r.Status().Patch(ctx, toPatch, patch); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to patch node %s status: %w", node.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// getNodeHealthAction returns the NodeHealthPolicy Action of the NodeSet.
+func getNodeHealthAction(nodeset *slinkyv1beta1.NodeSet) slinkyv1beta1.NodeHealthActionType {
+	if nodeset.Spec.NodeHealthPolicy.Action == "" {
+		return defaults.DefaultNodeSetNodeHealthAction
+	}
+	return nodeset.Spec.NodeHealthPolicy.Action
+}
+
+// nodeUnhealthyMessage returns the SlurmNodeUnhealthy condition message, which
+// identifies each unhealthy Slurm node on the Kubernetes node.
+func nodeUnhealthyMessage(unhealthy []unhealthySlurmNode) string {
+	messages := make([]string, 0, len(unhealthy))
+	for _, u := range unhealthy {
+		messages = append(messages, fmt.Sprintf("Slurm node %s is unhealthy: %s", u.name, u.reason))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// setNodeUnhealthyCondition sets the SlurmNodeUnhealthy condition for the
+// unhealthy Slurm nodes. Without any, a true condition is made false.
+func setNodeUnhealthyCondition(node *corev1.Node, unhealthy []unhealthySlurmNode) {
+	now := metav1.Now()
+	condition := corev1.NodeCondition{
+		Type:               slurmconditions.NodeConditionSlurmUnhealthy,
+		Status:             corev1.ConditionTrue,
+		LastHeartbeatTime:  now,
+		LastTransitionTime: now,
+		Reason:             SlurmNodeUnhealthyReason,
+		Message:            nodeUnhealthyMessage(unhealthy),
+	}
+	if len(unhealthy) == 0 {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = SlurmNodeHealthyReason
+		condition.Message = "Slurm nodes are healthy"
+	}
+	for i := range node.Status.Conditions {
+		oldCondition := &node.Status.Conditions[i]
+		if oldCondition.Type != condition.Type {
+			continue
+		}
+		if condition.Status == corev1.ConditionFalse && oldCondition.Status != corev1.ConditionTrue {
+			return
+		}
+		if oldCondition.Status == condition.Status &&
+			oldCondition.Reason == condition.Reason &&
+			oldCondition.Message == condition.Message {
+			return
+		}
+		if oldCondition.Status == condition.Status {
+			condition.LastTransitionTime = oldCondition.LastTransitionTime
+		}
+		*oldCondition = condition
+		return
+	}
+	if condition.Status == corev1.ConditionTrue {
+		node.Status.Conditions = append(node.Status.Conditions, condition)
+	}
+}
+
+// setNodeUnhealthyTaint adds the TaintNodeSlurmUnhealthy taint, whose value is the
+// Slurm node it was added for, or removes it if slurmNodeName is empty.
+func setNodeUnhealthyTaint(node *corev1.Node, slurmNodeName string) {
+	taints := make([]corev1.Taint, 0, len(node.Spec.Taints))
+	hasTaint := false
+	for _, t := range node.Spec.Taints {
+		if t.Key == slinkyv1beta1.TaintNodeSlurmUnhealthy {
+			if slurmNodeName == "" {
+				continue
+			}
+			hasTaint = true
+		}
+		taints = append(taints, t)
+	}
+	if slurmNodeName != "" && !hasTaint {
+		taints = append(taints, corev1.Taint{
+			Key:    slinkyv1beta1.TaintNodeSlurmUnhealthy,
+			Value:  slurmNodeName,
+			Effect: corev1.TaintEffectNoSchedule,
+		})
+	}
+	if len(taints) == 0 {
+		taints = nil
+	}
+	node.Spec.Taints = taints
+}
+
+// setNodeUnhealthyCordon cordons the node for the Slurm node, or uncordons it if
+// slurmNodeName is empty. The cordon is recorded by the
+// AnnotationNodeSlurmUnhealthyCordon annotation, whose value is the Slurm node it
+// was made for. A node which was already cordoned otherwise is left alone.
+func setNodeUnhealthyCordon(node *corev1.Node, slurmNodeName string) {
+	isOurs := isNodeCordonedForSlurmUnhealthy(node)
+	switch {
+	case slurmNodeName != "" && !isOurs && !node.Spec.Unschedulable:
+		if node.Annotations == nil {
+			node.Annotations = make(map[string]string)
+		}
+		node.Annotations[slinkyv1beta1.AnnotationNodeSlurmUnhealthyCordon] = slurmNodeName
+		node.Spec.Unschedulable = true
+	case slurmNodeName == "" && isOurs:
+		delete(node.Annotations, slinkyv1beta1.AnnotationNodeSlurmUnhealthyCordon)
+		node.Spec.Unschedulable = false
+	}
+}
+
+// hasNodeHealthMarks returns true if the node has any mark of the NodeSet
+// NodeHealthPolicy.
+func hasNodeHealthMarks(node *corev1.Node) bool {
+	if isNodeCordonedForSlurmUnhealthy(node) {
+		return true
+	}
+	for _, t := range node.Spec.Taints {
+		if t.Key == slinkyv1beta1.TaintNodeSlurmUnhealthy {
+			return true
+		}
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == slurmconditions.NodeConditionSlurmUnhealthy &&
+			condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// isNodeCordonedForSlurmUnhealthy returns true if the node was cordoned by the
+// NodeSet NodeHealthPolicy.
+func isNodeCordonedForSlurmUnhealthy(node *corev1.Node) bool {
+	_, ok := node.Annotations[slinkyv1beta1.AnnotationNodeSlurmUnhealthyCordon]
+	return ok
+}
@@ -0,0 +1,295 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/indexes"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func TestNodeSetReconciler_syncNodeHealth(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	newHealthNodeSet := func(enabled bool, action slinkyv1beta1.NodeHealthActionType) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, 1)
+		nodeset.Spec.NodeHealthPolicy.Enabled = enabled
+		nodeset.Spec.NodeHealthPolicy.Action = action
+		return nodeset
+	}
+	newSlurmNodeList := func(reason string, states ...slurmapi.V0044NodeState) *slurmtypes.V0044NodeList {
+		node := slurmtypes.V0044Node{V0044Node: slurmapi.V0044Node{
+			Name:  ptr.To("foo-0"),
+			State: ptr.To(states),
+		}}
+		if reason != "" {
+			node.Reason = ptr.To(reason)
+		}
+		return &slurmtypes.V0044NodeList{Items: []slurmtypes.V0044Node{node}}
+	}
+	unhealthyNodeList := newSlurmNodeList("NHC: GPU lost", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN)
+	healthyNodeList := newSlurmNodeList("", slurmapi.V0044NodeStateIDLE)
+	unhealthyCondition := corev1.NodeCondition{
+		Type:    slurmconditions.NodeConditionSlurmUnhealthy,
+		Status:  corev1.ConditionTrue,
+		Reason:  SlurmNodeUnhealthyReason,
+		Message: "Slurm node foo-0 is unhealthy: NHC: GPU lost",
+	}
+	newNode := func(mutateFn func(node *corev1.Node)) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-0",
+			},
+		}
+		if mutateFn != nil {
+			mutateFn(node)
+		}
+		return node
+	}
+
+	tests := []struct {
+		name              string
+		nodeset           *slinkyv1beta1.NodeSet
+		node              *corev1.Node
+		slurmNodeList     *slurmtypes.V0044NodeList
+		wantConditionTrue bool
+		wantTaint         bool
+		wantCordon        bool
+	}{
+		{
+			name:          "Disabled",
+			nodeset:       newHealthNodeSet(false, ""),
+			node:          newNode(nil),
+			slurmNodeList: unhealthyNodeList,
+		},
+		{
+			name:              "Unhealthy without action",
+			nodeset:           newHealthNodeSet(true, slinkyv1beta1.NodeHealthActionNone),
+			node:              newNode(nil),
+			slurmNodeList:     unhealthyNodeList,
+			wantConditionTrue: true,
+		},
+		{
+			name:              "Unhealthy with taint",
+			nodeset:           newHealthNodeSet(true, slinkyv1beta1.NodeHealthActionTaint),
+			node:              newNode(nil),
+			slurmNodeList:     unhealthyNodeList,
+			wantConditionTrue: true,
+			wantTaint:         true,
+		},
+		{
+			name:              "Unhealthy with cordon",
+			nodeset:           newHealthNodeSet(true, slinkyv1beta1.NodeHealthActionCordon),
+			node:              newNode(nil),
+			slurmNodeList:     unhealthyNodeList,
+			wantConditionTrue: true,
+			wantCordon:        true,
+		},
+		{
+			name:          "Drained by the operator",
+			nodeset:       newHealthNodeSet(true, slinkyv1beta1.NodeHealthActionCordon),
+			node:          newNode(nil),
+			slurmNodeList: newSlurmNodeList("slurm-operator: Pod (default/foo-0) was cordoned", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN),
+		},
+		{
+			name:    "Healthy again",
+			nodeset: newHealthNodeSet(true, slinkyv1beta1.NodeHealthActionCordon),
+			node: newNode(func(node *corev1.Node) {
+				node.Annotations = map[string]string{
+					slinkyv1beta1.AnnotationNodeSlurmUnhealthyCordon: "foo-0",
+				}
+				node.Spec.Unschedulable = true
+				node.Spec.Taints = []corev1.Taint{
+					{Key: slinkyv1beta1.TaintNodeSlurmUnhealthy, Value: "foo-0", Effect: corev1.TaintEffectNoSchedule},
+				}
+				node.Status.Conditions = []corev1.NodeCondition{unhealthyCondition}
+			}),
+			slurmNodeList: healthyNodeList,
+		},
+		{
+			name:    "Disabled after unhealthy",
+			nodeset: newHealthNodeSet(false, ""),
+			node: newNode(func(node *corev1.Node) {
+				node.Spec.Taints = []corev1.Taint{
+					{Key: slinkyv1beta1.TaintNodeSlurmUnhealthy, Value: "foo-0", Effect: corev1.TaintEffectNoSchedule},
+				}
+				node.Status.Conditions = []corev1.NodeCondition{unhealthyCondition}
+			}),
+			slurmNodeList: unhealthyNodeList,
+		},
+		{
+			name:    "Cordoned by others",
+			nodeset: newHealthNodeSet(true, slinkyv1beta1.NodeHealthActionCordon),
+			node: newNode(func(node *corev1.Node) {
+				node.Spec.Unschedulable = true
+			}),
+			slurmNodeList:     healthyNodeList,
+			wantConditionTrue: false,
+			wantCordon:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), tt.nodeset, controller, 0, "")
+			pod.Spec.NodeName = tt.node.Name
+			c := indexes.NewFakeClientBuilderWithIndexes(tt.nodeset.DeepCopy(), pod.DeepCopy(), tt.node.DeepCopy()).Build()
+			sclient := newFakeClientList(sinterceptor.Funcs{}, tt.slurmNodeList)
+			r := newNodeSetController(c, newClientMap(controller.Name, sclient))
+
+			err := r.syncNodeHealth(ctx, tt.nodeset, []*corev1.Pod{pod})
+			require.NoError(t, err)
+
+			gotNode := &corev1.Node{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tt.node), gotNode))
+
+			gotConditionTrue := false
+			for _, condition := range gotNode.Status.Conditions {
+				if condition.Type == slurmconditions.NodeConditionSlurmUnhealthy && condition.Status == corev1.ConditionTrue {
+					gotConditionTrue = true
+					require.Equal(t, unhealthyCondition.Message, condition.Message)
+				}
+			}
+			require.Equal(t, tt.wantConditionTrue, gotConditionTrue)
+
+			gotTaint := false
+			for _, taint := range gotNode.Spec.Taints {
+				if taint.Key == slinkyv1beta1.TaintNodeSlurmUnhealthy {
+					gotTaint = true
+				}
+			}
+			require.Equal(t, tt.wantTaint, gotTaint)
+			require.Equal(t, tt.wantCordon, gotNode.Spec.Unschedulable)
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncNodeHealth_sharedNode(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	newHealthNodeSet := func(name string, action slinkyv1beta1.NodeHealthActionType) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet(name, controller.Name, 1)
+		nodeset.UID = types.UID(name)
+		nodeset.Spec.NodeHealthPolicy.Enabled = true
+		nodeset.Spec.NodeHealthPolicy.Action = action
+		return nodeset
+	}
+	foo := newHealthNodeSet("foo", slinkyv1beta1.NodeHealthActionTaint)
+	bar := newHealthNodeSet("bar", slinkyv1beta1.NodeHealthActionCordon)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-0",
+		},
+	}
+	fooPod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), foo, controller, 0, "")
+	fooPod.Spec.NodeName = node.Name
+	barPod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), bar, controller, 0, "")
+	barPod.Spec.NodeName = node.Name
+	slurmNodeList := &slurmtypes.V0044NodeList{
+		Items: []slurmtypes.V0044Node{
+			{V0044Node: slurmapi.V0044Node{
+				Name:  ptr.To("foo-0"),
+				State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
+			}},
+			{V0044Node: slurmapi.V0044Node{
+				Name:   ptr.To("bar-0"),
+				State:  ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateDRAIN}),
+				Reason: ptr.To("NHC: GPU lost"),
+			}},
+		},
+	}
+
+	c := indexes.NewFakeClientBuilderWithIndexes(foo.DeepCopy(), bar.DeepCopy(), fooPod.DeepCopy(), barPod.DeepCopy(), node.DeepCopy()).Build()
+	sclient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+	r := newNodeSetController(c, newClientMap(controller.Name, sclient))
+
+	// The healthy Slurm node of foo must not unmark the node for the unhealthy
+	// Slurm node of bar, regardless of which NodeSet is synced.
+	require.NoError(t, r.syncNodeHealth(ctx, foo, []*corev1.Pod{fooPod}))
+	gotNode := &corev1.Node{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(node), gotNode))
+
+	require.NoError(t, r.syncNodeHealth(ctx, bar, []*corev1.Pod{barPod}))
+	require.NoError(t, r.syncNodeHealth(ctx, foo, []*corev1.Pod{fooPod}))
+	gotNodeAfter := &corev1.Node{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(node), gotNodeAfter))
+	require.Equal(t, gotNode.ResourceVersion, gotNodeAfter.ResourceVersion)
+
+	gotCondition := corev1.NodeCondition{}
+	for _, condition := range gotNodeAfter.Status.Conditions {
+		if condition.Type == slurmconditions.NodeConditionSlurmUnhealthy {
+			gotCondition = condition
+		}
+	}
+	require.Equal(t, corev1.ConditionTrue, gotCondition.Status)
+	require.Equal(t, "Slurm node bar-0 is unhealthy: NHC: GPU lost", gotCondition.Message)
+	require.Empty(t, gotNodeAfter.Spec.Taints)
+	require.True(t, gotNodeAfter.Spec.Unschedulable)
+	require.Equal(t, "bar-0", gotNodeAfter.Annotations[slinkyv1beta1.AnnotationNodeSlurmUnhealthyCordon])
+}
+
+func Test_setNodeUnhealthyTaint(t *testing.T) {
+	otherTaint := corev1.Taint{Key: slinkyv1beta1.TaintNodeSlurmUnhealthy, Value: "bar-0", Effect: corev1.TaintEffectNoSchedule}
+	ownTaint := corev1.Taint{Key: slinkyv1beta1.TaintNodeSlurmUnhealthy, Value: "foo-0", Effect: corev1.TaintEffectNoSchedule}
+	tests := []struct {
+		name          string
+		taints        []corev1.Taint
+		slurmNodeName string
+		want          []corev1.Taint
+	}{
+		{
+			name:          "Add",
+			slurmNodeName: "foo-0",
+			want:          []corev1.Taint{ownTaint},
+		},
+		{
+			name:   "Remove own",
+			taints: []corev1.Taint{ownTaint},
+			want:   nil,
+		},
+		{
+			name:   "Remove taint of other Slurm node",
+			taints: []corev1.Taint{otherTaint},
+			want:   nil,
+		},
+		{
+			name:          "Already tainted by other Slurm node",
+			taints:        []corev1.Taint{otherTaint},
+			slurmNodeName: "foo-0",
+			want:          []corev1.Taint{otherTaint},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{Spec: corev1.NodeSpec{Taints: tt.taints}}
+			setNodeUnhealthyTaint(node, tt.slurmNodeName)
+			require.Equal(t, tt.want, node.Spec.Taints)
+		})
+	}
+}
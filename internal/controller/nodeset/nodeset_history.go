@@ -10,10 +10,14 @@ import (
 	"maps"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/kubernetes/pkg/controller/history"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
 )
 
@@ -73,11 +77,17 @@ func (r *NodeSetReconciler) truncateHistory(
 // a new revision, or modify the Revision of an existing revision if an update to nodeset is detected.
 // This method expects that revisions is sorted when supplied.
 func (r *NodeSetReconciler) getNodeSetRevisions(
+	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
 	revisions []*appsv1.ControllerRevision,
 ) (*appsv1.ControllerRevision, *appsv1.ControllerRevision, int32, error) {
 	var currentRevision, updateRevision *appsv1.ControllerRevision
 
+	restartHash, err := r.getSlurmdRestartHash(ctx, nodeset)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
 	revisionCount := len(revisions)
 	history.SortControllerRevisions(revisions)
 
@@ -88,7 +98,7 @@ func (r *NodeSetReconciler) getNodeSetRevisions(
 	}
 
 	// create a new revision from the current nodeset
-	updateRevision, err := newRevision(nodeset, nextRevision(revisions), &collisionCount)
+	updateRevision, err = newRevision(nodeset, restartHash, nextRevision(revisions), &collisionCount)
 	if err != nil {
 		return nil, nil, collisionCount, err
 	}
@@ -121,11 +131,20 @@ func (r *NodeSetReconciler) getNodeSetRevisions(
 
 	// attempt to find the revision that corresponds to the current revision
 	for i := range revisions {
-		if revisions[i].Name == nodeset.Status.NodeSetHash {
+		if nodeset.Status.CurrentRevision != "" &&
+			historycontrol.GetRevision(revisions[i].GetLabels()) == nodeset.Status.CurrentRevision {
 			currentRevision = revisions[i]
 			break
 		}
 	}
+	if currentRevision == nil {
+		for i := range revisions {
+			if revisions[i].Name == nodeset.Status.NodeSetHash {
+				currentRevision = revisions[i]
+				break
+			}
+		}
+	}
 
 	// if the current revision is nil we initialize the history by setting it to the update revision
 	if currentRevision == nil {
@@ -135,6 +154,28 @@ func (r *NodeSetReconciler) getNodeSetRevisions(
 	return currentRevision, updateRevision, collisionCount, nil
 }
 
+// getSlurmdRestartHash returns the slurmd restart hash, which the Controller
+// publishes on its ConfigMap once slurmctld has restarted.
+func (r *NodeSetReconciler) getSlurmdRestartHash(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (string, error) {
+	controller, err := r.refResolver.GetController(ctx, nodeset.Spec.ControllerRef, nodeset.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	config := &corev1.ConfigMap{}
+	if err := r.Get(ctx, controller.ConfigKey(), config); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return config.Annotations[common.AnnotationSlurmdRestartHash], nil
+}
+
 // nextRevision finds the next valid revision number based on revisions. If the length of revisions
 // is 0 this is 1. Otherwise, it is 1 greater than the largest revision's Revision. This method
 // assumes that revisions has been sorted by Revision.
@@ -150,8 +191,8 @@ func nextRevision(revisions []*appsv1.ControllerRevision) int64 {
 // The Revision of the returned ControllerRevision is set to revision. If the returned error is nil, the returned
 // ControllerRevision is valid. NodeSet revisions are stored as patches that re-apply the current state of NodeSet
 // to a new NodeSet using a strategic merge patch to replace the saved state of the new NodeSet.
-func newRevision(nodeset *slinkyv1beta1.NodeSet, revision int64, collisionCount *int32) (*appsv1.ControllerRevision, error) {
-	patch, err := getPatch(nodeset)
+func newRevision(nodeset *slinkyv1beta1.NodeSet, restartHash string, revision int64, collisionCount *int32) (*appsv1.ControllerRevision, error) {
+	patch, err := getPatch(nodeset, restartHash)
 	if err != nil {
 		return nil, err
 	}
@@ -172,11 +213,64 @@ func newRevision(nodeset *slinkyv1beta1.NodeSet, revision int64, collisionCount
 	return cr, nil
 }
 
+// applyRevision returns a new NodeSet constructed by restoring the state in revision to nodeset. If the returned error
+// is nil, the returned NodeSet is valid.
+func applyRevision(nodeset *slinkyv1beta1.NodeSet, revision *appsv1.ControllerRevision) (*slinkyv1beta1.NodeSet, error) {
+	nodesetBytes, err := json.Marshal(nodeset)
+	if err != nil {
+		return nil, err
+	}
+	nodesetBytes, err = addReplacedFields(nodesetBytes, revision.Data.Raw)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(nodesetBytes, revision.Data.Raw, nodeset)
+	if err != nil {
+		return nil, err
+	}
+	restoredNodeSet := &slinkyv1beta1.NodeSet{}
+	if err := json.Unmarshal(patched, restoredNodeSet); err != nil {
+		return nil, err
+	}
+	return restoredNodeSet, nil
+}
+
+// addReplacedFields adds an empty object to the NodeSet spec for each field the
+// patch replaces but the NodeSet omits, otherwise the strategic merge patch
+// drops the replaced field instead of restoring it.
+func addReplacedFields(nodesetBytes, patch []byte) ([]byte, error) {
+	var rawPatch map[string]any
+	if err := json.Unmarshal(patch, &rawPatch); err != nil {
+		return nil, err
+	}
+	patchSpec, _ := rawPatch["spec"].(map[string]any)
+	var raw map[string]any
+	if err := json.Unmarshal(nodesetBytes, &raw); err != nil {
+		return nil, err
+	}
+	spec, ok := raw["spec"].(map[string]any)
+	if !ok {
+		spec = make(map[string]any)
+		raw["spec"] = spec
+	}
+	for key, value := range patchSpec {
+		field, ok := value.(map[string]any)
+		if !ok || field["$patch"] != "replace" {
+			continue
+		}
+		if _, ok := spec[key]; !ok {
+			spec[key] = map[string]any{}
+		}
+	}
+	return json.Marshal(raw)
+}
+
 // getPatch returns a strategic merge patch that can be applied to restore a NodeSet to a
 // previous version. If the returned error is nil the patch is valid. The current state that we save is just the
 // PodSpecTemplate. We can modify this later to encompass more state (or less) and remain compatible with previously
-// recorded patches.
-func getPatch(nodeset *slinkyv1beta1.NodeSet) ([]byte, error) {
+// recorded patches. A non-empty slurmd restart hash is recorded as a pod template annotation, such that a Slurm
+// configuration change which requires a slurmd restart is a new revision, rolled out by the update strategy.
+func getPatch(nodeset *slinkyv1beta1.NodeSet, restartHash string) ([]byte, error) {
 	crBytes, err := json.Marshal(nodeset)
 	if err != nil {
 		return nil, err
@@ -192,6 +286,19 @@ func getPatch(nodeset *slinkyv1beta1.NodeSet) ([]byte, error) {
 	template := spec["template"].(map[string]any)
 	specCopy["template"] = template
 	template["$patch"] = "replace"
+	if restartHash != "" {
+		metadata, ok := template["metadata"].(map[string]any)
+		if !ok {
+			metadata = make(map[string]any)
+			template["metadata"] = metadata
+		}
+		annotations, ok := metadata["annotations"].(map[string]any)
+		if !ok {
+			annotations = make(map[string]any)
+			metadata["annotations"] = annotations
+		}
+		annotations[common.AnnotationSlurmdRestartHash] = restartHash
+	}
 
 	// NOTE: Anything outside of pod template but should be included in the
 	// revision patch must be manually added here.
@@ -212,6 +319,18 @@ func getPatch(nodeset *slinkyv1beta1.NodeSet) ([]byte, error) {
 		ssh["$patch"] = "replace"
 		specCopy["ssh"] = ssh
 	}
+	if gres, ok := spec["gres"].(map[string]any); ok {
+		gres["$patch"] = "replace"
+		specCopy["gres"] = gres
+	}
+	if cpuTopology, ok := spec["cpuTopology"].(map[string]any); ok {
+		cpuTopology["$patch"] = "replace"
+		specCopy["cpuTopology"] = cpuTopology
+	}
+	if powerSaving, ok := spec["powerSaving"].(map[string]any); ok {
+		powerSaving["$patch"] = "replace"
+		specCopy["powerSaving"] = powerSaving
+	}
 
 	objCopy["spec"] = specCopy
 	patch, err := json.Marshal(objCopy)
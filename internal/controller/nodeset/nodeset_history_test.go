@@ -6,6 +6,7 @@ package nodeset
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
@@ -18,7 +19,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
 func TestNodeSetReconciler_truncateHistory(t *testing.T) {
@@ -170,21 +174,21 @@ func TestNodeSetReconciler_getNodeSetRevisions(t *testing.T) {
 			revisionList := &appsv1.ControllerRevisionList{
 				Items: []appsv1.ControllerRevision{
 					func() appsv1.ControllerRevision {
-						cr, err := newRevision(nodeset, 0, ptr.To[int32](0))
+						cr, err := newRevision(nodeset, "", 0, ptr.To[int32](0))
 						if err != nil {
 							panic(err)
 						}
 						return *cr
 					}(),
 					func() appsv1.ControllerRevision {
-						cr, err := newRevision(nodeset, 1, ptr.To[int32](1))
+						cr, err := newRevision(nodeset, "", 1, ptr.To[int32](1))
 						if err != nil {
 							panic(err)
 						}
 						return *cr
 					}(),
 					func() appsv1.ControllerRevision {
-						cr, err := newRevision(nodeset, 2, ptr.To[int32](2))
+						cr, err := newRevision(nodeset, "", 2, ptr.To[int32](2))
 						if err != nil {
 							panic(err)
 						}
@@ -213,21 +217,21 @@ func TestNodeSetReconciler_getNodeSetRevisions(t *testing.T) {
 			revisionList := &appsv1.ControllerRevisionList{
 				Items: []appsv1.ControllerRevision{
 					func() appsv1.ControllerRevision {
-						cr, err := newRevision(nodeset, 0, ptr.To[int32](0))
+						cr, err := newRevision(nodeset, "", 0, ptr.To[int32](0))
 						if err != nil {
 							panic(err)
 						}
 						return *cr
 					}(),
 					func() appsv1.ControllerRevision {
-						cr, err := newRevision(nodeset, 1, ptr.To[int32](1))
+						cr, err := newRevision(nodeset, "", 1, ptr.To[int32](1))
 						if err != nil {
 							panic(err)
 						}
 						return *cr
 					}(),
 					func() appsv1.ControllerRevision {
-						cr, err := newRevision(nodeset, 2, ptr.To[int32](2))
+						cr, err := newRevision(nodeset, "", 2, ptr.To[int32](2))
 						if err != nil {
 							panic(err)
 						}
@@ -252,11 +256,56 @@ func TestNodeSetReconciler_getNodeSetRevisions(t *testing.T) {
 				wantErr: false,
 			}
 		}(),
+		func() testCaseFields {
+			nodeset := newNodeSet("foo", "slurm", 2)
+			revisionList := &appsv1.ControllerRevisionList{
+				Items: []appsv1.ControllerRevision{
+					func() appsv1.ControllerRevision {
+						cr, err := newRevision(nodeset, "", 0, ptr.To[int32](0))
+						if err != nil {
+							panic(err)
+						}
+						return *cr
+					}(),
+					func() appsv1.ControllerRevision {
+						cr, err := newRevision(nodeset, "", 1, ptr.To[int32](1))
+						if err != nil {
+							panic(err)
+						}
+						return *cr
+					}(),
+					func() appsv1.ControllerRevision {
+						cr, err := newRevision(nodeset, "", 2, ptr.To[int32](2))
+						if err != nil {
+							panic(err)
+						}
+						return *cr
+					}(),
+				},
+			}
+			nodeset.Status.NodeSetHash = revisionList.Items[1].Name
+			nodeset.Status.CurrentRevision = historycontrol.GetRevision(revisionList.Items[0].Labels)
+
+			return testCaseFields{
+				name: "current revision does match",
+				fields: fields{
+					Client: fake.NewFakeClient(nodeset, revisionList),
+				},
+				args: args{
+					nodeset:   nodeset.DeepCopy(),
+					revisions: structutils.ReferenceList(revisionList.Items),
+				},
+				want:    revisionList.Items[0].DeepCopy(),
+				want1:   revisionList.Items[2].DeepCopy(),
+				want2:   0,
+				wantErr: false,
+			}
+		}(),
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := newNodeSetController(tt.fields.Client, nil)
-			got, got1, got2, err := r.getNodeSetRevisions(tt.args.nodeset, tt.args.revisions)
+			got, got1, got2, err := r.getNodeSetRevisions(t.Context(), tt.args.nodeset, tt.args.revisions)
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
@@ -268,3 +317,157 @@ func TestNodeSetReconciler_getNodeSetRevisions(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeSetReconciler_getSlurmdRestartHash(t *testing.T) {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	newConfig := func(hash string) *corev1.ConfigMap {
+		out := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: controller.ConfigKey().Namespace,
+				Name:      controller.ConfigKey().Name,
+			},
+		}
+		if hash != "" {
+			out.Annotations = map[string]string{
+				common.AnnotationSlurmdRestartHash: hash,
+			}
+		}
+		return out
+	}
+	tests := []struct {
+		name string
+		objs []client.Object
+		want string
+	}{
+		{
+			name: "No Controller",
+		},
+		{
+			name: "Nothing published",
+			objs: []client.Object{controller.DeepCopy(), newConfig("")},
+		},
+		{
+			name: "Published",
+			objs: []client.Object{controller.DeepCopy(), newConfig("foo")},
+			want: "foo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+			r := newNodeSetController(c, nil)
+			nodeset := newNodeSet("foo", controller.Name, 1)
+			nodeset.Namespace = controller.Namespace
+
+			got, err := r.getSlurmdRestartHash(t.Context(), nodeset)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_newRevision_restartHash(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 1)
+	revision, err := newRevision(nodeset, "", 1, ptr.To[int32](0))
+	require.NoError(t, err)
+
+	// Published, the revision changes.
+	updateRevision, err := newRevision(nodeset, "bar", 1, ptr.To[int32](0))
+	require.NoError(t, err)
+	require.NotEqual(t, revision.Name, updateRevision.Name)
+	require.Empty(t, nodeset.Spec.Template.Metadata.Annotations[common.AnnotationSlurmdRestartHash])
+
+	got, err := applyRevision(nodeset, updateRevision)
+	require.NoError(t, err)
+	require.Equal(t, "bar", got.Spec.Template.Metadata.Annotations[common.AnnotationSlurmdRestartHash])
+}
+
+func Test_getPatch_partition(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 2)
+	revision, err := newRevision(nodeset, "", 1, ptr.To[int32](0))
+	require.NoError(t, err)
+
+	// Partition membership is part of the Controller config, not the revision.
+	updatedNodeSet := nodeset.DeepCopy()
+	updatedNodeSet.Labels = map[string]string{"partition": "gpu"}
+	updatedNodeSet.Spec.Partition = slinkyv1beta1.NodeSetPartition{
+		Enabled: true,
+		Config:  "MaxTime=UNLIMITED",
+	}
+	updatedRevision, err := newRevision(updatedNodeSet, "", 1, ptr.To[int32](0))
+	require.NoError(t, err)
+	require.Equal(t, revision.Name, updatedRevision.Name)
+}
+
+func Test_applyRevision(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 2)
+	nodeset.Spec.Slurmd.Image = "slurmd:old"
+	revision, err := newRevision(nodeset, "", 1, ptr.To[int32](0))
+	require.NoError(t, err)
+
+	updatedNodeSet := nodeset.DeepCopy()
+	updatedNodeSet.Spec.Slurmd.Image = "slurmd:new"
+	updatedNodeSet.Spec.Replicas = ptr.To[int32](4)
+
+	got, err := applyRevision(updatedNodeSet, revision)
+	require.NoError(t, err)
+	require.Equal(t, "slurmd:old", got.Spec.Slurmd.Image)
+	require.Equal(t, ptr.To[int32](4), got.Spec.Replicas)
+	require.Equal(t, "slurmd:new", updatedNodeSet.Spec.Slurmd.Image)
+}
+
+func Test_getPatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(nodeset *slinkyv1beta1.NodeSet)
+	}{
+		{
+			name: "Gres",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.Gres = slinkyv1beta1.NodeSetGres{
+					Enabled: true,
+					Resources: []slinkyv1beta1.NodeSetGresResource{
+						{ResourceName: "nvidia.com/gpu", Name: "gpu"},
+					},
+				}
+			},
+		},
+		{
+			name: "CpuTopology",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.CpuTopology = slinkyv1beta1.NodeSetCpuTopology{
+					Enabled: true,
+					Sockets: ptr.To[int32](2),
+				}
+			},
+		},
+		{
+			name: "PowerSaving",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.PowerSaving = slinkyv1beta1.NodeSetPowerSaving{
+					Enabled:     true,
+					SuspendTime: metav1.Duration{Duration: 30 * time.Minute},
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := newNodeSet("foo", "slurm", 2)
+			revision, err := newRevision(nodeset, "", 1, ptr.To[int32](0))
+			require.NoError(t, err)
+
+			updatedNodeSet := nodeset.DeepCopy()
+			tt.mutate(updatedNodeSet)
+			updatedRevision, err := newRevision(updatedNodeSet, "", 1, ptr.To[int32](0))
+			require.NoError(t, err)
+			require.NotEqual(t, revision.Name, updatedRevision.Name)
+
+			got, err := applyRevision(nodeset, updatedRevision)
+			require.NoError(t, err)
+			gotPatch, err := getPatch(got, "")
+			require.NoError(t, err)
+			require.Equal(t, updatedRevision.Data.Raw, gotPatch)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	builder "github.com/SlinkyProject/slurm-operator/internal/builder/workerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// defaultSlurmNodeWeight is the Slurm node weight when none is configured.
+// Ref: https://slurm.schedmd.com/slurm.conf.html#OPT_Weight
+const defaultSlurmNodeWeight int32 = 1
+
+// getRevisionSpec returns the NodeSet spec recorded in the revision.
+func getRevisionSpec(revision *appsv1.ControllerRevision) (map[string]any, error) {
+	raw := make(map[string]any)
+	if err := json.Unmarshal(revision.Data.Raw, &raw); err != nil {
+		return nil, err
+	}
+	spec, ok := raw["spec"].(map[string]any)
+	if !ok {
+		spec = make(map[string]any)
+	}
+	return spec, nil
+}
+
+// getRevisionExtraConf returns the NodeSet ExtraConf recorded in the revision.
+func getRevisionExtraConf(revision *appsv1.ControllerRevision) (string, error) {
+	spec, err := getRevisionSpec(revision)
+	if err != nil {
+		return "", err
+	}
+	extraConf, _ := spec["extraConf"].(string)
+	return extraConf, nil
+}
+
+// isSlurmOnlyChange returns true if the revisions only differ by the Slurm node
+// attributes of their ExtraConf, which can be updated without restarting slurmd.
+func isSlurmOnlyChange(oldRevision, newRevision *appsv1.ControllerRevision) (bool, error) {
+	podSpec := func(revision *appsv1.ControllerRevision) (map[string]any, error) {
+		spec, err := getRevisionSpec(revision)
+		if err != nil {
+			return nil, err
+		}
+		extraConf, _ := spec["extraConf"].(string)
+		spec["extraConf"] = builder.PodExtraConf(extraConf)
+		return spec, nil
+	}
+	oldSpec, err := podSpec(oldRevision)
+	if err != nil {
+		return false, err
+	}
+	newSpec, err := podSpec(newRevision)
+	if err != nil {
+		return false, err
+	}
+	return apiequality.Semantic.DeepEqual(oldSpec, newSpec), nil
+}
+
+// getInPlaceNodeAttributes returns the Slurm node attributes of the old revision,
+// or nil if the pods of the old revision cannot be updated in place to the
+// update revision.
+func getInPlaceNodeAttributes(
+	nodeset *slinkyv1beta1.NodeSet,
+	oldRevision, updateRevision *appsv1.ControllerRevision,
+) (*builder.NodeAttributes, error) {
+	if oldRevision == nil || updateRevision == nil {
+		return nil, nil
+	}
+	ok, err := isSlurmOnlyChange(oldRevision, updateRevision)
+	if err != nil || !ok {
+		return nil, err
+	}
+	extraConf, err := getRevisionExtraConf(oldRevision)
+	if err != nil {
+		return nil, err
+	}
+	oldNodeSet := nodeset.DeepCopy()
+	oldNodeSet.Spec.ExtraConf = extraConf
+	return ptr.To(builder.GetNodeAttributes(oldNodeSet)), nil
+}
+
+// inPlaceUpdate is recorded on the pods which were updated in place.
+type inPlaceUpdate struct {
+	// Registered are the Slurm node attributes of the pod spec, which slurmd
+	// registers with.
+	Registered builder.NodeAttributes `json:"registered"`
+	// SlurmdRestarts is the restart count of the slurmd container when the
+	// Slurm node attributes of the pod revision were last applied.
+	SlurmdRestarts int32 `json:"slurmdRestarts"`
+}
+
+// getInPlaceUpdate returns the in-place update recorded on the pod, or nil if
+// the pod was not updated in place.
+func getInPlaceUpdate(pod *corev1.Pod) *inPlaceUpdate {
+	raw, ok := pod.GetAnnotations()[slinkyv1beta1.AnnotationPodInPlaceUpdate]
+	if !ok {
+		return nil
+	}
+	update := &inPlaceUpdate{}
+	if err := json.Unmarshal([]byte(raw), update); err != nil {
+		return nil
+	}
+	return update
+}
+
+// getSlurmdRestarts returns the restart count of the slurmd container.
+func getSlurmdRestarts(pod *corev1.Pod) int32 {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == labels.WorkerApp {
+			return status.RestartCount
+		}
+	}
+	return 0
+}
+
+// syncInPlaceUpdate updates the old pods in place whose revision only differs
+// from the update revision by Slurm node attributes (e.g. Features, Weight).
+// Those are applied live to the registered Slurm nodes, then the pods are
+// labeled with the update revision instead of being recreated. All other
+// changes are left to the update strategy.
+//
+// The pod spec of a pod updated in place still has the Slurm node attributes
+// it was created with, hence they are applied again whenever slurmd restarts
+// and re-registers.
+func (r *NodeSetReconciler) syncInPlaceUpdate(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	hash string,
+) error {
+	logger := log.FromContext(ctx)
+
+	newPods, oldPods := findUpdatedPods(pods, hash)
+	oldPods, _ = r.splitHeldPods(ctx, nodeset, oldPods)
+
+	revisions, err := r.listRevisions(nodeset)
+	if err != nil {
+		return err
+	}
+	revisionsByHash := make(map[string]*appsv1.ControllerRevision, len(revisions))
+	for _, revision := range revisions {
+		revisionsByHash[historycontrol.GetRevision(revision.GetLabels())] = revision
+	}
+	updateRevision := revisionsByHash[hash]
+
+	oldAttrsByHash := make(map[string]*builder.NodeAttributes)
+	podsToUpdate := make([]*corev1.Pod, 0, len(oldPods)+len(newPods))
+	for _, pod := range oldPods {
+		// Unregistered Slurm nodes are replaced instead, as slurmd would
+		// register with the old attributes.
+		if !podutils.IsHealthy(pod) || !slurmconditions.IsNodeRegistered(&pod.Status) {
+			continue
+		}
+		oldHash := historycontrol.GetRevision(pod.GetLabels())
+		oldAttrs, ok := oldAttrsByHash[oldHash]
+		if !ok {
+			oldAttrs, err = getInPlaceNodeAttributes(nodeset, revisionsByHash[oldHash], updateRevision)
+			if err != nil {
+				return err
+			}
+			oldAttrsByHash[oldHash] = oldAttrs
+		}
+		if oldAttrs != nil {
+			podsToUpdate = append(podsToUpdate, pod)
+		}
+	}
+	for _, pod := range newPods {
+		// Slurm nodes which re-registered with the attributes of the pod spec.
+		update := getInPlaceUpdate(pod)
+		if update == nil || update.SlurmdRestarts == getSlurmdRestarts(pod) {
+			continue
+		}
+		if !podutils.IsHealthy(pod) || !slurmconditions.IsNodeRegistered(&pod.Status) {
+			continue
+		}
+		podsToUpdate = append(podsToUpdate, pod)
+	}
+	if len(podsToUpdate) == 0 {
+		return nil
+	}
+
+	newAttrs := builder.GetNodeAttributes(nodeset)
+	updateFn := func(i int) error {
+		pod := podsToUpdate[i]
+		update := getInPlaceUpdate(pod)
+		if update == nil {
+			oldAttrs := oldAttrsByHash[historycontrol.GetRevision(pod.GetLabels())]
+			update = &inPlaceUpdate{Registered: *oldAttrs}
+		}
+		// The Slurm node has either the attributes of the pod spec, or those
+		// of the pod revision when it was updated in place before.
+		oldFeatures := update.Registered.Features
+		weightChanged := !ptr.Equal(update.Registered.Weight, newAttrs.Weight)
+		if oldAttrs := oldAttrsByHash[historycontrol.GetRevision(pod.GetLabels())]; oldAttrs != nil {
+			oldFeatures = structutils.MergeList(oldFeatures, oldAttrs.Features)
+			weightChanged = weightChanged || !ptr.Equal(oldAttrs.Weight, newAttrs.Weight)
+		}
+		var weight *int32
+		if weightChanged {
+			weight = ptr.To(ptr.Deref(newAttrs.Weight, defaultSlurmNodeWeight))
+		}
+		if err := r.slurmControl.UpdateNodeAttributes(ctx, nodeset, pod, oldFeatures, newAttrs.Features, weight); err != nil {
+			return err
+		}
+		update.SlurmdRestarts = getSlurmdRestarts(pod)
+		raw, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+		return objectutils.PatchObject(r.Client, ctx, pod, func(p *corev1.Pod) error {
+			historycontrol.SetRevision(p.Labels, hash)
+			if p.Annotations == nil {
+				p.Annotations = make(map[string]string)
+			}
+			p.Annotations[slinkyv1beta1.AnnotationPodInPlaceUpdate] = string(raw)
+			return nil
+		})
+	}
+	successCount, err := utils.SlowStartBatch(len(podsToUpdate), utils.SlowStartInitialBatchSize, updateFn)
+	if successCount > 0 {
+		logger.Info("Updated pods in place with Slurm node attributes",
+			"update", successCount)
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, InPlaceUpdateReason, "InPlaceUpdate",
+			"In-place update: applied Slurm node attributes of updated revision to %d pod(s)", successCount)
+	}
+	if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+		return err
+	}
+
+	return nil
+}
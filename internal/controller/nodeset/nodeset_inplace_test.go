@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
+)
+
+func newInPlaceRevision(t *testing.T, nodeset *slinkyv1beta1.NodeSet, revision int64) *appsv1.ControllerRevision {
+	cr, err := newRevision(nodeset, "", revision, ptr.To[int32](0))
+	require.NoError(t, err)
+	maps.Copy(cr.Labels, labels.NewBuilder().WithWorkerSelectorLabels(nodeset).Build())
+	return cr
+}
+
+func Test_isSlurmOnlyChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(nodeset *slinkyv1beta1.NodeSet)
+		want   bool
+	}{
+		{
+			name: "Features",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.ExtraConf = "Features=h100 RealMemory=1024"
+			},
+			want: true,
+		},
+		{
+			name: "Weight",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.ExtraConf = "Features=a100 Weight=10 RealMemory=1024"
+			},
+			want: true,
+		},
+		{
+			name: "Partition membership",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Labels = map[string]string{"partition": "gpu"}
+				nodeset.Spec.Partition = slinkyv1beta1.NodeSetPartition{Enabled: true, Config: "State=UP"}
+			},
+			want: true,
+		},
+		{
+			name: "Other ExtraConf",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.ExtraConf = "Features=a100 RealMemory=2048"
+			},
+			want: false,
+		},
+		{
+			name: "Pod template",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.Slurmd.Image = "slurmd:new"
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldNodeSet := newNodeSet("foo", "slurm", 2)
+			oldNodeSet.Spec.ExtraConf = "Features=a100 RealMemory=1024"
+			newNodeSet := oldNodeSet.DeepCopy()
+			tt.mutate(newNodeSet)
+
+			got, err := isSlurmOnlyChange(newInPlaceRevision(t, oldNodeSet, 1), newInPlaceRevision(t, newNodeSet, 2))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncInPlaceUpdate(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	tests := []struct {
+		name         string
+		mutate       func(nodeset *slinkyv1beta1.NodeSet)
+		noClient     bool
+		wantUpdated  bool
+		wantFeatures []string
+		wantWeight   int32
+	}{
+		{
+			name: "Slurm-only change is updated in place",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.ExtraConf = "Features=h100 Weight=10"
+			},
+			wantUpdated:  true,
+			wantFeatures: []string{"foo", "h100", slinkyv1beta1.NodeFeaturePrefix + "zone"},
+			wantWeight:   10,
+		},
+		{
+			name: "Pod template change is not updated in place",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.ExtraConf = "Features=h100"
+				nodeset.Spec.Slurmd.Image = "slurmd:new"
+			},
+			wantUpdated:  false,
+			wantFeatures: []string{"a100", "foo", slinkyv1beta1.NodeFeaturePrefix + "zone"},
+			wantWeight:   1,
+		},
+		{
+			name: "No Slurm client",
+			mutate: func(nodeset *slinkyv1beta1.NodeSet) {
+				nodeset.Spec.ExtraConf = "Features=h100"
+			},
+			noClient:     true,
+			wantUpdated:  false,
+			wantFeatures: []string{"a100", "foo", slinkyv1beta1.NodeFeaturePrefix + "zone"},
+			wantWeight:   1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			oldNodeSet := newNodeSet("foo", controller.Name, 2)
+			oldNodeSet.Spec.ExtraConf = "Features=a100"
+			nodeset := oldNodeSet.DeepCopy()
+			tt.mutate(nodeset)
+			oldRevision := newInPlaceRevision(t, oldNodeSet, 1)
+			updateRevision := newInPlaceRevision(t, nodeset, 2)
+			oldHash := historycontrol.GetRevision(oldRevision.Labels)
+			hash := historycontrol.GetRevision(updateRevision.Labels)
+
+			pods := []*corev1.Pod{
+				newSurgePod(oldNodeSet, controller, 0, oldHash, true),
+				newSurgePod(oldNodeSet, controller, 1, oldHash, true),
+			}
+			objs := []runtime.Object{nodeset.DeepCopy(), controller.DeepCopy(), oldRevision, updateRevision}
+			slurmNodeList := &slurmtypes.V0044NodeList{}
+			for _, pod := range pods {
+				objs = append(objs, pod.DeepCopy())
+				features := slurmapi.V0044CsvString{"a100", "foo", slinkyv1beta1.NodeFeaturePrefix + "zone"}
+				slurmNodeList.Items = append(slurmNodeList.Items, slurmtypes.V0044Node{
+					V0044Node: slurmapi.V0044Node{
+						Name:           ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State:          ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
+						Features:       ptr.To(slices.Clone(features)),
+						ActiveFeatures: ptr.To(slices.Clone(features)),
+						Weight:         ptr.To[int32](1),
+					},
+				})
+			}
+			c := fake.NewFakeClient(objs...)
+			sclient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+			clientMap := newClientMap(controller.Name, sclient)
+			if tt.noClient {
+				clientMap = clientmap.NewClientMap()
+			}
+			r := newNodeSetController(c, clientMap)
+
+			err := r.syncInPlaceUpdate(ctx, nodeset, pods, hash)
+			require.NoError(t, err)
+
+			wantHash := oldHash
+			if tt.wantUpdated {
+				wantHash = hash
+			}
+			for _, pod := range pods {
+				gotPod := &corev1.Pod{}
+				require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), gotPod))
+				require.Equal(t, wantHash, historycontrol.GetRevision(gotPod.Labels))
+				if tt.wantUpdated {
+					update := getInPlaceUpdate(gotPod)
+					require.NotNil(t, update)
+					require.Equal(t, []string{"a100", "foo"}, update.Registered.Features)
+				}
+
+				slurmNode := &slurmtypes.V0044Node{}
+				require.NoError(t, sclient.Get(ctx, slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod)), slurmNode))
+				gotFeatures := ptr.Deref(slurmNode.Features, slurmapi.V0044CsvString{})
+				slices.Sort(gotFeatures)
+				require.Equal(t, tt.wantFeatures, []string(gotFeatures))
+				require.Equal(t, tt.wantWeight, ptr.Deref(slurmNode.Weight, 0))
+			}
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncInPlaceUpdate_slurmdRestart(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	tests := []struct {
+		name           string
+		slurmdRestarts int32
+		wantFeatures   []string
+		wantWeight     int32
+	}{
+		{
+			name:           "Slurmd did not restart",
+			slurmdRestarts: 0,
+			wantFeatures:   []string{"a100", "foo", slinkyv1beta1.NodeFeaturePrefix + "zone"},
+			wantWeight:     1,
+		},
+		{
+			name:           "Slurmd re-registered with the pod spec attributes",
+			slurmdRestarts: 1,
+			wantFeatures:   []string{"foo", "h100", slinkyv1beta1.NodeFeaturePrefix + "zone"},
+			wantWeight:     10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			oldNodeSet := newNodeSet("foo", controller.Name, 1)
+			oldNodeSet.Spec.ExtraConf = "Features=a100"
+			nodeset := oldNodeSet.DeepCopy()
+			nodeset.Spec.ExtraConf = "Features=h100 Weight=10"
+			updateRevision := newInPlaceRevision(t, nodeset, 2)
+			hash := historycontrol.GetRevision(updateRevision.Labels)
+
+			// The pod was updated in place, then slurmd restarted.
+			pod := newSurgePod(oldNodeSet, controller, 0, hash, true)
+			pod.Annotations = map[string]string{
+				slinkyv1beta1.AnnotationPodInPlaceUpdate: `{"registered":{"features":["a100","foo"]},"slurmdRestarts":0}`,
+			}
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{Name: labels.WorkerApp, RestartCount: tt.slurmdRestarts},
+			}
+			objs := []runtime.Object{nodeset.DeepCopy(), controller.DeepCopy(), updateRevision, pod.DeepCopy()}
+			features := slurmapi.V0044CsvString{"a100", "foo", slinkyv1beta1.NodeFeaturePrefix + "zone"}
+			slurmNodeList := &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					{
+						V0044Node: slurmapi.V0044Node{
+							Name:           ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+							State:          ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
+							Features:       ptr.To(slices.Clone(features)),
+							ActiveFeatures: ptr.To(slices.Clone(features)),
+							Weight:         ptr.To[int32](1),
+						},
+					},
+				},
+			}
+			c := fake.NewFakeClient(objs...)
+			sclient := newFakeClientList(sinterceptor.Funcs{}, slurmNodeList)
+			r := newNodeSetController(c, newClientMap(controller.Name, sclient))
+
+			err := r.syncInPlaceUpdate(ctx, nodeset, []*corev1.Pod{pod}, hash)
+			require.NoError(t, err)
+
+			gotPod := &corev1.Pod{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pod), gotPod))
+			require.Equal(t, hash, historycontrol.GetRevision(gotPod.Labels))
+			require.Equal(t, tt.slurmdRestarts, getInPlaceUpdate(gotPod).SlurmdRestarts)
+
+			slurmNode := &slurmtypes.V0044Node{}
+			require.NoError(t, sclient.Get(ctx, slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod)), slurmNode))
+			gotFeatures := ptr.Deref(slurmNode.Features, slurmapi.V0044CsvString{})
+			slices.Sort(gotFeatures)
+			require.Equal(t, tt.wantFeatures, []string(gotFeatures))
+			require.Equal(t, tt.wantWeight, ptr.Deref(slurmNode.Weight, 0))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/flowcontrol"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	// jobOccupancyUpdateQPS and jobOccupancyUpdateBurst bound the pod patches
+	// of the Slurm job occupancy across all NodeSets, such that busy Slurm
+	// clusters do not cause a storm of pod patches.
+	jobOccupancyUpdateQPS   = 10
+	jobOccupancyUpdateBurst = 100
+
+	jobsRunningReason   = "JobsRunning"
+	noJobsRunningReason = "NoJobsRunning"
+)
+
+var jobOccupancyRateLimiter = flowcontrol.NewTokenBucketRateLimiter(jobOccupancyUpdateQPS, jobOccupancyUpdateBurst)
+
+// updateNodeSetPodJobs reflects the running Slurm jobs of the Slurm node of
+// each pod onto the SlurmJobsRunning pod condition. Pods are only patched when
+// their Slurm jobs changed, subject to jobOccupancyRateLimiter. Pods skipped by
+// the rate limit are updated by a later reconcile.
+func (r *NodeSetReconciler) updateNodeSetPodJobs(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	nodeStatus *slurmcontrol.SlurmNodeStatus,
+) error {
+	updateNodeSetPodJobsFn := func(i int) error {
+		pod := pods[i]
+		nodeJobs := nodeStatus.NodeJobs[nodesetutils.GetSlurmNodeName(pod)]
+
+		newCondition := newJobsRunningCondition(nodeJobs)
+		_, oldCondition := podutil.GetPodCondition(&pod.Status, slurmconditions.PodConditionJobsRunning)
+		if isJobOccupancyUpToDate(oldCondition, newCondition) {
+			return nil
+		}
+
+		if !jobOccupancyRateLimiter.TryAccept() {
+			return nil
+		}
+
+		mutateFn := func(pod *corev1.Pod) error {
+			podutil.UpdatePodCondition(&pod.Status, &newCondition)
+			return nil
+		}
+		if err := objectutils.StatusPatchObject(r.Client, ctx, pod, mutateFn); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, updateNodeSetPodJobsFn); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// isJobOccupancyUpToDate returns true if the pod already reflects the running
+// Slurm jobs. A pod which never had Slurm jobs is left alone.
+func isJobOccupancyUpToDate(oldCondition *corev1.PodCondition, newCondition corev1.PodCondition) bool {
+	if oldCondition == nil {
+		return newCondition.Status == corev1.ConditionFalse
+	}
+	return oldCondition.Status == newCondition.Status &&
+		oldCondition.Reason == newCondition.Reason &&
+		oldCondition.Message == newCondition.Message
+}
+
+// newJobsRunningCondition returns the SlurmJobsRunning pod condition of the
+// running Slurm jobs of a Slurm node.
+func newJobsRunningCondition(nodeJobs slurmcontrol.SlurmNodeJobs) corev1.PodCondition {
+	if len(nodeJobs.JobIDs) == 0 {
+		return corev1.PodCondition{
+			Type:   slurmconditions.PodConditionJobsRunning,
+			Status: corev1.ConditionFalse,
+			Reason: noJobsRunningReason,
+		}
+	}
+	var message strings.Builder
+	fmt.Fprintf(&message, "Running Slurm jobs %s", formatJobIDs(nodeJobs.JobIDs))
+	if len(nodeJobs.Users) > 0 {
+		fmt.Fprintf(&message, " of users %s", strings.Join(nodeJobs.Users, ","))
+	}
+	if len(nodeJobs.Partitions) > 0 {
+		fmt.Fprintf(&message, " in partitions %s", strings.Join(nodeJobs.Partitions, ","))
+	}
+	if !nodeJobs.EndTime.IsZero() {
+		fmt.Fprintf(&message, " until %s", nodeJobs.EndTime.UTC().Format(time.RFC3339))
+	}
+	return corev1.PodCondition{
+		Type:    slurmconditions.PodConditionJobsRunning,
+		Status:  corev1.ConditionTrue,
+		Reason:  jobsRunningReason,
+		Message: message.String(),
+	}
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func TestNodeSetReconciler_updateNodeSetPodJobs(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	endTime := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	nodeJobs := slurmcontrol.SlurmNodeJobs{
+		JobIDs:     []int32{1, 2},
+		Users:      []string{"alice", "bob"},
+		Partitions: []string{"debug"},
+		EndTime:    endTime,
+	}
+	newPod := func(conditions ...corev1.PodCondition) *corev1.Pod {
+		pod := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, ""))
+		pod.Status.Conditions = append(pod.Status.Conditions, conditions...)
+		return pod
+	}
+	jobsRunningCondition := newJobsRunningCondition(nodeJobs)
+
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		nodeJobs      map[string]slurmcontrol.SlurmNodeJobs
+		rateLimiter   flowcontrol.RateLimiter
+		wantCondition *corev1.PodCondition
+	}{
+		{
+			name:          "Never had jobs",
+			pod:           newPod(),
+			wantCondition: nil,
+		},
+		{
+			name:     "Jobs started",
+			pod:      newPod(),
+			nodeJobs: map[string]slurmcontrol.SlurmNodeJobs{"foo-0": nodeJobs},
+			wantCondition: &corev1.PodCondition{
+				Type:    slurmconditions.PodConditionJobsRunning,
+				Status:  corev1.ConditionTrue,
+				Reason:  jobsRunningReason,
+				Message: "Running Slurm jobs 1,2 of users alice,bob in partitions debug until 2025-01-01T12:00:00Z",
+			},
+		},
+		{
+			name: "Jobs ended",
+			pod:  newPod(jobsRunningCondition),
+			wantCondition: &corev1.PodCondition{
+				Type:   slurmconditions.PodConditionJobsRunning,
+				Status: corev1.ConditionFalse,
+				Reason: noJobsRunningReason,
+			},
+		},
+		{
+			name:        "Rate limited",
+			pod:         newPod(jobsRunningCondition),
+			rateLimiter: flowcontrol.NewFakeNeverRateLimiter(),
+			wantCondition: &corev1.PodCondition{
+				Type:    slurmconditions.PodConditionJobsRunning,
+				Status:  corev1.ConditionTrue,
+				Reason:  jobsRunningReason,
+				Message: "Running Slurm jobs 1,2 of users alice,bob in partitions debug until 2025-01-01T12:00:00Z",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().
+				WithObjects(nodeset.DeepCopy(), tt.pod.DeepCopy()).
+				WithStatusSubresource(&corev1.Pod{}).
+				Build()
+			r := &NodeSetReconciler{
+				Client: c,
+			}
+			nodeStatus := &slurmcontrol.SlurmNodeStatus{
+				NodeJobs: tt.nodeJobs,
+			}
+			if tt.rateLimiter != nil {
+				defer func(rateLimiter flowcontrol.RateLimiter) {
+					jobOccupancyRateLimiter = rateLimiter
+				}(jobOccupancyRateLimiter)
+				jobOccupancyRateLimiter = tt.rateLimiter
+			}
+
+			err := r.updateNodeSetPodJobs(ctx, nodeset, []*corev1.Pod{tt.pod}, nodeStatus)
+			require.NoError(t, err)
+
+			gotPod := &corev1.Pod{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tt.pod), gotPod))
+			_, gotCondition := podutil.GetPodCondition(&gotPod.Status, slurmconditions.PodConditionJobsRunning)
+			if tt.wantCondition == nil {
+				require.Nil(t, gotCondition)
+				return
+			}
+			require.NotNil(t, gotCondition)
+			require.Equal(t, tt.wantCondition.Status, gotCondition.Status)
+			require.Equal(t, tt.wantCondition.Reason, gotCondition.Reason)
+			require.Equal(t, tt.wantCondition.Message, gotCondition.Message)
+		})
+	}
+}
+
+func Test_newJobsRunningCondition(t *testing.T) {
+	tests := []struct {
+		name        string
+		nodeJobs    slurmcontrol.SlurmNodeJobs
+		wantStatus  corev1.ConditionStatus
+		wantMessage string
+	}{
+		{
+			name:       "No jobs",
+			nodeJobs:   slurmcontrol.SlurmNodeJobs{},
+			wantStatus: corev1.ConditionFalse,
+		},
+		{
+			name: "Unlimited jobs",
+			nodeJobs: slurmcontrol.SlurmNodeJobs{
+				JobIDs:     []int32{3},
+				Users:      []string{"alice"},
+				Partitions: []string{"debug", "gpu"},
+			},
+			wantStatus:  corev1.ConditionTrue,
+			wantMessage: "Running Slurm jobs 3 of users alice in partitions debug,gpu",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newJobsRunningCondition(tt.nodeJobs)
+			require.Equal(t, tt.wantStatus, got.Status)
+			require.Equal(t, tt.wantMessage, got.Message)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+)
+
+const (
+	powerSaveActionResume  = "resume"
+	powerSaveActionSuspend = "suspend"
+
+	// powerSaveMaxBodySize limits the request body of the power saving endpoint.
+	powerSaveMaxBodySize = 1 << 20
+)
+
+// syncPowerSavingPods handles NodeSet pod creation and deletion in accordance
+// with the power saving state of the Slurm nodes, instead of the replica count.
+// The replicas are the pool of Slurm CLOUD nodes which may be resumed.
+// Returns true if pods were scaled.
+func (r *NodeSetReconciler) syncPowerSavingPods(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	hash string,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	replicas := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+	nodeNames := make([]string, 0, replicas)
+	for ordinal := range replicas {
+		nodeNames = append(nodeNames, common.GetSlurmNodeNameForOrdinal(nodeset, ordinal))
+	}
+
+	powerStates, err := r.slurmControl.GetNodePowerStates(ctx, nodeset, nodeNames)
+	if err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			// Cannot determine which nodes Slurm has resumed or suspended at this time.
+			return false, nil
+		}
+		return false, err
+	}
+
+	usedOrdinals := set.New[int]()
+	podsToKeep := make([]*corev1.Pod, 0, len(pods))
+	podsToDelete := make([]*corev1.Pod, 0)
+	for _, pod := range pods {
+		ordinal := nodesetutils.GetOrdinal(pod)
+		usedOrdinals.Insert(ordinal)
+		powerState := powerStates[nodesetutils.GetSlurmNodeName(pod)]
+		if ordinal >= replicas || powerState.IsSuspended() {
+			podsToDelete = append(podsToDelete, pod)
+			continue
+		}
+		podsToKeep = append(podsToKeep, pod)
+	}
+
+	podsToCreate := make([]*corev1.Pod, 0)
+	for ordinal, nodeName := range nodeNames {
+		powerState, ok := powerStates[nodeName]
+		if !ok || powerState.IsSuspended() || usedOrdinals.Has(ordinal) {
+			continue
+		}
+		pod, err := r.newNodeSetPodOrdinal(r.Client, ctx, nodeset, ordinal, hash)
+		if err != nil {
+			return false, err
+		}
+		podsToCreate = append(podsToCreate, pod)
+	}
+
+	if len(podsToCreate) == 0 && len(podsToDelete) == 0 {
+		return false, nil
+	}
+
+	logger.V(2).Info("Scaling NodeSet pods for Slurm power saving",
+		"creating", len(podsToCreate), "deleting", len(podsToDelete))
+	if len(podsToCreate) > 0 {
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, PowerSavingReason, "Resume",
+			"Creating %d Pod(s) for resumed Slurm nodes", len(podsToCreate))
+	}
+	if len(podsToDelete) > 0 {
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, PowerSavingReason, "Suspend",
+			"Deleting %d Pod(s) for suspended Slurm nodes", len(podsToDelete))
+	}
+	return true, r.doPodScale(ctx, nodeset, podsToKeep, podsToDelete, podsToCreate)
+}
+
+// isPodSuspended returns true if Slurm has suspended the Slurm node of the pod.
+func (r *NodeSetReconciler) isPodSuspended(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+) (bool, error) {
+	if !common.IsPowerSavingEnabled(nodeset) {
+		return false, nil
+	}
+	nodeName := nodesetutils.GetSlurmNodeName(pod)
+	powerStates, err := r.slurmControl.GetNodePowerStates(ctx, nodeset, []string{nodeName})
+	if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+		return false, err
+	}
+	return powerStates[nodeName].IsSuspended(), nil
+}
+
+// PowerSaveHandler returns the handler of the power saving endpoint, which is
+// notified by the Slurm ResumeProgram and SuspendProgram.
+//
+// The endpoint only requests the power saving NodeSets to be reconciled, the
+// power state of the Slurm nodes is always observed from Slurm. Requests must
+// present a Slurm JWT of the SlurmUser, signed by the Controller of the
+// NodeSets, and only the NodeSets of that Controller are reconciled.
+func (r *NodeSetReconciler) PowerSaveHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /powersave/{action}", r.handlePowerSave)
+	return mux
+}
+
+func (r *NodeSetReconciler) handlePowerSave(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logger := log.FromContext(ctx)
+
+	action := req.PathValue("action")
+	if action != powerSaveActionResume && action != powerSaveActionSuspend {
+		http.NotFound(w, req)
+		return
+	}
+
+	authToken, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || authToken == "" {
+		http.Error(w, "missing Slurm auth token", http.StatusUnauthorized)
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, powerSaveMaxBodySize)
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	logger.V(1).Info("Received power saving request", "action", action, "nodes", req.PostForm.Get("nodes"))
+
+	nodesetList := &slinkyv1beta1.NodeSetList{}
+	if err := r.List(ctx, nodesetList); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	authorized := false
+	verified := make(map[types.NamespacedName]bool)
+	for i := range nodesetList.Items {
+		nodeset := &nodesetList.Items[i]
+		if !common.IsPowerSavingEnabled(nodeset) {
+			continue
+		}
+		controllerKey := types.NamespacedName{Namespace: nodeset.Namespace, Name: nodeset.Spec.ControllerRef.Name}
+		ok, checked := verified[controllerKey]
+		if !checked {
+			ok = r.verifyPowerSaveToken(ctx, nodeset, authToken)
+			verified[controllerKey] = ok
+		}
+		if !ok {
+			continue
+		}
+		authorized = true
+		select {
+		case r.powerSaveEvents <- event.GenericEvent{Object: nodeset}:
+		default:
+			logger.V(1).Info("Dropped power saving request, queue is full", "nodeset", klog.KObj(nodeset))
+		}
+	}
+	if !authorized {
+		http.Error(w, "invalid Slurm auth token", http.StatusUnauthorized)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verifyPowerSaveToken returns true if the Slurm JWT is of the SlurmUser and
+// signed by the Controller of the NodeSet.
+func (r *NodeSetReconciler) verifyPowerSaveToken(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	authToken string,
+) bool {
+	logger := log.FromContext(ctx)
+
+	controller, err := r.refResolver.GetController(ctx, nodeset.Spec.ControllerRef, nodeset.Namespace)
+	if err != nil {
+		logger.V(1).Info("Failed to get Controller for power saving request", "nodeset", klog.KObj(nodeset), "err", err)
+		return false
+	}
+	signingKey, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthJwtRef(), controller.Namespace)
+	if err != nil {
+		logger.V(1).Info("Failed to get JWT key for power saving request", "controller", klog.KObj(controller), "err", err)
+		return false
+	}
+	claims, err := slurmjwt.ParseTokenClaims(authToken, signingKey)
+	if err != nil {
+		return false
+	}
+	return claims["sun"] == common.SlurmUser
+}
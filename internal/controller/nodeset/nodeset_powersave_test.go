@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func TestNodeSetReconciler_syncPowerSavingPods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	newPowerSavingNodeSet := func(replicas int32) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, replicas)
+		nodeset.Spec.Partition.Enabled = true
+		nodeset.Spec.PowerSaving.Enabled = true
+		return nodeset
+	}
+	newSlurmNode := func(name string, states ...slurmapi.V0044NodeState) slurmtypes.V0044Node {
+		return slurmtypes.V0044Node{
+			V0044Node: slurmapi.V0044Node{
+				Name:  ptr.To(name),
+				State: ptr.To(states),
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		nodeset    *slinkyv1beta1.NodeSet
+		pods       func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod
+		clientMap  *clientmap.ClientMap
+		wantScaled bool
+		wantPods   []string
+	}{
+		{
+			name:    "No client",
+			nodeset: newPowerSavingNodeSet(2),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return nil
+			},
+			clientMap:  clientmap.NewClientMap(),
+			wantScaled: false,
+			wantPods:   []string{},
+		},
+		{
+			name:    "Create pods for resumed nodes",
+			nodeset: newPowerSavingNodeSet(3),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return nil
+			},
+			clientMap: newClientMap(controller.Name, newFakeClientList(sinterceptor.Funcs{}, &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					newSlurmNode("foo-0", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStatePOWERINGUP),
+					newSlurmNode("foo-1", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStatePOWEREDDOWN),
+				},
+			})),
+			wantScaled: true,
+			wantPods:   []string{"foo-0"},
+		},
+		{
+			name:    "Delete pods for suspended nodes",
+			nodeset: newPowerSavingNodeSet(2),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")),
+					makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")),
+				}
+			},
+			clientMap: newClientMap(controller.Name, newFakeClientList(sinterceptor.Funcs{}, &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					newSlurmNode("foo-0", slurmapi.V0044NodeStateIDLE),
+					newSlurmNode("foo-1", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStatePOWERINGDOWN),
+				},
+			})),
+			wantScaled: true,
+			wantPods:   []string{"foo-0"},
+		},
+		{
+			name:    "Nothing to do",
+			nodeset: newPowerSavingNodeSet(2),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")),
+				}
+			},
+			clientMap: newClientMap(controller.Name, newFakeClientList(sinterceptor.Funcs{}, &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					newSlurmNode("foo-0", slurmapi.V0044NodeStateALLOCATED),
+					newSlurmNode("foo-1", slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStatePOWEREDDOWN),
+				},
+			})),
+			wantScaled: false,
+			wantPods:   []string{"foo-0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pods := tt.pods(tt.nodeset)
+			objs := []runtime.Object{tt.nodeset.DeepCopy(), controller.DeepCopy()}
+			for _, pod := range pods {
+				objs = append(objs, pod.DeepCopy())
+			}
+			c := fake.NewFakeClient(objs...)
+			r := newNodeSetController(c, tt.clientMap)
+
+			gotScaled, err := r.syncPowerSavingPods(ctx, tt.nodeset.DeepCopy(), pods, "")
+			require.NoError(t, err)
+			require.Equal(t, tt.wantScaled, gotScaled)
+
+			podList := &corev1.PodList{}
+			require.NoError(t, c.List(ctx, podList))
+			gotPods := []string{}
+			for _, pod := range podList.Items {
+				gotPods = append(gotPods, pod.Name)
+			}
+			sort.Strings(gotPods)
+			require.Equal(t, tt.wantPods, gotPods)
+		})
+	}
+}
+
+func TestNodeSetReconciler_PowerSaveHandler(t *testing.T) {
+	slurmKeyRef := testutils.NewSlurmKeyRef("slurm")
+	jwtKeyRef := testutils.NewJwtKeyRef("slurm")
+	jwtKeySecret := testutils.NewJwtKeySecret(jwtKeyRef)
+	controller := testutils.NewController("slurm", slurmKeyRef, jwtKeyRef, nil)
+	nodeset := newNodeSet("foo", controller.Name, 2)
+	nodeset.Spec.PowerSaving.Enabled = true
+	other := newNodeSet("bar", controller.Name, 2)
+
+	newAuthToken := func(signingKey []byte, username string) string {
+		authToken, err := slurmjwt.NewToken(signingKey).WithUsername(username).NewSignedToken()
+		require.NoError(t, err)
+		return authToken
+	}
+	authToken := newAuthToken(jwtKeySecret.Data[jwtKeyRef.Key], common.SlurmUser)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		authToken  string
+		wantStatus int
+		wantEvents []string
+	}{
+		{
+			name:       "Resume",
+			method:     http.MethodPost,
+			path:       "/powersave/resume",
+			authToken:  authToken,
+			wantStatus: http.StatusAccepted,
+			wantEvents: []string{"foo"},
+		},
+		{
+			name:       "Suspend",
+			method:     http.MethodPost,
+			path:       "/powersave/suspend",
+			authToken:  authToken,
+			wantStatus: http.StatusAccepted,
+			wantEvents: []string{"foo"},
+		},
+		{
+			name:       "Missing auth token",
+			method:     http.MethodPost,
+			path:       "/powersave/resume",
+			wantStatus: http.StatusUnauthorized,
+			wantEvents: []string{},
+		},
+		{
+			name:       "Auth token of another key",
+			method:     http.MethodPost,
+			path:       "/powersave/resume",
+			authToken:  newAuthToken([]byte("other.key"), common.SlurmUser),
+			wantStatus: http.StatusUnauthorized,
+			wantEvents: []string{},
+		},
+		{
+			name:       "Auth token of another user",
+			method:     http.MethodPost,
+			path:       "/powersave/resume",
+			authToken:  newAuthToken(jwtKeySecret.Data[jwtKeyRef.Key], "alice"),
+			wantStatus: http.StatusUnauthorized,
+			wantEvents: []string{},
+		},
+		{
+			name:       "Unknown action",
+			method:     http.MethodPost,
+			path:       "/powersave/foo",
+			authToken:  authToken,
+			wantStatus: http.StatusNotFound,
+			wantEvents: []string{},
+		},
+		{
+			name:       "Wrong method",
+			method:     http.MethodGet,
+			path:       "/powersave/resume",
+			authToken:  authToken,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantEvents: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClient(controller.DeepCopy(), jwtKeySecret.DeepCopy(), nodeset.DeepCopy(), other.DeepCopy())
+			r := newNodeSetController(c, clientmap.NewClientMap())
+			r.powerSaveEvents = make(chan event.GenericEvent, 10)
+
+			form := url.Values{"nodes": []string{"foo-[0-1]"}}
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.authToken != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.authToken)
+			}
+			rec := httptest.NewRecorder()
+			r.PowerSaveHandler().ServeHTTP(rec, req)
+			require.Equal(t, tt.wantStatus, rec.Code)
+
+			close(r.powerSaveEvents)
+			gotEvents := []string{}
+			for evt := range r.powerSaveEvents {
+				gotEvents = append(gotEvents, evt.Object.GetName())
+			}
+			require.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// syncReboot honors Slurm node reboot requests (e.g. `scontrol reboot`), as
+// slurmd cannot reboot its container. The pod of a Slurm node with a pending
+// reboot is deleted, such that it is recreated, once the reboot was issued by
+// Slurm or the Slurm node has no running jobs. Once the replacement pod is ready,
+// its Slurm node is returned to service with the next state and reason of the
+// reboot request.
+//
+// The deleted pod is recorded on the Slurm node, such that its replacement is
+// not deleted again.
+func (r *NodeSetReconciler) syncReboot(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+
+	syncRebootFn := func(i int) error {
+		pod := pods[i]
+
+		if !slurmconditions.IsNodeRebootPending(&pod.Status) || podutils.IsTerminating(pod) {
+			return nil
+		}
+
+		rebootPodUID, err := r.slurmControl.GetNodeRebootPod(ctx, nodeset, pod)
+		if err != nil {
+			if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+				return nil
+			}
+			return err
+		}
+
+		if rebootPodUID != "" && rebootPodUID != string(pod.UID) {
+			// The pod has replaced the one deleted for the reboot.
+			if !podutils.IsRunningAndReady(pod) ||
+				slurmconditions.IsConditionTrue(&pod.Status, slurmconditions.PodConditionNotResponding) {
+				return nil
+			}
+			logger.Info("Pod was replaced for Slurm node reboot, returning Slurm node to service",
+				"pod", klog.KObj(pod))
+			r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeNormal, SlurmNodeRebootReason, "Resume",
+				"Returning Slurm node %s to service: Pod %s was replaced for its reboot",
+				nodesetutils.GetSlurmNodeName(pod), klog.KObj(pod))
+			if err := r.slurmControl.MakeNodeRebooted(ctx, nodeset, pod); err != nil &&
+				!errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+				return err
+			}
+			return nil
+		}
+
+		isIssued := slurmconditions.IsConditionTrue(&pod.Status, slurmconditions.PodConditionRebootIssued)
+		if !isIssued && slurmconditions.IsNodeBusy(&pod.Status) {
+			// Wait for the running jobs to complete, as Slurm would.
+			return nil
+		}
+
+		logger.Info("Slurm node reboot is pending, replacing pod",
+			"pod", klog.KObj(pod), "rebootIssued", isIssued)
+		r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeNormal, SlurmNodeRebootReason, "Delete",
+			"Replacing Pod %s: Slurm node %s has a pending reboot",
+			klog.KObj(pod), nodesetutils.GetSlurmNodeName(pod))
+		if err := r.slurmControl.SetNodeRebootPod(ctx, nodeset, pod); err != nil {
+			if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+				return nil
+			}
+			return err
+		}
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		return nil
+	}
+	if _, err := utils.SlowStartBatch(len(pods), utils.SlowStartInitialBatchSize, syncRebootFn); err != nil {
+		return err
+	}
+
+	return nil
+}
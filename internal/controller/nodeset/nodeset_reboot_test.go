@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	sinterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podinfo"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func TestNodeSetReconciler_syncReboot(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	newPod := func(conditionTypes ...corev1.PodConditionType) *corev1.Pod {
+		pod := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, ""))
+		pod.UID = "uid-new"
+		for _, conditionType := range conditionTypes {
+			pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+				Type:   conditionType,
+				Status: corev1.ConditionTrue,
+			})
+		}
+		return pod
+	}
+	newSlurmNodeList := func(rebootPodUID string, states ...slurmapi.V0044NodeState) *slurmtypes.V0044NodeList {
+		podInfo := podinfo.PodInfo{RebootPodUID: rebootPodUID}
+		node := slurmtypes.V0044Node{V0044Node: slurmapi.V0044Node{
+			Name:  ptr.To("foo-0"),
+			State: ptr.To(states),
+			Extra: ptr.To(podInfo.ToString()),
+			NextStateAfterReboot: ptr.To([]slurmapi.V0044NodeNextStateAfterReboot{
+				slurmapi.V0044NodeNextStateAfterRebootRESUME,
+			}),
+		}}
+		return &slurmtypes.V0044NodeList{Items: []slurmtypes.V0044Node{node}}
+	}
+
+	tests := []struct {
+		name             string
+		pod              *corev1.Pod
+		slurmNodeList    *slurmtypes.V0044NodeList
+		wantDeleted      bool
+		wantRebootPodUID string
+		wantReboot       bool
+	}{
+		{
+			name:          "No reboot",
+			pod:           newPod(slurmconditions.PodConditionIdle),
+			slurmNodeList: newSlurmNodeList("", slurmapi.V0044NodeStateIDLE),
+		},
+		{
+			name: "Reboot requested with running jobs",
+			pod:  newPod(slurmconditions.PodConditionAllocated, slurmconditions.PodConditionRebootRequested),
+			slurmNodeList: newSlurmNodeList("",
+				slurmapi.V0044NodeStateALLOCATED, slurmapi.V0044NodeStateREBOOTREQUESTED),
+			wantReboot: true,
+		},
+		{
+			name: "Reboot requested without running jobs",
+			pod:  newPod(slurmconditions.PodConditionIdle, slurmconditions.PodConditionRebootRequested),
+			slurmNodeList: newSlurmNodeList("",
+				slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateREBOOTREQUESTED),
+			wantDeleted:      true,
+			wantRebootPodUID: "uid-new",
+			wantReboot:       true,
+		},
+		{
+			name: "Reboot issued",
+			pod:  newPod(slurmconditions.PodConditionMixed, slurmconditions.PodConditionRebootIssued),
+			slurmNodeList: newSlurmNodeList("",
+				slurmapi.V0044NodeStateMIXED, slurmapi.V0044NodeStateREBOOTISSUED),
+			wantDeleted:      true,
+			wantRebootPodUID: "uid-new",
+			wantReboot:       true,
+		},
+		{
+			name: "Replacement pod not responding",
+			pod: newPod(slurmconditions.PodConditionIdle, slurmconditions.PodConditionRebootIssued,
+				slurmconditions.PodConditionNotResponding),
+			slurmNodeList: newSlurmNodeList("uid-old",
+				slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateREBOOTISSUED, slurmapi.V0044NodeStateNOTRESPONDING),
+			wantRebootPodUID: "uid-old",
+			wantReboot:       true,
+		},
+		{
+			name: "Replacement pod ready",
+			pod:  newPod(slurmconditions.PodConditionIdle, slurmconditions.PodConditionRebootIssued),
+			slurmNodeList: newSlurmNodeList("uid-old",
+				slurmapi.V0044NodeStateIDLE, slurmapi.V0044NodeStateREBOOTISSUED),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewFakeClient(nodeset.DeepCopy(), tt.pod.DeepCopy())
+			sclient := newFakeClientList(sinterceptor.Funcs{}, tt.slurmNodeList)
+			r := newNodeSetController(c, newClientMap(controller.Name, sclient))
+
+			err := r.syncReboot(ctx, nodeset, []*corev1.Pod{tt.pod})
+			require.NoError(t, err)
+
+			err = c.Get(ctx, client.ObjectKeyFromObject(tt.pod), &corev1.Pod{})
+			require.Equal(t, tt.wantDeleted, apierrors.IsNotFound(err))
+
+			gotNode := &slurmtypes.V0044Node{}
+			mapKey := types.NamespacedName{
+				Namespace: nodeset.Namespace,
+				Name:      nodeset.Spec.ControllerRef.Name,
+			}
+			sc := r.ClientMap.Get(mapKey)
+			require.NotNil(t, sc)
+			require.NoError(t, sc.Get(ctx, slurmclient.ObjectKey("foo-0"), gotNode))
+			gotPodInfo := podinfo.PodInfo{}
+			require.NoError(t, podinfo.ParseIntoPodInfo(gotNode.Extra, &gotPodInfo))
+			require.Equal(t, tt.wantRebootPodUID, gotPodInfo.RebootPodUID)
+			gotReboot := gotNode.GetStateAsSet().HasAny(slurmapi.V0044NodeStateREBOOTREQUESTED, slurmapi.V0044NodeStateREBOOTISSUED)
+			require.Equal(t, tt.wantReboot, gotReboot)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// remediationPodConditions are the Slurm node states, as pod conditions, which
+// indicate the Slurm node has failed.
+var remediationPodConditions = []corev1.PodConditionType{
+	slurmconditions.PodConditionNotResponding,
+	slurmconditions.PodConditionFail,
+	slurmconditions.PodConditionInvalidReg,
+}
+
+// syncRemediation enforces the NodeSet RemediationPolicy. A pod whose Slurm node
+// has failed for at least the FailedDuration, and has no running jobs, is deleted
+// such that it is recreated. At most MaxRemediations pods are deleted within the
+// RemediationWindow, and deletions on a Kubernetes node are subject to the failed
+// pods backoff.
+//
+// Only the pod is deleted, its PersistentVolumeClaims are left to the
+// PersistentVolumeClaimRetentionPolicy and are reused by the replacement pod.
+func (r *NodeSetReconciler) syncRemediation(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) error {
+	logger := log.FromContext(ctx)
+
+	policy := nodeset.Spec.RemediationPolicy
+	if !policy.Enabled {
+		return nil
+	}
+
+	key := objectutils.KeyFunc(nodeset)
+	now := time.Now()
+
+	for _, pod := range pods {
+		failedState, failedSince, ok := getSlurmNodeFailedSince(pod)
+		if !ok || podutils.IsTerminating(pod) {
+			continue
+		}
+		if slurmconditions.IsNodeBusy(&pod.Status) {
+			// A Slurm node with running jobs is not replaced.
+			continue
+		}
+		if remaining := failedSince.Add(policy.FailedDuration.Duration).Sub(now); remaining > 0 {
+			durationStore.Push(key, remaining)
+			continue
+		}
+
+		status := calculateRemediationStatus(nodeset, now)
+		if status.WindowRemediations >= policy.MaxRemediations {
+			logger.V(1).Info("Pod remediation has been limited by the remediation budget",
+				"pod", klog.KObj(pod), "windowRemediations", status.WindowRemediations,
+				"maxRemediations", policy.MaxRemediations)
+			durationStore.Push(key, status.WindowStartTime.Add(policy.RemediationWindow.Duration).Sub(now))
+			return nil
+		}
+
+		backoffKey := failedPodsBackoffKey(nodeset, pod.Spec.NodeName)
+		backoffNow := failedPodsBackoff.Clock.Now()
+		if failedPodsBackoff.IsInBackOffSinceUpdate(backoffKey, backoffNow) {
+			delay := failedPodsBackoff.Get(backoffKey)
+			logger.V(4).Info("Pod remediation on node has been limited by backoff",
+				"pod", klog.KObj(pod), "node", pod.Spec.NodeName, "currentDelay", delay)
+			r.EnqueueNodeSetAfter(nodeset, delay)
+			continue
+		}
+		failedPodsBackoff.Next(backoffKey, backoffNow)
+
+		logger.Info("Slurm node has persistently failed, replacing pod",
+			"pod", klog.KObj(pod), "state", failedState, "failedSince", failedSince)
+		r.eventRecorder.Eventf(nodeset, pod, corev1.EventTypeWarning, RemediationReason, "Delete",
+			"Replacing Pod %s: Slurm node %s has been %s since %s with no running jobs",
+			klog.KObj(pod), nodesetutils.GetSlurmNodeName(pod), failedState, failedSince.Format(time.RFC3339))
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		status.Remediations++
+		status.WindowRemediations++
+		status.LastRemediationTime = ptr.To(metav1.NewTime(now.Truncate(time.Second)))
+		if err := r.updateRemediationStatus(ctx, nodeset, status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSlurmNodeFailedSince returns the failed Slurm node state of the pod, and
+// since when the Slurm node has been failed.
+func getSlurmNodeFailedSince(pod *corev1.Pod) (string, time.Time, bool) {
+	var failedState string
+	var failedSince time.Time
+	for _, conditionType := range remediationPodConditions {
+		_, condition := podutil.GetPodCondition(&pod.Status, conditionType)
+		if condition == nil || condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		if failedSince.IsZero() || condition.LastTransitionTime.Time.Before(failedSince) {
+			failedState = string(conditionType)[len(slurmconditions.PodStatePrefix):]
+			failedSince = condition.LastTransitionTime.Time
+		}
+	}
+	return failedState, failedSince, failedState != ""
+}
+
+// calculateRemediationStatus returns the RemediationPolicy status of the NodeSet,
+// with a new RemediationWindow if the current one has ended.
+func calculateRemediationStatus(nodeset *slinkyv1beta1.NodeSet, now time.Time) *slinkyv1beta1.NodeSetRemediationStatus {
+	status := &slinkyv1beta1.NodeSetRemediationStatus{}
+	if nodeset.Status.Remediation != nil {
+		status = nodeset.Status.Remediation.DeepCopy()
+	}
+	window := nodeset.Spec.RemediationPolicy.RemediationWindow.Duration
+	if status.WindowStartTime == nil || !now.Before(status.WindowStartTime.Add(window)) {
+		status.WindowStartTime = ptr.To(metav1.NewTime(now.Truncate(time.Second)))
+		status.WindowRemediations = 0
+	}
+	return status
+}
+
+// updateRemediationStatus records the RemediationPolicy status of the NodeSet,
+// such that the remediation budget holds across reconciles.
+func (r *NodeSetReconciler) updateRemediationStatus(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	status *slinkyv1beta1.NodeSetRemediationStatus,
+) error {
+	// Patch a copy, the NodeSet has in-memory defaults which must not be overwritten.
+	toPatch := nodeset.DeepCopy()
+	mutateFn := func(nodeset *slinkyv1beta1.NodeSet) error {
+		nodeset.Status.Remediation = status
+		return nil
+	}
+	if err := objectutils.StatusPatchObject(r.Client, ctx, toPatch, mutateFn); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to update remediation status: %w", err)
+	}
+	nodeset.Status.Remediation = status
+	return nil
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func TestNodeSetReconciler_syncRemediation(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	newRemediationNodeSet := func(remediation *slinkyv1beta1.NodeSetRemediationStatus) *slinkyv1beta1.NodeSet {
+		nodeset := newNodeSet("foo", controller.Name, 1)
+		nodeset.Spec.RemediationPolicy = slinkyv1beta1.NodeSetRemediationPolicy{
+			Enabled:           true,
+			FailedDuration:    metav1.Duration{Duration: 10 * time.Minute},
+			MaxRemediations:   1,
+			RemediationWindow: metav1.Duration{Duration: time.Hour},
+		}
+		nodeset.Status.Remediation = remediation
+		return nodeset
+	}
+	newPod := func(nodeName string, since time.Duration, conditionTypes ...corev1.PodConditionType) *corev1.Pod {
+		pod := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), newNodeSet("foo", controller.Name, 1), controller, 0, ""))
+		pod.Spec.NodeName = nodeName
+		for _, conditionType := range conditionTypes {
+			pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+				Type:               conditionType,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+			})
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name             string
+		nodeset          *slinkyv1beta1.NodeSet
+		pod              *corev1.Pod
+		wantDeleted      bool
+		wantRemediations int32
+	}{
+		{
+			name:    "Disabled",
+			nodeset: newNodeSet("foo", controller.Name, 1),
+			pod:     newPod("node-disabled", time.Hour, slurmconditions.PodConditionDown, slurmconditions.PodConditionNotResponding),
+		},
+		{
+			name:    "Slurm node healthy",
+			nodeset: newRemediationNodeSet(nil),
+			pod:     newPod("node-healthy", time.Hour, slurmconditions.PodConditionIdle),
+		},
+		{
+			name:    "Slurm node failed within FailedDuration",
+			nodeset: newRemediationNodeSet(nil),
+			pod:     newPod("node-recent", time.Minute, slurmconditions.PodConditionDown, slurmconditions.PodConditionNotResponding),
+		},
+		{
+			name:    "Slurm node failed with running jobs",
+			nodeset: newRemediationNodeSet(nil),
+			pod:     newPod("node-busy", time.Hour, slurmconditions.PodConditionAllocated, slurmconditions.PodConditionFail),
+		},
+		{
+			name:             "Slurm node failed after FailedDuration",
+			nodeset:          newRemediationNodeSet(nil),
+			pod:              newPod("node-failed", time.Hour, slurmconditions.PodConditionDown, slurmconditions.PodConditionInvalidReg),
+			wantDeleted:      true,
+			wantRemediations: 1,
+		},
+		{
+			name: "Remediation budget exhausted",
+			nodeset: newRemediationNodeSet(&slinkyv1beta1.NodeSetRemediationStatus{
+				Remediations:       1,
+				WindowRemediations: 1,
+				WindowStartTime:    ptr.To(metav1.NewTime(time.Now().Add(-time.Minute))),
+			}),
+			pod:              newPod("node-budget", time.Hour, slurmconditions.PodConditionDown, slurmconditions.PodConditionNotResponding),
+			wantRemediations: 1,
+		},
+		{
+			name: "Remediation window ended",
+			nodeset: newRemediationNodeSet(&slinkyv1beta1.NodeSetRemediationStatus{
+				Remediations:       1,
+				WindowRemediations: 1,
+				WindowStartTime:    ptr.To(metav1.NewTime(time.Now().Add(-2 * time.Hour))),
+			}),
+			pod:              newPod("node-window", time.Hour, slurmconditions.PodConditionDown, slurmconditions.PodConditionNotResponding),
+			wantDeleted:      true,
+			wantRemediations: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().
+				WithRuntimeObjects(tt.nodeset.DeepCopy(), tt.pod.DeepCopy()).
+				WithStatusSubresource(tt.nodeset).
+				Build()
+			r := newNodeSetController(c, nil)
+
+			nodeset := tt.nodeset.DeepCopy()
+			err := r.syncRemediation(ctx, nodeset, []*corev1.Pod{tt.pod})
+			require.NoError(t, err)
+
+			err = c.Get(ctx, client.ObjectKeyFromObject(tt.pod), &corev1.Pod{})
+			require.Equal(t, tt.wantDeleted, apierrors.IsNotFound(err))
+
+			gotNodeSet := &slinkyv1beta1.NodeSet{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(tt.nodeset), gotNodeSet))
+			var gotRemediations int32
+			if gotNodeSet.Status.Remediation != nil {
+				gotRemediations = gotNodeSet.Status.Remediation.Remediations
+			}
+			require.Equal(t, tt.wantRemediations, gotRemediations)
+			if tt.wantDeleted {
+				require.Equal(t, tt.wantRemediations, nodeset.Status.Remediation.Remediations)
+			}
+		})
+	}
+}
+
+func Test_getSlurmNodeFailedSince(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	newCondition := func(conditionType corev1.PodConditionType, since time.Duration) corev1.PodCondition {
+		return corev1.PodCondition{
+			Type:               conditionType,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now.Add(-since)),
+		}
+	}
+	tests := []struct {
+		name       string
+		conditions []corev1.PodCondition
+		wantState  string
+		wantSince  time.Time
+		wantOk     bool
+	}{
+		{
+			name:       "Not failed",
+			conditions: []corev1.PodCondition{newCondition(slurmconditions.PodConditionIdle, time.Hour)},
+		},
+		{
+			name: "Earliest failed state",
+			conditions: []corev1.PodCondition{
+				newCondition(slurmconditions.PodConditionDown, time.Hour),
+				newCondition(slurmconditions.PodConditionNotResponding, time.Minute),
+				newCondition(slurmconditions.PodConditionFail, time.Hour),
+			},
+			wantState: "Fail",
+			wantSince: now.Add(-time.Hour),
+			wantOk:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Status: corev1.PodStatus{Conditions: tt.conditions}}
+			gotState, gotSince, gotOk := getSlurmNodeFailedSince(pod)
+			require.Equal(t, tt.wantState, gotState)
+			require.True(t, tt.wantSince.Equal(gotSince))
+			require.Equal(t, tt.wantOk, gotOk)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/defaults"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// getMaxSurge returns the number of pods which may be created over the replicas
+// during a rolling update, or zero if the NodeSet does not surge.
+func getMaxSurge(nodeset *slinkyv1beta1.NodeSet) int {
+	if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset || common.IsPowerSavingEnabled(nodeset) {
+		return 0
+	}
+	switch nodeset.Spec.UpdateStrategy.Type {
+	case slinkyv1beta1.ScheduledUpdateNodeSetStrategyType, slinkyv1beta1.OnDeleteNodeSetStrategyType:
+		return 0
+	}
+	replicas := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+	return mathutils.GetScaledValueFromIntOrPercent(nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge, replicas, true, 0)
+}
+
+// getSurgeAllowance returns the number of pods over the replicas which are
+// expected during a rolling update with surge, hence must not be scaled-in.
+// Terminating pods are already leaving and count against the surge, such that
+// the pods never exceed the replicas by more than maxSurge.
+func getSurgeAllowance(nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod, hash string) int {
+	maxSurge := getMaxSurge(nodeset)
+	if maxSurge == 0 {
+		return 0
+	}
+
+	_, oldPods := findUpdatedPods(pods, hash)
+	numTerminating := countTerminatingPods(pods)
+	numSurge := max(min(maxSurge, len(oldPods))-numTerminating, 0)
+
+	return numTerminating + numSurge
+}
+
+// countTerminatingPods returns the number of terminating pods.
+func countTerminatingPods(pods []*corev1.Pod) int {
+	numTerminating := 0
+	for _, pod := range pods {
+		if podutils.IsTerminating(pod) {
+			numTerminating++
+		}
+	}
+	return numTerminating
+}
+
+// syncSurgePods creates updated pods at new ordinals over the replicas, up to
+// maxSurge, such that old pods can be replaced without losing capacity.
+func (r *NodeSetReconciler) syncSurgePods(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	hash string,
+) error {
+	logger := log.FromContext(ctx)
+
+	maxSurge := getMaxSurge(nodeset)
+	if maxSurge == 0 {
+		return nil
+	}
+
+	newPods, oldPods := findUpdatedPods(pods, hash)
+	if eligiblePods, _ := r.splitHeldPods(ctx, nodeset, oldPods); len(eligiblePods) == 0 {
+		return nil
+	}
+
+	replicas := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+	numTerminating := countTerminatingPods(pods)
+	numCreate := min(replicas+maxSurge-len(newPods)-len(oldPods)-numTerminating, replicas-len(newPods))
+	if numCreate <= 0 {
+		return nil
+	}
+
+	podsToCreate, err := r.newNodeSetPodsUnusedOrdinals(ctx, nodeset, pods, numCreate, hash)
+	if err != nil {
+		return err
+	}
+	logger.Info("Surge pods for Rolling Update",
+		"maxSurge", maxSurge, "create", len(podsToCreate))
+	r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, RollingUpdateReason, "RollingUpdate",
+		"Rolling update: creating %d surge pod(s) with updated revision", len(podsToCreate))
+	return r.doPodScale(ctx, nodeset, nil, nil, podsToCreate)
+}
+
+// splitSurgeUpdatePods returns the healthy old pods which have been replaced by
+// available new pods whose Slurm nodes are idle, and the remaining old pods.
+// New pods which already run jobs have been idle before, hence count as well.
+func splitSurgeUpdatePods(
+	nodeset *slinkyv1beta1.NodeSet,
+	newPods, healthyOldPods []*corev1.Pod,
+) (podsToDelete, remainingOldPods []*corev1.Pod) {
+	replicas := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+
+	numIdle := 0
+	now := metav1.Now()
+	for _, pod := range newPods {
+		if podutil.IsPodAvailable(pod, nodeset.Spec.MinReadySeconds, now) &&
+			(slurmconditions.IsNodeIdle(&pod.Status) || isNodeRunningJobs(&pod.Status)) {
+			numIdle++
+		}
+	}
+
+	numDelete := mathutils.Clamp(numIdle+len(healthyOldPods)-replicas, 0, len(healthyOldPods))
+	return nodesetutils.SplitActivePods(healthyOldPods, numDelete)
+}
+
+// isNodeRunningJobs returns true when the registered Slurm node is busy.
+func isNodeRunningJobs(status *corev1.PodStatus) bool {
+	return slurmconditions.IsNodeRegistered(status) && slurmconditions.IsNodeBusy(status)
+}
+
+// calculateSurgeReplicas returns the number of non-terminated pods over the replicas.
+func calculateSurgeReplicas(nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) int32 {
+	if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+		return 0
+	}
+	replicas := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+	numActive := 0
+	for _, pod := range pods {
+		if !podutils.IsTerminating(pod) {
+			numActive++
+		}
+	}
+	return int32(max(numActive-replicas, 0))
+}
@@ -0,0 +1,343 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package nodeset
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	surgeOldHash = "old"
+	surgeNewHash = "new"
+)
+
+func newSurgeNodeSet(controller *slinkyv1beta1.Controller, replicas int32, maxSurge intstr.IntOrString) *slinkyv1beta1.NodeSet {
+	nodeset := newNodeSet("foo", controller.Name, replicas)
+	nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+	nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(maxSurge)
+	return nodeset
+}
+
+func newSurgePod(
+	nodeset *slinkyv1beta1.NodeSet,
+	controller *slinkyv1beta1.Controller,
+	ordinal int,
+	hash string,
+	idle bool,
+) *corev1.Pod {
+	pod := makePodHealthy(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, ordinal, hash))
+	if idle {
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:   slurmconditions.PodConditionIdle,
+			Status: corev1.ConditionTrue,
+		})
+	}
+	return pod
+}
+
+func Test_getMaxSurge(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{ObjectMeta: metav1.ObjectMeta{Name: "slurm"}}
+	tests := []struct {
+		name    string
+		nodeset func() *slinkyv1beta1.NodeSet
+		want    int
+	}{
+		{
+			name: "Unset",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				return newNodeSet("foo", controller.Name, 4)
+			},
+			want: 0,
+		},
+		{
+			name: "Absolute",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				return newSurgeNodeSet(controller, 4, intstr.FromInt32(2))
+			},
+			want: 2,
+		},
+		{
+			name: "Percent rounds up",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				return newSurgeNodeSet(controller, 4, intstr.FromString("30%"))
+			},
+			want: 2,
+		},
+		{
+			name: "DaemonSet mode",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newSurgeNodeSet(controller, 4, intstr.FromInt32(2))
+				nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+				return nodeset
+			},
+			want: 0,
+		},
+		{
+			name: "OnDelete strategy",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newSurgeNodeSet(controller, 4, intstr.FromInt32(2))
+				nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.OnDeleteNodeSetStrategyType
+				return nodeset
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getMaxSurge(tt.nodeset()))
+		})
+	}
+}
+
+func Test_getSurgeAllowance(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{ObjectMeta: metav1.ObjectMeta{Name: "slurm"}}
+	nodeset := newSurgeNodeSet(controller, 2, intstr.FromInt32(1))
+	terminating := newSurgePod(nodeset, controller, 0, surgeOldHash, true)
+	terminating.DeletionTimestamp = ptr.To(metav1.Now())
+	tests := []struct {
+		name string
+		pods []*corev1.Pod
+		want int
+	}{
+		{
+			name: "Surging",
+			pods: []*corev1.Pod{
+				newSurgePod(nodeset, controller, 0, surgeOldHash, true),
+				newSurgePod(nodeset, controller, 1, surgeOldHash, true),
+				newSurgePod(nodeset, controller, 2, surgeNewHash, false),
+			},
+			want: 1,
+		},
+		{
+			name: "Surging with terminating old pod",
+			pods: []*corev1.Pod{
+				terminating,
+				newSurgePod(nodeset, controller, 1, surgeOldHash, true),
+				newSurgePod(nodeset, controller, 2, surgeNewHash, true),
+				newSurgePod(nodeset, controller, 3, surgeNewHash, false),
+			},
+			want: 1,
+		},
+		{
+			name: "Update complete",
+			pods: []*corev1.Pod{
+				newSurgePod(nodeset, controller, 2, surgeNewHash, true),
+				newSurgePod(nodeset, controller, 3, surgeNewHash, true),
+				newSurgePod(nodeset, controller, 4, surgeNewHash, true),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getSurgeAllowance(nodeset, tt.pods, surgeNewHash))
+		})
+	}
+}
+
+func TestNodeSetReconciler_syncSurgePods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	tests := []struct {
+		name     string
+		nodeset  *slinkyv1beta1.NodeSet
+		pods     func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod
+		wantPods []string
+	}{
+		{
+			name:    "No surge",
+			nodeset: newNodeSet("foo", controller.Name, 2),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					newSurgePod(nodeset, controller, 0, surgeOldHash, true),
+					newSurgePod(nodeset, controller, 1, surgeOldHash, true),
+				}
+			},
+			wantPods: []string{"foo-0", "foo-1"},
+		},
+		{
+			name:    "Create surge pods at new ordinals",
+			nodeset: newSurgeNodeSet(controller, 3, intstr.FromInt32(2)),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					newSurgePod(nodeset, controller, 0, surgeOldHash, true),
+					newSurgePod(nodeset, controller, 1, surgeOldHash, true),
+					newSurgePod(nodeset, controller, 2, surgeOldHash, true),
+				}
+			},
+			wantPods: []string{"foo-0", "foo-1", "foo-2", "foo-3", "foo-4"},
+		},
+		{
+			name:    "Surge is exhausted",
+			nodeset: newSurgeNodeSet(controller, 3, intstr.FromInt32(1)),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					newSurgePod(nodeset, controller, 0, surgeOldHash, true),
+					newSurgePod(nodeset, controller, 1, surgeOldHash, true),
+					newSurgePod(nodeset, controller, 2, surgeOldHash, true),
+					newSurgePod(nodeset, controller, 3, surgeNewHash, false),
+				}
+			},
+			wantPods: []string{"foo-0", "foo-1", "foo-2", "foo-3"},
+		},
+		{
+			name:    "Terminating pods count against the surge",
+			nodeset: newSurgeNodeSet(controller, 2, intstr.FromInt32(1)),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				terminating := newSurgePod(nodeset, controller, 0, surgeOldHash, true)
+				terminating.DeletionTimestamp = ptr.To(metav1.Now())
+				terminating.Finalizers = []string{"test"}
+				return []*corev1.Pod{
+					terminating,
+					newSurgePod(nodeset, controller, 1, surgeOldHash, true),
+					newSurgePod(nodeset, controller, 2, surgeNewHash, true),
+				}
+			},
+			wantPods: []string{"foo-0", "foo-1", "foo-2"},
+		},
+		{
+			name:    "Do not surge over the updated replicas",
+			nodeset: newSurgeNodeSet(controller, 2, intstr.FromInt32(2)),
+			pods: func(nodeset *slinkyv1beta1.NodeSet) []*corev1.Pod {
+				return []*corev1.Pod{
+					newSurgePod(nodeset, controller, 1, surgeOldHash, true),
+					newSurgePod(nodeset, controller, 2, surgeNewHash, true),
+				}
+			},
+			wantPods: []string{"foo-0", "foo-1", "foo-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pods := tt.pods(tt.nodeset)
+			objs := []runtime.Object{tt.nodeset.DeepCopy(), controller.DeepCopy()}
+			for _, pod := range pods {
+				objs = append(objs, pod.DeepCopy())
+			}
+			c := fake.NewFakeClient(objs...)
+			r := newNodeSetController(c, clientmap.NewClientMap())
+
+			err := r.syncSurgePods(ctx, tt.nodeset, pods, surgeNewHash)
+			require.NoError(t, err)
+
+			podList := &corev1.PodList{}
+			require.NoError(t, c.List(ctx, podList))
+			gotPods := []string{}
+			for _, pod := range podList.Items {
+				gotPods = append(gotPods, pod.Name)
+			}
+			sort.Strings(gotPods)
+			require.Equal(t, tt.wantPods, gotPods)
+		})
+	}
+}
+
+func Test_splitSurgeUpdatePods(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{ObjectMeta: metav1.ObjectMeta{Name: "slurm"}}
+	nodeset := newSurgeNodeSet(controller, 2, intstr.FromInt32(2))
+	oldPods := func() []*corev1.Pod {
+		return []*corev1.Pod{
+			newSurgePod(nodeset, controller, 0, surgeOldHash, true),
+			newSurgePod(nodeset, controller, 1, surgeOldHash, true),
+		}
+	}
+	tests := []struct {
+		name       string
+		newPods    []*corev1.Pod
+		wantDelete int
+	}{
+		{
+			name:       "Surge pods not idle",
+			newPods:    []*corev1.Pod{newSurgePod(nodeset, controller, 2, surgeNewHash, false), newSurgePod(nodeset, controller, 3, surgeNewHash, false)},
+			wantDelete: 0,
+		},
+		{
+			name:       "One surge pod idle",
+			newPods:    []*corev1.Pod{newSurgePod(nodeset, controller, 2, surgeNewHash, true), newSurgePod(nodeset, controller, 3, surgeNewHash, false)},
+			wantDelete: 1,
+		},
+		{
+			name:       "All surge pods idle",
+			newPods:    []*corev1.Pod{newSurgePod(nodeset, controller, 2, surgeNewHash, true), newSurgePod(nodeset, controller, 3, surgeNewHash, true)},
+			wantDelete: 2,
+		},
+		{
+			name: "Surge pod idle but not responding",
+			newPods: []*corev1.Pod{
+				func() *corev1.Pod {
+					pod := newSurgePod(nodeset, controller, 2, surgeNewHash, true)
+					pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+						Type:   slurmconditions.PodConditionNotResponding,
+						Status: corev1.ConditionTrue,
+					})
+					return pod
+				}(),
+			},
+			wantDelete: 0,
+		},
+		{
+			name: "Surge pod running jobs",
+			newPods: []*corev1.Pod{
+				func() *corev1.Pod {
+					pod := newSurgePod(nodeset, controller, 2, surgeNewHash, false)
+					pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+						Type:   slurmconditions.PodConditionAllocated,
+						Status: corev1.ConditionTrue,
+					})
+					return pod
+				}(),
+			},
+			wantDelete: 1,
+		},
+		{
+			name: "Surge pod not ready",
+			newPods: []*corev1.Pod{
+				makePodRunningNotReady(nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 2, surgeNewHash)),
+			},
+			wantDelete: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podsToDelete, remainingOldPods := splitSurgeUpdatePods(nodeset, tt.newPods, oldPods())
+			require.Len(t, podsToDelete, tt.wantDelete)
+			require.Len(t, remainingOldPods, 2-tt.wantDelete)
+		})
+	}
+}
+
+func Test_calculateSurgeReplicas(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{ObjectMeta: metav1.ObjectMeta{Name: "slurm"}}
+	nodeset := newSurgeNodeSet(controller, 2, intstr.FromInt32(2))
+	terminating := newSurgePod(nodeset, controller, 0, surgeOldHash, true)
+	terminating.DeletionTimestamp = ptr.To(metav1.Now())
+	pods := []*corev1.Pod{
+		terminating,
+		newSurgePod(nodeset, controller, 1, surgeOldHash, true),
+		newSurgePod(nodeset, controller, 2, surgeNewHash, true),
+		newSurgePod(nodeset, controller, 3, surgeNewHash, false),
+	}
+	require.Equal(t, int32(1), calculateSurgeReplicas(nodeset, pods))
+	require.Equal(t, int32(0), calculateSurgeReplicas(nodeset, pods[:2]))
+}
@@ -33,7 +33,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	builder "github.com/SlinkyProject/slurm-operator/internal/builder/workerbuilder"
 	"github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/slurmcontrol"
 	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
@@ -45,6 +47,7 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podcontrol"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/topologyutils"
 )
 
 const (
@@ -95,7 +98,7 @@ func (r *NodeSetReconciler) Sync(ctx context.Context, req reconcile.Request) err
 		return err
 	}
 
-	currentRevision, updateRevision, collisionCount, err := r.getNodeSetRevisions(nodeset, revisions)
+	currentRevision, updateRevision, collisionCount, err := r.getNodeSetRevisions(ctx, nodeset, revisions)
 	if err != nil {
 		return err
 	}
@@ -106,25 +109,31 @@ func (r *NodeSetReconciler) Sync(ctx context.Context, req reconcile.Request) err
 		return err
 	}
 
+	// The Slurm jobs are listed once and shared by the steps which need them.
+	jobs, err := r.slurmControl.ListJobs(ctx, nodeset)
+	if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+		return err
+	}
+
 	if !r.expectations.SatisfiedExpectations(logger, key) || nodeset.DeletionTimestamp != nil {
-		return r.syncStatus(ctx, nodeset, nodesetPods, currentRevision, updateRevision, collisionCount, hash)
+		return r.syncStatus(ctx, nodeset, nodesetPods, jobs, currentRevision, updateRevision, collisionCount, hash)
 	}
 
-	if err := r.sync(ctx, nodeset, nodesetPods, hash); err != nil {
-		return r.syncStatus(ctx, nodeset, nodesetPods, currentRevision, updateRevision, collisionCount, hash, err)
+	if err := r.sync(ctx, nodeset, nodesetPods, jobs, hash); err != nil {
+		return r.syncStatus(ctx, nodeset, nodesetPods, jobs, currentRevision, updateRevision, collisionCount, hash, err)
 	}
 
 	if r.expectations.SatisfiedExpectations(logger, key) {
 		if err := r.syncUpdate(ctx, nodeset, nodesetPods, hash); err != nil {
-			return r.syncStatus(ctx, nodeset, nodesetPods, currentRevision, updateRevision, collisionCount, hash, err)
+			return r.syncStatus(ctx, nodeset, nodesetPods, jobs, currentRevision, updateRevision, collisionCount, hash, err)
 		}
 		if err := r.truncateHistory(ctx, nodeset, revisions, currentRevision, updateRevision); err != nil {
 			err = fmt.Errorf("failed to clean up revisions of NodeSet(%s): %w", klog.KObj(nodeset), err)
-			return r.syncStatus(ctx, nodeset, nodesetPods, currentRevision, updateRevision, collisionCount, hash, err)
+			return r.syncStatus(ctx, nodeset, nodesetPods, jobs, currentRevision, updateRevision, collisionCount, hash, err)
 		}
 	}
 
-	return r.syncStatus(ctx, nodeset, nodesetPods, currentRevision, updateRevision, collisionCount, hash)
+	return r.syncStatus(ctx, nodeset, nodesetPods, jobs, currentRevision, updateRevision, collisionCount, hash)
 }
 
 type SyncFinalizer struct {
@@ -175,6 +184,11 @@ func (r *NodeSetReconciler) syncReservationFinalizer(ctx context.Context, nodese
 		return err
 	}
 	if !reservationExists {
+		// Between maintenance windows of a schedule, the reservation of the next
+		// window has yet to be created, so keep the finalizer.
+		if isScheduledBetweenWindows(nodeset) {
+			return nil
+		}
 		return r.removeReservationFinalizerIfNeeded(ctx, nodeset)
 	}
 
@@ -193,6 +207,15 @@ func (r *NodeSetReconciler) syncReservationFinalizer(ctx context.Context, nodese
 	return nil
 }
 
+// isScheduledBetweenWindows returns true if the NodeSet is not being deleted and
+// still has its ScheduledUpdate strategy recur on a schedule.
+func isScheduledBetweenWindows(nodeset *slinkyv1beta1.NodeSet) bool {
+	return nodeset.DeletionTimestamp.IsZero() &&
+		nodeset.Spec.UpdateStrategy.Type == slinkyv1beta1.ScheduledUpdateNodeSetStrategyType &&
+		nodeset.Spec.UpdateStrategy.ScheduledUpdate.Schedule != "" &&
+		ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas) > 0
+}
+
 func (r *NodeSetReconciler) addReservationFinalizerIfNeeded(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
 	if controllerutil.ContainsFinalizer(nodeset, slinkyv1beta1.FinalizerNodeSetReservation) {
 		return nil
@@ -355,6 +378,7 @@ func (r *NodeSetReconciler) sync(
 	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
 	pods []*corev1.Pod,
+	jobs *slurmcontrol.SlurmJobs,
 	hash string,
 ) error {
 	steps := []syncsteps.Step[*slinkyv1beta1.NodeSet]{
@@ -396,12 +420,48 @@ func (r *NodeSetReconciler) sync(
 				return r.syncSlurmDeadline(ctx, nodeset, pods)
 			},
 		},
+		{
+			Name: "NodeHealth",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncNodeHealth(ctx, nodeset, pods)
+			},
+		},
 		{
 			Name: "Cordon",
 			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
 				return r.syncCordon(ctx, nodeset, pods)
 			},
 		},
+		{
+			Name: "ConditionDrain",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncConditionDrain(ctx, nodeset, pods)
+			},
+		},
+		{
+			Name: "DrainPolicy",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncDrainPolicy(ctx, nodeset, pods)
+			},
+		},
+		{
+			Name: "Remediation",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncRemediation(ctx, nodeset, pods)
+			},
+		},
+		{
+			Name: "Reboot",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncReboot(ctx, nodeset, pods)
+			},
+		},
+		{
+			Name: "Autoscale",
+			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
+				return r.syncAutoscale(ctx, nodeset, pods, jobs)
+			},
+		},
 		{
 			Name: "NodeSetPods",
 			SyncFn: func(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error {
@@ -516,7 +576,8 @@ func (r *NodeSetReconciler) syncCordon(
 			return err
 		}
 
-		nodeIsCordoned := node.Spec.Unschedulable
+		// A cordon by the NodeHealthPolicy reflects the Slurm node, it is not propagated back into Slurm.
+		nodeIsCordoned := node.Spec.Unschedulable && !isNodeCordonedForSlurmUnhealthy(node)
 		podIsCordoned := podutils.IsPodCordon(pod)
 		slurmNodeIsUnresponsive, err := r.slurmControl.IsNodeDownForUnresponsive(ctx, nodeset, pod)
 		if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
@@ -585,6 +646,10 @@ func (r *NodeSetReconciler) syncCordon(
 				return err
 			}
 
+		// If Slurm node is drained by the ConditionDrainPolicy, leave it to syncConditionDrain
+		case isPodConditionDrain(pod):
+			return nil
+
 		// If pod is uncordoned, undrain the Slurm node
 		case !podIsCordoned:
 			reason := fmt.Sprintf("Pod (%s) was uncordoned", klog.KObj(pod))
@@ -602,11 +667,15 @@ func (r *NodeSetReconciler) syncCordon(
 	return nil
 }
 
-// syncSlurmNodeRecords prunes Slurm node records under certain conditions.
+// syncSlurmNodeRecords pre-registers and prunes Slurm node records under certain conditions.
 func (r *NodeSetReconciler) syncSlurmNodeRecords(
 	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
 ) error {
+	if err := r.syncSlurmNodeRecordsPreRegister(ctx, nodeset); err != nil {
+		return err
+	}
+
 	switch nodeset.Spec.PruneSlurmNodeRecords {
 	default:
 		fallthrough
@@ -617,6 +686,81 @@ func (r *NodeSetReconciler) syncSlurmNodeRecords(
 	}
 }
 
+// isPreRegisterEnabled returns true if the NodeSet Slurm nodes are pre-registered as FUTURE nodes.
+func isPreRegisterEnabled(nodeset *slinkyv1beta1.NodeSet) bool {
+	return nodeset.Spec.PreRegisterSlurmNodes &&
+		nodeset.Spec.ScalingMode != slinkyv1beta1.ScalingModeDaemonset &&
+		!common.IsPowerSavingEnabled(nodeset)
+}
+
+// syncSlurmNodeRecordsPreRegister creates FUTURE Slurm node records for the
+// NodeSet replicas, such that Slurm can plan jobs against them before their
+// pods exist, and deletes the FUTURE Slurm node records beyond the replicas.
+// Slurm nodes are read from the node cache refreshed by RefreshNodeCache.
+func (r *NodeSetReconciler) syncSlurmNodeRecordsPreRegister(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+) error {
+	logger := log.FromContext(ctx)
+
+	if !isPreRegisterEnabled(nodeset) {
+		return nil
+	}
+
+	replicas := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
+	nodeNames := make([]string, 0, replicas)
+	for ordinal := range replicas {
+		nodeNames = append(nodeNames, common.GetSlurmNodeNameForOrdinal(nodeset, ordinal))
+	}
+
+	futureNodes, err := r.slurmControl.GetFutureNodesForNodeSet(ctx, nodeset)
+	if err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return nil
+		}
+		return err
+	}
+	nodeNameSet := set.New(nodeNames...)
+	condemnedNodes := make([]string, 0)
+	for _, nodeName := range futureNodes {
+		if !nodeNameSet.Has(nodeName) {
+			condemnedNodes = append(condemnedNodes, nodeName)
+		}
+	}
+
+	createdNodes, err := r.slurmControl.CreateFutureNodes(ctx, nodeset, nodeNames, builder.SlurmdNodeConf(nodeset))
+	if len(createdNodes) > 0 {
+		logger.V(1).Info("Pre-registered FUTURE Slurm nodes", "nodes", createdNodes)
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, SlurmNodePreRegisteredReason, "Create",
+			"Pre-registered %d FUTURE Slurm node(s)", len(createdNodes))
+	}
+	if err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return nil
+		}
+		return err
+	}
+
+	syncSlurmNodeRecordsFn := func(i int) error {
+		nodeName := condemnedNodes[i]
+		logger.V(1).Info("Deleting pre-registered FUTURE Slurm node beyond replicas", "slurmNode", nodeName)
+		if err := r.slurmControl.DeleteNode(ctx, nodeset, nodeName); err != nil {
+			return fmt.Errorf("failed to delete FUTURE Slurm node %s: %w", nodeName, err)
+		}
+		return nil
+	}
+	deleted, err := utils.SlowStartBatch(len(condemnedNodes), utils.SlowStartInitialBatchSize, syncSlurmNodeRecordsFn)
+	if deleted > 0 {
+		r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, SlurmNodePreRegisteredReason, "Delete",
+			"Deleted %d FUTURE Slurm node(s) beyond replicas", deleted)
+	}
+	if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+		return err
+	}
+
+	return nil
+}
+
 // syncSlurmNodeRecordsNodeNotFound handles Slurm node record pruning for NodeNotFound.
 func (r *NodeSetReconciler) syncSlurmNodeRecordsNodeNotFound(
 	ctx context.Context,
@@ -780,12 +924,24 @@ func (r *NodeSetReconciler) syncSlurmDeadline(
 	return nil
 }
 
-// syncSlurmTopology handles the Slurm Node's topology.
+// syncSlurmTopology handles the Slurm Node's topology. The topology line is
+// taken from the Kubernetes node annotation, else generated from the node
+// labels by the Controller's topology.
 func (r *NodeSetReconciler) syncSlurmTopology(
 	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
 	pods []*corev1.Pod,
 ) error {
+	var topology slinkyv1beta1.ControllerTopology
+	controller, err := r.refResolver.GetController(ctx, nodeset.Spec.ControllerRef, nodeset.Namespace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		topology = controller.Spec.Topology
+	}
+
 	syncSlurmTopologyFn := func(i int) error {
 		pod := pods[i]
 
@@ -803,7 +959,7 @@ func (r *NodeSetReconciler) syncSlurmTopology(
 			return err
 		}
 
-		topologySpec := node.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+		topologySpec := topologyutils.GetNodeTopologySpec(topology, node)
 		mutateFn := func(pod *corev1.Pod) error {
 			pod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec] = topologySpec
 			return nil
@@ -829,16 +985,17 @@ func (r *NodeSetReconciler) syncSlurmTopology(
 }
 
 // syncSlurmFeatures reconciles the NodeFeaturePrefix-namespaced Slurm node features of
-// each pod from its K8s Node's AnnotationNodeFeaturesSpec. The operator owns only
-// that namespace: it replaces the prefixed features with the (prefixed) annotation
+// each pod from its K8s Node's AnnotationNodeFeaturesSpec, merged with the features
+// mapped from the Node labels by the NodeSet FeatureLabels. The operator owns only
+// that namespace: it replaces the prefixed features with the (prefixed) desired
 // values and preserves all other features, including the NodeSet baseline (seeded
 // at slurmd registration via --conf), ExtraConf features, and externally-managed
-// features such as those from NodeFeaturesPlugins. Removing the annotation clears
-// the node's prefixed features.
+// features such as those from NodeFeaturesPlugins. Removing the annotation and
+// the matching labels clears the node's prefixed features.
 //
 // Features are applied through the reconcile loop and are eventually consistent: a
-// Node annotation change re-enqueues the NodeSet, after which the prefixed features
-// are reconciled to match.
+// Node annotation or label change re-enqueues the NodeSet, after which the prefixed
+// features are reconciled to match.
 func (r *NodeSetReconciler) syncSlurmFeatures(
 	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
@@ -861,10 +1018,13 @@ func (r *NodeSetReconciler) syncSlurmFeatures(
 			return err
 		}
 
-		// An absent annotation yields an empty feature set, which clears any
-		// previously applied prefixed features on the Slurm node.
+		// An absent annotation and no matching feature labels yield an empty
+		// feature set, which clears any previously applied prefixed features
+		// on the Slurm node.
 		annotation := node.Annotations[slinkyv1beta1.AnnotationNodeFeaturesSpec]
-		features := structutils.SortedDedup(strings.Split(annotation, ","))
+		features := strings.Split(annotation, ",")
+		features = append(features, builder.GetNodeLabelFeatures(nodeset, node)...)
+		features = structutils.SortedDedup(features)
 
 		if err := r.slurmControl.UpdateNodeFeatures(ctx, nodeset, pod, slinkyv1beta1.NodeFeaturePrefix, features); err != nil &&
 			!errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
@@ -1081,6 +1241,10 @@ func (r *NodeSetReconciler) syncNodeSetPods(
 			}
 			podsToCreate[i] = pod
 		}
+		podsToCreate, err := r.holdNewPods(ctx, nodeset, podsToCreate, hash)
+		if err != nil {
+			return err
+		}
 		if len(podsToDelete) > 0 || len(podsToCreate) > 0 {
 			if len(podsToCreate) > 0 {
 				r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingUpReason, "ScaleUp",
@@ -1095,6 +1259,14 @@ func (r *NodeSetReconciler) syncNodeSetPods(
 	} else {
 		logger.V(2).Info("Processing NodeSet pods in StatefulSet mode")
 
+		if common.IsPowerSavingEnabled(nodeset) {
+			if scaled, err := r.syncPowerSavingPods(ctx, nodeset, podsNewScaling, hash); scaled || err != nil {
+				return err
+			}
+			logger.V(2).Info("Processing NodeSet pods", "number of pods to process", len(podsNewScaling), "number of pods to delete", len(podsOldScaling))
+			return r.doPodProcessing(ctx, nodeset, podsNewScaling, podsOldScaling, hash)
+		}
+
 		// Handle replica scaling by comparing the known pods to the target number of replicas.
 		// Create or delete pods as needed to reach the target number.
 		replicaCount := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
@@ -1102,28 +1274,21 @@ func (r *NodeSetReconciler) syncNodeSetPods(
 		if diff < 0 {
 			diff = -diff
 
-			podsToCreate := make([]*corev1.Pod, diff)
-			usedOrdinals := set.New[int]()
-			for _, pod := range pods {
-				usedOrdinals.Insert(nodesetutils.GetOrdinal(pod))
+			podsToCreate, err := r.newNodeSetPodsUnusedOrdinals(ctx, nodeset, pods, diff, hash)
+			if err != nil {
+				return err
 			}
-			ordinal := 0
-			for i := range diff {
-				for usedOrdinals.Has(ordinal) {
-					ordinal++
-				}
-				pod, err := r.newNodeSetPodOrdinal(r.Client, ctx, nodeset, ordinal, hash)
-				if err != nil {
-					return err
-				}
-				usedOrdinals.Insert(ordinal)
-				podsToCreate[i] = pod
+			podsToCreate, err = r.holdNewPods(ctx, nodeset, podsToCreate, hash)
+			if err != nil {
+				return err
 			}
 			logger.V(2).Info("Too few NodeSet pods", "need", replicaCount, "creating", diff)
 			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingUpReason, "ScaleUp",
 				"Creating %d Pod(s) to stabilize at %d replicas", diff, replicaCount)
 			return r.doPodScale(ctx, nodeset, podsNewScaling, nil, podsToCreate)
 		}
+		// Surge pods of a rolling update are expected over the replica count.
+		diff -= getSurgeAllowance(nodeset, podsNewScaling, hash)
 		if diff > 0 {
 			logger.V(2).Info("Too many NodeSet pods", "need", replicaCount, "deleting", diff)
 			r.eventRecorder.Eventf(nodeset, nil, corev1.EventTypeNormal, ScalingDownReason, "ScaleDown",
@@ -1334,6 +1499,34 @@ func (r *NodeSetReconciler) newNodeSetPodOrdinal(
 	return pod, nil
 }
 
+// newNodeSetPodsUnusedOrdinals returns count new pods at the lowest ordinals not used by pods.
+func (r *NodeSetReconciler) newNodeSetPodsUnusedOrdinals(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+	count int,
+	revisionHash string,
+) ([]*corev1.Pod, error) {
+	podsToCreate := make([]*corev1.Pod, count)
+	usedOrdinals := set.New[int]()
+	for _, pod := range pods {
+		usedOrdinals.Insert(nodesetutils.GetOrdinal(pod))
+	}
+	ordinal := 0
+	for i := range count {
+		for usedOrdinals.Has(ordinal) {
+			ordinal++
+		}
+		pod, err := r.newNodeSetPodOrdinal(r.Client, ctx, nodeset, ordinal, revisionHash)
+		if err != nil {
+			return nil, err
+		}
+		usedOrdinals.Insert(ordinal)
+		podsToCreate[i] = pod
+	}
+	return podsToCreate, nil
+}
+
 func getPodKeys(pods []*corev1.Pod) []string {
 	podKeys := make([]string, 0, len(pods))
 	for _, pod := range pods {
@@ -1363,6 +1556,22 @@ func (r *NodeSetReconciler) processCondemned(
 		return nil
 	}
 
+	// A suspended Slurm node is idle and must not be drained, otherwise Slurm
+	// will not resume it again.
+	isSuspended, err := r.isPodSuspended(ctx, nodeset, pod)
+	if err != nil {
+		return err
+	}
+	if isSuspended {
+		logger.V(2).Info("NodeSet Pod is terminating, Slurm node was suspended")
+		if err := r.podControl.DeleteNodeSetPod(ctx, nodeset, pod); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
 	isDrained, err := r.slurmControl.IsNodeDrained(ctx, nodeset, pod)
 	if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
 		return err
@@ -1548,6 +1757,8 @@ func (r *NodeSetReconciler) makePodUncordon(ctx context.Context, pod *corev1.Pod
 	logger.Info("Uncordon Pod", "Pod", klog.KObj(pod))
 	mutateFn := func(pod *corev1.Pod) error {
 		delete(pod.Annotations, slinkyv1beta1.AnnotationPodCordon)
+		delete(pod.Annotations, slinkyv1beta1.AnnotationPodDrainStart)
+		delete(pod.Annotations, slinkyv1beta1.AnnotationPodDrainEscalated)
 		return nil
 	}
 	if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
@@ -1568,6 +1779,12 @@ func (r *NodeSetReconciler) syncPodUncordon(ctx context.Context, nodeset *slinky
 		return nil // Skip
 	}
 
+	// Slurm node may have been drained by the ConditionDrainPolicy
+	if isPodConditionDrain(pod) {
+		logger.V(1).Info("Skipping uncordon for pod which is drained by node conditions")
+		return nil // Skip
+	}
+
 	// Slurm node may have been externally set in down, drain, fail, etc...
 	if ok, err := r.slurmControl.IsNodeReasonOurs(ctx, nodeset, pod); err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
 		return err
@@ -1597,6 +1814,11 @@ func (r *NodeSetReconciler) syncUpdate(
 	pods []*corev1.Pod,
 	hash string,
 ) error {
+	// Slurm-only changes are applied in place, regardless of the update strategy.
+	if err := r.syncInPlaceUpdate(ctx, nodeset, pods, hash); err != nil {
+		return err
+	}
+
 	switch nodeset.Spec.UpdateStrategy.Type {
 	default:
 		fallthrough
@@ -1620,6 +1842,11 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 	logger := log.FromContext(ctx)
 
 	_, oldPods := findUpdatedPods(pods, hash)
+	oldPods, _ = r.splitHeldPods(ctx, nodeset, oldPods)
+
+	if err := r.syncSurgePods(ctx, nodeset, pods, hash); err != nil {
+		return err
+	}
 
 	unhealthyPods, _ := nodesetutils.SplitUnhealthyPods(oldPods)
 	if len(unhealthyPods) > 0 {
@@ -1650,6 +1877,9 @@ func (r *NodeSetReconciler) syncRollingUpdate(
 // For RollingUpdate, unavailable new pods and replica slots with no live pod
 // count against maxUnavailable, while unhealthy old pods neither consume the
 // budget nor become deletion candidates (callers condemn them separately).
+// With maxSurge, old pods only become deletion candidates once replaced by
+// surge pods instead. Old pods held by a paused, partitioned, or canary rollout
+// are never deletion candidates.
 func (r *NodeSetReconciler) splitUpdatePods(
 	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
@@ -1663,8 +1893,22 @@ func (r *NodeSetReconciler) splitUpdatePods(
 		fallthrough
 	case slinkyv1beta1.RollingUpdateNodeSetStrategyType:
 		newPods, oldPods := findUpdatedPods(pods, hash)
+		oldPods, heldPods := r.splitHeldPods(ctx, nodeset, oldPods)
 		_, healthyOldPods := nodesetutils.SplitUnhealthyPods(oldPods)
 
+		if getMaxSurge(nodeset) > 0 {
+			podsToDelete, remainingOldPods := splitSurgeUpdatePods(nodeset, newPods, healthyOldPods)
+			remainingPods := make([]*corev1.Pod, len(newPods))
+			copy(remainingPods, newPods)
+			remainingPods = append(remainingPods, remainingOldPods...)
+			remainingPods = append(remainingPods, heldPods...)
+
+			logger.V(1).Info("calculated pod lists for surge update",
+				"updatePods", len(podsToDelete),
+				"remainingPods", len(remainingPods))
+			return podsToDelete, remainingPods
+		}
+
 		total := int(ptr.Deref(nodeset.Spec.Replicas, defaults.DefaultNodeSetReplicas))
 		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
 			total = len(pods)
@@ -1692,6 +1936,7 @@ func (r *NodeSetReconciler) splitUpdatePods(
 		remainingPods := make([]*corev1.Pod, len(newPods))
 		copy(remainingPods, newPods)
 		remainingPods = append(remainingPods, remainingOldPods...)
+		remainingPods = append(remainingPods, heldPods...)
 
 		logger.V(1).Info("calculated pod lists for update",
 			"maxUnavailable", maxUnavailable,
@@ -1699,6 +1944,13 @@ func (r *NodeSetReconciler) splitUpdatePods(
 			"remainingPods", len(remainingPods))
 		return podsToDelete, remainingPods
 	case slinkyv1beta1.ScheduledUpdateNodeSetStrategyType:
+		// Pods may only be updated within an active maintenance window of the schedule.
+		if !nodesetutils.IsMaintenanceWindowActive(nodeset, time.Now()) {
+			logger.V(1).Info("no active maintenance window, deferring update",
+				"schedule", nodeset.Spec.UpdateStrategy.ScheduledUpdate.Schedule)
+			return nil, nil
+		}
+
 		eligiblePods, err := r.slurmControl.GetPodsUnderReservation(ctx, nodeset, pods)
 		if err != nil {
 			if !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
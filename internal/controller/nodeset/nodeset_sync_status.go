@@ -45,6 +45,7 @@ func (r *NodeSetReconciler) syncStatus(
 	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
 	pods []*corev1.Pod,
+	jobs *slurmcontrol.SlurmJobs,
 	currentRevision, updateRevision *appsv1.ControllerRevision,
 	collisionCount int32,
 	hash string,
@@ -58,11 +59,11 @@ func (r *NodeSetReconciler) syncStatus(
 		errs = append(errs, err)
 	}
 
-	if err := r.syncNodeSetStatus(ctx, nodeset, pods, currentRevision, updateRevision, collisionCount, hash); err != nil {
+	if err := r.syncNodeSetStatus(ctx, nodeset, pods, jobs, currentRevision, updateRevision, collisionCount, hash); err != nil {
 		errs = append(errs, err)
 	}
 
-	if err := r.syncNodeSetPodStatus(ctx, nodeset, pods); err != nil {
+	if err := r.syncNodeSetPodStatus(ctx, nodeset, pods, jobs); err != nil {
 		errs = append(errs, err)
 	}
 
@@ -80,7 +81,11 @@ func (r *NodeSetReconciler) syncSlurmStatus(
 		if !podutils.IsHealthy(pod) {
 			return nil
 		}
-		if err := r.slurmControl.UpdateNodeWithPodInfo(ctx, nodeset, pod); err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+		nodeLabels, err := r.getPodInfoNodeLabels(ctx, nodeset, pod)
+		if err != nil {
+			return err
+		}
+		if err := r.slurmControl.UpdateNodeWithPodInfo(ctx, nodeset, pod, nodeLabels); err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
 			return err
 		}
 		return nil
@@ -92,11 +97,44 @@ func (r *NodeSetReconciler) syncSlurmStatus(
 	return nil
 }
 
+// getPodInfoNodeLabels returns the PodInfoNodeLabels of the NodeSet which the
+// Kubernetes node of the pod has.
+func (r *NodeSetReconciler) getPodInfoNodeLabels(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pod *corev1.Pod,
+) (map[string]string, error) {
+	if pod.Spec.NodeName == "" || len(nodeset.Spec.PodInfoNodeLabels) == 0 {
+		return nil, nil
+	}
+
+	node := &corev1.Node{}
+	nodeKey := types.NamespacedName{Name: pod.Spec.NodeName}
+	if err := r.Get(ctx, nodeKey, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	nodeLabels := make(map[string]string)
+	for _, key := range nodeset.Spec.PodInfoNodeLabels {
+		if value, ok := node.Labels[key]; ok {
+			nodeLabels[key] = value
+		}
+	}
+	if len(nodeLabels) == 0 {
+		return nil, nil
+	}
+	return nodeLabels, nil
+}
+
 // syncSlurmStatus handles synchronizing NodeSet Status.
 func (r *NodeSetReconciler) syncNodeSetStatus(
 	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
 	pods []*corev1.Pod,
+	jobs *slurmcontrol.SlurmJobs,
 	currentRevision, updateRevision *appsv1.ControllerRevision,
 	collisionCount int32,
 	hash string,
@@ -110,7 +148,7 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 	if err != nil {
 		return err
 	}
-	slurmNodeStatus, err := r.slurmControl.CalculateNodeStatus(ctx, nodeset, pods)
+	slurmNodeStatus, err := r.slurmControl.CalculateNodeStatus(ctx, nodeset, pods, jobs)
 	if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
 		return err
 	}
@@ -118,10 +156,19 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 	if err != nil {
 		return err
 	}
+	preRegistered, err := r.calculatePreRegistered(ctx, nodeset, pods)
+	if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+		return err
+	}
+
+	currentHash, updateHash, currentReplicas := calculateRevisionStatus(pods, currentRevision, updateRevision, replicaStatus)
 
 	newStatus := slinkyv1beta1.NodeSetStatus{
 		Replicas:            replicaStatus.Replicas,
 		UpdatedReplicas:     replicaStatus.Updated,
+		CurrentReplicas:     currentReplicas,
+		CurrentRevision:     currentHash,
+		UpdateRevision:      updateHash,
 		ReadyReplicas:       replicaStatus.Ready,
 		AvailableReplicas:   replicaStatus.Available,
 		UnavailableReplicas: replicaStatus.Unavailable,
@@ -130,10 +177,18 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 		SlurmAllocated:      slurmNodeStatus.Allocated + slurmNodeStatus.Mixed,
 		SlurmDown:           slurmNodeStatus.Down,
 		SlurmDrain:          slurmNodeStatus.Drain,
+		SlurmPreRegistered:  preRegistered,
+		SlurmRunningJobs:    slurmNodeStatus.RunningJobs,
+		SlurmPendingJobs:    slurmNodeStatus.PendingJobs,
+		SurgeReplicas:       calculateSurgeReplicas(nodeset, pods),
 		ObservedGeneration:  nodeset.Generation,
 		NodeSetHash:         hash,
 		CollisionCount:      &collisionCount,
 		OrdinalToNode:       ordinalToNode,
+		Autoscaling:         calculateAutoscalingStatus(nodeset),
+		Remediation:         nodeset.Status.Remediation,
+		MaintenanceWindow:   nodesetutils.GetMaintenanceWindow(nodeset, time.Now()),
+		NodeCpuTopology:     calculateNodeCpuTopology(nodeset, pods),
 		Selector:            selector.String(),
 		Conditions:          []metav1.Condition{},
 	}
@@ -142,6 +197,11 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 	if err := r.applyReservationCondition(ctx, nodeset, &newStatus.Conditions); err != nil {
 		return err
 	}
+	if drainCondition := calculateDrainEscalatedCondition(nodeset, pods); drainCondition != nil {
+		meta.SetStatusCondition(&newStatus.Conditions, *drainCondition)
+	} else {
+		meta.RemoveStatusCondition(&newStatus.Conditions, slurmconditions.NodeSetConditionDrainEscalated)
+	}
 
 	if apiequality.Semantic.DeepEqual(nodeset.Status, newStatus) {
 		logger.V(2).Info("NodeSet Status has not changed, skipping status update", "status", nodeset.Status)
@@ -164,6 +224,36 @@ func (r *NodeSetReconciler) syncNodeSetStatus(
 	return nil
 }
 
+// calculatePreRegistered returns the number of pre-registered FUTURE Slurm nodes
+// which are not yet backed by a NodeSet pod.
+func (r *NodeSetReconciler) calculatePreRegistered(
+	ctx context.Context,
+	nodeset *slinkyv1beta1.NodeSet,
+	pods []*corev1.Pod,
+) (int32, error) {
+	if !isPreRegisterEnabled(nodeset) {
+		return 0, nil
+	}
+
+	futureNodes, err := r.slurmControl.GetFutureNodesForNodeSet(ctx, nodeset)
+	if err != nil {
+		return 0, err
+	}
+
+	podNodeNameSet := set.New[string]()
+	for _, pod := range pods {
+		podNodeNameSet.Insert(nodesetutils.GetSlurmNodeName(pod))
+	}
+
+	var preRegistered int32
+	for _, nodeName := range futureNodes {
+		if !podNodeNameSet.Has(nodeName) {
+			preRegistered++
+		}
+	}
+	return preRegistered, nil
+}
+
 type replicaStatus struct {
 	Replicas    int32
 	Ready       int32
@@ -267,6 +357,7 @@ func (r *NodeSetReconciler) calculateReservationCondition(ctx context.Context, n
 	}
 
 	reservationCondition := meta.FindStatusCondition(conditions, slurmconditions.NodeSetConditionReservationCreated)
+	startTime := nodesetutils.GetScheduledUpdate(nodeset, time.Now()).StartTime
 
 	if reservationCondition != nil {
 		switch {
@@ -275,18 +366,18 @@ func (r *NodeSetReconciler) calculateReservationCondition(ctx context.Context, n
 			condition := &metav1.Condition{
 				Type:               slurmconditions.NodeSetConditionReservationCreated,
 				Status:             metav1.ConditionTrue,
-				LastTransitionTime: nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime,
+				LastTransitionTime: startTime,
 				ObservedGeneration: 1,
 				Reason:             "Created",
 			}
 			return condition, nil
 
 		// If the condition already exists, but the reservation start time does not match
-		case reservationExists && reservationCondition.LastTransitionTime != nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime:
+		case reservationExists && !reservationCondition.LastTransitionTime.Equal(&startTime):
 			condition := &metav1.Condition{
 				Type:               slurmconditions.NodeSetConditionReservationCreated,
 				Status:             metav1.ConditionTrue,
-				LastTransitionTime: nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime,
+				LastTransitionTime: startTime,
 				ObservedGeneration: reservationCondition.ObservedGeneration + 1,
 				Reason:             "Created",
 			}
@@ -299,7 +390,7 @@ func (r *NodeSetReconciler) calculateReservationCondition(ctx context.Context, n
 			condition := &metav1.Condition{
 				Type:               slurmconditions.NodeSetConditionReservationCreated,
 				Status:             metav1.ConditionFalse,
-				LastTransitionTime: nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime,
+				LastTransitionTime: startTime,
 				ObservedGeneration: reservationCondition.ObservedGeneration + 1,
 				Reason:             "NotExists",
 			}
@@ -312,7 +403,7 @@ func (r *NodeSetReconciler) calculateReservationCondition(ctx context.Context, n
 		condition := &metav1.Condition{
 			Type:               slurmconditions.NodeSetConditionReservationCreated,
 			Status:             metav1.ConditionTrue,
-			LastTransitionTime: nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime,
+			LastTransitionTime: startTime,
 			ObservedGeneration: 1,
 			Reason:             "Created",
 		}
@@ -397,8 +488,9 @@ func (r *NodeSetReconciler) syncNodeSetPodStatus(
 	ctx context.Context,
 	nodeset *slinkyv1beta1.NodeSet,
 	pods []*corev1.Pod,
+	jobs *slurmcontrol.SlurmJobs,
 ) error {
-	slurmNodeStatus, err := r.slurmControl.CalculateNodeStatus(ctx, nodeset, pods)
+	slurmNodeStatus, err := r.slurmControl.CalculateNodeStatus(ctx, nodeset, pods, jobs)
 	if err != nil && !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
 		return err
 	}
@@ -407,6 +499,10 @@ func (r *NodeSetReconciler) syncNodeSetPodStatus(
 		return err
 	}
 
+	if err := r.updateNodeSetPodJobs(ctx, nodeset, pods, &slurmNodeStatus); err != nil {
+		return err
+	}
+
 	if err := r.updateNodeSetPodPDBLabels(ctx, nodeset, pods); err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
 	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
 	slurmfake "github.com/SlinkyProject/slurm-client/pkg/client/fake"
 	slurminterceptor "github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
@@ -169,7 +170,8 @@ func TestNodeSetReconciler_syncStatus(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := newNodeSetController(tt.fields.Client, tt.fields.ClientMap)
-			err := r.syncStatus(tt.args.ctx, tt.args.nodeset, tt.args.pods, tt.args.currentRevision, tt.args.updateRevision, tt.args.collisionCount, tt.args.hash, tt.args.errors...)
+			jobs, _ := r.slurmControl.ListJobs(tt.args.ctx, tt.args.nodeset)
+			err := r.syncStatus(tt.args.ctx, tt.args.nodeset, tt.args.pods, jobs, tt.args.currentRevision, tt.args.updateRevision, tt.args.collisionCount, tt.args.hash, tt.args.errors...)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -256,6 +258,62 @@ func TestNodeSetReconciler_syncSlurmStatus(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_getPodInfoNodeLabels(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 1)
+	nodeset.Spec.PodInfoNodeLabels = []string{
+		corev1.LabelTopologyZone,
+		corev1.LabelInstanceTypeStable,
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-0",
+			Labels: map[string]string{
+				corev1.LabelTopologyZone: "zone-a",
+				corev1.LabelHostname:     "node-0",
+			},
+		},
+	}
+	newPod := func(nodeName string) *corev1.Pod {
+		pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, &slinkyv1beta1.Controller{}, 0, "")
+		pod.Spec.NodeName = nodeName
+		return pod
+	}
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want map[string]string
+	}{
+		{
+			name: "Not scheduled",
+			pod:  newPod(""),
+			want: nil,
+		},
+		{
+			name: "Node not found",
+			pod:  newPod("node-1"),
+			want: nil,
+		},
+		{
+			name: "Selected labels",
+			pod:  newPod(node.Name),
+			want: map[string]string{
+				corev1.LabelTopologyZone: "zone-a",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &NodeSetReconciler{
+				Client: fake.NewFakeClient(node.DeepCopy()),
+			}
+			got, err := r.getPodInfoNodeLabels(context.Background(), nodeset, tt.pod)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestNodeSetReconciler_syncNodeSetStatus(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -336,6 +394,9 @@ func TestNodeSetReconciler_syncNodeSetStatus(t *testing.T) {
 					ReadyReplicas:     2,
 					AvailableReplicas: 2,
 					UpdatedReplicas:   2,
+					CurrentReplicas:   2,
+					CurrentRevision:   "12345",
+					UpdateRevision:    "12345",
 					Desired:           2,
 					SlurmIdle:         2,
 					NodeSetHash:       "12345",
@@ -394,6 +455,8 @@ func TestNodeSetReconciler_syncNodeSetStatus(t *testing.T) {
 				},
 				wantStatus: &slinkyv1beta1.NodeSetStatus{
 					Replicas:            2,
+					CurrentReplicas:     2,
+					CurrentRevision:     "12345",
 					UnavailableReplicas: 2,
 					Desired:             2,
 					NodeSetHash:         "12345",
@@ -407,7 +470,8 @@ func TestNodeSetReconciler_syncNodeSetStatus(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := newNodeSetController(tt.fields.Client, tt.fields.ClientMap)
-			err := r.syncNodeSetStatus(tt.args.ctx, tt.args.nodeset, tt.args.pods, tt.args.currentRevision, tt.args.updateRevision, tt.args.collisionCount, tt.args.hash)
+			jobs, _ := r.slurmControl.ListJobs(tt.args.ctx, tt.args.nodeset)
+			err := r.syncNodeSetStatus(tt.args.ctx, tt.args.nodeset, tt.args.pods, jobs, tt.args.currentRevision, tt.args.updateRevision, tt.args.collisionCount, tt.args.hash)
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
@@ -1343,3 +1407,67 @@ func Test_calculateOrdinalToNode(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeSetReconciler_calculatePreRegistered(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 3)
+	nodeset.Spec.PreRegisterSlurmNodes = true
+	pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
+	futureNode := func(name string) slurmtypes.V0044Node {
+		return slurmtypes.V0044Node{V0044Node: slurmapi.V0044Node{
+			Name:     ptr.To(name),
+			State:    ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateFUTURE}),
+			Features: ptr.To(slurmapi.V0044CsvString{"foo"}),
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		nodeset   *slinkyv1beta1.NodeSet
+		clientMap *clientmap.ClientMap
+		want      int32
+		wantErr   bool
+	}{
+		{
+			name: "disabled",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				ns := nodeset.DeepCopy()
+				ns.Spec.PreRegisterSlurmNodes = false
+				return ns
+			}(),
+			clientMap: newClientMap(controller.Name, newFakeClientList(slurminterceptor.Funcs{}, &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{futureNode("foo-1")},
+			})),
+			want: 0,
+		},
+		{
+			name:      "no client",
+			nodeset:   nodeset.DeepCopy(),
+			clientMap: clientmap.NewClientMap(),
+			want:      0,
+			wantErr:   true,
+		},
+		{
+			name:    "future nodes without pods",
+			nodeset: nodeset.DeepCopy(),
+			clientMap: newClientMap(controller.Name, newFakeClientList(slurminterceptor.Funcs{}, &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{futureNode("foo-0"), futureNode("foo-1"), futureNode("foo-2")},
+			})),
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newNodeSetController(fake.NewFakeClient(), tt.clientMap)
+			got, err := r.calculatePreRegistered(context.Background(), tt.nodeset, []*corev1.Pod{pod})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("calculatePreRegistered() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
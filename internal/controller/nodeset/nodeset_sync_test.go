@@ -50,6 +50,7 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/utils/historycontrol"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podinfo"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
@@ -64,6 +65,7 @@ func newNodeSetController(client client.Client, clientMap *clientmap.ClientMap)
 		eventRecorder:  eventRecorder,
 		historyControl: historycontrol.NewHistoryControl(client),
 		podControl:     podcontrol.NewPodControl(client, eventRecorder),
+		refResolver:    refresolver.New(client),
 		slurmControl:   slurmcontrol.NewSlurmControl(clientMap),
 		expectations:   kubecontroller.NewUIDTrackingControllerExpectations(kubecontroller.NewControllerExpectations()),
 	}
@@ -324,6 +326,25 @@ func TestNodeSetReconciler_syncReservationFinalizer(t *testing.T) {
 			wantErr:        false,
 			wantFinalizers: nil,
 		},
+		{
+			name: "no reservation between maintenance windows keeps finalizer",
+			fields: fields{
+				slurmControl: slurmcontrol.NewSlurmControl(clientMap),
+			},
+			args: args{
+				nodeset: func() *slinkyv1beta1.NodeSet {
+					ns := newNodeSet("foo", controller.Name, 2)
+					ns.Spec.UpdateStrategy.Type = slinkyv1beta1.ScheduledUpdateNodeSetStrategyType
+					ns.Spec.UpdateStrategy.ScheduledUpdate.Schedule = "0 2 * * SUN#1"
+					ns.Spec.UpdateStrategy.ScheduledUpdate.Duration = metav1.Duration{Duration: 6 * time.Hour}
+					ns.Finalizers = []string{slinkyv1beta1.FinalizerNodeSetReservation}
+					return ns
+				}(),
+				controller: controller,
+			},
+			wantErr:        false,
+			wantFinalizers: []string{slinkyv1beta1.FinalizerNodeSetReservation},
+		},
 		{
 			name: "deleting with reservation removes finalizer",
 			fields: fields{
@@ -742,7 +763,8 @@ func TestNodeSetReconciler_sync(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := newNodeSetController(tt.fields.Client, tt.fields.ClientMap)
-			err := r.sync(tt.args.ctx, tt.args.nodeset, tt.args.pods, tt.args.hash)
+			jobs, _ := r.slurmControl.ListJobs(tt.args.ctx, tt.args.nodeset)
+			err := r.sync(tt.args.ctx, tt.args.nodeset, tt.args.pods, jobs, tt.args.hash)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -778,7 +800,7 @@ func BenchmarkNodeSetReconciler_sync(b *testing.B) {
 				r := newNodeSetController(kubeClient, clientMap)
 				b.StartTimer()
 
-				if err := r.sync(context.TODO(), nodeset, nil, ""); (err != nil) != bb.wantErr {
+				if err := r.sync(context.TODO(), nodeset, nil, nil, ""); (err != nil) != bb.wantErr {
 					b.Errorf("NodeSetReconciler.sync() error = %v, wantErr %v", err, bb.wantErr)
 				}
 			}
@@ -1449,6 +1471,31 @@ func TestNodeSetReconciler_syncCordon(t *testing.T) {
 			wantSlurmDrain:  true,
 			wantReasonSub:   "kube-node-1",
 		},
+		{
+			name: "kubernetes node cordoned by node health policy is not propagated",
+			kubeNode: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "kube-node-1",
+					Annotations: map[string]string{
+						slinkyv1beta1.AnnotationNodeSlurmUnhealthyCordon: slurmNodeName,
+					},
+				},
+				Spec: corev1.NodeSpec{Unschedulable: true},
+			},
+			pod: newPod(false),
+			slurmNodeList: &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					{
+						V0044Node: slurmapi.V0044Node{
+							Name:  ptr.To(slurmNodeName),
+							State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
+						},
+					},
+				},
+			},
+			wantPodCordoned: false,
+			wantSlurmDrain:  false,
+		},
 		{
 			name: "kubernetes node cordon reason annotation is propagated to slurm",
 			kubeNode: &corev1.Node{
@@ -1518,6 +1565,38 @@ func TestNodeSetReconciler_syncCordon(t *testing.T) {
 			wantPodCordoned: false,
 			wantSlurmDrain:  false,
 		},
+		{
+			name: "slurm node drained by condition drain policy is not undrained",
+			kubeNode: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "kube-node-1"},
+				Spec:       corev1.NodeSpec{Unschedulable: false},
+			},
+			pod: func() *corev1.Pod {
+				p := newPod(false)
+				if p.Annotations == nil {
+					p.Annotations = make(map[string]string)
+				}
+				p.Annotations[slinkyv1beta1.AnnotationPodConditionDrain] = "GPUUnhealthy"
+				return p
+			}(),
+			slurmNodeList: &slurmtypes.V0044NodeList{
+				Items: []slurmtypes.V0044Node{
+					{
+						V0044Node: slurmapi.V0044Node{
+							Name: ptr.To(slurmNodeName),
+							State: ptr.To([]slurmapi.V0044NodeState{
+								slurmapi.V0044NodeStateIDLE,
+								slurmapi.V0044NodeStateDRAIN,
+							}),
+							Reason: ptr.To("slurm-operator: Node (kube-node-1) has conditions GPUUnhealthy=True"),
+						},
+					},
+				},
+			},
+			wantPodCordoned: false,
+			wantSlurmDrain:  true,
+			wantReasonSub:   "GPUUnhealthy",
+		},
 		{
 			name: "external slurm reason is left unchanged",
 			kubeNode: &corev1.Node{
@@ -4130,6 +4209,28 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 	pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 0, "")
 	pod2 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")
 	pod2.Spec.NodeName = node2.Name
+	node3 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node2",
+			Labels: map[string]string{
+				corev1.LabelTopologyZone: "zone-a",
+			},
+		},
+	}
+	topologyController := controller.DeepCopy()
+	topologyController.Namespace = nodeset.Namespace
+	topologyController.Spec.Topology = slinkyv1beta1.ControllerTopology{
+		Topologies: []slinkyv1beta1.ControllerTopologySpec{
+			{
+				Name: "topo-switch",
+				Tree: &slinkyv1beta1.ControllerTopologyTree{
+					SwitchLabels: []string{corev1.LabelTopologyZone},
+				},
+			},
+		},
+	}
+	pod3 := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 1, "")
+	pod3.Spec.NodeName = node3.Name
 
 	tests := []struct {
 		name      string
@@ -4137,7 +4238,9 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 		clientMap *clientmap.ClientMap
 		nodeset   *slinkyv1beta1.NodeSet
 		pods      []*corev1.Pod
-		wantErr   bool
+		// wantTopology is the expected topology line, if not the node annotation.
+		wantTopology string
+		wantErr      bool
 	}{
 		{
 			name:      "pending",
@@ -4168,6 +4271,29 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 			nodeset: nodeset,
 			pods:    []*corev1.Pod{pod2.DeepCopy()},
 		},
+		{
+			name:   "node labels",
+			client: fake.NewFakeClient(topologyController.DeepCopy(), node3.DeepCopy(), pod3.DeepCopy()),
+			clientMap: func() *clientmap.ClientMap {
+				nodeList := &slurmtypes.V0044NodeList{
+					Items: []slurmtypes.V0044Node{
+						{
+							V0044Node: slurmapi.V0044Node{
+								Name: ptr.To(nodesetutils.GetSlurmNodeName(pod3)),
+								State: ptr.To([]slurmapi.V0044NodeState{
+									slurmapi.V0044NodeStateIDLE,
+								}),
+							},
+						},
+					},
+				}
+				sclient := newFakeClientList(sinterceptor.Funcs{}, nodeList)
+				return newClientMap(controller.Name, sclient)
+			}(),
+			nodeset:      nodeset,
+			pods:         []*corev1.Pod{pod3.DeepCopy()},
+			wantTopology: "topo-switch:zone-a",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4189,6 +4315,9 @@ func TestNodeSetReconciler_syncSlurmTopology(t *testing.T) {
 				checkNodeKey := types.NamespacedName{Name: pod.Spec.NodeName}
 				require.NoError(t, tt.client.Get(ctx, checkNodeKey, checkNode), "Get() failed")
 				topologySpec := checkNode.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+				if tt.wantTopology != "" {
+					topologySpec = tt.wantTopology
+				}
 				require.True(t, apiequality.Semantic.DeepEqual(checkPod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec], topologySpec), "pod and node topology are incongruent: node = '%v' ; pod = '%v'", topologySpec, checkPod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec])
 
 				mapKey := types.NamespacedName{
@@ -4264,6 +4393,26 @@ func TestNodeSetReconciler_syncSlurmFeatures(t *testing.T) {
 	podClean := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 4, "")
 	podClean.Spec.NodeName = nodeClean.Name
 
+	// Node with labels mapped to features by the NodeSet FeatureLabels, merged
+	// with its features annotation.
+	nodeLabeled := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-labeled",
+			Labels: map[string]string{
+				"nvidia.com/gpu.product": "H100",
+			},
+			Annotations: map[string]string{
+				slinkyv1beta1.AnnotationNodeFeaturesSpec: "nn-cccc3333",
+			},
+		},
+	}
+	podLabeled := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, 5, "")
+	podLabeled.Spec.NodeName = nodeLabeled.Name
+	nodesetLabeled := nodeset.DeepCopy()
+	nodesetLabeled.Spec.FeatureLabels = []slinkyv1beta1.NodeSetFeatureLabel{
+		{Key: "nvidia.com/gpu.product", Template: "gpu_{value}"},
+	}
+
 	prefix := slinkyv1beta1.NodeFeaturePrefix
 	// slurmNodeForPod returns a one-node list for the pod's Slurm node, seeded with
 	// the given features in both available and active (e.g. the registration baseline).
@@ -4344,6 +4493,15 @@ func TestNodeSetReconciler_syncSlurmFeatures(t *testing.T) {
 			// DaemonSet pods resolve the Slurm node name via pod.Spec.Hostname.
 			wantFeatures: []string{"foo", prefix + "IB", prefix + "nn-bbbb2222"},
 		},
+		{
+			name:       "allocated with feature labels",
+			client:     fake.NewFakeClient(nodeLabeled.DeepCopy(), podLabeled.DeepCopy()),
+			slurmNodes: slurmNodeForPod(podLabeled, "foo"),
+			nodeset:    nodesetLabeled,
+			pods:       []*corev1.Pod{podLabeled.DeepCopy()},
+			// Label features are merged with the annotation under the prefix.
+			wantFeatures: []string{"foo", prefix + "gpu_H100", prefix + "nn-cccc3333"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -5126,6 +5284,90 @@ func TestNodeSetReconciler_syncSlurmNodeRecords(t *testing.T) {
 	}
 }
 
+func TestNodeSetReconciler_syncSlurmNodeRecordsPreRegister(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+	}
+	futureNode := func(name string) slurmtypes.V0044Node {
+		return slurmtypes.V0044Node{V0044Node: slurmapi.V0044Node{
+			Name:     ptr.To(name),
+			State:    ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateFUTURE}),
+			Features: ptr.To(slurmapi.V0044CsvString{"foo"}),
+		}}
+	}
+
+	tests := []struct {
+		name          string
+		preRegister   bool
+		replicas      int32
+		slurmNodes    []slurmtypes.V0044Node
+		wantCreated   []string
+		wantRemaining []string
+		wantPruned    []string
+	}{
+		{
+			name:          "disabled",
+			preRegister:   false,
+			replicas:      2,
+			slurmNodes:    []slurmtypes.V0044Node{futureNode("foo-2")},
+			wantCreated:   []string{},
+			wantRemaining: []string{"foo-2"},
+		},
+		{
+			name:        "create missing nodes",
+			preRegister: true,
+			replicas:    3,
+			slurmNodes: []slurmtypes.V0044Node{
+				{V0044Node: slurmapi.V0044Node{
+					Name:  ptr.To("foo-0"),
+					State: ptr.To([]slurmapi.V0044NodeState{slurmapi.V0044NodeStateIDLE}),
+				}},
+				futureNode("foo-1"),
+			},
+			wantCreated:   []string{"NodeName=foo-2 State=FUTURE Features=foo"},
+			wantRemaining: []string{"foo-0", "foo-1"},
+		},
+		{
+			name:          "delete nodes beyond replicas",
+			preRegister:   true,
+			replicas:      1,
+			slurmNodes:    []slurmtypes.V0044Node{futureNode("foo-0"), futureNode("foo-1"), futureNode("foo-2")},
+			wantCreated:   []string{},
+			wantRemaining: []string{"foo-0"},
+			wantPruned:    []string{"foo-1", "foo-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeset := newNodeSet("foo", controller.Name, tt.replicas)
+			nodeset.Spec.PreRegisterSlurmNodes = tt.preRegister
+
+			gotCreated := []string{}
+			kclient := fake.NewFakeClient(nodeset)
+			sclient := newFakeClientList(sinterceptor.Funcs{
+				Create: func(_ context.Context, _ slurmobject.Object, req any, _ ...slurmclient.CreateOption) error {
+					gotCreated = append(gotCreated, req.(slurmapi.V0044OpenapiCreateNodeReq).NodeConf)
+					return nil
+				},
+			}, &slurmtypes.V0044NodeList{Items: tt.slurmNodes})
+			r := newNodeSetController(kclient, newClientMap(controller.Name, sclient))
+
+			err := r.syncSlurmNodeRecords(context.Background(), nodeset)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCreated, gotCreated)
+
+			for _, name := range tt.wantRemaining {
+				require.NoError(t, sclient.Get(context.Background(), slurmclient.ObjectKey(name), &slurmtypes.V0044Node{}), "expected Slurm node %q to remain", name)
+			}
+			for _, name := range tt.wantPruned {
+				require.Error(t, sclient.Get(context.Background(), slurmclient.ObjectKey(name), &slurmtypes.V0044Node{}), "expected Slurm node %q to be pruned", name)
+			}
+		})
+	}
+}
+
 func Test_sanitizeSlurmReason(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
 	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podinfo"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reservationutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/timestore"
 	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
@@ -40,17 +42,22 @@ import (
 type SlurmControlInterface interface {
 	// RefreshNodeCache forces the Node cache to be refreshed
 	RefreshNodeCache(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) error
-	// UpdateNodeWithPodInfo handles updating the Node with its pod info
-	UpdateNodeWithPodInfo(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
+	// UpdateNodeWithPodInfo handles updating the Node with its pod info and the labels of its Kubernetes node
+	UpdateNodeWithPodInfo(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, nodeLabels map[string]string) error
 	// UpdateNodeTopology handles updating the Node with its topologySpec.
 	UpdateNodeTopology(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, topologySpec string) error
 	// UpdateNodeFeatures reconciles the prefix-namespaced Slurm node features to the
 	// given (unprefixed) values, preserving all features outside the prefix.
 	UpdateNodeFeatures(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, prefix string, features []string) error
+	// UpdateNodeAttributes handles updating the Slurm node attributes which do not require a slurmd restart,
+	// replacing the old features with the new ones and setting the weight, unless nil.
+	UpdateNodeAttributes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, oldFeatures, newFeatures []string, weight *int32) error
 	// MakeNodeDrain handles adding the DRAIN state to the slurm node.
 	MakeNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string, overrideReason bool) error
 	// MakeNodeUndrain handles removing the DRAIN state from the slurm node.
 	MakeNodeUndrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
+	// MakeNodeDown handles setting the DOWN state on the slurm node, which requeues or terminates its running jobs.
+	MakeNodeDown(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error
 	// IsNodeDrain checks if the slurm node has the DRAIN state.
 	IsNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
 	// IsNodeDrained checks if the slurm node is drained.
@@ -59,10 +66,26 @@ type SlurmControlInterface interface {
 	IsNodeDownForUnresponsive(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
 	// IsNodeReasonOurs reports if the node reason was set by the operator.
 	IsNodeReasonOurs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error)
-	// CalculateNodeStatus returns the current state of the registered slurm nodes.
-	CalculateNodeStatus(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (SlurmNodeStatus, error)
+	// GetNodeUnhealthyReason returns the reason of the slurm node, if it was set DOWN or DRAIN outside of the operator.
+	GetNodeUnhealthyReason(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (string, error)
+	// GetNodeRebootPod returns the UID of the pod which was deleted to reboot the slurm node, if any.
+	GetNodeRebootPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (string, error)
+	// SetNodeRebootPod records the pod as deleted to reboot the slurm node.
+	SetNodeRebootPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
+	// MakeNodeRebooted handles returning the slurm node to service, with the next state and reason of its reboot request.
+	MakeNodeRebooted(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error
+	// ListJobs returns the Slurm jobs, to be listed once per reconcile and shared by the calculations on them.
+	ListJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (*SlurmJobs, error)
+	// CalculateNodeStatus returns the current state of the registered slurm nodes, and of their given Slurm jobs.
+	CalculateNodeStatus(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod, jobs *SlurmJobs) (SlurmNodeStatus, error)
 	// GetNodeDeadlines returns a map of node to its deadline time.Time calculated from running jobs.
 	GetNodeDeadlines(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (*timestore.TimeStore, error)
+	// GetRunningJobsForPod returns the IDs of the running Slurm jobs allocated to the Slurm node of the pod.
+	GetRunningJobsForPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) ([]int32, error)
+	// CancelJobs cancels the given Slurm jobs.
+	CancelJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, jobIDs []int32) error
+	// GetNodePowerStates returns the power saving state of the given Slurm nodes, if they exist.
+	GetNodePowerStates(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string) (map[string]SlurmNodePowerState, error)
 	// GetNodesForPods returns a list of Slurm nodes associated with the NodeSet pods.
 	GetNodesForPods(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) ([]string, error)
 	// CheckReservationForNodeSet returns true when a reservation exists for a NodeSet
@@ -77,6 +100,12 @@ type SlurmControlInterface interface {
 	GetDefunctNodesForNodeSet(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) ([]DefunctNode, error)
 	// DeleteNode deletes a Slurm node by name.
 	DeleteNode(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeName string) error
+	// GetFutureNodesForNodeSet returns the names of the FUTURE Slurm nodes with the NodeSet feature,
+	// as found in the node cache refreshed by RefreshNodeCache.
+	GetFutureNodesForNodeSet(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) ([]string, error)
+	// CreateFutureNodes creates the given Slurm nodes in the FUTURE state, if they do not exist
+	// in the node cache refreshed by RefreshNodeCache.
+	CreateFutureNodes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string, nodeConf string) ([]string, error)
 }
 
 var ErrNoSlurmClient = errors.New("NoSlurmClient")
@@ -106,7 +135,7 @@ func (r *realSlurmControl) RefreshNodeCache(ctx context.Context, nodeset *slinky
 }
 
 // UpdateNodeWithPodInfo implements SlurmControlInterface.
-func (r *realSlurmControl) UpdateNodeWithPodInfo(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+func (r *realSlurmControl) UpdateNodeWithPodInfo(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, nodeLabels map[string]string) error {
 	logger := log.FromContext(ctx)
 
 	slurmClient := r.lookupClient(nodeset)
@@ -131,11 +160,15 @@ func (r *realSlurmControl) UpdateNodeWithPodInfo(ctx context.Context, nodeset *s
 		Node:        pod.Spec.NodeName,
 		NodeSetName: nodeset.Name,
 		NodeSetUID:  string(nodeset.UID),
+		NodeLabels:  nodeLabels,
+	}
+	podInfoOld, fromComment := parseNodePodInfo(slurmNode)
+	if isNodeRebootPending(slurmNode) {
+		// Preserve the pod which was deleted to reboot the Slurm node.
+		podInfo.RebootPodUID = podInfoOld.RebootPodUID
 	}
-	podInfoOld := &podinfo.PodInfo{}
-	_ = podinfo.ParseIntoPodInfo(slurmNode.Comment, podInfoOld)
 
-	if podInfoOld.Equal(podInfo) {
+	if !fromComment && podInfoOld.Equal(podInfo) {
 		logger.V(3).Info("Node already contains podInfo, skipping update request",
 			"node", slurmNode.GetKey(), "podInfo", podInfo)
 		return nil
@@ -144,7 +177,11 @@ func (r *realSlurmControl) UpdateNodeWithPodInfo(ctx context.Context, nodeset *s
 	logger.Info("Update Slurm Node with Kubernetes Pod info",
 		"Node", slurmNode.Name, "podInfo", podInfo)
 	req := slurmapi.V0044UpdateNodeMsg{
-		Comment: ptr.To(podInfo.ToString()),
+		Extra: ptr.To(podInfo.ToString()),
+	}
+	if fromComment {
+		// Migrate the podInfo, which used to be stored in the Comment.
+		req.Comment = ptr.To("")
 	}
 	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
 		if !errors.Is(err, slurmerrors.ErrObjectNotFound) {
@@ -152,7 +189,7 @@ func (r *realSlurmControl) UpdateNodeWithPodInfo(ctx context.Context, nodeset *s
 		}
 	}
 
-	if podInfoOld.Node != "" {
+	if podInfoOld.Node != "" && podInfoOld.Node != podInfo.Node {
 		logger.Info("Update Slurm Node state due to Kubernetes node migration", "Node", slurmNode.Name)
 		req := slurmapi.V0044UpdateNodeMsg{
 			State: ptr.To([]slurmapi.V0044UpdateNodeMsgState{slurmapi.V0044UpdateNodeMsgStateIDLE}),
@@ -167,6 +204,22 @@ func (r *realSlurmControl) UpdateNodeWithPodInfo(ctx context.Context, nodeset *s
 	return nil
 }
 
+// parseNodePodInfo returns the podInfo of the Slurm node from its Extra field.
+// Otherwise, it falls back to the Comment field, where the podInfo used to be
+// stored, and reports it as such. JSON without a pod name is not a podInfo,
+// hence is left to the admin.
+func parseNodePodInfo(node *slurmtypes.V0044Node) (*podinfo.PodInfo, bool) {
+	info := &podinfo.PodInfo{}
+	if err := podinfo.ParseIntoPodInfo(node.Extra, info); err == nil && info.PodName != "" {
+		return info, false
+	}
+	info = &podinfo.PodInfo{}
+	if err := podinfo.ParseIntoPodInfo(node.Comment, info); err == nil && info.PodName != "" {
+		return info, true
+	}
+	return &podinfo.PodInfo{}, false
+}
+
 // UpdateNodeTopology implements SlurmControlInterface.
 func (r *realSlurmControl) UpdateNodeTopology(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, topologySpec string) error {
 	logger := log.FromContext(ctx)
@@ -280,6 +333,81 @@ func replaceFeatureNamespace(current []string, prefix string, desired []string)
 	return structutils.SortedDedup(structutils.MergeList(preserved, desired))
 }
 
+// UpdateNodeAttributes implements SlurmControlInterface.
+func (r *realSlurmControl) UpdateNodeAttributes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, oldFeatures, newFeatures []string, weight *int32) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do UpdateNodeAttributes()",
+			"pod", klog.KObj(pod))
+		return ErrNoSlurmClient
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	req := slurmapi.V0044UpdateNodeMsg{}
+	needsUpdate := false
+
+	// Only the old features are replaced, such that features which are managed
+	// otherwise (e.g. NodeFeaturePrefix, NodeFeaturesPlugins) are preserved.
+	curAvailable := ptr.Deref(slurmNode.Features, slurmapi.V0044CsvString{})
+	curActive := ptr.Deref(slurmNode.ActiveFeatures, slurmapi.V0044CsvString{})
+	newAvailable := replaceFeatures(curAvailable, oldFeatures, newFeatures)
+	newActive := replaceFeatures(curActive, oldFeatures, newFeatures)
+	if !set.New(newAvailable...).Equal(set.New(curAvailable...)) ||
+		!set.New(newActive...).Equal(set.New(curActive...)) {
+		req.Features = new(slurmapi.V0044CsvString(newAvailable))
+		req.FeaturesAct = new(slurmapi.V0044CsvString(newActive))
+		needsUpdate = true
+	}
+
+	if weight != nil && ptr.Deref(slurmNode.Weight, 0) != *weight {
+		req.Weight = &slurmapi.V0044Uint32NoValStruct{
+			Number: weight,
+			Set:    ptr.To(true),
+		}
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		logger.V(3).Info("Node attributes already in sync, skipping update request",
+			"node", slurmNode.GetKey())
+		return nil
+	}
+
+	logger.Info("Update Slurm Node attributes", "Node", slurmNode.GetKey(),
+		"features", newFeatures, "weight", weight)
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// replaceFeatures returns current with the old features replaced by the new
+// features. The result is sorted and de-duplicated.
+func replaceFeatures(current, oldFeatures, newFeatures []string) []string {
+	oldSet := set.New(oldFeatures...)
+	preserved := make([]string, 0, len(current))
+	for _, f := range current {
+		if !oldSet.Has(f) {
+			preserved = append(preserved, f)
+		}
+	}
+	return structutils.SortedDedup(structutils.MergeList(preserved, newFeatures))
+}
+
 const nodeReasonPrefix = "slurm-operator: "
 
 // MakeNodeDrain implements SlurmControlInterface.
@@ -384,6 +512,48 @@ func (r *realSlurmControl) MakeNodeUndrain(ctx context.Context, nodeset *slinkyv
 	return nil
 }
 
+// MakeNodeDown implements SlurmControlInterface.
+func (r *realSlurmControl) MakeNodeDown(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, reason string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do MakeNodeDown()",
+			"pod", klog.KObj(pod))
+		return ErrNoSlurmClient
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN) {
+		logger.V(1).Info("Node is already down, skipping down request",
+			"node", slurmNode.GetKey(), "nodeState", slurmNode.State)
+		return nil
+	}
+
+	logger.V(1).Info("make slurm node down",
+		"pod", klog.KObj(pod))
+	req := slurmapi.V0044UpdateNodeMsg{
+		State:  ptr.To([]slurmapi.V0044UpdateNodeMsgState{slurmapi.V0044UpdateNodeMsgStateDOWN}),
+		Reason: ptr.To(FormatNodeReason(reason)),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
 // IsNodeDrain implements SlurmControlInterface.
 func (r *realSlurmControl) IsNodeDrain(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (bool, error) {
 	logger := log.FromContext(ctx)
@@ -496,6 +666,44 @@ func (r *realSlurmControl) IsNodeReasonOurs(ctx context.Context, nodeset *slinky
 	return true, nil
 }
 
+// GetNodeUnhealthyReason implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeUnhealthyReason(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (string, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodeUnhealthyReason()",
+			"pod", klog.KObj(pod))
+		return "", ErrNoSlurmClient
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	isDown := slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDOWN)
+	isDrain := slurmNode.GetStateAsSet().Has(slurmapi.V0044NodeStateDRAIN)
+	if !isDown && !isDrain {
+		return "", nil
+	}
+
+	// An unresponsive slurmd reflects the pod, not the health of the host.
+	nodeReason := ptr.Deref(slurmNode.Reason, "")
+	switch {
+	case nodeReason == "",
+		strings.HasPrefix(nodeReason, nodeReasonPrefix),
+		isDown && strings.Contains(nodeReason, "Not responding"):
+		return "", nil
+	}
+
+	return nodeReason, nil
+}
+
 type SlurmNodeStatus struct {
 	Total int32
 
@@ -509,24 +717,218 @@ type SlurmNodeStatus struct {
 	Unknown   int32
 
 	// Flag State
-	Completing    int32
-	Drain         int32
-	Fail          int32
-	Invalid       int32
-	InvalidReg    int32
-	Maintenance   int32
-	NotResponding int32
-	Undrain       int32
+	Completing      int32
+	Drain           int32
+	Fail            int32
+	Invalid         int32
+	InvalidReg      int32
+	Maintenance     int32
+	NotResponding   int32
+	RebootIssued    int32
+	RebootRequested int32
+	Undrain         int32
 
 	// Per-node State as Conditions
 	NodeStates map[string][]corev1.PodCondition
+
+	// Number of running Slurm jobs allocated to the Slurm nodes.
+	RunningJobs int32
+	// Number of pending Slurm jobs which may run on the Slurm nodes.
+	PendingJobs int32
+
+	// Per-node running Slurm jobs
+	NodeJobs map[string]SlurmNodeJobs
+}
+
+// SlurmNodeJobs are the running Slurm jobs allocated to a Slurm node.
+type SlurmNodeJobs struct {
+	// Sorted IDs of the jobs.
+	JobIDs []int32
+	// Sorted unique users of the jobs.
+	Users []string
+	// Sorted unique partitions of the jobs.
+	Partitions []string
+	// EndTime is when the last job is expected to end, zero if unlimited.
+	EndTime time.Time
+}
+
+// GetNodeRebootPod implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeRebootPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) (string, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodeRebootPod()",
+			"pod", klog.KObj(pod))
+		return "", ErrNoSlurmClient
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if !isNodeRebootPending(slurmNode) {
+		return "", nil
+	}
+	podInfo, _ := parseNodePodInfo(slurmNode)
+	return podInfo.RebootPodUID, nil
+}
+
+// SetNodeRebootPod implements SlurmControlInterface.
+func (r *realSlurmControl) SetNodeRebootPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do SetNodeRebootPod()",
+			"pod", klog.KObj(pod))
+		return ErrNoSlurmClient
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	podInfo, _ := parseNodePodInfo(slurmNode)
+	if podInfo.RebootPodUID == string(pod.UID) {
+		return nil
+	}
+	podInfo.RebootPodUID = string(pod.UID)
+
+	logger.V(1).Info("set slurm node reboot pod",
+		"pod", klog.KObj(pod), "podUID", pod.UID)
+	req := slurmapi.V0044UpdateNodeMsg{
+		Extra: ptr.To(podInfo.ToString()),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// MakeNodeRebooted implements SlurmControlInterface.
+func (r *realSlurmControl) MakeNodeRebooted(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do MakeNodeRebooted()",
+			"pod", klog.KObj(pod))
+		return ErrNoSlurmClient
+	}
+
+	slurmNode := &slurmtypes.V0044Node{}
+	key := slurmobject.ObjectKey(nodesetutils.GetSlurmNodeName(pod))
+	if err := slurmClient.Get(ctx, key, slurmNode); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !isNodeRebootPending(slurmNode) {
+		logger.V(1).Info("Node has no pending reboot, skipping rebooted request",
+			"node", slurmNode.GetKey(), "nodeState", slurmNode.State)
+		return nil
+	}
+
+	reason := ptr.Deref(slurmNode.Reason, "")
+	if reason == "" {
+		reason = FormatNodeReason("Node rebooted")
+	}
+	nextStates := ptr.Deref(slurmNode.NextStateAfterReboot, []slurmapi.V0044NodeNextStateAfterReboot{})
+	podInfo, _ := parseNodePodInfo(slurmNode)
+	podInfo.RebootPodUID = ""
+
+	// Resuming the Slurm node completes its reboot, as slurmd did not boot again.
+	logger.V(1).Info("make slurm node rebooted",
+		"pod", klog.KObj(pod), "nextState", nextStates)
+	req := slurmapi.V0044UpdateNodeMsg{
+		State: ptr.To([]slurmapi.V0044UpdateNodeMsgState{slurmapi.V0044UpdateNodeMsgStateRESUME}),
+		Extra: ptr.To(podInfo.ToString()),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var nextState slurmapi.V0044UpdateNodeMsgState
+	switch {
+	case slices.Contains(nextStates, slurmapi.V0044NodeNextStateAfterRebootDOWN):
+		nextState = slurmapi.V0044UpdateNodeMsgStateDOWN
+	case slices.Contains(nextStates, slurmapi.V0044NodeNextStateAfterRebootDRAIN):
+		nextState = slurmapi.V0044UpdateNodeMsgStateDRAIN
+	default:
+		return nil
+	}
+	req = slurmapi.V0044UpdateNodeMsg{
+		State:  ptr.To([]slurmapi.V0044UpdateNodeMsgState{nextState}),
+		Reason: ptr.To(reason),
+	}
+	if err := slurmClient.Update(ctx, slurmNode, req); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// isNodeRebootPending returns true if a reboot of the Slurm node was requested
+// or issued, and has not completed yet.
+func isNodeRebootPending(node *slurmtypes.V0044Node) bool {
+	return node.GetStateAsSet().HasAny(slurmapi.V0044NodeStateREBOOTREQUESTED, slurmapi.V0044NodeStateREBOOTISSUED)
+}
+
+// SlurmJobs is a snapshot of the Slurm jobs.
+type SlurmJobs struct {
+	items []slurmtypes.V0044JobInfo
+}
+
+// ListJobs implements SlurmControlInterface.
+func (r *realSlurmControl) ListJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (*SlurmJobs, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do ListJobs()")
+		return nil, ErrNoSlurmClient
+	}
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return &SlurmJobs{}, nil
+		}
+		return nil, err
+	}
+
+	return &SlurmJobs{items: jobList.Items}, nil
 }
 
 // CalculateNodeStatus implements SlurmControlInterface.
-func (r *realSlurmControl) CalculateNodeStatus(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) (SlurmNodeStatus, error) {
+func (r *realSlurmControl) CalculateNodeStatus(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod, jobs *SlurmJobs) (SlurmNodeStatus, error) {
 	logger := log.FromContext(ctx)
 	status := SlurmNodeStatus{
 		NodeStates: make(map[string][]corev1.PodCondition),
+		NodeJobs:   make(map[string]SlurmNodeJobs),
 	}
 
 	slurmClient := r.lookupClient(nodeset)
@@ -549,12 +951,14 @@ func (r *realSlurmControl) CalculateNodeStatus(ctx context.Context, nodeset *sli
 		podNodeNameSet.Insert(podNodeName)
 	}
 
+	partitionSet := set.New[string]()
 	for _, node := range nodeList.Items {
 		nodeName := ptr.Deref(node.Name, "")
 		if !podNodeNameSet.Has(nodeName) {
 			continue
 		}
 		status.Total++
+		partitionSet.Insert(ptr.Deref(node.Partitions, slurmapi.V0044CsvString{})...)
 		// Slurm Node Base States
 		switch {
 		case node.GetStateAsSet().Has(slurmapi.V0044NodeStateALLOCATED):
@@ -622,6 +1026,16 @@ func (r *realSlurmControl) CalculateNodeStatus(ctx context.Context, nodeset *sli
 				nodeState(node, slurmconditions.PodConditionNotResponding))
 			status.NotResponding++
 		}
+		if node.GetStateAsSet().Has(slurmapi.V0044NodeStateREBOOTISSUED) {
+			status.NodeStates[nodeName] = append(status.NodeStates[nodeName],
+				nodeState(node, slurmconditions.PodConditionRebootIssued))
+			status.RebootIssued++
+		}
+		if node.GetStateAsSet().Has(slurmapi.V0044NodeStateREBOOTREQUESTED) {
+			status.NodeStates[nodeName] = append(status.NodeStates[nodeName],
+				nodeState(node, slurmconditions.PodConditionRebootRequested))
+			status.RebootRequested++
+		}
 		if node.GetStateAsSet().Has(slurmapi.V0044NodeStateUNDRAIN) {
 			status.NodeStates[nodeName] = append(status.NodeStates[nodeName],
 				nodeState(node, slurmconditions.PodConditionUndrain))
@@ -629,9 +1043,138 @@ func (r *realSlurmControl) CalculateNodeStatus(ctx context.Context, nodeset *sli
 		}
 	}
 
+	if jobs != nil {
+		calculateNodeJobs(ctx, &status, jobs.items, podNodeNameSet, partitionSet)
+	}
+
 	return status, nil
 }
 
+// calculateNodeJobs counts the running Slurm jobs allocated to the Slurm nodes,
+// and the pending Slurm jobs of their partitions, then collects the running
+// Slurm jobs of each Slurm node.
+func calculateNodeJobs(
+	ctx context.Context,
+	status *SlurmNodeStatus,
+	jobs []slurmtypes.V0044JobInfo,
+	nodeNameSet, partitionSet set.Set[string],
+) {
+	logger := log.FromContext(ctx)
+
+	nodeJobs := make(map[string]*SlurmNodeJobs)
+	unlimitedNodes := set.New[string]()
+	for _, job := range jobs {
+		switch {
+		case job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING):
+			slurmNodeNames, err := hostlist.Expand(ptr.Deref(job.Nodes, ""))
+			if err != nil {
+				logger.Error(err, "failed to expand job node hostlist",
+					"job", ptr.Deref(job.JobId, 0))
+				continue
+			}
+			if !nodeNameSet.HasAny(slurmNodeNames...) {
+				continue
+			}
+			status.RunningJobs++
+
+			startTime_NoVal := ptr.Deref(job.StartTime, slurmapi.V0044Uint64NoValStruct{})
+			startTime := time.Unix(ptr.Deref(startTime_NoVal.Number, 0), 0)
+			timeLimit_NoVal := ptr.Deref(job.TimeLimit, slurmapi.V0044Uint32NoValStruct{})
+			timeLimit := time.Duration(ptr.Deref(timeLimit_NoVal.Number, 0)) * time.Minute
+			isUnlimited := ptr.Deref(timeLimit_NoVal.Infinite, false)
+
+			for _, slurmNodeName := range slurmNodeNames {
+				if !nodeNameSet.Has(slurmNodeName) {
+					continue
+				}
+				nj, ok := nodeJobs[slurmNodeName]
+				if !ok {
+					nj = &SlurmNodeJobs{}
+					nodeJobs[slurmNodeName] = nj
+				}
+				nj.JobIDs = append(nj.JobIDs, ptr.Deref(job.JobId, 0))
+				nj.Users = append(nj.Users, ptr.Deref(job.UserName, ""))
+				nj.Partitions = append(nj.Partitions, ptr.Deref(job.Partition, ""))
+				if isUnlimited {
+					unlimitedNodes.Insert(slurmNodeName)
+				} else if endTime := startTime.Add(timeLimit); endTime.After(nj.EndTime) {
+					nj.EndTime = endTime
+				}
+			}
+		case job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStatePENDING):
+			// A pending job may be submitted to multiple partitions.
+			jobPartitions := strings.Split(ptr.Deref(job.Partition, ""), ",")
+			if partitionSet.HasAny(jobPartitions...) {
+				status.PendingJobs++
+			}
+		}
+	}
+
+	for slurmNodeName, nj := range nodeJobs {
+		slices.Sort(nj.JobIDs)
+		nj.Users = set.New(nj.Users...).Delete("").SortedList()
+		nj.Partitions = set.New(nj.Partitions...).Delete("").SortedList()
+		if unlimitedNodes.Has(slurmNodeName) {
+			nj.EndTime = time.Time{}
+		}
+		status.NodeJobs[slurmNodeName] = *nj
+	}
+}
+
+type SlurmJobDemand struct {
+	// Number of pending jobs which may be satisfied by more nodes.
+	PendingJobs int32
+	// Number of nodes requested by the pending jobs.
+	PendingNodes int32
+	// Number of running jobs.
+	RunningJobs int32
+}
+
+// pendingReasonsDemand are pending job reasons which can be resolved by adding
+// more nodes, hence count towards demand. Other reasons, such as QOS and
+// association limits, reservations, licenses and dependencies, cannot.
+var pendingReasonsDemand = set.New(
+	"NodeDown",
+	"Priority",
+	"Resources",
+)
+
+// isPendingForNodes returns true if the pending job reason is resolved by
+// adding more nodes.
+func isPendingForNodes(reason string) bool {
+	return pendingReasonsDemand.Has(reason) || strings.HasPrefix(reason, "Nodes")
+}
+
+// CalculateDemand returns the pending and running Slurm jobs of the given partitions.
+func (j *SlurmJobs) CalculateDemand(partitions []string) SlurmJobDemand {
+	demand := SlurmJobDemand{}
+	if j == nil {
+		return demand
+	}
+
+	partitionSet := set.New(partitions...)
+	for _, job := range j.items {
+		jobPartitions := strings.Split(ptr.Deref(job.Partition, ""), ",")
+		if !partitionSet.HasAny(jobPartitions...) {
+			continue
+		}
+		switch {
+		case job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING):
+			demand.RunningJobs++
+		case job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStatePENDING):
+			if !isPendingForNodes(ptr.Deref(job.StateReason, "")) {
+				continue
+			}
+			nodeCount_NoVal := ptr.Deref(job.NodeCount, slurmapi.V0044Uint32NoValStruct{})
+			nodeCount := max(ptr.Deref(nodeCount_NoVal.Number, 0), 1)
+			demand.PendingJobs++
+			demand.PendingNodes += nodeCount
+		}
+	}
+
+	return demand
+}
+
 const infiniteDuration = time.Duration(math.MaxInt64)
 
 // GetNodeDeadlines implements SlurmControlInterface.
@@ -689,6 +1232,70 @@ func (r *realSlurmControl) GetNodeDeadlines(ctx context.Context, nodeset *slinky
 	return ts, nil
 }
 
+// GetRunningJobsForPod implements SlurmControlInterface.
+func (r *realSlurmControl) GetRunningJobsForPod(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod) ([]int32, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetRunningJobsForPod()",
+			"pod", klog.KObj(pod))
+		return nil, ErrNoSlurmClient
+	}
+
+	slurmNodeName := nodesetutils.GetSlurmNodeName(pod)
+
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList); err != nil {
+		return nil, err
+	}
+
+	jobIDs := []int32{}
+	for _, job := range jobList.Items {
+		if !job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING) {
+			continue
+		}
+		slurmNodeNames, err := hostlist.Expand(ptr.Deref(job.Nodes, ""))
+		if err != nil {
+			logger.Error(err, "failed to expand job node hostlist",
+				"job", ptr.Deref(job.JobId, 0))
+			return nil, err
+		}
+		if !slices.Contains(slurmNodeNames, slurmNodeName) {
+			continue
+		}
+		jobIDs = append(jobIDs, ptr.Deref(job.JobId, 0))
+	}
+	slices.Sort(jobIDs)
+
+	return jobIDs, nil
+}
+
+// CancelJobs implements SlurmControlInterface.
+func (r *realSlurmControl) CancelJobs(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, jobIDs []int32) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do CancelJobs()", "jobIDs", jobIDs)
+		return ErrNoSlurmClient
+	}
+
+	for _, jobID := range jobIDs {
+		logger.V(1).Info("cancel slurm job", "jobID", jobID)
+		job := &slurmtypes.V0044JobInfo{
+			V0044JobInfo: slurmapi.V0044JobInfo{
+				JobId: new(jobID),
+			},
+		}
+		if err := slurmClient.Delete(ctx, job); err != nil && !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetNodesForPods implements SlurmControlInterface.
 func (r *realSlurmControl) GetNodesForPods(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, pods []*corev1.Pod) ([]string, error) {
 	logger := log.FromContext(ctx)
@@ -718,12 +1325,76 @@ func (r *realSlurmControl) GetNodesForPods(ctx context.Context, nodeset *slinkyv
 		if !podNodeNameSet.Has(nodeName) {
 			continue
 		}
+		// A pre-registered node is not registered until its slurmd is.
+		if node.GetStateAsSet().Has(slurmapi.V0044NodeStateFUTURE) {
+			continue
+		}
 		slurmNodeNames = append(slurmNodeNames, nodeName)
 	}
 
 	return slurmNodeNames, nil
 }
 
+// SlurmNodePowerState is the power saving state of a Slurm node.
+type SlurmNodePowerState string
+
+const (
+	// The node is powered up, or power saving is not applicable.
+	SlurmNodePoweredUp SlurmNodePowerState = "PoweredUp"
+	// The node was resumed and is pending registration.
+	SlurmNodePoweringUp SlurmNodePowerState = "PoweringUp"
+	// The node was suspended and is pending shutdown.
+	SlurmNodePoweringDown SlurmNodePowerState = "PoweringDown"
+	// The node is suspended.
+	SlurmNodePoweredDown SlurmNodePowerState = "PoweredDown"
+)
+
+// IsSuspended returns true if Slurm has suspended the node.
+func (s SlurmNodePowerState) IsSuspended() bool {
+	return s == SlurmNodePoweringDown || s == SlurmNodePoweredDown
+}
+
+// GetNodePowerStates implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodePowerStates(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string) (map[string]SlurmNodePowerState, error) {
+	logger := log.FromContext(ctx)
+	powerStates := make(map[string]SlurmNodePowerState, len(nodeNames))
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetNodePowerStates()")
+		return powerStates, ErrNoSlurmClient
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return powerStates, nil
+		}
+		return powerStates, err
+	}
+
+	nodeNameSet := set.New(nodeNames...)
+	for _, node := range nodeList.Items {
+		nodeName := ptr.Deref(node.Name, "")
+		if !nodeNameSet.Has(nodeName) {
+			continue
+		}
+		stateSet := node.GetStateAsSet()
+		switch {
+		case stateSet.Has(slurmapi.V0044NodeStatePOWERINGDOWN):
+			powerStates[nodeName] = SlurmNodePoweringDown
+		case stateSet.Has(slurmapi.V0044NodeStatePOWEREDDOWN):
+			powerStates[nodeName] = SlurmNodePoweredDown
+		case stateSet.Has(slurmapi.V0044NodeStatePOWERINGUP):
+			powerStates[nodeName] = SlurmNodePoweringUp
+		default:
+			powerStates[nodeName] = SlurmNodePoweredUp
+		}
+	}
+
+	return powerStates, nil
+}
+
 type DefunctNode struct {
 	Name    string
 	PodInfo podinfo.PodInfo
@@ -750,10 +1421,7 @@ func (r *realSlurmControl) GetDefunctNodesForNodeSet(ctx context.Context, nodese
 			continue
 		}
 
-		info := &podinfo.PodInfo{}
-		if err := podinfo.ParseIntoPodInfo(node.Comment, info); err != nil {
-			continue
-		}
+		info, _ := parseNodePodInfo(&node)
 		if info.Namespace != nodeset.Namespace ||
 			info.PodName == "" ||
 			info.NodeSetName != nodeset.Name ||
@@ -798,6 +1466,85 @@ func (r *realSlurmControl) DeleteNode(ctx context.Context, nodeset *slinkyv1beta
 	return nil
 }
 
+// GetFutureNodesForNodeSet implements SlurmControlInterface.
+func (r *realSlurmControl) GetFutureNodesForNodeSet(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do GetFutureNodesForNodeSet()")
+		return nil, ErrNoSlurmClient
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	feature := common.GetSlurmNodeSetName(nodeset)
+	futureNodes := make([]string, 0)
+	for _, node := range nodeList.Items {
+		if !node.GetStateAsSet().Has(slurmapi.V0044NodeStateFUTURE) {
+			continue
+		}
+		if !slices.Contains(ptr.Deref(node.Features, slurmapi.V0044CsvString{}), feature) {
+			continue
+		}
+		nodeName := ptr.Deref(node.Name, "")
+		if nodeName == "" {
+			continue
+		}
+		futureNodes = append(futureNodes, nodeName)
+	}
+	slices.Sort(futureNodes)
+
+	return futureNodes, nil
+}
+
+// CreateFutureNodes implements SlurmControlInterface.
+func (r *realSlurmControl) CreateFutureNodes(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, nodeNames []string, nodeConf string) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(nodeset)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for nodeset, cannot do CreateFutureNodes()")
+		return nil, ErrNoSlurmClient
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil && !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+		return nil, err
+	}
+	existingNodes := set.New[string]()
+	for _, node := range nodeList.Items {
+		existingNodes.Insert(ptr.Deref(node.Name, ""))
+	}
+
+	createdNodes := make([]string, 0)
+	for _, nodeName := range nodeNames {
+		if existingNodes.Has(nodeName) {
+			continue
+		}
+		slurmNode := &slurmtypes.V0044Node{
+			V0044Node: slurmapi.V0044Node{
+				Name: new(nodeName),
+			},
+		}
+		req := slurmapi.V0044OpenapiCreateNodeReq{
+			NodeConf: strings.TrimSpace(fmt.Sprintf("NodeName=%s State=FUTURE %s", nodeName, nodeConf)),
+		}
+		if err := slurmClient.Create(ctx, slurmNode, req); err != nil {
+			return createdNodes, fmt.Errorf("failed to create FUTURE Slurm node %s: %w", nodeName, err)
+		}
+		createdNodes = append(createdNodes, nodeName)
+	}
+
+	return createdNodes, nil
+}
+
 // CheckReservationForNodeSet returns the state of the reservation
 // for the given nodeset in Slurm.
 func (r *realSlurmControl) CheckReservationForNodeSet(ctx context.Context, nodeset *slinkyv1beta1.NodeSet) (bool, error) {
@@ -908,7 +1655,15 @@ func (r *realSlurmControl) SyncReservationForNodeSet(ctx context.Context, nodese
 
 	name := "SlurmOperatorMaint-" + nodeset.Name
 
-	reservationDesc, newReservationInfo, err := formatReservationForSchedule(name, nodeset.Spec.UpdateStrategy.ScheduledUpdate)
+	// With a schedule, the reservation is of the active or next maintenance window.
+	scheduledUpdate := nodesetutils.GetScheduledUpdate(nodeset, time.Now())
+	if scheduledUpdate.StartTime.IsZero() {
+		logger.V(1).Info("no maintenance window for NodeSet, skipping reservation",
+			"schedule", scheduledUpdate.Schedule)
+		return nil
+	}
+
+	reservationDesc, newReservationInfo, err := formatReservationForSchedule(name, scheduledUpdate)
 	if err != nil {
 		return fmt.Errorf("SyncReservationForNodeSet() failed to format Reservation=%s for NodeSet=%s with error=%w", *reservationDesc.Name, nodeset.Name, err)
 	}
@@ -982,7 +1737,7 @@ func (r *realSlurmControl) SyncReservationForNodeSet(ctx context.Context, nodese
 		}
 
 		reservationActive = isReservationActive(*oldReservationInfo)
-		startTimeChanged = !nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime.Time.Equal(startTime)
+		startTimeChanged = !scheduledUpdate.StartTime.Time.Equal(startTime)
 
 		// We should honor existing start times for reoccuring reservations, Slurm updates them for us.
 		// This approach is required until we have a slurmapi.V0044ReservationInfo.Status field from Slurm
@@ -1034,7 +1789,7 @@ func (r *realSlurmControl) SyncReservationForNodeSet(ctx context.Context, nodese
 
 	case !slurmReservationExists && !created:
 		forceStart := reservationHasFlags(newReservationInfo, []slurmapi.V0044ReservationInfoFlags{slurmapi.V0044ReservationInfoFlagsFORCESTART}, true)
-		pastStartTime := nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime.Time.Before(time.Now())
+		pastStartTime := scheduledUpdate.StartTime.Time.Before(time.Now())
 
 		if forceStart || !pastStartTime {
 			err = slurmClient.Create(ctx, &newReservationInfo, reservationDesc)
@@ -1092,15 +1847,7 @@ func updateReservationNodes(ctx context.Context, slurmClient slurmclient.Client,
 // slurmapi.V0044ReservationInfoStatus field, this helper function should be
 // deleted in favor of using that field directly.
 func isReservationActive(reservation slurmtypes.V0044ReservationInfo) bool {
-	start := time.Unix(*reservation.StartTime.Number, 0)
-	end := time.Unix(*reservation.EndTime.Number, 0)
-
-	now := time.Now().In(time.UTC)
-	if start.Before(now) && end.After(now) {
-		return true
-	}
-
-	return false
+	return reservationutils.IsReservationActive(reservation, time.Now().In(time.UTC))
 }
 
 // getReservationStatus() returns the boolean and time value from the NodeSet's
@@ -1156,65 +1903,17 @@ var (
 )
 
 func formatReservationForSchedule(name string, schedule slinkyv1beta1.ScheduledUpdateNodeSetStrategy) (slurmapi.V0044ReservationDescMsg, slurmtypes.V0044ReservationInfo, error) {
-	var reservationInfo slurmtypes.V0044ReservationInfo
-	var reservation slurmapi.V0044ReservationDescMsg
-
-	startTime := timeToUint64NoVal(schedule.StartTime.In(time.UTC))
-	endTime := timeToUint64NoVal(schedule.StartTime.In(time.UTC).Add(schedule.Duration.Duration))
-	duration := durationToUint32NoVal(schedule.Duration.Duration)
-
-	descFlags := parseReservationFlags(schedule.Flags, resDescFlags)
-	infoFlags := parseReservationFlags(schedule.Flags, resInfoFlags)
-
-	reservationInfo = slurmtypes.V0044ReservationInfo{
-		V0044ReservationInfo: slurmapi.V0044ReservationInfo{
-			Name:      &name,
-			StartTime: &startTime,
-			EndTime:   &endTime,
-			Flags:     &infoFlags,
-			Users:     ptr.To(common.SlurmUser),
-		},
-	}
-	reservation = slurmapi.V0044ReservationDescMsg{
-		Name:      &name,
-		StartTime: &startTime,
-		Duration:  &duration,
-		Flags:     &descFlags,
-		Users:     ptr.To(slurmapi.V0044CsvString{common.SlurmUser}),
-	}
+	reservation, reservationInfo := reservationutils.FormatReservation(reservationutils.Reservation{
+		Name:      name,
+		StartTime: schedule.StartTime.Time,
+		Duration:  schedule.Duration.Duration,
+		Flags:     schedule.Flags,
+		Users:     []string{common.SlurmUser},
+	}, resInfoFlags, resDescFlags)
 
 	return reservation, reservationInfo, nil
 }
 
-func timeToUint64NoVal(t time.Time) slurmapi.V0044Uint64NoValStruct {
-	return slurmapi.V0044Uint64NoValStruct{
-		Infinite: new(false),
-		Number:   ptr.To(t.Unix()),
-		Set:      new(true),
-	}
-}
-
-func durationToUint32NoVal(d time.Duration) slurmapi.V0044Uint32NoValStruct {
-	return slurmapi.V0044Uint32NoValStruct{
-		Infinite: new(false),
-		Number:   ptr.To(int32(d.Minutes())),
-		Set:      new(true),
-	}
-}
-
-func parseReservationFlags[T slurmapi.V0044ReservationInfoFlags | slurmapi.V0044ReservationDescMsgFlags](flags []string, reqFlags []T) []T {
-	// Required flags
-	flagSet := set.New(reqFlags...)
-
-	// User flags
-	for _, flag := range flags {
-		f := T(strings.ToUpper(flag))
-		flagSet.Insert(f)
-	}
-
-	return flagSet.SortedList()
-}
-
 func (r *realSlurmControl) lookupClient(nodeset *slinkyv1beta1.NodeSet) slurmclient.Client {
 	key := ktypes.NamespacedName{
 		Namespace: nodeset.Namespace,
@@ -49,16 +49,30 @@ func slurmUpdateFn(_ context.Context, obj object.Object, req any, opts ...client
 			switch stateReq {
 			case api.V0044UpdateNodeMsgStateUNDRAIN:
 				stateSet.Delete(api.V0044NodeStateDRAIN)
+			case api.V0044UpdateNodeMsgStateRESUME:
+				stateSet.Delete(api.V0044NodeStateDOWN, api.V0044NodeStateDRAIN,
+					api.V0044NodeStateREBOOTREQUESTED, api.V0044NodeStateREBOOTISSUED)
+				o.Reason = nil
 			default:
 				stateSet.Insert(api.V0044NodeState(stateReq))
 			}
 		}
 		o.State = ptr.To(stateSet.UnsortedList())
-		o.Comment = r.Comment
-		o.Reason = r.Reason
+		if r.Comment != nil {
+			o.Comment = r.Comment
+		}
+		if r.Extra != nil {
+			o.Extra = r.Extra
+		}
+		if r.Reason != nil {
+			o.Reason = r.Reason
+		}
 		o.Topology = r.TopologyStr
 		o.Features = r.Features
 		o.ActiveFeatures = r.FeaturesAct
+		if r.Weight != nil {
+			o.Weight = r.Weight.Number
+		}
 	case *types.V0044ReservationInfo:
 		_, ok := req.(api.V0044ReservationDescMsg)
 		if !ok {
@@ -98,19 +112,28 @@ func Test_realSlurmControl_UpdateNodeWithPodInfo(t *testing.T) {
 	nodeset.UID = k8stypes.UID("foo-uid")
 	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
 	pod.Spec.NodeName = "foo"
+	oldPodInfo := podinfo.PodInfo{
+		Namespace:   nodeset.Namespace,
+		PodName:     pod.Name,
+		Node:        pod.Spec.NodeName,
+		NodeSetName: nodeset.Name,
+		NodeSetUID:  string(nodeset.UID),
+	}
 	type fields struct {
 		node *types.V0044Node
 	}
 	type args struct {
-		ctx     context.Context
-		nodeset *slinkyv1beta1.NodeSet
-		pod     *corev1.Pod
+		ctx        context.Context
+		nodeset    *slinkyv1beta1.NodeSet
+		pod        *corev1.Pod
+		nodeLabels map[string]string
 	}
 	tests := []struct {
 		name        string
 		fields      fields
 		args        args
 		wantPodInfo podinfo.PodInfo
+		wantComment string
 		wantErr     bool
 	}{
 		{
@@ -130,21 +153,111 @@ func Test_realSlurmControl_UpdateNodeWithPodInfo(t *testing.T) {
 				nodeset: nodeset,
 				pod:     pod,
 			},
+			wantPodInfo: oldPodInfo,
+		},
+		{
+			name: "With node labels",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateIDLE,
+						}),
+						Extra: ptr.To(oldPodInfo.ToString()),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+				nodeLabels: map[string]string{
+					corev1.LabelTopologyZone: "zone-a",
+				},
+			},
 			wantPodInfo: podinfo.PodInfo{
 				Namespace:   nodeset.Namespace,
 				PodName:     pod.Name,
 				Node:        pod.Spec.NodeName,
 				NodeSetName: nodeset.Name,
 				NodeSetUID:  string(nodeset.UID),
+				NodeLabels: map[string]string{
+					corev1.LabelTopologyZone: "zone-a",
+				},
 			},
 		},
+		{
+			name: "Migrate from comment",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateIDLE,
+						}),
+						Comment: ptr.To(oldPodInfo.ToString()),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantPodInfo: oldPodInfo,
+			wantComment: "",
+		},
+		{
+			name: "Migrate from comment, without podInfo in extra",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateIDLE,
+						}),
+						Extra:   ptr.To(`{"rack":"42"}`),
+						Comment: ptr.To(oldPodInfo.ToString()),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantPodInfo: oldPodInfo,
+			wantComment: "",
+		},
+		{
+			name: "Preserve comment",
+			fields: fields{
+				node: &types.V0044Node{
+					V0044Node: api.V0044Node{
+						Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+						State: ptr.To([]api.V0044NodeState{
+							api.V0044NodeStateIDLE,
+						}),
+						Comment: ptr.To("rack 42"),
+					},
+				},
+			},
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pod:     pod,
+			},
+			wantPodInfo: oldPodInfo,
+			wantComment: "rack 42",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithObjects(tt.fields.node).Build()
 			controllerName := tt.args.nodeset.Spec.ControllerRef.Name
 			r := NewSlurmControl(testutils.NewClientMap(controllerName, tt.args.nodeset.Namespace, sclient))
-			err := r.UpdateNodeWithPodInfo(tt.args.ctx, tt.args.nodeset, tt.args.pod)
+			err := r.UpdateNodeWithPodInfo(tt.args.ctx, tt.args.nodeset, tt.args.pod, tt.args.nodeLabels)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -157,8 +270,9 @@ func Test_realSlurmControl_UpdateNodeWithPodInfo(t *testing.T) {
 				}
 			}
 			checkPodInfo := podinfo.PodInfo{}
-			_ = podinfo.ParseIntoPodInfo(checkNode.Comment, &checkPodInfo)
+			_ = podinfo.ParseIntoPodInfo(checkNode.Extra, &checkPodInfo)
 			require.True(t, apiequality.Semantic.DeepEqual(checkPodInfo, tt.wantPodInfo), "UpdateNodeWithPodInfo() podInfo = %v, want %v", checkPodInfo, tt.wantPodInfo)
+			require.Equal(t, tt.wantComment, ptr.Deref(checkNode.Comment, ""))
 		})
 	}
 }
@@ -618,6 +732,94 @@ func Test_realSlurmControl_UpdateNodeFeatures(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_UpdateNodeAttributes(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	prefix := slinkyv1beta1.NodeFeaturePrefix
+	nodeWith := func(features []string, weight int32) *types.V0044Node {
+		return &types.V0044Node{
+			V0044Node: api.V0044Node{
+				Name:           ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+				State:          ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE}),
+				Features:       ptr.To(api.V0044CsvString(features)),
+				ActiveFeatures: ptr.To(api.V0044CsvString(features)),
+				Weight:         ptr.To(weight),
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		node         *types.V0044Node
+		oldFeatures  []string
+		newFeatures  []string
+		weight       *int32
+		wantSkip     bool
+		wantFeatures []string
+		wantWeight   int32
+	}{
+		{
+			name:         "replaces old features, preserves others",
+			node:         nodeWith([]string{"foo", "a100", prefix + "zone"}, 1),
+			oldFeatures:  []string{"foo", "a100"},
+			newFeatures:  []string{"foo", "h100"},
+			wantFeatures: []string{"foo", "h100", prefix + "zone"},
+			wantWeight:   1,
+		},
+		{
+			name:         "updates weight",
+			node:         nodeWith([]string{"foo"}, 1),
+			oldFeatures:  []string{"foo"},
+			newFeatures:  []string{"foo"},
+			weight:       ptr.To[int32](10),
+			wantFeatures: []string{"foo"},
+			wantWeight:   10,
+		},
+		{
+			name:         "already in sync skips",
+			node:         nodeWith([]string{"foo", "h100"}, 10),
+			oldFeatures:  []string{"foo", "a100"},
+			newFeatures:  []string{"foo", "h100"},
+			weight:       ptr.To[int32](10),
+			wantSkip:     true,
+			wantFeatures: []string{"foo", "h100"},
+			wantWeight:   10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates := 0
+			updateFn := func(ctx context.Context, obj object.Object, req any, opts ...client.UpdateOption) error {
+				updates++
+				return slurmUpdateFn(ctx, obj, req, opts...)
+			}
+			sclient := fake.NewClientBuilder().WithUpdateFn(updateFn).WithObjects(tt.node).Build()
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, nodeset.Namespace, sclient))
+			err := r.UpdateNodeAttributes(ctx, nodeset, pod, tt.oldFeatures, tt.newFeatures, tt.weight)
+			require.NoError(t, err)
+			if tt.wantSkip {
+				require.Zero(t, updates)
+			}
+
+			checkNode := &types.V0044Node{}
+			require.NoError(t, sclient.Get(ctx, tt.node.GetKey(), checkNode))
+			gotFeatures := ptr.Deref(checkNode.Features, api.V0044CsvString{})
+			gotActive := ptr.Deref(checkNode.ActiveFeatures, api.V0044CsvString{})
+			slices.Sort(gotFeatures)
+			slices.Sort(gotActive)
+			require.Equal(t, tt.wantFeatures, []string(gotFeatures))
+			require.Equal(t, tt.wantFeatures, []string(gotActive))
+			require.Equal(t, tt.wantWeight, ptr.Deref(checkNode.Weight, 0))
+		})
+	}
+}
+
 func Test_realSlurmControl_IsNodeDrain(t *testing.T) {
 	ctx := context.Background()
 	controller := &slinkyv1beta1.Controller{
@@ -1463,6 +1665,95 @@ func Test_realSlurmControl_IsNodeReasonOurs(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_GetNodeUnhealthyReason(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	newNode := func(reason string, states ...api.V0044NodeState) *types.V0044Node {
+		node := &types.V0044Node{
+			V0044Node: api.V0044Node{
+				Name:  ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+				State: ptr.To(states),
+			},
+		}
+		if reason != "" {
+			node.Reason = ptr.To(reason)
+		}
+		return node
+	}
+	tests := []struct {
+		name string
+		node *types.V0044Node
+		want string
+	}{
+		{
+			name: "Healthy",
+			node: newNode("", api.V0044NodeStateIDLE),
+			want: "",
+		},
+		{
+			name: "Drain by health check",
+			node: newNode("NHC: GPU ECC errors", api.V0044NodeStateIDLE, api.V0044NodeStateDRAIN),
+			want: "NHC: GPU ECC errors",
+		},
+		{
+			name: "Down by admin",
+			node: newNode("bad DIMM", api.V0044NodeStateDOWN),
+			want: "bad DIMM",
+		},
+		{
+			name: "Drain by operator",
+			node: newNode(nodeReasonPrefix+"Pod (default/foo-0) was cordoned", api.V0044NodeStateIDLE, api.V0044NodeStateDRAIN),
+			want: "",
+		},
+		{
+			name: "Down for not responding",
+			node: newNode("Not responding", api.V0044NodeStateDOWN),
+			want: "",
+		},
+		{
+			name: "Reason without state",
+			node: newNode("stale reason", api.V0044NodeStateIDLE),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithObjects(tt.node).Build()
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+			got, err := r.GetNodeUnhealthyReason(ctx, nodeset, pod)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_realSlurmControl_ListJobs(t *testing.T) {
+	ctx := context.Background()
+	nodeset := newNodeSet("foo", "slurm", 1)
+	jobList := &types.V0044JobInfoList{
+		Items: []types.V0044JobInfo{
+			{V0044JobInfo: api.V0044JobInfo{JobId: ptr.To[int32](1)}},
+		},
+	}
+
+	r := &realSlurmControl{clientMap: clientmap.NewClientMap()}
+	_, err := r.ListJobs(ctx, nodeset)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+
+	sclient := fake.NewClientBuilder().WithLists(jobList).Build()
+	r = &realSlurmControl{clientMap: testutils.NewClientMap(nodeset.Spec.ControllerRef.Name, nodeset.Namespace, sclient)}
+	got, err := r.ListJobs(ctx, nodeset)
+	require.NoError(t, err)
+	require.Equal(t, jobList.Items, got.items)
+}
+
 func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 	ctx := context.Background()
 	controller := &slinkyv1beta1.Controller{
@@ -2200,13 +2491,121 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Running and pending jobs",
+			fields: func() fields {
+				nodeName := nodesetutils.GetSlurmNodeName(nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, ""))
+				nodeList := &types.V0044NodeList{
+					Items: []types.V0044Node{
+						{
+							V0044Node: api.V0044Node{
+								Name: ptr.To(nodeName),
+								State: ptr.To([]api.V0044NodeState{
+									api.V0044NodeStateALLOCATED,
+								}),
+								Partitions: ptr.To(api.V0044CsvString{"foo"}),
+							},
+						},
+					},
+				}
+				jobList := &types.V0044JobInfoList{
+					Items: []types.V0044JobInfo{
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](2),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Nodes:     ptr.To(nodeName),
+								Partition: ptr.To("foo"),
+								UserName:  ptr.To("bob"),
+								StartTime: ptr.To(api.V0044Uint64NoValStruct{Number: ptr.To[int64](1000)}),
+								TimeLimit: ptr.To(api.V0044Uint32NoValStruct{Number: ptr.To[int32](10)}),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](1),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Nodes:     ptr.To(nodeName),
+								Partition: ptr.To("foo"),
+								UserName:  ptr.To("alice"),
+								StartTime: ptr.To(api.V0044Uint64NoValStruct{Number: ptr.To[int64](1000)}),
+								TimeLimit: ptr.To(api.V0044Uint32NoValStruct{Number: ptr.To[int32](20)}),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](3),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Nodes:     ptr.To("other-0"),
+								Partition: ptr.To("foo"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](4),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition: ptr.To("bar,foo"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](5),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition: ptr.To("bar"),
+							},
+						},
+					},
+				}
+				sclient := fake.NewClientBuilder().WithLists(nodeList, jobList).Build()
+				return fields{
+					clientMap: testutils.NewClientMap(controller.Name, controller.Namespace, sclient),
+				}
+			}(),
+			args: args{
+				ctx:     ctx,
+				nodeset: nodeset,
+				pods: []*corev1.Pod{
+					nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, ""),
+				},
+			},
+			want: SlurmNodeStatus{
+				Total: 1,
+
+				Allocated: 1,
+
+				RunningJobs: 2,
+				PendingJobs: 1,
+
+				NodeStates: func() map[string][]corev1.PodCondition {
+					nodeStates := make(map[string][]corev1.PodCondition)
+					nodeStates[nodesetutils.GetSlurmNodeName(nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, ""))] = []corev1.PodCondition{
+						{
+							Type:    slurmconditions.PodConditionAllocated,
+							Status:  corev1.ConditionTrue,
+							Message: "",
+						},
+					}
+					return nodeStates
+				}(),
+				NodeJobs: map[string]SlurmNodeJobs{
+					nodesetutils.GetSlurmNodeName(nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")): {
+						JobIDs:     []int32{1, 2},
+						Users:      []string{"alice", "bob"},
+						Partitions: []string{"foo"},
+						EndTime:    time.Unix(1000, 0).Add(20 * time.Minute),
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &realSlurmControl{
 				clientMap: tt.fields.clientMap,
 			}
-			got, err := r.CalculateNodeStatus(tt.args.ctx, tt.args.nodeset, tt.args.pods)
+			jobs, _ := r.ListJobs(tt.args.ctx, tt.args.nodeset)
+			got, err := r.CalculateNodeStatus(tt.args.ctx, tt.args.nodeset, tt.args.pods, jobs)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -2217,6 +2616,130 @@ func Test_realSlurmControl_CalculateNodeStatus(t *testing.T) {
 	}
 }
 
+func TestSlurmJobs_CalculateDemand(t *testing.T) {
+	type fields struct {
+		jobList *types.V0044JobInfoList
+	}
+	type args struct {
+		partitions []string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   SlurmJobDemand
+	}{
+		{
+			name: "Empty",
+			fields: fields{
+				jobList: &types.V0044JobInfoList{},
+			},
+			args: args{
+				partitions: []string{"foo"},
+			},
+			want: SlurmJobDemand{},
+		},
+		{
+			name: "Pending and running",
+			fields: fields{
+				jobList: &types.V0044JobInfoList{
+					Items: []types.V0044JobInfo{
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](1),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+								Partition: ptr.To("foo"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](2),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition:   ptr.To("bar,foo"),
+								StateReason: ptr.To("Resources"),
+								NodeCount:   ptr.To(api.V0044Uint32NoValStruct{Number: ptr.To[int32](4)}),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](3),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition:   ptr.To("foo"),
+								StateReason: ptr.To("Priority"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](4),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition:   ptr.To("foo"),
+								StateReason: ptr.To("JobHeldUser"),
+								NodeCount:   ptr.To(api.V0044Uint32NoValStruct{Number: ptr.To[int32](8)}),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](5),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition:   ptr.To("bar"),
+								StateReason: ptr.To("Resources"),
+								NodeCount:   ptr.To(api.V0044Uint32NoValStruct{Number: ptr.To[int32](2)}),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:     ptr.To[int32](6),
+								JobState:  ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateCOMPLETED}),
+								Partition: ptr.To("foo"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](7),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition:   ptr.To("foo"),
+								StateReason: ptr.To("QOSMaxNodePerJobLimit"),
+								NodeCount:   ptr.To(api.V0044Uint32NoValStruct{Number: ptr.To[int32](16)}),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](8),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition:   ptr.To("foo"),
+								StateReason: ptr.To("AssocGrpNodeLimit"),
+							},
+						},
+						{
+							V0044JobInfo: api.V0044JobInfo{
+								JobId:       ptr.To[int32](9),
+								JobState:    ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStatePENDING}),
+								Partition:   ptr.To("foo"),
+								StateReason: ptr.To("ReqNodeNotAvail"),
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				partitions: []string{"foo"},
+			},
+			want: SlurmJobDemand{
+				PendingJobs:  2,
+				PendingNodes: 5,
+				RunningJobs:  1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &SlurmJobs{items: tt.fields.jobList.Items}
+			got := j.CalculateDemand(tt.args.partitions)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_realSlurmControl_GetNodeDeadlines(t *testing.T) {
 	ctx := context.Background()
 	controller := &slinkyv1beta1.Controller{
@@ -2376,6 +2899,66 @@ func Test_realSlurmControl_GetNodeDeadlines(t *testing.T) {
 	}
 }
 
+func Test_realSlurmControl_GetNodePowerStates(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 4)
+	tests := []struct {
+		name      string
+		nodeList  *types.V0044NodeList
+		nodeNames []string
+		want      map[string]SlurmNodePowerState
+	}{
+		{
+			name:      "empty",
+			nodeList:  &types.V0044NodeList{},
+			nodeNames: []string{"foo-0"},
+			want:      map[string]SlurmNodePowerState{},
+		},
+		{
+			name: "power states",
+			nodeList: &types.V0044NodeList{
+				Items: []types.V0044Node{
+					{V0044Node: api.V0044Node{
+						Name:  ptr.To("foo-0"),
+						State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE}),
+					}},
+					{V0044Node: api.V0044Node{
+						Name:  ptr.To("foo-1"),
+						State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStatePOWERINGUP}),
+					}},
+					{V0044Node: api.V0044Node{
+						Name:  ptr.To("foo-2"),
+						State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStatePOWERINGDOWN}),
+					}},
+					{V0044Node: api.V0044Node{
+						Name:  ptr.To("foo-3"),
+						State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStatePOWEREDDOWN}),
+					}},
+					{V0044Node: api.V0044Node{
+						Name:  ptr.To("bar-0"),
+						State: ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStatePOWEREDDOWN}),
+					}},
+				},
+			},
+			nodeNames: []string{"foo-0", "foo-1", "foo-2", "foo-3", "foo-4"},
+			want: map[string]SlurmNodePowerState{
+				"foo-0": SlurmNodePoweredUp,
+				"foo-1": SlurmNodePoweringUp,
+				"foo-2": SlurmNodePoweringDown,
+				"foo-3": SlurmNodePoweredDown,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithLists(tt.nodeList).Build()
+			r := NewSlurmControl(testutils.NewClientMap(nodeset.Spec.ControllerRef.Name, nodeset.Namespace, sclient))
+			got, err := r.GetNodePowerStates(context.Background(), nodeset, tt.nodeNames)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func Test_realSlurmControl_GetNodesForPods(t *testing.T) {
 	controller := &slinkyv1beta1.Controller{
 		ObjectMeta: metav1.ObjectMeta{
@@ -3525,7 +4108,7 @@ func Test_realSlurmControl_GetDefunctNodesForNodeSet(t *testing.T) {
 									api.V0044NodeStateDOWN,
 									api.V0044NodeStateNOTRESPONDING,
 								}),
-								Comment: podInfo(nodeset, defunctPodName, "worker-a"),
+								Extra: podInfo(nodeset, defunctPodName, "worker-a"),
 							},
 						},
 						{
@@ -3619,3 +4202,341 @@ func Test_realSlurmControl_DeleteNode(t *testing.T) {
 	getErr := sclient.Get(ctx, node.GetKey(), checkNode)
 	require.True(t, errors.Is(getErr, slurmerrors.ErrObjectNotFound), "DeleteNode() node still exists: %v", getErr)
 }
+
+// cachedNodeListFn returns nodeList and fails the test if the Slurm node list
+// bypasses the node cache, which is refreshed once per reconcile by RefreshNodeCache.
+func cachedNodeListFn(t *testing.T, nodeList *types.V0044NodeList) func(context.Context, object.ObjectList, ...client.ListOption) error {
+	return func(_ context.Context, list object.ObjectList, opts ...client.ListOption) error {
+		for _, opt := range opts {
+			if listOpts, ok := opt.(*client.ListOptions); ok && listOpts.RefreshCache {
+				t.Errorf("List() refreshed the node cache")
+			}
+		}
+		*list.(*types.V0044NodeList) = *nodeList
+		return nil
+	}
+}
+
+func Test_realSlurmControl_GetFutureNodesForNodeSet(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 2)
+	tests := []struct {
+		name     string
+		nodeList *types.V0044NodeList
+		want     []string
+	}{
+		{
+			name:     "empty",
+			nodeList: &types.V0044NodeList{},
+			want:     []string{},
+		},
+		{
+			name: "future nodes of nodeset",
+			nodeList: &types.V0044NodeList{
+				Items: []types.V0044Node{
+					{V0044Node: api.V0044Node{
+						Name:     ptr.To("foo-3"),
+						State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateFUTURE}),
+						Features: ptr.To(api.V0044CsvString{"foo"}),
+					}},
+					{V0044Node: api.V0044Node{
+						Name:     ptr.To("foo-0"),
+						State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateIDLE}),
+						Features: ptr.To(api.V0044CsvString{"foo"}),
+					}},
+					{V0044Node: api.V0044Node{
+						Name:     ptr.To("foo-2"),
+						State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateFUTURE}),
+						Features: ptr.To(api.V0044CsvString{"a", "foo"}),
+					}},
+					{V0044Node: api.V0044Node{
+						Name:     ptr.To("bar-0"),
+						State:    ptr.To([]api.V0044NodeState{api.V0044NodeStateFUTURE}),
+						Features: ptr.To(api.V0044CsvString{"bar"}),
+					}},
+				},
+			},
+			want: []string{"foo-2", "foo-3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				List: cachedNodeListFn(t, tt.nodeList),
+			}).WithLists(tt.nodeList).Build()
+			r := NewSlurmControl(testutils.NewClientMap(nodeset.Spec.ControllerRef.Name, nodeset.Namespace, sclient))
+			got, err := r.GetFutureNodesForNodeSet(context.Background(), nodeset)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_realSlurmControl_CreateFutureNodes(t *testing.T) {
+	nodeset := newNodeSet("foo", "slurm", 3)
+	tests := []struct {
+		name         string
+		nodeList     *types.V0044NodeList
+		createErr    error
+		nodeNames    []string
+		want         []string
+		wantNodeConf []string
+		wantErr      bool
+	}{
+		{
+			name:         "create missing nodes",
+			nodeList:     &types.V0044NodeList{Items: []types.V0044Node{{V0044Node: api.V0044Node{Name: ptr.To("foo-0")}}}},
+			nodeNames:    []string{"foo-0", "foo-1", "foo-2"},
+			want:         []string{"foo-1", "foo-2"},
+			wantNodeConf: []string{"NodeName=foo-1 State=FUTURE Features=foo", "NodeName=foo-2 State=FUTURE Features=foo"},
+		},
+		{
+			name:         "nothing to create",
+			nodeList:     &types.V0044NodeList{Items: []types.V0044Node{{V0044Node: api.V0044Node{Name: ptr.To("foo-0")}}}},
+			nodeNames:    []string{"foo-0"},
+			want:         []string{},
+			wantNodeConf: []string{},
+		},
+		{
+			name:         "create error",
+			nodeList:     &types.V0044NodeList{},
+			createErr:    errors.New("Internal Server Error"),
+			nodeNames:    []string{"foo-0"},
+			want:         []string{},
+			wantNodeConf: []string{"NodeName=foo-0 State=FUTURE Features=foo"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotNodeConf := []string{}
+			sclient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(_ context.Context, _ object.Object, req any, _ ...client.CreateOption) error {
+					gotNodeConf = append(gotNodeConf, req.(api.V0044OpenapiCreateNodeReq).NodeConf)
+					return tt.createErr
+				},
+				List: cachedNodeListFn(t, tt.nodeList),
+			}).WithLists(tt.nodeList).Build()
+			r := NewSlurmControl(testutils.NewClientMap(nodeset.Spec.ControllerRef.Name, nodeset.Namespace, sclient))
+			got, err := r.CreateFutureNodes(context.Background(), nodeset, tt.nodeNames, "Features=foo")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateFutureNodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantNodeConf, gotNodeConf)
+		})
+	}
+}
+
+func Test_realSlurmControl_MakeNodeDown(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	tests := []struct {
+		name       string
+		node       *types.V0044Node
+		wantReason string
+	}{
+		{
+			name: "draining",
+			node: &types.V0044Node{
+				V0044Node: api.V0044Node{
+					Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+					State: ptr.To([]api.V0044NodeState{
+						api.V0044NodeStateALLOCATED,
+						api.V0044NodeStateDRAIN,
+					}),
+				},
+			},
+			wantReason: FormatNodeReason("test"),
+		},
+		{
+			name: "already down",
+			node: &types.V0044Node{
+				V0044Node: api.V0044Node{
+					Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+					State: ptr.To([]api.V0044NodeState{
+						api.V0044NodeStateDOWN,
+					}),
+					Reason: ptr.To("other"),
+				},
+			},
+			wantReason: "other",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithObjects(tt.node).Build()
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, nodeset.Namespace, sclient))
+			err := r.MakeNodeDown(ctx, nodeset, pod, "test")
+			require.NoError(t, err)
+
+			checkNode := &types.V0044Node{}
+			require.NoError(t, sclient.Get(ctx, tt.node.GetKey(), checkNode))
+			require.True(t, checkNode.GetStateAsSet().Has(api.V0044NodeStateDOWN))
+			require.Equal(t, tt.wantReason, ptr.Deref(checkNode.Reason, ""))
+		})
+	}
+}
+
+func Test_realSlurmControl_NodeReboot(t *testing.T) {
+	ctx := context.Background()
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	pod.UID = "uid-old"
+	newPod := pod.DeepCopy()
+	newPod.UID = "uid-new"
+	newNode := func(nextState api.V0044NodeNextStateAfterReboot, reason string) *types.V0044Node {
+		node := &types.V0044Node{
+			V0044Node: api.V0044Node{
+				Name: ptr.To(nodesetutils.GetSlurmNodeName(pod)),
+				State: ptr.To([]api.V0044NodeState{
+					api.V0044NodeStateIDLE,
+					api.V0044NodeStateREBOOTISSUED,
+				}),
+				NextStateAfterReboot: ptr.To([]api.V0044NodeNextStateAfterReboot{nextState}),
+			},
+		}
+		if reason != "" {
+			node.Reason = ptr.To(reason)
+		}
+		return node
+	}
+	tests := []struct {
+		name       string
+		node       *types.V0044Node
+		wantStates []api.V0044NodeState
+		wantReason string
+	}{
+		{
+			name:       "Next state resume",
+			node:       newNode(api.V0044NodeNextStateAfterRebootRESUME, "kernel update"),
+			wantStates: []api.V0044NodeState{api.V0044NodeStateIDLE},
+		},
+		{
+			name:       "Next state down",
+			node:       newNode(api.V0044NodeNextStateAfterRebootDOWN, "kernel update"),
+			wantStates: []api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateDOWN},
+			wantReason: "kernel update",
+		},
+		{
+			name:       "Next state drain without reason",
+			node:       newNode(api.V0044NodeNextStateAfterRebootDRAIN, ""),
+			wantStates: []api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStateDRAIN},
+			wantReason: FormatNodeReason("Node rebooted"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithUpdateFn(slurmUpdateFn).WithObjects(tt.node).Build()
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, nodeset.Namespace, sclient))
+
+			rebootPodUID, err := r.GetNodeRebootPod(ctx, nodeset, pod)
+			require.NoError(t, err)
+			require.Empty(t, rebootPodUID)
+
+			require.NoError(t, r.SetNodeRebootPod(ctx, nodeset, pod))
+			rebootPodUID, err = r.GetNodeRebootPod(ctx, nodeset, newPod)
+			require.NoError(t, err)
+			require.Equal(t, string(pod.UID), rebootPodUID)
+
+			// The replacement pod info does not lose the reboot pod.
+			require.NoError(t, r.UpdateNodeWithPodInfo(ctx, nodeset, newPod, nil))
+			rebootPodUID, err = r.GetNodeRebootPod(ctx, nodeset, newPod)
+			require.NoError(t, err)
+			require.Equal(t, string(pod.UID), rebootPodUID)
+
+			require.NoError(t, r.MakeNodeRebooted(ctx, nodeset, newPod))
+			checkNode := &types.V0044Node{}
+			require.NoError(t, sclient.Get(ctx, tt.node.GetKey(), checkNode))
+			require.ElementsMatch(t, tt.wantStates, ptr.Deref(checkNode.State, nil))
+			require.Equal(t, tt.wantReason, ptr.Deref(checkNode.Reason, ""))
+			rebootPodUID, err = r.GetNodeRebootPod(ctx, nodeset, newPod)
+			require.NoError(t, err)
+			require.Empty(t, rebootPodUID)
+		})
+	}
+}
+
+func Test_realSlurmControl_GetRunningJobsForPod(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	nodeset := newNodeSet("foo", controller.Name, 1)
+	pod := nodesetutils.NewNodeSetStatefulSetPod(kubefake.NewFakeClient(), nodeset, controller, 0, "")
+	newJob := func(jobID int32, nodes string, state api.V0044JobInfoJobState) types.V0044JobInfo {
+		return types.V0044JobInfo{
+			V0044JobInfo: api.V0044JobInfo{
+				JobId:    ptr.To(jobID),
+				JobState: ptr.To([]api.V0044JobInfoJobState{state}),
+				Nodes:    ptr.To(nodes),
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		jobList *types.V0044JobInfoList
+		want    []int32
+	}{
+		{
+			name:    "empty",
+			jobList: &types.V0044JobInfoList{},
+			want:    []int32{},
+		},
+		{
+			name: "running jobs on node",
+			jobList: &types.V0044JobInfoList{
+				Items: []types.V0044JobInfo{
+					newJob(3, "foo-[0-1]", api.V0044JobInfoJobStateRUNNING),
+					newJob(1, "foo-0", api.V0044JobInfoJobStateRUNNING),
+					newJob(2, "foo-0", api.V0044JobInfoJobStatePENDING),
+					newJob(4, "foo-1", api.V0044JobInfoJobStateRUNNING),
+				},
+			},
+			want: []int32{1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().WithLists(tt.jobList).Build()
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, nodeset.Namespace, sclient))
+			got, err := r.GetRunningJobsForPod(context.Background(), nodeset, pod)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_realSlurmControl_CancelJobs(t *testing.T) {
+	ctx := context.Background()
+	nodeset := newNodeSet("foo", "slurm", 1)
+	job := &types.V0044JobInfo{
+		V0044JobInfo: api.V0044JobInfo{
+			JobId:    ptr.To[int32](1),
+			JobState: ptr.To([]api.V0044JobInfoJobState{api.V0044JobInfoJobStateRUNNING}),
+		},
+	}
+	sclient := fake.NewClientBuilder().WithObjects(job).Build()
+	r := NewSlurmControl(testutils.NewClientMap(nodeset.Spec.ControllerRef.Name, nodeset.Namespace, sclient))
+
+	err := r.CancelJobs(ctx, nodeset, []int32{1, 2})
+	require.NoError(t, err)
+
+	checkJob := &types.V0044JobInfo{}
+	getErr := sclient.Get(ctx, job.GetKey(), checkJob)
+	require.True(t, errors.Is(getErr, slurmerrors.ErrObjectNotFound), "CancelJobs() job still exists: %v", getErr)
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/cronutils"
+)
+
+// GetMaintenanceWindow returns the maintenance window of the ScheduledUpdate
+// strategy which is active at now, or else the next one. It returns nil if the
+// NodeSet has no such maintenance window.
+func GetMaintenanceWindow(nodeset *slinkyv1beta1.NodeSet, now time.Time) *slinkyv1beta1.NodeSetMaintenanceWindow {
+	if nodeset.Spec.UpdateStrategy.Type != slinkyv1beta1.ScheduledUpdateNodeSetStrategyType {
+		return nil
+	}
+	scheduledUpdate := nodeset.Spec.UpdateStrategy.ScheduledUpdate
+	duration := scheduledUpdate.Duration.Duration
+
+	var start, end time.Time
+	if scheduledUpdate.Schedule != "" {
+		schedule, err := cronutils.Parse(scheduledUpdate.Schedule)
+		if err != nil {
+			return nil
+		}
+		start, end = cronutils.GetWindow(schedule, duration, now)
+	} else {
+		start = scheduledUpdate.StartTime.Time
+		end = start.Add(duration)
+	}
+	if start.IsZero() || !now.Before(end) {
+		return nil
+	}
+
+	// NOTE: times are made local to match how metav1.Time is decoded.
+	return &slinkyv1beta1.NodeSetMaintenanceWindow{
+		StartTime: metav1.NewTime(start.Local()),
+		EndTime:   metav1.NewTime(end.Local()),
+		Active:    !now.Before(start),
+	}
+}
+
+// GetScheduledUpdate returns the ScheduledUpdate strategy of the NodeSet, whose
+// StartTime is the maintenance window of its schedule which is active at now,
+// or else the next one.
+func GetScheduledUpdate(nodeset *slinkyv1beta1.NodeSet, now time.Time) slinkyv1beta1.ScheduledUpdateNodeSetStrategy {
+	scheduledUpdate := *nodeset.Spec.UpdateStrategy.ScheduledUpdate.DeepCopy()
+	if scheduledUpdate.Schedule == "" {
+		return scheduledUpdate
+	}
+	scheduledUpdate.StartTime = metav1.Time{}
+	if window := GetMaintenanceWindow(nodeset, now); window != nil {
+		scheduledUpdate.StartTime = window.StartTime
+	}
+	return scheduledUpdate
+}
+
+// IsMaintenanceWindowActive returns true if the NodeSet is not limited to the
+// maintenance windows of a schedule, or one is active at now.
+func IsMaintenanceWindowActive(nodeset *slinkyv1beta1.NodeSet, now time.Time) bool {
+	if nodeset.Spec.UpdateStrategy.ScheduledUpdate.Schedule == "" {
+		return true
+	}
+	window := GetMaintenanceWindow(nodeset, now)
+	return window != nil && window.Active
+}
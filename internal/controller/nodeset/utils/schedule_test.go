@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestGetMaintenanceWindow(t *testing.T) {
+	april := time.Date(2026, time.April, 5, 2, 0, 0, 0, time.UTC)
+	newNodeSet := func(schedule string, startTime time.Time) *slinkyv1beta1.NodeSet {
+		return &slinkyv1beta1.NodeSet{
+			Spec: slinkyv1beta1.NodeSetSpec{
+				UpdateStrategy: slinkyv1beta1.NodeSetUpdateStrategy{
+					Type: slinkyv1beta1.ScheduledUpdateNodeSetStrategyType,
+					ScheduledUpdate: slinkyv1beta1.ScheduledUpdateNodeSetStrategy{
+						StartTime: metav1.NewTime(startTime),
+						Schedule:  schedule,
+						Duration:  metav1.Duration{Duration: 6 * time.Hour},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		nodeset *slinkyv1beta1.NodeSet
+		now     time.Time
+		want    *slinkyv1beta1.NodeSetMaintenanceWindow
+	}{
+		{
+			name: "Not scheduled",
+			nodeset: func() *slinkyv1beta1.NodeSet {
+				nodeset := newNodeSet("0 2 * * SUN#1", time.Time{})
+				nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.RollingUpdateNodeSetStrategyType
+				return nodeset
+			}(),
+			now:  april,
+			want: nil,
+		},
+		{
+			name:    "Next window of schedule",
+			nodeset: newNodeSet("0 2 * * SUN#1", time.Time{}),
+			now:     april.Add(-time.Hour),
+			want: &slinkyv1beta1.NodeSetMaintenanceWindow{
+				StartTime: metav1.NewTime(april.Local()),
+				EndTime:   metav1.NewTime(april.Add(6 * time.Hour).Local()),
+			},
+		},
+		{
+			name:    "Active window of schedule",
+			nodeset: newNodeSet("0 2 * * SUN#1", time.Time{}),
+			now:     april.Add(time.Hour),
+			want: &slinkyv1beta1.NodeSetMaintenanceWindow{
+				StartTime: metav1.NewTime(april.Local()),
+				EndTime:   metav1.NewTime(april.Add(6 * time.Hour).Local()),
+				Active:    true,
+			},
+		},
+		{
+			name:    "Invalid schedule",
+			nodeset: newNodeSet("0 2 * *", time.Time{}),
+			now:     april,
+			want:    nil,
+		},
+		{
+			name:    "Active window of start time",
+			nodeset: newNodeSet("", april),
+			now:     april.Add(time.Hour),
+			want: &slinkyv1beta1.NodeSetMaintenanceWindow{
+				StartTime: metav1.NewTime(april.Local()),
+				EndTime:   metav1.NewTime(april.Add(6 * time.Hour).Local()),
+				Active:    true,
+			},
+		},
+		{
+			name:    "Past window of start time",
+			nodeset: newNodeSet("", april),
+			now:     april.Add(7 * time.Hour),
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, GetMaintenanceWindow(tt.nodeset, tt.now))
+		})
+	}
+}
+
+func TestGetScheduledUpdate(t *testing.T) {
+	april := time.Date(2026, time.April, 5, 2, 0, 0, 0, time.UTC)
+	nodeset := &slinkyv1beta1.NodeSet{
+		Spec: slinkyv1beta1.NodeSetSpec{
+			UpdateStrategy: slinkyv1beta1.NodeSetUpdateStrategy{
+				Type: slinkyv1beta1.ScheduledUpdateNodeSetStrategyType,
+				ScheduledUpdate: slinkyv1beta1.ScheduledUpdateNodeSetStrategy{
+					Schedule: "0 2 * * SUN#1",
+					Duration: metav1.Duration{Duration: 6 * time.Hour},
+					Flags:    []string{"IGNORE_JOBS"},
+				},
+			},
+		},
+	}
+
+	got := GetScheduledUpdate(nodeset, april.Add(-time.Hour))
+	require.True(t, april.Equal(got.StartTime.Time))
+	require.Equal(t, []string{"IGNORE_JOBS"}, got.Flags)
+	require.True(t, nodeset.Spec.UpdateStrategy.ScheduledUpdate.StartTime.IsZero())
+
+	require.False(t, IsMaintenanceWindowActive(nodeset, april.Add(-time.Hour)))
+	require.True(t, IsMaintenanceWindowActive(nodeset, april.Add(time.Hour)))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/SlinkyProject/slurm-operator/internal/utils/mathutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/podutils"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 // ActivePods type allows custom sorting of pods so a controller can pick the best ones to delete.
@@ -55,6 +56,12 @@ func (o ActivePods) Less(i, j int) bool {
 		return !podutil.IsPodReady(pod1)
 	}
 
+	// Step: idle < busy
+	// If only one of the pods is a busy Slurm node, the idle one is smaller
+	if slurmconditions.IsNodeBusy(&pod1.Status) != slurmconditions.IsNodeBusy(&pod2.Status) {
+		return !slurmconditions.IsNodeBusy(&pod1.Status)
+	}
+
 	// Step: lower pod-deletion-cost < higher pod-deletion-cost
 	podDeletionCost1, _ := structutils.GetNumberFromAnnotations(pod1.Annotations, slinkyv1beta1.AnnotationPodDeletionCost)
 	podDeletionCost2, _ := structutils.GetNumberFromAnnotations(pod2.Annotations, slinkyv1beta1.AnnotationPodDeletionCost)
@@ -15,8 +15,10 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
 )
 
 func TestSortingActivePods(t *testing.T) {
@@ -218,6 +220,25 @@ func TestSortingActivePods(t *testing.T) {
 				"deadlineLater",
 			},
 		},
+		{
+			name: "Sort busy",
+			pods: []corev1.Pod{
+				newRunningPod("ordinal-0", nil),
+				newBusyPod(newRunningPod("ordinal-1", nil)),
+				newBusyPod(newRunningPod("ordinal-2", map[string]string{
+					slinkyv1beta1.AnnotationPodDeletionCost: "-10",
+				})),
+				newRunningPod("ordinal-3", map[string]string{
+					slinkyv1beta1.AnnotationPodDeletionCost: "10",
+				}),
+			},
+			wantOrder: []string{
+				"ordinal-0",
+				"ordinal-3",
+				"ordinal-2",
+				"ordinal-1",
+			},
+		},
 		{
 			name: "Sort deletion cost",
 			pods: []corev1.Pod{
@@ -323,6 +344,15 @@ func newRunningPod(name string, annotations map[string]string) corev1.Pod {
 	}
 }
 
+// newBusyPod returns the pod as a busy Slurm node.
+func newBusyPod(pod corev1.Pod) corev1.Pod {
+	pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+		Type:   slurmconditions.PodConditionAllocated,
+		Status: corev1.ConditionTrue,
+	})
+	return pod
+}
+
 func Test_afterOrZero(t *testing.T) {
 	type args struct {
 		t1 time.Time
@@ -430,6 +460,20 @@ func TestSplitActivePods(t *testing.T) {
 			wantPods1Names: []string{"foo-1", "foo-0"},
 			wantPods2Names: []string{},
 		},
+		{
+			name: "Busy pods with higher ordinals",
+			args: args{
+				pods: []*corev1.Pod{
+					ptr.To(newRunningPod("foo-0", nil)),
+					ptr.To(newRunningPod("foo-1", nil)),
+					ptr.To(newBusyPod(newRunningPod("foo-2", nil))),
+					ptr.To(newBusyPod(newRunningPod("foo-3", nil))),
+				},
+				partition: 2,
+			},
+			wantPods1Names: []string{"foo-1", "foo-0"},
+			wantPods2Names: []string{"foo-3", "foo-2"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+func NewNodeSetEventHandler(reader client.Reader) *NodeSetEventHandler {
+	return &NodeSetEventHandler{
+		Reader:      reader,
+		refResolver: refresolver.New(reader),
+	}
+}
+
+var _ handler.EventHandler = &NodeSetEventHandler{}
+
+// NodeSetEventHandler enqueues the Partitions of the NodeSet's Controller, as
+// the NodeSet labels may change which Partitions select it.
+type NodeSetEventHandler struct {
+	client.Reader
+	refResolver *refresolver.RefResolver
+}
+
+func (e *NodeSetEventHandler) Create(
+	ctx context.Context,
+	evt event.CreateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *NodeSetEventHandler) Update(
+	ctx context.Context,
+	evt event.UpdateEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.ObjectOld, q)
+	e.enqueueRequest(ctx, evt.ObjectNew, q)
+}
+
+func (e *NodeSetEventHandler) Delete(
+	ctx context.Context,
+	evt event.DeleteEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	e.enqueueRequest(ctx, evt.Object, q)
+}
+
+func (e *NodeSetEventHandler) Generic(
+	ctx context.Context,
+	evt event.GenericEvent,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	// Intentionally blank
+}
+
+func (e *NodeSetEventHandler) enqueueRequest(
+	ctx context.Context,
+	obj client.Object,
+	q workqueue.TypedRateLimitingInterface[reconcile.Request],
+) {
+	logger := log.FromContext(ctx)
+
+	nodeset, ok := obj.(*slinkyv1beta1.NodeSet)
+	if !ok {
+		return
+	}
+
+	controller, err := e.refResolver.GetController(ctx, nodeset.Spec.ControllerRef, nodeset.Namespace)
+	if err != nil {
+		return
+	}
+
+	list, err := e.refResolver.GetPartitionsForController(ctx, controller)
+	if err != nil {
+		logger.Error(err, "failed to list Partitions referencing Controller")
+		return
+	}
+
+	for _, item := range list.Items {
+		objectutils.EnqueueRequest(q, &item)
+	}
+}
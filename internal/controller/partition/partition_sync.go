@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/partition/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+// Sync implements control logic for synchronizing a Partition.
+//
+// The Partition configuration is rendered into the slurm.conf of its
+// Controller, which is reconfigured upon change. The State is also applied live
+// to the Slurm partition, then the selected NodeSets and the Slurm partition
+// counts are synced.
+func (r *PartitionReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	partition := &slinkyv1beta1.Partition{}
+	if err := r.Get(ctx, req.NamespacedName, partition); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Partition has been deleted")
+			return nil
+		}
+		return err
+	}
+	partition = partition.DeepCopy()
+	key := objectutils.KeyFunc(partition)
+
+	if !partition.DeletionTimestamp.IsZero() {
+		logger.Info("Partition is being deleted, skipping sync")
+		return nil
+	} else {
+		durationStore.Push(key, 30*time.Second)
+	}
+
+	if err := r.syncState(ctx, partition); err != nil {
+		return r.syncStatus(ctx, partition, err)
+	}
+
+	return r.syncStatus(ctx, partition)
+}
+
+// syncState applies the Partition State to the Slurm partition, such that it
+// takes effect without waiting for a reconfigure.
+func (r *PartitionReconciler) syncState(
+	ctx context.Context,
+	partition *slinkyv1beta1.Partition,
+) error {
+	logger := log.FromContext(ctx)
+
+	if partition.Spec.State == "" {
+		return nil
+	}
+
+	controller, err := r.refResolver.GetController(ctx, partition.Spec.ControllerRef, partition.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := r.slurmControl.UpdatePartitionState(ctx, controller, partition.PartitionName(), partition.Spec.State); err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			logger.V(1).Info("Waiting for Slurm client to update Partition state")
+			return nil
+		}
+		r.eventRecorder.Eventf(partition, nil, corev1.EventTypeWarning, UpdateStateFailedReason, "UpdateState",
+			"Failed to set Slurm partition state to %s: %v", partition.Spec.State, err)
+		return fmt.Errorf("failed to update Partition(%s) state: %w", klog.KObj(partition), err)
+	}
+
+	return nil
+}
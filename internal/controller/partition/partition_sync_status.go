@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/partition/slurmcontrol"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *PartitionReconciler) syncStatus(
+	ctx context.Context,
+	partition *slinkyv1beta1.Partition,
+	errors ...error,
+) error {
+	if err := r.syncPartitionStatus(ctx, partition); err != nil {
+		errors = append(errors, err)
+	}
+
+	return utilerrors.NewAggregate(errors)
+}
+
+func (r *PartitionReconciler) syncPartitionStatus(
+	ctx context.Context,
+	partition *slinkyv1beta1.Partition,
+) error {
+	logger := log.FromContext(ctx)
+
+	controller, err := r.refResolver.GetController(ctx, partition.Spec.ControllerRef, partition.Namespace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		controller = nil
+	}
+	nodesetList, err := r.refResolver.GetNodeSetsForController(ctx, controller)
+	if err != nil {
+		return err
+	}
+
+	// The Slurm counts are retained while the Slurm client is unavailable.
+	newStatus := slinkyv1beta1.PartitionStatus{
+		Nodes:       partition.Status.Nodes,
+		RunningJobs: partition.Status.RunningJobs,
+		PendingJobs: partition.Status.PendingJobs,
+		Conditions:  []metav1.Condition{},
+	}
+	newStatus.Conditions = append(newStatus.Conditions, partition.Status.Conditions...)
+	for _, nodeset := range common.GetNodeSetsForPartition(partition, nodesetList) {
+		newStatus.NodeSets = append(newStatus.NodeSets, nodeset.Name)
+	}
+
+	if controller != nil {
+		status, err := r.slurmControl.GetPartitionStatus(ctx, controller, partition.PartitionName())
+		if err != nil {
+			if !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+				return err
+			}
+		} else {
+			newStatus.Nodes = status.Nodes
+			newStatus.RunningJobs = status.RunningJobs
+			newStatus.PendingJobs = status.PendingJobs
+		}
+	}
+
+	if apiequality.Semantic.DeepEqual(partition.Status, newStatus) {
+		logger.V(2).Info("Partition Status has not changed, skipping status update",
+			"partition", klog.KObj(partition), "status", partition.Status)
+		return nil
+	}
+
+	if err := r.updateStatus(ctx, partition, &newStatus); err != nil {
+		return fmt.Errorf("error updating Partition(%s) status: %w",
+			klog.KObj(partition), err)
+	}
+
+	return nil
+}
+
+func (r *PartitionReconciler) updateStatus(
+	ctx context.Context,
+	partition *slinkyv1beta1.Partition,
+	newStatus *slinkyv1beta1.PartitionStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	namespacedName := types.NamespacedName{
+		Namespace: partition.GetNamespace(),
+		Name:      partition.GetName(),
+	}
+
+	logger.V(1).Info("Pending Partition Status update",
+		"partition", klog.KObj(partition), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.Partition{}
+		if err := r.Get(ctx, namespacedName, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/partition/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+func init() {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+}
+
+type fakeSlurmControl struct {
+	status *slurmcontrol.PartitionStatus
+	err    error
+
+	states   map[string]slinkyv1beta1.PartitionState
+	stateErr error
+}
+
+func (f fakeSlurmControl) GetPartitionStatus(context.Context, *slinkyv1beta1.Controller, string) (*slurmcontrol.PartitionStatus, error) {
+	return f.status, f.err
+}
+
+func (f fakeSlurmControl) UpdatePartitionState(_ context.Context, _ *slinkyv1beta1.Controller, partitionName string, state slinkyv1beta1.PartitionState) error {
+	if f.stateErr != nil {
+		return f.stateErr
+	}
+	if f.states != nil {
+		f.states[partitionName] = state
+	}
+	return nil
+}
+
+func TestPartitionReconciler_syncPartitionStatus(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	newNodeSet := func(name string, labels map[string]string) *slinkyv1beta1.NodeSet {
+		return &slinkyv1beta1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      name,
+				Labels:    labels,
+			},
+			Spec: slinkyv1beta1.NodeSetSpec{
+				ControllerRef: corev1.LocalObjectReference{
+					Name: controller.Name,
+				},
+			},
+		}
+	}
+	newPartition := func(status slinkyv1beta1.PartitionStatus) *slinkyv1beta1.Partition {
+		return &slinkyv1beta1.Partition{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      "gpu-all",
+			},
+			Spec: slinkyv1beta1.PartitionSpec{
+				ControllerRef: corev1.LocalObjectReference{
+					Name: controller.Name,
+				},
+				NodeSetSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"gpu": "true"},
+				},
+			},
+			Status: status,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		partition  *slinkyv1beta1.Partition
+		status     *slurmcontrol.PartitionStatus
+		err        error
+		wantStatus slinkyv1beta1.PartitionStatus
+		wantErr    bool
+	}{
+		{
+			name:      "Counts and NodeSets",
+			partition: newPartition(slinkyv1beta1.PartitionStatus{}),
+			status: &slurmcontrol.PartitionStatus{
+				Nodes:       4,
+				RunningJobs: 2,
+				PendingJobs: 1,
+			},
+			wantStatus: slinkyv1beta1.PartitionStatus{
+				NodeSets:    []string{"gpu-a", "gpu-b"},
+				Nodes:       4,
+				RunningJobs: 2,
+				PendingJobs: 1,
+			},
+		},
+		{
+			name: "ErrNoSlurmClient retains counts",
+			partition: newPartition(slinkyv1beta1.PartitionStatus{
+				Nodes:       3,
+				RunningJobs: 1,
+			}),
+			err: slurmcontrol.ErrNoSlurmClient,
+			wantStatus: slinkyv1beta1.PartitionStatus{
+				NodeSets:    []string{"gpu-a", "gpu-b"},
+				Nodes:       3,
+				RunningJobs: 1,
+			},
+		},
+		{
+			name:      "Slurm error is returned",
+			partition: newPartition(slinkyv1beta1.PartitionStatus{}),
+			err:       errors.New("internal error"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fake.NewClientBuilder().
+				WithObjects(
+					controller.DeepCopy(),
+					newNodeSet("gpu-b", map[string]string{"gpu": "true"}),
+					newNodeSet("gpu-a", map[string]string{"gpu": "true"}),
+					newNodeSet("cpu", nil),
+					tt.partition.DeepCopy(),
+				).
+				WithStatusSubresource(&slinkyv1beta1.Partition{}).
+				Build()
+			r := &PartitionReconciler{
+				Client:      kubeClient,
+				refResolver: refresolver.New(kubeClient),
+				slurmControl: fakeSlurmControl{
+					status: tt.status,
+					err:    tt.err,
+				},
+			}
+
+			err := r.syncPartitionStatus(t.Context(), tt.partition)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got := &slinkyv1beta1.Partition{}
+			require.NoError(t, kubeClient.Get(t.Context(), client.ObjectKeyFromObject(tt.partition), got))
+			require.Equal(t, tt.wantStatus.NodeSets, got.Status.NodeSets)
+			require.Equal(t, tt.wantStatus.Nodes, got.Status.Nodes)
+			require.Equal(t, tt.wantStatus.RunningJobs, got.Status.RunningJobs)
+			require.Equal(t, tt.wantStatus.PendingJobs, got.Status.PendingJobs)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package partition
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/partition/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+)
+
+func TestPartitionReconciler_syncState(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	newPartition := func(state slinkyv1beta1.PartitionState) *slinkyv1beta1.Partition {
+		return &slinkyv1beta1.Partition{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      "gpu-all",
+			},
+			Spec: slinkyv1beta1.PartitionSpec{
+				ControllerRef: corev1.LocalObjectReference{
+					Name: controller.Name,
+				},
+				State: state,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		partition  *slinkyv1beta1.Partition
+		objs       []client.Object
+		stateErr   error
+		wantStates map[string]slinkyv1beta1.PartitionState
+		wantErr    bool
+	}{
+		{
+			name:      "Drain",
+			partition: newPartition(slinkyv1beta1.PartitionStateDrain),
+			objs:      []client.Object{controller.DeepCopy()},
+			wantStates: map[string]slinkyv1beta1.PartitionState{
+				"gpu-all": slinkyv1beta1.PartitionStateDrain,
+			},
+		},
+		{
+			name:      "Down",
+			partition: newPartition(slinkyv1beta1.PartitionStateDown),
+			objs:      []client.Object{controller.DeepCopy()},
+			wantStates: map[string]slinkyv1beta1.PartitionState{
+				"gpu-all": slinkyv1beta1.PartitionStateDown,
+			},
+		},
+		{
+			name:       "No state",
+			partition:  newPartition(""),
+			objs:       []client.Object{controller.DeepCopy()},
+			wantStates: map[string]slinkyv1beta1.PartitionState{},
+		},
+		{
+			name:       "Controller not found",
+			partition:  newPartition(slinkyv1beta1.PartitionStateUp),
+			wantStates: map[string]slinkyv1beta1.PartitionState{},
+		},
+		{
+			name:       "No Slurm client",
+			partition:  newPartition(slinkyv1beta1.PartitionStateUp),
+			objs:       []client.Object{controller.DeepCopy()},
+			stateErr:   slurmcontrol.ErrNoSlurmClient,
+			wantStates: map[string]slinkyv1beta1.PartitionState{},
+		},
+		{
+			name:       "Slurm error is returned",
+			partition:  newPartition(slinkyv1beta1.PartitionStateUp),
+			objs:       []client.Object{controller.DeepCopy()},
+			stateErr:   errors.New("internal error"),
+			wantStates: map[string]slinkyv1beta1.PartitionState{},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := fake.NewClientBuilder().
+				WithObjects(tt.objs...).
+				Build()
+			states := map[string]slinkyv1beta1.PartitionState{}
+			r := &PartitionReconciler{
+				Client:        kubeClient,
+				refResolver:   refresolver.New(kubeClient),
+				eventRecorder: events.NewFakeRecorder(10),
+				slurmControl: fakeSlurmControl{
+					states:   states,
+					stateErr: tt.stateErr,
+				},
+			}
+
+			err := r.syncState(t.Context(), tt.partition)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantStates, states)
+		})
+	}
+}
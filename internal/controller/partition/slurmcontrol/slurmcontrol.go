@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmerrors "github.com/SlinkyProject/slurm-client/pkg/errors"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+)
+
+var ErrNoSlurmClient = errors.New("NoSlurmClient")
+
+type SlurmControlInterface interface {
+	// GetPartitionStatus returns the node and job counts of the Slurm partition.
+	GetPartitionStatus(ctx context.Context, controller *slinkyv1beta1.Controller, partitionName string) (*PartitionStatus, error)
+	// UpdatePartitionState sets the state of the Slurm partition, unless it already has it.
+	UpdatePartitionState(ctx context.Context, controller *slinkyv1beta1.Controller, partitionName string, state slinkyv1beta1.PartitionState) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+type PartitionStatus struct {
+	Nodes       int32
+	RunningJobs int32
+	PendingJobs int32
+}
+
+// GetPartitionStatus implements SlurmControlInterface.
+func (r *realSlurmControl) GetPartitionStatus(ctx context.Context, controller *slinkyv1beta1.Controller, partitionName string) (*PartitionStatus, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do GetPartitionStatus()")
+		return nil, ErrNoSlurmClient
+	}
+
+	status := &PartitionStatus{}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		if !tolerateError(err) {
+			return nil, err
+		}
+	}
+	for _, node := range nodeList.Items {
+		nodePartitions := ptr.Deref(node.Partitions, slurmapi.V0044CsvString{})
+		if slices.Contains(nodePartitions, partitionName) {
+			status.Nodes++
+		}
+	}
+
+	opts := &slurmclient.ListOptions{
+		SkipCache: true,
+	}
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList, opts); err != nil {
+		if !tolerateError(err) {
+			return nil, err
+		}
+	}
+	for _, job := range jobList.Items {
+		// A pending job may be submitted to multiple partitions.
+		jobPartitions := strings.Split(ptr.Deref(job.Partition, ""), ",")
+		if !slices.Contains(jobPartitions, partitionName) {
+			continue
+		}
+		switch {
+		case job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStateRUNNING):
+			status.RunningJobs++
+		case job.GetStateAsSet().Has(slurmapi.V0044JobInfoJobStatePENDING):
+			status.PendingJobs++
+		}
+	}
+
+	return status, nil
+}
+
+// partitionState is the state of a Slurm partition, as reported and updated
+// through the Slurm REST API.
+type partitionState struct {
+	Name      string `json:"name,omitempty"`
+	Partition struct {
+		State []string `json:"state,omitempty"`
+	} `json:"partition"`
+}
+
+// UpdatePartitionState implements SlurmControlInterface.
+func (r *realSlurmControl) UpdatePartitionState(ctx context.Context, controller *slinkyv1beta1.Controller, partitionName string, state slinkyv1beta1.PartitionState) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do UpdatePartitionState()")
+		return ErrNoSlurmClient
+	}
+
+	partitionInfo := &slurmtypes.V0044PartitionInfo{}
+	if err := slurmClient.Get(ctx, slurmobject.ObjectKey(partitionName), partitionInfo); err != nil {
+		// The partition is created by the reconfigure of slurm.conf.
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	current := partitionState{}
+	if err := convert(partitionInfo.V0044PartitionInfo, &current); err != nil {
+		return err
+	}
+	if slices.Contains(current.Partition.State, string(state)) {
+		logger.V(3).Info("Partition state already in sync, skipping update request",
+			"partition", partitionName, "state", state)
+		return nil
+	}
+
+	desired := partitionState{Name: partitionName}
+	desired.Partition.State = []string{string(state)}
+	req := slurmapi.V0044PartitionInfo{}
+	if err := convert(desired, &req); err != nil {
+		return err
+	}
+
+	logger.Info("Update Slurm Partition state", "partition", partitionName,
+		"oldState", current.Partition.State, "newState", state)
+	if err := slurmClient.Update(ctx, partitionInfo, req); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// convert converts in to out by their JSON representation.
+func convert(in, out any) error {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+func (r *realSlurmControl) lookupClient(controller *slinkyv1beta1.Controller) slurmclient.Client {
+	key := ktypes.NamespacedName{
+		Namespace: controller.Namespace,
+		Name:      controller.Name,
+	}
+	return r.clientMap.Get(key)
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
+
+func tolerateError(err error) bool {
+	switch {
+	case err == nil, errors.Is(err, slurmerrors.ErrObjectNotFound):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newNode(name string, partitions ...string) types.V0044Node {
+	return types.V0044Node{
+		V0044Node: api.V0044Node{
+			Name:       ptr.To(name),
+			Partitions: ptr.To(api.V0044CsvString(partitions)),
+		},
+	}
+}
+
+func newJob(id int32, partition string, state api.V0044JobInfoJobState) types.V0044JobInfo {
+	return types.V0044JobInfo{
+		V0044JobInfo: api.V0044JobInfo{
+			JobId:     ptr.To(id),
+			JobState:  ptr.To([]api.V0044JobInfoJobState{state}),
+			Partition: ptr.To(partition),
+		},
+	}
+}
+
+func Test_realSlurmControl_GetPartitionStatus(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	type fields struct {
+		nodeList *types.V0044NodeList
+		jobList  *types.V0044JobInfoList
+	}
+	type args struct {
+		ctx           context.Context
+		controller    *slinkyv1beta1.Controller
+		partitionName string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *PartitionStatus
+		wantErr bool
+	}{
+		{
+			name: "Empty",
+			fields: fields{
+				nodeList: &types.V0044NodeList{},
+				jobList:  &types.V0044JobInfoList{},
+			},
+			args: args{
+				ctx:           context.TODO(),
+				controller:    controller,
+				partitionName: "gpu-all",
+			},
+			want: &PartitionStatus{},
+		},
+		{
+			name: "Nodes and jobs",
+			fields: fields{
+				nodeList: &types.V0044NodeList{
+					Items: []types.V0044Node{
+						newNode("gpu-a-0", "gpu-a", "gpu-all"),
+						newNode("gpu-b-0", "gpu-all"),
+						newNode("cpu-0", "cpu"),
+						newNode("cpu-1"),
+					},
+				},
+				jobList: &types.V0044JobInfoList{
+					Items: []types.V0044JobInfo{
+						newJob(1, "gpu-all", api.V0044JobInfoJobStateRUNNING),
+						newJob(2, "cpu,gpu-all", api.V0044JobInfoJobStatePENDING),
+						newJob(3, "gpu-all", api.V0044JobInfoJobStatePENDING),
+						newJob(4, "gpu-all", api.V0044JobInfoJobStateCOMPLETED),
+						newJob(5, "cpu", api.V0044JobInfoJobStateRUNNING),
+					},
+				},
+			},
+			args: args{
+				ctx:           context.TODO(),
+				controller:    controller,
+				partitionName: "gpu-all",
+			},
+			want: &PartitionStatus{
+				Nodes:       2,
+				RunningJobs: 1,
+				PendingJobs: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sclient := fake.NewClientBuilder().
+				WithLists(tt.fields.nodeList, tt.fields.jobList).
+				Build()
+			r := NewSlurmControl(testutils.NewClientMap(tt.args.controller.Name, tt.args.controller.Namespace, sclient))
+			got, err := r.GetPartitionStatus(tt.args.ctx, tt.args.controller, tt.args.partitionName)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_realSlurmControl_GetPartitionStatus_NoClient(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	r := NewSlurmControl(testutils.NewClientMap("other", controller.Namespace, fake.NewFakeClient()))
+	_, err := r.GetPartitionStatus(context.TODO(), controller, "gpu-all")
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+}
+
+func newPartitionInfo(name string, state api.V0044PartitionInfoPartitionState) types.V0044PartitionInfo {
+	partitionInfo := types.V0044PartitionInfo{}
+	raw := fmt.Sprintf(`{"name":%q,"partition":{"state":[%q]}}`, name, state)
+	if err := json.Unmarshal([]byte(raw), &partitionInfo.V0044PartitionInfo); err != nil {
+		panic(err)
+	}
+	return partitionInfo
+}
+
+func Test_realSlurmControl_UpdatePartitionState(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	tests := []struct {
+		name          string
+		partitionList *types.V0044PartitionInfoList
+		partitionName string
+		state         slinkyv1beta1.PartitionState
+		wantUpdates   []string
+	}{
+		{
+			name: "Drain",
+			partitionList: &types.V0044PartitionInfoList{
+				Items: []types.V0044PartitionInfo{
+					newPartitionInfo("gpu-all", api.V0044PartitionInfoPartitionStateUP),
+				},
+			},
+			partitionName: "gpu-all",
+			state:         slinkyv1beta1.PartitionStateDrain,
+			wantUpdates:   []string{`{"name":"gpu-all","partition":{"state":["DRAIN"]}}`},
+		},
+		{
+			name: "Already in sync",
+			partitionList: &types.V0044PartitionInfoList{
+				Items: []types.V0044PartitionInfo{
+					newPartitionInfo("gpu-all", api.V0044PartitionInfoPartitionStateDOWN),
+				},
+			},
+			partitionName: "gpu-all",
+			state:         slinkyv1beta1.PartitionStateDown,
+		},
+		{
+			name:          "Partition not found",
+			partitionList: &types.V0044PartitionInfoList{},
+			partitionName: "gpu-all",
+			state:         slinkyv1beta1.PartitionStateDown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates := []string{}
+			updateFn := func(_ context.Context, _ object.Object, req any, _ ...slurmclient.UpdateOption) error {
+				raw, err := json.Marshal(req)
+				if err != nil {
+					return err
+				}
+				updates = append(updates, string(raw))
+				return nil
+			}
+			sclient := fake.NewClientBuilder().
+				WithUpdateFn(updateFn).
+				WithLists(tt.partitionList).
+				Build()
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+			err := r.UpdatePartitionState(context.TODO(), controller, tt.partitionName, tt.state)
+			require.NoError(t, err)
+			for i := range updates {
+				require.JSONEq(t, tt.wantUpdates[i], updates[i])
+			}
+			require.Len(t, updates, len(tt.wantUpdates))
+		})
+	}
+}
+
+func Test_realSlurmControl_UpdatePartitionState_NoClient(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	r := NewSlurmControl(testutils.NewClientMap("other", controller.Namespace, fake.NewFakeClient()))
+	err := r.UpdatePartitionState(context.TODO(), controller, "gpu-all", slinkyv1beta1.PartitionStateDown)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package qos
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
+)
+
+// Sync implements control logic for synchronizing a QOS.
+func (r *QOSReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	return r.syncer().Sync(ctx, req)
+}
+
+// syncer returns the Syncer of the QOS into the Slurm database.
+func (r *QOSReconciler) syncer() *slurmdb.Syncer[*slinkyv1beta1.QOS] {
+	return &slurmdb.Syncer[*slinkyv1beta1.QOS]{
+		Client:        r.Client,
+		RefResolver:   r.refResolver,
+		DurationStore: durationStore,
+
+		Kind:      "QOS",
+		Finalizer: slinkyv1beta1.FinalizerQOS,
+
+		NewObject: func() *slinkyv1beta1.QOS {
+			return &slinkyv1beta1.QOS{}
+		},
+		SlurmdbRef: func(qos *slinkyv1beta1.QOS) slinkyv1beta1.SlurmdbRef {
+			return qos.Spec.SlurmdbRef
+		},
+		Conditions: func(qos *slinkyv1beta1.QOS) *[]metav1.Condition {
+			return &qos.Status.Conditions
+		},
+
+		SyncFn:   r.slurmControl.SyncQOS,
+		DeleteFn: r.slurmControl.DeleteQOS,
+	}
+}
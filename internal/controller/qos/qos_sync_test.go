@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package qos
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/qos/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func init() {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+}
+
+type fakeSlurmControl struct {
+	err error
+
+	synced  []string
+	deleted bool
+}
+
+func (f *fakeSlurmControl) SyncQOS(_ context.Context, controllers []slinkyv1beta1.Controller, _ *slinkyv1beta1.QOS) error {
+	for _, controller := range controllers {
+		f.synced = append(f.synced, controller.Name)
+	}
+	return f.err
+}
+
+func (f *fakeSlurmControl) DeleteQOS(context.Context, []slinkyv1beta1.Controller, *slinkyv1beta1.QOS) error {
+	f.deleted = true
+	return f.err
+}
+
+var _ slurmcontrol.SlurmControlInterface = &fakeSlurmControl{}
+
+func newQOS(deleting bool) *slinkyv1beta1.QOS {
+	out := &slinkyv1beta1.QOS{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "normal",
+		},
+		Spec: slinkyv1beta1.QOSSpec{
+			SlurmdbRef: slinkyv1beta1.SlurmdbRef{
+				AccountingRef: &corev1.LocalObjectReference{Name: "slurmdbd"},
+			},
+		},
+	}
+	if deleting {
+		out.Finalizers = []string{slinkyv1beta1.FinalizerQOS}
+	}
+	return out
+}
+
+// The reconcile flow is covered by the slurmdb package, only its wiring to the
+// QOS is tested here.
+func TestQOSReconciler_Sync(t *testing.T) {
+	tests := []struct {
+		name          string
+		deleting      bool
+		err           error
+		wantSynced    []string
+		wantDeleted   bool
+		wantCondition metav1.ConditionStatus
+		wantErr       bool
+	}{
+		{
+			name:          "Sync",
+			wantSynced:    []string{"slurm-a", "slurm-b"},
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name:          "Sync error",
+			err:           errors.New("internal error"),
+			wantSynced:    []string{"slurm-a", "slurm-b"},
+			wantCondition: metav1.ConditionFalse,
+			wantErr:       true,
+		},
+		{
+			name:        "Delete",
+			deleting:    true,
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qos := newQOS(tt.deleting)
+			c := fake.NewClientBuilder().
+				WithObjects(testutils.NewSlurmdbObjects("slurmdbd", "slurm-a", "slurm-b")...).
+				WithObjects(qos).
+				WithStatusSubresource(&slinkyv1beta1.QOS{}).
+				Build()
+			slurmControl := &fakeSlurmControl{err: tt.err}
+			r := &QOSReconciler{
+				Client:       c,
+				refResolver:  refresolver.New(c),
+				slurmControl: slurmControl,
+			}
+
+			req := client.ObjectKeyFromObject(qos)
+			if tt.deleting {
+				require.NoError(t, c.Delete(context.TODO(), qos))
+			}
+			err := r.Sync(context.TODO(), reconcile.Request{NamespacedName: req})
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.wantSynced, slurmControl.synced)
+			require.Equal(t, tt.wantDeleted, slurmControl.deleted)
+
+			got := &slinkyv1beta1.QOS{}
+			err = c.Get(context.TODO(), req, got)
+			if tt.deleting {
+				require.True(t, apierrors.IsNotFound(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, got.Finalizers, slinkyv1beta1.FinalizerQOS)
+			condition := meta.FindStatusCondition(got.Status.Conditions, slurmconditions.SlurmdbConditionSynced)
+			require.NotNil(t, condition)
+			require.Equal(t, tt.wantCondition, condition.Status)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmerrors "github.com/SlinkyProject/slurm-client/pkg/errors"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
+	slurmdbcontrol "github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+)
+
+var ErrNoSlurmClient = slurmdb.ErrNoSlurmClient
+
+type SlurmControlInterface interface {
+	// SyncQOS creates or updates the Slurm QOS.
+	SyncQOS(ctx context.Context, controllers []slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) error
+	// DeleteQOS deletes the Slurm QOS, if it exists.
+	DeleteQOS(ctx context.Context, controllers []slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// SyncQOS implements SlurmControlInterface.
+func (r *realSlurmControl) SyncQOS(ctx context.Context, controllers []slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := slurmdbcontrol.LookupClient(r.clientMap, controllers)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controllers, cannot do SyncQOS()")
+		return ErrNoSlurmClient
+	}
+
+	newQos := slurmdbutils.NewQos(qos)
+	req := slurmapi.V0044Qos{}
+	if err := slurmdbutils.Convert(newQos, &req); err != nil {
+		return err
+	}
+
+	qosInfo, err := getQos(ctx, slurmClient, newQos.Name)
+	if err != nil {
+		return err
+	}
+
+	if qosInfo == nil {
+		qosInfo = &slurmtypes.V0044Qos{V0044Qos: req}
+		if err := slurmClient.Create(ctx, qosInfo, req); err != nil {
+			return fmt.Errorf("SyncQOS() failed to Create QOSName=%s with error=%w", newQos.Name, err)
+		}
+		return nil
+	}
+
+	oldQos := slurmdbutils.Qos{}
+	if err := slurmdbutils.Convert(qosInfo, &oldQos); err != nil {
+		return err
+	}
+	if slurmdbutils.IsQosMatch(oldQos, newQos) {
+		return nil
+	}
+
+	if err := slurmClient.Update(ctx, qosInfo, req); err != nil {
+		return fmt.Errorf("SyncQOS() failed to Update QOSName=%s with error=%w", newQos.Name, err)
+	}
+
+	return nil
+}
+
+// DeleteQOS implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteQOS(ctx context.Context, controllers []slinkyv1beta1.Controller, qos *slinkyv1beta1.QOS) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := slurmdbcontrol.LookupClient(r.clientMap, controllers)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controllers, cannot do DeleteQOS()")
+		return ErrNoSlurmClient
+	}
+
+	name := qos.QOSName()
+	qosInfo, err := getQos(ctx, slurmClient, name)
+	if err != nil || qosInfo == nil {
+		return err
+	}
+
+	if err := slurmClient.Delete(ctx, qosInfo); err != nil && !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+		return fmt.Errorf("DeleteQOS() failed to Delete QOSName=%s with error=%w", name, err)
+	}
+
+	return nil
+}
+
+// getQos returns the Slurm QOS, or nil if it does not exist.
+func getQos(ctx context.Context, slurmClient slurmclient.Client, name string) (*slurmtypes.V0044Qos, error) {
+	qosInfo := &slurmtypes.V0044Qos{}
+	key := slurmobject.ObjectKey(name)
+	if err := slurmClient.Get(ctx, key, qosInfo); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return qosInfo, nil
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
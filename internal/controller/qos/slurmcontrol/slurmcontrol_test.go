@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newController() slinkyv1beta1.Controller {
+	return slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+}
+
+func newQOS(priority int32) *slinkyv1beta1.QOS {
+	return &slinkyv1beta1.QOS{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "normal",
+		},
+		Spec: slinkyv1beta1.QOSSpec{
+			Priority: ptr.To(priority),
+			Flags:    []string{"DenyOnLimit"},
+		},
+	}
+}
+
+func newQosInfo(qos *slinkyv1beta1.QOS) *types.V0044Qos {
+	out := &types.V0044Qos{}
+	_ = slurmdbutils.Convert(slurmdbutils.NewQos(qos), out)
+	return out
+}
+
+func Test_realSlurmControl_SyncQOS(t *testing.T) {
+	controller := newController()
+	tests := []struct {
+		name       string
+		qos        *slinkyv1beta1.QOS
+		existing   *types.V0044Qos
+		wantCreate bool
+		wantUpdate bool
+	}{
+		{
+			name:       "Create",
+			qos:        newQOS(10),
+			wantCreate: true,
+		},
+		{
+			name:     "Unchanged",
+			qos:      newQOS(10),
+			existing: newQosInfo(newQOS(10)),
+		},
+		{
+			name:       "Priority drift",
+			qos:        newQOS(10),
+			existing:   newQosInfo(newQOS(1)),
+			wantUpdate: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, updated bool
+			builder := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(context.Context, object.Object, any, ...client.CreateOption) error {
+					created = true
+					return nil
+				},
+				Update: func(context.Context, object.Object, any, ...client.UpdateOption) error {
+					updated = true
+					return nil
+				},
+			})
+			if tt.existing != nil {
+				builder = builder.WithObjects(tt.existing)
+			}
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, builder.Build()))
+			err := r.SyncQOS(context.TODO(), []slinkyv1beta1.Controller{controller}, tt.qos)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCreate, created)
+			require.Equal(t, tt.wantUpdate, updated)
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteQOS(t *testing.T) {
+	controller := newController()
+	qos := newQOS(10)
+	var deleted bool
+	sclient := fake.NewClientBuilder().
+		WithObjects(newQosInfo(qos)).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(context.Context, object.Object, ...client.DeleteOption) error {
+				deleted = true
+				return nil
+			},
+		}).
+		Build()
+	r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+	require.NoError(t, r.DeleteQOS(context.TODO(), []slinkyv1beta1.Controller{controller}, qos))
+	require.True(t, deleted)
+
+	// Deleting an absent QOS is a no-op.
+	r = NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, fake.NewFakeClient()))
+	require.NoError(t, r.DeleteQOS(context.TODO(), []slinkyv1beta1.Controller{controller}, qos))
+}
+
+func Test_realSlurmControl_NoClient(t *testing.T) {
+	controllers := []slinkyv1beta1.Controller{newController()}
+	qos := newQOS(10)
+	r := NewSlurmControl(testutils.NewClientMap("other", corev1.NamespaceDefault, fake.NewFakeClient()))
+	err := r.SyncQOS(context.TODO(), controllers, qos)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+	err = r.DeleteQOS(context.TODO(), controllers, qos)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+}
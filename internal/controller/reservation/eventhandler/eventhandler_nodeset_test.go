@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package eventhandler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func newObjects() []client.Object {
+	return []client.Object{
+		&slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "slurm",
+			},
+		},
+		&slinkyv1beta1.Reservation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "all",
+			},
+			Spec: slinkyv1beta1.ReservationSpec{
+				ControllerRef: corev1.LocalObjectReference{
+					Name: "slurm",
+				},
+			},
+		},
+		&slinkyv1beta1.Reservation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "gpu",
+			},
+			Spec: slinkyv1beta1.ReservationSpec{
+				ControllerRef: corev1.LocalObjectReference{
+					Name: "slurm",
+				},
+			},
+		},
+		&slinkyv1beta1.Reservation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other",
+			},
+			Spec: slinkyv1beta1.ReservationSpec{
+				ControllerRef: corev1.LocalObjectReference{
+					Name: "slurm1",
+				},
+			},
+		},
+	}
+}
+
+func newNodeSet(controllerName string) *slinkyv1beta1.NodeSet {
+	return &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "slurm",
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			ControllerRef: corev1.LocalObjectReference{
+				Name: controllerName,
+			},
+		},
+	}
+}
+
+func Test_NodeSetEventHandler_Create(t *testing.T) {
+	type fields struct {
+		client client.Client
+	}
+	type args struct {
+		ctx context.Context
+		evt event.CreateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{},
+				q:   newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "non-empty",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(newObjects()...).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newNodeSet("slurm"),
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
+		{
+			name: "controller not found",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(newObjects()...).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.CreateEvent{
+					Object: newNodeSet("slurm1"),
+				},
+				q: newQueue(),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewNodeSetEventHandler(tt.fields.client)
+			e.Create(tt.args.ctx, tt.args.evt, tt.args.q)
+			require.Equal(t, tt.want, tt.args.q.Len())
+		})
+	}
+}
+
+func Test_NodeSetEventHandler_Update(t *testing.T) {
+	type fields struct {
+		client client.Client
+	}
+	type args struct {
+		ctx context.Context
+		evt event.UpdateEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{},
+				q:   newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "non-empty",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(newObjects()...).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.UpdateEvent{
+					ObjectOld: newNodeSet("slurm"),
+					ObjectNew: newNodeSet("slurm"),
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewNodeSetEventHandler(tt.fields.client)
+			e.Update(tt.args.ctx, tt.args.evt, tt.args.q)
+			require.Equal(t, tt.want, tt.args.q.Len())
+		})
+	}
+}
+
+func Test_NodeSetEventHandler_Delete(t *testing.T) {
+	type fields struct {
+		client client.Client
+	}
+	type args struct {
+		ctx context.Context
+		evt event.DeleteEvent
+		q   workqueue.TypedRateLimitingInterface[reconcile.Request]
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   int
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				client: fake.NewFakeClient(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{},
+				q:   newQueue(),
+			},
+			want: 0,
+		},
+		{
+			name: "non-empty",
+			fields: fields{
+				client: fake.NewClientBuilder().
+					WithObjects(newObjects()...).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				evt: event.DeleteEvent{
+					Object: newNodeSet("slurm"),
+				},
+				q: newQueue(),
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewNodeSetEventHandler(tt.fields.client)
+			e.Delete(tt.args.ctx, tt.args.evt, tt.args.q)
+			require.Equal(t, tt.want, tt.args.q.Len())
+		})
+	}
+}
+
+func Test_NodeSetEventHandler_Generic(t *testing.T) {
+	e := NewNodeSetEventHandler(fake.NewFakeClient())
+	q := newQueue()
+	e.Generic(context.TODO(), event.GenericEvent{Object: newNodeSet("slurm")}, q)
+	require.Equal(t, 0, q.Len())
+}
@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/reservation/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+)
+
+// Sync implements control logic for synchronizing a Reservation.
+func (r *ReservationReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	reservation := &slinkyv1beta1.Reservation{}
+	if err := r.Get(ctx, req.NamespacedName, reservation); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Reservation has been deleted")
+			return nil
+		}
+		return err
+	}
+	reservation = reservation.DeepCopy()
+	key := objectutils.KeyFunc(reservation)
+
+	controller, err := r.refResolver.GetController(ctx, reservation.Spec.ControllerRef, reservation.Namespace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		controller = nil
+	}
+
+	if !reservation.DeletionTimestamp.IsZero() {
+		logger.Info("Reservation is being deleted")
+		return r.syncFinalizer(ctx, controller, reservation)
+	} else {
+		durationStore.Push(key, 30*time.Second)
+	}
+
+	if err := r.addFinalizerIfNeeded(ctx, reservation); err != nil {
+		return err
+	}
+
+	if err := r.sync(ctx, controller, reservation); err != nil {
+		return r.syncStatus(ctx, controller, reservation, err)
+	}
+
+	return r.syncStatus(ctx, controller, reservation)
+}
+
+// sync creates or updates the Slurm reservation with the selected Slurm nodes.
+func (r *ReservationReconciler) sync(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	reservation *slinkyv1beta1.Reservation,
+) error {
+	logger := log.FromContext(ctx)
+
+	if controller == nil {
+		return fmt.Errorf("failed to get Controller(%s) of Reservation(%s): not found",
+			reservation.Spec.ControllerRef.Name, klog.KObj(reservation))
+	}
+
+	// An ended reservation is not recreated, Slurm removes it by itself.
+	// Recurring reservations are kept in sync, as Slurm moves them forward.
+	if reservation.HasEnded(time.Now()) {
+		logger.V(1).Info("Reservation has ended, skipping sync")
+		return nil
+	}
+
+	nodes, err := r.getReservationNodes(ctx, controller, reservation)
+	if err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return nil
+		}
+		return err
+	}
+	if len(nodes) == 0 {
+		return errors.New("no Slurm nodes are selected")
+	}
+
+	if err := r.slurmControl.SyncReservation(ctx, controller, reservation, nodes); err != nil {
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// getReservationNodes returns the sorted names of the registered Slurm nodes,
+// of the selected NodeSet pods, up to the requested node count.
+func (r *ReservationReconciler) getReservationNodes(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	reservation *slinkyv1beta1.Reservation,
+) ([]string, error) {
+	candidates, err := r.getCandidateNodes(ctx, controller, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	slurmNodes, err := r.slurmControl.GetNodeNames(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+	nodes := candidates.Intersection(set.New(slurmNodes...)).SortedList()
+
+	if count := int(ptr.Deref(reservation.Spec.NodeCount, 0)); count > 0 && count < len(nodes) {
+		nodes = nodes[:count]
+	}
+
+	return nodes, nil
+}
+
+// getCandidateNodes returns the Slurm node names of the pods, of the NodeSets of
+// the Controller, which are selected by the Reservation.
+func (r *ReservationReconciler) getCandidateNodes(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	reservation *slinkyv1beta1.Reservation,
+) (set.Set[string], error) {
+	podSelector := k8slabels.Everything()
+	if reservation.Spec.PodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(reservation.Spec.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		podSelector = selector
+	}
+
+	nodesetList, err := r.refResolver.GetNodeSetsForController(ctx, controller)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := set.New[string]()
+	for _, nodeset := range nodesetList.Items {
+		if len(reservation.Spec.NodeSets) > 0 && !slices.Contains(reservation.Spec.NodeSets, nodeset.Name) {
+			continue
+		}
+
+		selectorLabels := labels.NewBuilder().WithWorkerSelectorLabels(&nodeset).Build()
+		opts := &client.ListOptions{
+			Namespace:     nodeset.Namespace,
+			LabelSelector: k8slabels.SelectorFromSet(k8slabels.Set(selectorLabels)),
+		}
+		podList := &corev1.PodList{}
+		if err := r.List(ctx, podList, opts); err != nil {
+			return nil, err
+		}
+
+		for _, pod := range podList.Items {
+			if !pod.DeletionTimestamp.IsZero() || !nodesetutils.IsPodFromNodeSet(&nodeset, &pod) {
+				continue
+			}
+			if !podSelector.Matches(k8slabels.Set(pod.Labels)) {
+				continue
+			}
+			nodes.Insert(nodesetutils.GetSlurmNodeName(&pod))
+		}
+	}
+
+	return nodes, nil
+}
+
+// syncFinalizer deletes the Slurm reservation, then removes the finalizer.
+func (r *ReservationReconciler) syncFinalizer(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	reservation *slinkyv1beta1.Reservation,
+) error {
+	if !controllerutil.ContainsFinalizer(reservation, slinkyv1beta1.FinalizerReservation) {
+		return nil
+	}
+
+	// If the controller does not exist, the Slurm reservation cannot be
+	// deleted and the finalizer must be removed to permit cleanup.
+	if controller != nil {
+		if err := r.slurmControl.DeleteReservation(ctx, controller, reservation); err != nil {
+			return err
+		}
+	}
+
+	finalizers := set.New(reservation.Finalizers...)
+	finalizers.Delete(slinkyv1beta1.FinalizerReservation)
+	return r.updateFinalizers(ctx, reservation, finalizers.SortedList())
+}
+
+func (r *ReservationReconciler) addFinalizerIfNeeded(ctx context.Context, reservation *slinkyv1beta1.Reservation) error {
+	if controllerutil.ContainsFinalizer(reservation, slinkyv1beta1.FinalizerReservation) {
+		return nil
+	}
+
+	finalizers := slices.Concat(reservation.Finalizers, []string{slinkyv1beta1.FinalizerReservation})
+	return r.updateFinalizers(ctx, reservation, finalizers)
+}
+
+func (r *ReservationReconciler) updateFinalizers(ctx context.Context, reservation *slinkyv1beta1.Reservation, newFinalizers []string) error {
+	logger := log.FromContext(ctx)
+
+	logger.V(1).Info("Pending Reservation Finalizer update", "newFinalizers", newFinalizers)
+
+	mutateFn := func(reservation *slinkyv1beta1.Reservation) error {
+		reservation.Finalizers = newFinalizers
+		return nil
+	}
+
+	if err := objectutils.PatchObject(r.Client, ctx, reservation, mutateFn); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
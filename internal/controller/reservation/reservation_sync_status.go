@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/reservation/slurmcontrol"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	ReservationSyncedReason     = "Synced"
+	ReservationSyncFailedReason = "SyncFailed"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *ReservationReconciler) syncStatus(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	reservation *slinkyv1beta1.Reservation,
+	errors ...error,
+) error {
+	syncErr := utilerrors.NewAggregate(errors)
+	if err := r.syncReservationStatus(ctx, controller, reservation, syncErr); err != nil {
+		errors = append(errors, err)
+	}
+
+	return utilerrors.NewAggregate(errors)
+}
+
+func (r *ReservationReconciler) syncReservationStatus(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	reservation *slinkyv1beta1.Reservation,
+	syncErr error,
+) error {
+	logger := log.FromContext(ctx)
+
+	// The Slurm reservation is retained while the Slurm client is unavailable.
+	newStatus := slinkyv1beta1.ReservationStatus{
+		State:      reservation.Status.State,
+		Nodes:      reservation.Status.Nodes,
+		NodeCount:  reservation.Status.NodeCount,
+		StartTime:  reservation.Status.StartTime,
+		EndTime:    reservation.Status.EndTime,
+		Conditions: []metav1.Condition{},
+	}
+	newStatus.Conditions = append(newStatus.Conditions, reservation.Status.Conditions...)
+
+	var status *slurmcontrol.ReservationStatus
+	noSlurmClient := controller == nil
+	if controller != nil {
+		var err error
+		status, err = r.slurmControl.GetReservation(ctx, controller, reservation)
+		if err != nil {
+			if !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+				return err
+			}
+			noSlurmClient = true
+		}
+	}
+
+	now := time.Now()
+	switch {
+	case status != nil:
+		newStatus.Nodes = status.Nodes
+		newStatus.NodeCount = status.NodeCount
+		newStatus.StartTime = ptr.To(metav1.NewTime(status.StartTime))
+		newStatus.EndTime = ptr.To(metav1.NewTime(status.EndTime))
+		newStatus.State = calculateState(status.StartTime, status.EndTime, now)
+	case !noSlurmClient:
+		newStatus.Nodes = ""
+		newStatus.NodeCount = 0
+		newStatus.StartTime = ptr.To(reservation.Spec.StartTime)
+		newStatus.EndTime = ptr.To(metav1.NewTime(reservation.EndTime()))
+		newStatus.State = slinkyv1beta1.ReservationStatePending
+		if reservation.HasEnded(now) {
+			newStatus.State = slinkyv1beta1.ReservationStateEnded
+		}
+	}
+
+	// The Slurm reservation cannot be synced while the Slurm client is unavailable.
+	if syncErr != nil || !noSlurmClient {
+		meta.SetStatusCondition(&newStatus.Conditions, newSyncedCondition(reservation, syncErr))
+	}
+
+	if apiequality.Semantic.DeepEqual(reservation.Status, newStatus) {
+		logger.V(2).Info("Reservation Status has not changed, skipping status update",
+			"reservation", klog.KObj(reservation), "status", reservation.Status)
+		return nil
+	}
+
+	if err := r.updateStatus(ctx, reservation, &newStatus); err != nil {
+		return fmt.Errorf("error updating Reservation(%s) status: %w",
+			klog.KObj(reservation), err)
+	}
+
+	return nil
+}
+
+// newSyncedCondition returns the Synced condition from the result of the sync.
+func newSyncedCondition(reservation *slinkyv1beta1.Reservation, syncErr error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               slurmconditions.ReservationConditionSynced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: reservation.Generation,
+		Reason:             ReservationSyncedReason,
+	}
+	if syncErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = ReservationSyncFailedReason
+		condition.Message = syncErr.Error()
+	}
+	return condition
+}
+
+// calculateState returns the state of a Slurm reservation from its times.
+func calculateState(startTime, endTime, now time.Time) slinkyv1beta1.ReservationState {
+	switch {
+	case now.Before(startTime):
+		return slinkyv1beta1.ReservationStatePending
+	case now.Before(endTime):
+		return slinkyv1beta1.ReservationStateActive
+	default:
+		return slinkyv1beta1.ReservationStateEnded
+	}
+}
+
+func (r *ReservationReconciler) updateStatus(
+	ctx context.Context,
+	reservation *slinkyv1beta1.Reservation,
+	newStatus *slinkyv1beta1.ReservationStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	namespacedName := types.NamespacedName{
+		Namespace: reservation.GetNamespace(),
+		Name:      reservation.GetName(),
+	}
+
+	logger.V(1).Info("Pending Reservation Status update",
+		"reservation", klog.KObj(reservation), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.Reservation{}
+		if err := r.Get(ctx, namespacedName, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
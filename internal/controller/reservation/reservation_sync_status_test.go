@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/reservation/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func TestReservationReconciler_syncReservationStatus(t *testing.T) {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+	now := time.Now().Truncate(time.Second)
+	future := now.Add(time.Hour)
+	past := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name          string
+		controller    *slinkyv1beta1.Controller
+		reservation   *slinkyv1beta1.Reservation
+		status        *slurmcontrol.ReservationStatus
+		err           error
+		syncErr       error
+		wantState     slinkyv1beta1.ReservationState
+		wantNodes     string
+		wantCondition metav1.ConditionStatus
+	}{
+		{
+			name:       "Pending",
+			controller: controller,
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				StartTime: metav1.NewTime(future),
+			}),
+			status: &slurmcontrol.ReservationStatus{
+				Nodes:     "gpu-[0-1]",
+				NodeCount: 2,
+				StartTime: future,
+				EndTime:   future.Add(time.Hour),
+			},
+			wantState:     slinkyv1beta1.ReservationStatePending,
+			wantNodes:     "gpu-[0-1]",
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name:       "Active",
+			controller: controller,
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				StartTime: metav1.NewTime(now.Add(-time.Minute)),
+			}),
+			status: &slurmcontrol.ReservationStatus{
+				Nodes:     "gpu-0",
+				NodeCount: 1,
+				StartTime: now.Add(-time.Minute),
+				EndTime:   future,
+			},
+			wantState:     slinkyv1beta1.ReservationStateActive,
+			wantNodes:     "gpu-0",
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name:       "Ended",
+			controller: controller,
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				StartTime: metav1.NewTime(past),
+			}),
+			wantState:     slinkyv1beta1.ReservationStateEnded,
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name:       "Sync failed",
+			controller: controller,
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				StartTime: metav1.NewTime(future),
+			}),
+			syncErr:       errors.New("no Slurm nodes are selected"),
+			wantState:     slinkyv1beta1.ReservationStatePending,
+			wantCondition: metav1.ConditionFalse,
+		},
+		{
+			name:       "No Slurm client",
+			controller: controller,
+			reservation: func() *slinkyv1beta1.Reservation {
+				reservation := newReservation(slinkyv1beta1.ReservationSpec{
+					StartTime: metav1.NewTime(future),
+				})
+				reservation.Status = slinkyv1beta1.ReservationStatus{
+					State: slinkyv1beta1.ReservationStatePending,
+					Nodes: "gpu-0",
+				}
+				return reservation
+			}(),
+			err:       slurmcontrol.ErrNoSlurmClient,
+			wantState: slinkyv1beta1.ReservationStatePending,
+			wantNodes: "gpu-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithObjects(tt.reservation).
+				WithStatusSubresource(&slinkyv1beta1.Reservation{}).
+				Build()
+			r := &ReservationReconciler{
+				Client:       c,
+				refResolver:  refresolver.New(c),
+				slurmControl: &fakeSlurmControl{status: tt.status, err: tt.err},
+			}
+			err := r.syncReservationStatus(context.TODO(), tt.controller, tt.reservation, tt.syncErr)
+			require.NoError(t, err)
+
+			got := &slinkyv1beta1.Reservation{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(tt.reservation), got))
+			require.Equal(t, tt.wantState, got.Status.State)
+			require.Equal(t, tt.wantNodes, got.Status.Nodes)
+
+			condition := meta.FindStatusCondition(got.Status.Conditions, slurmconditions.ReservationConditionSynced)
+			if tt.wantCondition == "" {
+				require.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			require.Equal(t, tt.wantCondition, condition.Status)
+		})
+	}
+}
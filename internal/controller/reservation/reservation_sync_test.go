@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	nodesetutils "github.com/SlinkyProject/slurm-operator/internal/controller/nodeset/utils"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/reservation/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func init() {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+}
+
+type fakeSlurmControl struct {
+	nodeNames []string
+	status    *slurmcontrol.ReservationStatus
+	err       error
+
+	synced  []string
+	deleted bool
+}
+
+func (f *fakeSlurmControl) GetNodeNames(context.Context, *slinkyv1beta1.Controller) ([]string, error) {
+	return f.nodeNames, f.err
+}
+
+func (f *fakeSlurmControl) GetReservation(context.Context, *slinkyv1beta1.Controller, *slinkyv1beta1.Reservation) (*slurmcontrol.ReservationStatus, error) {
+	return f.status, f.err
+}
+
+func (f *fakeSlurmControl) SyncReservation(_ context.Context, _ *slinkyv1beta1.Controller, _ *slinkyv1beta1.Reservation, nodes []string) error {
+	f.synced = nodes
+	return f.err
+}
+
+func (f *fakeSlurmControl) DeleteReservation(context.Context, *slinkyv1beta1.Controller, *slinkyv1beta1.Reservation) error {
+	f.deleted = true
+	return f.err
+}
+
+var _ slurmcontrol.SlurmControlInterface = &fakeSlurmControl{}
+
+func newReservation(spec slinkyv1beta1.ReservationSpec) *slinkyv1beta1.Reservation {
+	spec.ControllerRef = corev1.LocalObjectReference{
+		Name: "slurm",
+	}
+	spec.Users = []string{"alice"}
+	if spec.StartTime.IsZero() {
+		spec.StartTime = metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+	}
+	return &slinkyv1beta1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "maint",
+		},
+		Spec: spec,
+	}
+}
+
+func newObjects() []client.Object {
+	controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+	cpu := testutils.NewNodeset("cpu", controller, 2)
+	gpu := testutils.NewNodeset("gpu", controller, 2)
+	objs := []client.Object{controller, cpu, gpu}
+	for _, nodeset := range []*slinkyv1beta1.NodeSet{cpu, gpu} {
+		nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeStatefulset
+		for ordinal := range 2 {
+			pod := nodesetutils.NewNodeSetStatefulSetPod(fake.NewFakeClient(), nodeset, controller, ordinal, "")
+			pod.Labels["rack"] = []string{"a", "b"}[ordinal]
+			objs = append(objs, pod)
+		}
+	}
+	return objs
+}
+
+func TestReservationReconciler_getReservationNodes(t *testing.T) {
+	allNodes := []string{"cpu-0", "cpu-1", "gpu-0", "gpu-1"}
+	tests := []struct {
+		name        string
+		reservation *slinkyv1beta1.Reservation
+		nodeNames   []string
+		want        []string
+	}{
+		{
+			name: "NodeSets",
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				NodeSets: []string{"gpu"},
+			}),
+			nodeNames: allNodes,
+			want:      []string{"gpu-0", "gpu-1"},
+		},
+		{
+			name: "PodSelector",
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"rack": "a"},
+				},
+			}),
+			nodeNames: allNodes,
+			want:      []string{"cpu-0", "gpu-0"},
+		},
+		{
+			name: "NodeSets and PodSelector",
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				NodeSets: []string{"cpu"},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"rack": "b"},
+				},
+			}),
+			nodeNames: allNodes,
+			want:      []string{"cpu-1"},
+		},
+		{
+			name: "NodeCount",
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				NodeSets:  []string{"cpu", "gpu"},
+				NodeCount: ptr.To[int32](3),
+			}),
+			nodeNames: allNodes,
+			want:      []string{"cpu-0", "cpu-1", "gpu-0"},
+		},
+		{
+			name: "Unregistered Slurm nodes",
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				NodeSets: []string{"cpu"},
+			}),
+			nodeNames: []string{"cpu-1"},
+			want:      []string{"cpu-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(newObjects()...).Build()
+			r := &ReservationReconciler{
+				Client:       c,
+				refResolver:  refresolver.New(c),
+				slurmControl: &fakeSlurmControl{nodeNames: tt.nodeNames},
+			}
+			controller := &slinkyv1beta1.Controller{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: corev1.NamespaceDefault, Name: "slurm"}, controller))
+			got, err := r.getReservationNodes(context.TODO(), controller, tt.reservation)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReservationReconciler_Sync(t *testing.T) {
+	reservation := newReservation(slinkyv1beta1.ReservationSpec{
+		NodeSets: []string{"gpu"},
+	})
+	c := fake.NewClientBuilder().
+		WithObjects(newObjects()...).
+		WithObjects(reservation).
+		WithStatusSubresource(&slinkyv1beta1.Reservation{}).
+		Build()
+	slurmControl := &fakeSlurmControl{nodeNames: []string{"gpu-0", "gpu-1"}}
+	r := &ReservationReconciler{
+		Client:       c,
+		refResolver:  refresolver.New(c),
+		slurmControl: slurmControl,
+	}
+
+	req := client.ObjectKeyFromObject(reservation)
+	require.NoError(t, r.Sync(context.TODO(), reconcile.Request{NamespacedName: req}))
+	require.Equal(t, []string{"gpu-0", "gpu-1"}, slurmControl.synced)
+
+	got := &slinkyv1beta1.Reservation{}
+	require.NoError(t, c.Get(context.TODO(), req, got))
+	require.Contains(t, got.Finalizers, slinkyv1beta1.FinalizerReservation)
+
+	// Deleting the Reservation deletes the Slurm reservation and the finalizer.
+	require.NoError(t, c.Delete(context.TODO(), got))
+	require.NoError(t, r.Sync(context.TODO(), reconcile.Request{NamespacedName: req}))
+	require.True(t, slurmControl.deleted)
+	err := c.Get(context.TODO(), req, got)
+	require.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReservationReconciler_sync(t *testing.T) {
+	pastStartTime := metav1.NewTime(time.Now().Add(-48 * time.Hour).Truncate(time.Second))
+	tests := []struct {
+		name        string
+		reservation *slinkyv1beta1.Reservation
+		wantSynced  []string
+	}{
+		{
+			name: "Pending",
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				NodeSets: []string{"gpu"},
+			}),
+			wantSynced: []string{"gpu-0", "gpu-1"},
+		},
+		{
+			name: "Ended",
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				NodeSets:  []string{"gpu"},
+				StartTime: pastStartTime,
+			}),
+		},
+		{
+			name: "Recurring reservation past its first occurrence",
+			reservation: newReservation(slinkyv1beta1.ReservationSpec{
+				NodeSets:  []string{"gpu"},
+				Flags:     []string{"DAILY"},
+				StartTime: pastStartTime,
+			}),
+			wantSynced: []string{"gpu-0", "gpu-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithObjects(newObjects()...).
+				Build()
+			slurmControl := &fakeSlurmControl{nodeNames: []string{"gpu-0", "gpu-1"}}
+			r := &ReservationReconciler{
+				Client:       c,
+				refResolver:  refresolver.New(c),
+				slurmControl: slurmControl,
+			}
+			controller, err := r.refResolver.GetController(context.TODO(), tt.reservation.Spec.ControllerRef, tt.reservation.Namespace)
+			require.NoError(t, err)
+
+			require.NoError(t, r.sync(context.TODO(), controller, tt.reservation))
+			require.Equal(t, tt.wantSynced, slurmControl.synced)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/puttsk/hostlist"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmerrors "github.com/SlinkyProject/slurm-client/pkg/errors"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/reservationutils"
+)
+
+var ErrNoSlurmClient = errors.New("NoSlurmClient")
+
+type SlurmControlInterface interface {
+	// GetNodeNames returns the names of the Slurm nodes registered with the controller.
+	GetNodeNames(ctx context.Context, controller *slinkyv1beta1.Controller) ([]string, error)
+	// GetReservation returns the Slurm reservation, or nil if it does not exist.
+	GetReservation(ctx context.Context, controller *slinkyv1beta1.Controller, reservation *slinkyv1beta1.Reservation) (*ReservationStatus, error)
+	// SyncReservation creates or updates the Slurm reservation with the given Slurm nodes.
+	SyncReservation(ctx context.Context, controller *slinkyv1beta1.Controller, reservation *slinkyv1beta1.Reservation, nodes []string) error
+	// DeleteReservation deletes the Slurm reservation, if it exists.
+	DeleteReservation(ctx context.Context, controller *slinkyv1beta1.Controller, reservation *slinkyv1beta1.Reservation) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+type ReservationStatus struct {
+	Nodes     string
+	NodeCount int32
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// GetNodeNames implements SlurmControlInterface.
+func (r *realSlurmControl) GetNodeNames(ctx context.Context, controller *slinkyv1beta1.Controller) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do GetNodeNames()")
+		return nil, ErrNoSlurmClient
+	}
+
+	nodeList := &slurmtypes.V0044NodeList{}
+	if err := slurmClient.List(ctx, nodeList); err != nil {
+		if !tolerateError(err) {
+			return nil, err
+		}
+	}
+
+	nodeNames := make([]string, 0, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		nodeNames = append(nodeNames, ptr.Deref(node.Name, ""))
+	}
+
+	return nodeNames, nil
+}
+
+// GetReservation implements SlurmControlInterface.
+func (r *realSlurmControl) GetReservation(ctx context.Context, controller *slinkyv1beta1.Controller, reservation *slinkyv1beta1.Reservation) (*ReservationStatus, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do GetReservation()")
+		return nil, ErrNoSlurmClient
+	}
+
+	reservationInfo, err := getReservation(ctx, slurmClient, reservation.ReservationName())
+	if err != nil || reservationInfo == nil {
+		return nil, err
+	}
+
+	status := &ReservationStatus{
+		Nodes:     ptr.Deref(reservationInfo.NodeList, ""),
+		NodeCount: ptr.Deref(reservationInfo.NodeCount, 0),
+	}
+	if reservationInfo.StartTime != nil {
+		status.StartTime = time.Unix(ptr.Deref(reservationInfo.StartTime.Number, 0), 0)
+	}
+	if reservationInfo.EndTime != nil {
+		status.EndTime = time.Unix(ptr.Deref(reservationInfo.EndTime.Number, 0), 0)
+	}
+
+	return status, nil
+}
+
+// SyncReservation implements SlurmControlInterface.
+func (r *realSlurmControl) SyncReservation(ctx context.Context, controller *slinkyv1beta1.Controller, reservation *slinkyv1beta1.Reservation, nodes []string) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do SyncReservation()")
+		return ErrNoSlurmClient
+	}
+
+	name := reservation.ReservationName()
+	nodeList, err := hostlist.Compress(nodes)
+	if err != nil {
+		return err
+	}
+
+	// Slurm rejects a start time in the past, so a reservation created late
+	// starts now and keeps its end time. A recurring reservation is created
+	// at its current or next occurrence instead.
+	now := time.Now().In(time.UTC)
+	startTime := reservation.Spec.StartTime.Time
+	duration := reservation.Duration()
+	if period := reservation.RecurrencePeriod(); period > 0 && !reservation.EndTime().After(now) {
+		occurrences := now.Sub(reservation.EndTime())/period + 1
+		startTime = startTime.Add(occurrences * period)
+	}
+	if startTime.Before(now) {
+		duration = startTime.Add(duration).Sub(now)
+		startTime = now
+	}
+
+	reservationDesc, newReservationInfo := reservationutils.FormatReservation(reservationutils.Reservation{
+		Name:      name,
+		StartTime: startTime,
+		Duration:  duration,
+		Flags:     reservation.Spec.Flags,
+		Users:     reservation.Spec.Users,
+		Accounts:  reservation.Spec.Accounts,
+		NodeList:  nodeList,
+	}, nil, nil)
+
+	oldReservationInfo, err := getReservation(ctx, slurmClient, name)
+	if err != nil {
+		return err
+	}
+
+	if oldReservationInfo == nil {
+		if err := slurmClient.Create(ctx, &newReservationInfo, reservationDesc); err != nil {
+			return fmt.Errorf("SyncReservation() failed to Create ReservationName=%s with error=%w", name, err)
+		}
+		return nil
+	}
+
+	timesMatch := true
+	switch {
+	case reservationHasFlags(newReservationInfo, reoccuringInfoFlags):
+		// Slurm moves a reoccurring reservation to its next occurrence, so its
+		// times are honored.
+		reservationDesc.StartTime = nil
+		reservationDesc.Duration = nil
+	case reservationutils.IsReservationActive(*oldReservationInfo, now):
+		// Slurm will not allow the start time of an active reservation to be
+		// updated, but its end time may be.
+		reservationDesc.StartTime = nil
+		reservationDesc.Duration = nil
+		reservationDesc.EndTime = newReservationInfo.EndTime
+		timesMatch = getNumber(oldReservationInfo.EndTime) == getNumber(newReservationInfo.EndTime)
+	default:
+		timesMatch = getNumber(oldReservationInfo.StartTime) == getNumber(newReservationInfo.StartTime) &&
+			getNumber(oldReservationInfo.EndTime) == getNumber(newReservationInfo.EndTime)
+	}
+	if timesMatch && isReservationMatch(*oldReservationInfo, newReservationInfo) {
+		return nil
+	}
+
+	if err := slurmClient.Update(ctx, oldReservationInfo, reservationDesc); err != nil && !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+		return fmt.Errorf("SyncReservation() failed to Update ReservationName=%s with error=%w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteReservation implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteReservation(ctx context.Context, controller *slinkyv1beta1.Controller, reservation *slinkyv1beta1.Reservation) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do DeleteReservation()")
+		return ErrNoSlurmClient
+	}
+
+	reservationInfo, err := getReservation(ctx, slurmClient, reservation.ReservationName())
+	if err != nil || reservationInfo == nil {
+		return err
+	}
+
+	if err := slurmClient.Delete(ctx, reservationInfo); err != nil && !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// getReservation returns the Slurm reservation, or nil if it does not exist.
+func getReservation(ctx context.Context, slurmClient slurmclient.Client, name string) (*slurmtypes.V0044ReservationInfo, error) {
+	reservationInfo := &slurmtypes.V0044ReservationInfo{}
+	key := slurmobject.ObjectKey(name)
+	if err := slurmClient.Get(ctx, key, reservationInfo); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if reservationInfo.Name == nil {
+		return nil, nil
+	}
+	return reservationInfo, nil
+}
+
+// isReservationMatch returns true if the flags, users, accounts and nodes of
+// the Slurm reservation already match the desired ones.
+func isReservationMatch(old, new slurmtypes.V0044ReservationInfo) bool {
+	// The output-only flag SPEC_NODES is set on reservations with a node list.
+	oldFlags := set.New(ptr.Deref(old.Flags, nil)...)
+	oldFlags.Delete(slurmapi.V0044ReservationInfoFlagsSPECNODES)
+	newFlags := set.New(ptr.Deref(new.Flags, nil)...)
+	if !oldFlags.Equal(newFlags) {
+		return false
+	}
+
+	if !isCsvMatch(old.Users, new.Users) || !isCsvMatch(old.Accounts, new.Accounts) {
+		return false
+	}
+
+	oldNodes, _ := hostlist.Expand(ptr.Deref(old.NodeList, ""))
+	newNodes, _ := hostlist.Expand(ptr.Deref(new.NodeList, ""))
+	return set.New(oldNodes...).Equal(set.New(newNodes...))
+}
+
+var reoccuringInfoFlags = []slurmapi.V0044ReservationInfoFlags{
+	slurmapi.V0044ReservationInfoFlagsHOURLY,
+	slurmapi.V0044ReservationInfoFlagsDAILY,
+	slurmapi.V0044ReservationInfoFlagsWEEKLY,
+	slurmapi.V0044ReservationInfoFlagsWEEKEND,
+	slurmapi.V0044ReservationInfoFlagsWEEKDAY,
+}
+
+func reservationHasFlags(reservation slurmtypes.V0044ReservationInfo, flags []slurmapi.V0044ReservationInfoFlags) bool {
+	return set.New(ptr.Deref(reservation.Flags, nil)...).HasAny(flags...)
+}
+
+func isCsvMatch(old, new *string) bool {
+	split := func(s *string) set.Set[string] {
+		out := set.New[string]()
+		for item := range strings.SplitSeq(ptr.Deref(s, ""), ",") {
+			if item != "" {
+				out.Insert(item)
+			}
+		}
+		return out
+	}
+	return split(old).Equal(split(new))
+}
+
+func getNumber(val *slurmapi.V0044Uint64NoValStruct) int64 {
+	if val == nil {
+		return 0
+	}
+	return ptr.Deref(val.Number, 0)
+}
+
+func (r *realSlurmControl) lookupClient(controller *slinkyv1beta1.Controller) slurmclient.Client {
+	key := ktypes.NamespacedName{
+		Namespace: controller.Namespace,
+		Name:      controller.Name,
+	}
+	return r.clientMap.Get(key)
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
+
+func tolerateError(err error) bool {
+	switch {
+	case err == nil, errors.Is(err, slurmerrors.ErrObjectNotFound):
+		return true
+	default:
+		return false
+	}
+}
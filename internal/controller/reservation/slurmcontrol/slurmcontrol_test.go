@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newController() *slinkyv1beta1.Controller {
+	return &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+}
+
+func newReservation(startTime time.Time) *slinkyv1beta1.Reservation {
+	return &slinkyv1beta1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "maint",
+		},
+		Spec: slinkyv1beta1.ReservationSpec{
+			ControllerRef: corev1.LocalObjectReference{
+				Name: "slurm",
+			},
+			Users:     []string{"alice", "bob"},
+			StartTime: metav1.NewTime(startTime),
+			Duration:  metav1.Duration{Duration: time.Hour},
+		},
+	}
+}
+
+func newReservationInfo(startTime time.Time, nodeList string) *types.V0044ReservationInfo {
+	return &types.V0044ReservationInfo{
+		V0044ReservationInfo: api.V0044ReservationInfo{
+			Name:      ptr.To("maint"),
+			StartTime: ptr.To(api.V0044Uint64NoValStruct{Number: ptr.To(startTime.Unix()), Set: ptr.To(true)}),
+			EndTime:   ptr.To(api.V0044Uint64NoValStruct{Number: ptr.To(startTime.Add(time.Hour).Unix()), Set: ptr.To(true)}),
+			Flags:     ptr.To([]api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsSPECNODES}),
+			Users:     ptr.To("bob,alice"),
+			NodeList:  ptr.To(nodeList),
+			NodeCount: ptr.To(int32(2)),
+		},
+	}
+}
+
+func Test_realSlurmControl_GetNodeNames(t *testing.T) {
+	controller := newController()
+	sclient := fake.NewClientBuilder().
+		WithLists(&types.V0044NodeList{
+			Items: []types.V0044Node{
+				{V0044Node: api.V0044Node{Name: ptr.To("node-0")}},
+				{V0044Node: api.V0044Node{Name: ptr.To("node-1")}},
+			},
+		}).
+		Build()
+	r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+	got, err := r.GetNodeNames(context.TODO(), controller)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"node-0", "node-1"}, got)
+}
+
+func Test_realSlurmControl_GetReservation(t *testing.T) {
+	controller := newController()
+	startTime := time.Now().Add(time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name    string
+		client  client.Client
+		want    *ReservationStatus
+		wantErr bool
+	}{
+		{
+			name:   "Not found",
+			client: fake.NewFakeClient(),
+			want:   nil,
+		},
+		{
+			name:   "Found",
+			client: fake.NewClientBuilder().WithObjects(newReservationInfo(startTime, "node-[0-1]")).Build(),
+			want: &ReservationStatus{
+				Nodes:     "node-[0-1]",
+				NodeCount: 2,
+				StartTime: startTime,
+				EndTime:   startTime.Add(time.Hour),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, tt.client))
+			got, err := r.GetReservation(context.TODO(), controller, newReservation(startTime))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_realSlurmControl_SyncReservation(t *testing.T) {
+	controller := newController()
+	startTime := time.Now().Add(time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name        string
+		reservation *slinkyv1beta1.Reservation
+		existing    *types.V0044ReservationInfo
+		nodes       []string
+		wantCreate  bool
+		wantUpdate  bool
+		// wantStartTime is the start time of the created reservation, if set.
+		wantStartTime time.Time
+	}{
+		{
+			name:        "Create",
+			reservation: newReservation(startTime),
+			nodes:       []string{"node-0", "node-1"},
+			wantCreate:  true,
+		},
+		{
+			name:        "Unchanged",
+			reservation: newReservation(startTime),
+			existing:    newReservationInfo(startTime, "node-[0-1]"),
+			nodes:       []string{"node-1", "node-0"},
+		},
+		{
+			name:        "Nodes changed",
+			reservation: newReservation(startTime),
+			existing:    newReservationInfo(startTime, "node-[0-1]"),
+			nodes:       []string{"node-0", "node-2"},
+			wantUpdate:  true,
+		},
+		{
+			name: "Accounts changed",
+			reservation: func() *slinkyv1beta1.Reservation {
+				reservation := newReservation(startTime)
+				reservation.Spec.Accounts = []string{"physics"}
+				return reservation
+			}(),
+			existing:   newReservationInfo(startTime, "node-[0-1]"),
+			nodes:      []string{"node-0", "node-1"},
+			wantUpdate: true,
+		},
+		{
+			name:        "Start time changed",
+			reservation: newReservation(startTime.Add(time.Hour)),
+			existing:    newReservationInfo(startTime, "node-[0-1]"),
+			nodes:       []string{"node-0", "node-1"},
+			wantUpdate:  true,
+		},
+		{
+			name:        "Active",
+			reservation: newReservation(time.Now().Add(-time.Minute).Truncate(time.Second)),
+			existing:    newReservationInfo(time.Now().Add(-time.Minute).Truncate(time.Second), "node-[0-1]"),
+			nodes:       []string{"node-0", "node-1"},
+		},
+		{
+			name: "Create recurring at its next occurrence",
+			reservation: func() *slinkyv1beta1.Reservation {
+				reservation := newReservation(startTime.Add(-48 * time.Hour))
+				reservation.Spec.Flags = []string{"DAILY"}
+				return reservation
+			}(),
+			nodes:         []string{"node-0", "node-1"},
+			wantCreate:    true,
+			wantStartTime: startTime,
+		},
+		{
+			name: "Recurring changed after its first occurrence",
+			reservation: func() *slinkyv1beta1.Reservation {
+				reservation := newReservation(startTime.Add(-48 * time.Hour))
+				reservation.Spec.Flags = []string{"DAILY"}
+				reservation.Spec.Accounts = []string{"physics"}
+				return reservation
+			}(),
+			existing: func() *types.V0044ReservationInfo {
+				reservationInfo := newReservationInfo(startTime, "node-[0-1]")
+				reservationInfo.Flags = ptr.To([]api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsDAILY})
+				return reservationInfo
+			}(),
+			nodes:      []string{"node-0", "node-1"},
+			wantUpdate: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, updated bool
+			var createdStartTime time.Time
+			builder := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(_ context.Context, _ object.Object, req any, _ ...client.CreateOption) error {
+					created = true
+					if desc, ok := req.(api.V0044ReservationDescMsg); ok && desc.StartTime != nil {
+						createdStartTime = time.Unix(ptr.Deref(desc.StartTime.Number, 0), 0)
+					}
+					return nil
+				},
+				Update: func(context.Context, object.Object, any, ...client.UpdateOption) error {
+					updated = true
+					return nil
+				},
+			})
+			if tt.existing != nil {
+				builder = builder.WithObjects(tt.existing)
+			}
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, builder.Build()))
+			err := r.SyncReservation(context.TODO(), controller, tt.reservation, tt.nodes)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCreate, created)
+			require.Equal(t, tt.wantUpdate, updated)
+			if !tt.wantStartTime.IsZero() {
+				require.True(t, tt.wantStartTime.Equal(createdStartTime), "got start time %v", createdStartTime)
+			}
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteReservation(t *testing.T) {
+	controller := newController()
+	startTime := time.Now().Add(time.Hour).Truncate(time.Second)
+	sclient := fake.NewClientBuilder().WithObjects(newReservationInfo(startTime, "node-[0-1]")).Build()
+	r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+
+	require.NoError(t, r.DeleteReservation(context.TODO(), controller, newReservation(startTime)))
+	got, err := r.GetReservation(context.TODO(), controller, newReservation(startTime))
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	// Deleting an absent reservation is a no-op.
+	require.NoError(t, r.DeleteReservation(context.TODO(), controller, newReservation(startTime)))
+}
+
+func Test_realSlurmControl_NoClient(t *testing.T) {
+	controller := newController()
+	reservation := newReservation(time.Now())
+	r := NewSlurmControl(testutils.NewClientMap("other", controller.Namespace, fake.NewFakeClient()))
+	_, err := r.GetNodeNames(context.TODO(), controller)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+	_, err = r.GetReservation(context.TODO(), controller, reservation)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+	err = r.SyncReservation(context.TODO(), controller, reservation, []string{"node-0"})
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+	err = r.DeleteReservation(context.TODO(), controller, reservation)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+}
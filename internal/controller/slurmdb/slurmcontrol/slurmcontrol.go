@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slurmcontrol holds the Slurm client logic shared by the controllers
+// of the objects which are synced into the Slurm database.
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ktypes "k8s.io/apimachinery/pkg/types"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmerrors "github.com/SlinkyProject/slurm-client/pkg/errors"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+)
+
+// LookupClient returns the Slurm client of the first controller which has one.
+// Controllers sharing a slurmdbd are interchangeable for its accounting data.
+func LookupClient(clientMap *clientmap.ClientMap, controllers []slinkyv1beta1.Controller) slurmclient.Client {
+	for _, controller := range controllers {
+		key := ktypes.NamespacedName{
+			Namespace: controller.Namespace,
+			Name:      controller.Name,
+		}
+		if slurmClient := clientMap.Get(key); slurmClient != nil {
+			return slurmClient
+		}
+	}
+	return nil
+}
+
+// SyncAssoc creates the Slurm association, or updates it when it drifted.
+func SyncAssoc(ctx context.Context, slurmClient slurmclient.Client, assocs []slurmtypes.V0044Assoc, newAssoc slurmdbutils.Assoc) error {
+	req := slurmapi.V0044Assoc{}
+	if err := slurmdbutils.Convert(newAssoc, &req); err != nil {
+		return err
+	}
+
+	for _, assocInfo := range assocs {
+		oldAssoc := slurmdbutils.Assoc{}
+		if err := slurmdbutils.Convert(assocInfo, &oldAssoc); err != nil {
+			return err
+		}
+		if !slurmdbutils.IsAssocKeyMatch(oldAssoc, newAssoc) {
+			continue
+		}
+		if slurmdbutils.IsAssocMatch(oldAssoc, newAssoc) {
+			return nil
+		}
+		if err := slurmClient.Update(ctx, &assocInfo, req); err != nil {
+			return fmt.Errorf("SyncAssoc() failed to Update Association(User=%s,Account=%s,Cluster=%s) with error=%w",
+				newAssoc.User, newAssoc.Account, newAssoc.Cluster, err)
+		}
+		return nil
+	}
+
+	assocInfo := &slurmtypes.V0044Assoc{V0044Assoc: req}
+	if err := slurmClient.Create(ctx, assocInfo, req); err != nil {
+		return fmt.Errorf("SyncAssoc() failed to Create Association(User=%s,Account=%s,Cluster=%s) with error=%w",
+			newAssoc.User, newAssoc.Account, newAssoc.Cluster, err)
+	}
+
+	return nil
+}
+
+// ListAssocs returns the Slurm associations, tolerating that none exist.
+func ListAssocs(ctx context.Context, slurmClient slurmclient.Client) ([]slurmtypes.V0044Assoc, error) {
+	assocList := &slurmtypes.V0044AssocList{}
+	if err := slurmClient.List(ctx, assocList); err != nil {
+		if !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil, err
+		}
+	}
+	return assocList.Items, nil
+}
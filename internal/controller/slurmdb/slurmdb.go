@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slurmdb implements the control logic shared by the controllers of
+// the objects which are synced into the Slurm database (slurmdbd), such as
+// Accounts, QOS and SlurmUsers.
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+// ErrNoSlurmClient is returned when no controller of the object has a Slurm
+// client, hence the Slurm database cannot be reached.
+var ErrNoSlurmClient = errors.New("NoSlurmClient")
+
+const (
+	SyncedReason     = "Synced"
+	SyncFailedReason = "SyncFailed"
+)
+
+// SyncFunc syncs the object into, or deletes it from, the Slurm database of
+// the controllers.
+type SyncFunc[T client.Object] func(ctx context.Context, controllers []slinkyv1beta1.Controller, obj T) error
+
+// Syncer synchronizes an object of kind T into the Slurm database.
+//
+// The object is synced by SyncFn while it exists, and deleted by DeleteFn
+// before its finalizer is removed. The result of the sync is recorded on the
+// Synced condition.
+type Syncer[T client.Object] struct {
+	client.Client
+	RefResolver   *refresolver.RefResolver
+	DurationStore *durationstore.DurationStore
+
+	// Kind is the kind of the object, for logs and errors.
+	Kind string
+	// Finalizer guards the deletion of the object from the Slurm database.
+	Finalizer string
+
+	// NewObject returns an empty object.
+	NewObject func() T
+	// SlurmdbRef returns the reference to the Slurm database of the object.
+	SlurmdbRef func(obj T) slinkyv1beta1.SlurmdbRef
+	// Conditions returns the status conditions of the object.
+	Conditions func(obj T) *[]metav1.Condition
+	// StatusFn optionally sets the remaining status of the object from the
+	// result of the sync.
+	StatusFn func(controllers []slinkyv1beta1.Controller, obj T, syncErr error)
+
+	SyncFn   SyncFunc[T]
+	DeleteFn SyncFunc[T]
+}
+
+// Sync implements control logic for synchronizing the object.
+func (s *Syncer[T]) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	obj := s.NewObject()
+	if err := s.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info(s.Kind + " has been deleted")
+			return nil
+		}
+		return err
+	}
+	obj = obj.DeepCopyObject().(T)
+	key := objectutils.KeyFunc(obj)
+
+	controllerList, err := s.RefResolver.GetControllersForSlurmdbRef(ctx, s.SlurmdbRef(obj), obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+	controllers := controllerList.Items
+
+	if !obj.GetDeletionTimestamp().IsZero() {
+		logger.Info(s.Kind + " is being deleted")
+		return s.syncFinalizer(ctx, controllers, obj)
+	} else {
+		s.DurationStore.Push(key, 30*time.Second)
+	}
+
+	if err := s.addFinalizerIfNeeded(ctx, obj); err != nil {
+		return err
+	}
+
+	if err := s.sync(ctx, controllers, obj); err != nil {
+		// The Slurm object cannot be observed while the Slurm client is unavailable.
+		if errors.Is(err, ErrNoSlurmClient) {
+			return nil
+		}
+		return s.syncStatus(ctx, controllers, obj, err)
+	}
+
+	return s.syncStatus(ctx, controllers, obj)
+}
+
+// sync creates or updates the Slurm object.
+func (s *Syncer[T]) sync(ctx context.Context, controllers []slinkyv1beta1.Controller, obj T) error {
+	if len(controllers) == 0 {
+		return fmt.Errorf("failed to get Controllers of %s(%s): not found", s.Kind, klog.KObj(obj))
+	}
+
+	return s.SyncFn(ctx, controllers, obj)
+}
+
+// syncFinalizer deletes the Slurm object, then removes the finalizer.
+func (s *Syncer[T]) syncFinalizer(ctx context.Context, controllers []slinkyv1beta1.Controller, obj T) error {
+	if !controllerutil.ContainsFinalizer(obj, s.Finalizer) {
+		return nil
+	}
+
+	// If no controller exists, the Slurm object cannot be deleted and the
+	// finalizer must be removed to permit cleanup.
+	if len(controllers) > 0 {
+		if err := s.DeleteFn(ctx, controllers, obj); err != nil {
+			return err
+		}
+	}
+
+	finalizers := set.New(obj.GetFinalizers()...)
+	finalizers.Delete(s.Finalizer)
+	return s.updateFinalizers(ctx, obj, finalizers.SortedList())
+}
+
+func (s *Syncer[T]) addFinalizerIfNeeded(ctx context.Context, obj T) error {
+	if controllerutil.ContainsFinalizer(obj, s.Finalizer) {
+		return nil
+	}
+
+	finalizers := slices.Concat(obj.GetFinalizers(), []string{s.Finalizer})
+	return s.updateFinalizers(ctx, obj, finalizers)
+}
+
+func (s *Syncer[T]) updateFinalizers(ctx context.Context, obj T, newFinalizers []string) error {
+	logger := log.FromContext(ctx)
+
+	logger.V(1).Info("Pending "+s.Kind+" Finalizer update", "newFinalizers", newFinalizers)
+
+	mutateFn := func(obj T) error {
+		obj.SetFinalizers(newFinalizers)
+		return nil
+	}
+
+	if err := objectutils.PatchObject(s.Client, ctx, obj, mutateFn); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncStatus handles determining and updating the status.
+func (s *Syncer[T]) syncStatus(
+	ctx context.Context,
+	controllers []slinkyv1beta1.Controller,
+	obj T,
+	errors ...error,
+) error {
+	logger := log.FromContext(ctx)
+
+	syncErr := utilerrors.NewAggregate(errors)
+	mutateFn := func(obj T) error {
+		if s.StatusFn != nil {
+			s.StatusFn(controllers, obj, syncErr)
+		}
+		meta.SetStatusCondition(s.Conditions(obj), NewSyncedCondition(obj, syncErr))
+		return nil
+	}
+
+	logger.V(1).Info("Pending "+s.Kind+" Status update", "object", klog.KObj(obj))
+	if err := objectutils.StatusPatchObject(s.Client, ctx, obj, mutateFn); err != nil {
+		if !apierrors.IsNotFound(err) {
+			errors = append(errors, fmt.Errorf("error updating %s(%s) status: %w",
+				s.Kind, klog.KObj(obj), err))
+		}
+	}
+
+	return utilerrors.NewAggregate(errors)
+}
+
+// NewSyncedCondition returns the Synced condition from the result of the sync.
+func NewSyncedCondition(obj metav1.Object, syncErr error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               slurmconditions.SlurmdbConditionSynced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             SyncedReason,
+	}
+	if syncErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = SyncFailedReason
+		condition.Message = syncErr.Error()
+	}
+	return condition
+}
+
+// ClusterNames returns the sorted Slurm cluster names of the controllers.
+func ClusterNames(controllers []slinkyv1beta1.Controller) []string {
+	clusters := set.New[string]()
+	for _, controller := range controllers {
+		clusters.Insert(controller.ClusterName())
+	}
+	return clusters.SortedList()
+}
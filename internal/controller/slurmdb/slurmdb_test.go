@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/durationstore"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func init() {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+}
+
+type fakeSlurmControl struct {
+	err error
+
+	synced  []string
+	deleted bool
+}
+
+func (f *fakeSlurmControl) sync(_ context.Context, controllers []slinkyv1beta1.Controller, _ *slinkyv1beta1.Account) error {
+	for _, controller := range controllers {
+		f.synced = append(f.synced, controller.Name)
+	}
+	return f.err
+}
+
+func (f *fakeSlurmControl) delete(context.Context, []slinkyv1beta1.Controller, *slinkyv1beta1.Account) error {
+	f.deleted = true
+	return f.err
+}
+
+func newSyncer(c client.Client, slurmControl *fakeSlurmControl) *Syncer[*slinkyv1beta1.Account] {
+	return &Syncer[*slinkyv1beta1.Account]{
+		Client:        c,
+		RefResolver:   refresolver.New(c),
+		DurationStore: durationstore.NewDurationStore(durationstore.Greater),
+
+		Kind:      "Account",
+		Finalizer: slinkyv1beta1.FinalizerAccount,
+
+		NewObject: func() *slinkyv1beta1.Account {
+			return &slinkyv1beta1.Account{}
+		},
+		SlurmdbRef: func(account *slinkyv1beta1.Account) slinkyv1beta1.SlurmdbRef {
+			return account.Spec.SlurmdbRef
+		},
+		Conditions: func(account *slinkyv1beta1.Account) *[]metav1.Condition {
+			return &account.Status.Conditions
+		},
+		StatusFn: func(controllers []slinkyv1beta1.Controller, account *slinkyv1beta1.Account, syncErr error) {
+			if syncErr == nil {
+				account.Status.Clusters = ClusterNames(controllers)
+			}
+		},
+
+		SyncFn:   slurmControl.sync,
+		DeleteFn: slurmControl.delete,
+	}
+}
+
+func newAccount(ref slinkyv1beta1.SlurmdbRef) *slinkyv1beta1.Account {
+	return &slinkyv1beta1.Account{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "physics",
+		},
+		Spec: slinkyv1beta1.AccountSpec{
+			SlurmdbRef: ref,
+		},
+	}
+}
+
+func newObjects() []client.Object {
+	newController := func(name string) *slinkyv1beta1.Controller {
+		return &slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: corev1.NamespaceDefault,
+				Name:      name,
+			},
+			Spec: slinkyv1beta1.ControllerSpec{
+				AccountingRef: &corev1.LocalObjectReference{Name: "slurmdbd"},
+			},
+		}
+	}
+	accounting := &slinkyv1beta1.Accounting{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurmdbd",
+		},
+	}
+	return []client.Object{accounting, newController("slurm-a"), newController("slurm-b")}
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	tests := []struct {
+		name          string
+		ref           slinkyv1beta1.SlurmdbRef
+		err           error
+		wantSynced    []string
+		wantClusters  []string
+		wantCondition metav1.ConditionStatus
+	}{
+		{
+			name: "AccountingRef",
+			ref: slinkyv1beta1.SlurmdbRef{
+				AccountingRef: &corev1.LocalObjectReference{Name: "slurmdbd"},
+			},
+			wantSynced:    []string{"slurm-a", "slurm-b"},
+			wantClusters:  []string{"default_slurm-a", "default_slurm-b"},
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name: "ControllerRef",
+			ref: slinkyv1beta1.SlurmdbRef{
+				ControllerRef: &corev1.LocalObjectReference{Name: "slurm-b"},
+			},
+			wantSynced:    []string{"slurm-b"},
+			wantClusters:  []string{"default_slurm-b"},
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name: "No Controllers",
+			ref: slinkyv1beta1.SlurmdbRef{
+				ControllerRef: &corev1.LocalObjectReference{Name: "slurm-c"},
+			},
+			wantCondition: metav1.ConditionFalse,
+		},
+		{
+			name: "Sync error",
+			ref: slinkyv1beta1.SlurmdbRef{
+				ControllerRef: &corev1.LocalObjectReference{Name: "slurm-a"},
+			},
+			err:           errors.New("invalid parent account"),
+			wantSynced:    []string{"slurm-a"},
+			wantCondition: metav1.ConditionFalse,
+		},
+		{
+			name: "No Slurm client",
+			ref: slinkyv1beta1.SlurmdbRef{
+				ControllerRef: &corev1.LocalObjectReference{Name: "slurm-a"},
+			},
+			err:        ErrNoSlurmClient,
+			wantSynced: []string{"slurm-a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newAccount(tt.ref)
+			c := fake.NewClientBuilder().
+				WithObjects(newObjects()...).
+				WithObjects(account).
+				WithStatusSubresource(&slinkyv1beta1.Account{}).
+				Build()
+			slurmControl := &fakeSlurmControl{err: tt.err}
+			s := newSyncer(c, slurmControl)
+
+			req := client.ObjectKeyFromObject(account)
+			err := s.Sync(context.TODO(), reconcile.Request{NamespacedName: req})
+			require.Equal(t, tt.wantCondition == metav1.ConditionFalse, err != nil)
+			require.Equal(t, tt.wantSynced, slurmControl.synced)
+
+			got := &slinkyv1beta1.Account{}
+			require.NoError(t, c.Get(context.TODO(), req, got))
+			require.Contains(t, got.Finalizers, slinkyv1beta1.FinalizerAccount)
+			require.Equal(t, tt.wantClusters, got.Status.Clusters)
+			condition := meta.FindStatusCondition(got.Status.Conditions, slurmconditions.SlurmdbConditionSynced)
+			if tt.wantCondition == "" {
+				require.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			require.Equal(t, tt.wantCondition, condition.Status)
+		})
+	}
+}
+
+func TestSyncer_syncFinalizer(t *testing.T) {
+	tests := []struct {
+		name        string
+		ref         slinkyv1beta1.SlurmdbRef
+		wantDeleted bool
+	}{
+		{
+			name: "Delete Slurm object",
+			ref: slinkyv1beta1.SlurmdbRef{
+				AccountingRef: &corev1.LocalObjectReference{Name: "slurmdbd"},
+			},
+			wantDeleted: true,
+		},
+		{
+			name: "No Controllers",
+			ref: slinkyv1beta1.SlurmdbRef{
+				AccountingRef: &corev1.LocalObjectReference{Name: "other"},
+			},
+			wantDeleted: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newAccount(tt.ref)
+			account.Finalizers = []string{slinkyv1beta1.FinalizerAccount}
+			c := fake.NewClientBuilder().
+				WithObjects(newObjects()...).
+				WithObjects(account).
+				WithStatusSubresource(&slinkyv1beta1.Account{}).
+				Build()
+			slurmControl := &fakeSlurmControl{}
+			s := newSyncer(c, slurmControl)
+
+			req := client.ObjectKeyFromObject(account)
+			require.NoError(t, c.Delete(context.TODO(), account))
+			require.NoError(t, s.Sync(context.TODO(), reconcile.Request{NamespacedName: req}))
+			require.Equal(t, tt.wantDeleted, slurmControl.deleted)
+			err := c.Get(context.TODO(), req, &slinkyv1beta1.Account{})
+			require.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestNewSyncedCondition(t *testing.T) {
+	account := newAccount(slinkyv1beta1.SlurmdbRef{})
+	account.Generation = 2
+
+	got := NewSyncedCondition(account, nil)
+	require.Equal(t, metav1.ConditionTrue, got.Status)
+	require.Equal(t, SyncedReason, got.Reason)
+	require.Equal(t, int64(2), got.ObservedGeneration)
+
+	got = NewSyncedCondition(account, errors.New("invalid parent account"))
+	require.Equal(t, metav1.ConditionFalse, got.Status)
+	require.Equal(t, SyncFailedReason, got.Reason)
+	require.Equal(t, "invalid parent account", got.Message)
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	clienttoken "github.com/SlinkyProject/slurm-client/pkg/client/token"
+	slurmerrors "github.com/SlinkyProject/slurm-client/pkg/errors"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjobutils"
+)
+
+var ErrNoSlurmClient = errors.New("NoSlurmClient")
+
+type SlurmControlInterface interface {
+	// SubmitJob submits the Slurm job of the SlurmJob, and returns its job ID.
+	// If the SlurmJob already submitted a job, its job ID is returned instead.
+	// The job is submitted as the user of the authToken, never as the user of
+	// the Slurm client.
+	SubmitJob(ctx context.Context, controller *slinkyv1beta1.Controller, slurmJob *slinkyv1beta1.SlurmJob, script, authToken string) (int32, error)
+	// GetJob returns the Slurm job, or nil if it does not exist.
+	GetJob(ctx context.Context, controller *slinkyv1beta1.Controller, jobID int32) (*slurmjobutils.JobInfo, error)
+	// CancelJob cancels the Slurm job, if it exists.
+	CancelJob(ctx context.Context, controller *slinkyv1beta1.Controller, jobID int32) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+
+	// newClient returns a Slurm client of the server, which authenticates with
+	// the token.
+	newClient func(server, authToken string) (slurmclient.Client, error)
+}
+
+// SubmitJob implements SlurmControlInterface.
+func (r *realSlurmControl) SubmitJob(ctx context.Context, controller *slinkyv1beta1.Controller, slurmJob *slinkyv1beta1.SlurmJob, script, authToken string) (int32, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do SubmitJob()")
+		return 0, ErrNoSlurmClient
+	}
+
+	// A previous submission may have succeeded without being recorded.
+	opts := &slurmclient.ListOptions{
+		SkipCache: true,
+	}
+	jobList := &slurmtypes.V0044JobInfoList{}
+	if err := slurmClient.List(ctx, jobList, opts); err != nil {
+		if !tolerateError(err) {
+			return 0, err
+		}
+	}
+	for _, job := range jobList.Items {
+		jobInfo := slurmjobutils.JobInfo{}
+		if err := slurmdbutils.Convert(job, &jobInfo); err != nil {
+			return 0, err
+		}
+		if jobInfo.Comment == slurmJob.JobComment() && jobInfo.JobId != nil {
+			logger.V(1).Info("found submitted slurm job", "jobID", *jobInfo.JobId)
+			return *jobInfo.JobId, nil
+		}
+	}
+
+	if authToken == "" {
+		return 0, errors.New("SubmitJob() requires the auth token of the job user")
+	}
+	userClient, err := r.newClient(slurmClient.GetServer(), authToken)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create slurm client: %w", err)
+	}
+
+	req := slurmapi.V0044JobSubmitReq{}
+	if err := slurmdbutils.Convert(slurmjobutils.NewJobSubmitReq(slurmJob, script), &req); err != nil {
+		return 0, err
+	}
+	jobInfo := &slurmtypes.V0044JobInfo{}
+	if err := userClient.Create(ctx, jobInfo, req); err != nil {
+		return 0, fmt.Errorf("SubmitJob() failed to Create Job(%s) with error=%w", slurmJob.JobName(), err)
+	}
+	if jobInfo.JobId == nil {
+		return 0, fmt.Errorf("SubmitJob() failed to Create Job(%s): no job ID", slurmJob.JobName())
+	}
+	logger.V(1).Info("submitted slurm job", "jobID", *jobInfo.JobId)
+
+	return *jobInfo.JobId, nil
+}
+
+// GetJob implements SlurmControlInterface.
+func (r *realSlurmControl) GetJob(ctx context.Context, controller *slinkyv1beta1.Controller, jobID int32) (*slurmjobutils.JobInfo, error) {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do GetJob()")
+		return nil, ErrNoSlurmClient
+	}
+
+	job := &slurmtypes.V0044JobInfo{}
+	key := slurmobject.ObjectKey(strconv.Itoa(int(jobID)))
+	if err := slurmClient.Get(ctx, key, job); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	jobInfo := &slurmjobutils.JobInfo{}
+	if err := slurmdbutils.Convert(job, jobInfo); err != nil {
+		return nil, err
+	}
+
+	return jobInfo, nil
+}
+
+// CancelJob implements SlurmControlInterface.
+func (r *realSlurmControl) CancelJob(ctx context.Context, controller *slinkyv1beta1.Controller, jobID int32) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := r.lookupClient(controller)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controller, cannot do CancelJob()")
+		return ErrNoSlurmClient
+	}
+
+	logger.V(1).Info("cancel slurm job", "jobID", jobID)
+	job := &slurmtypes.V0044JobInfo{
+		V0044JobInfo: slurmapi.V0044JobInfo{
+			JobId: ptr.To(jobID),
+		},
+	}
+	if err := slurmClient.Delete(ctx, job); err != nil && !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+		return fmt.Errorf("CancelJob() failed to Delete JobId=%d with error=%w", jobID, err)
+	}
+
+	return nil
+}
+
+func (r *realSlurmControl) lookupClient(controller *slinkyv1beta1.Controller) slurmclient.Client {
+	key := ktypes.NamespacedName{
+		Namespace: controller.Namespace,
+		Name:      controller.Name,
+	}
+	return r.clientMap.Get(key)
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+		newClient: newClient,
+	}
+}
+
+func newClient(server, authToken string) (slurmclient.Client, error) {
+	config := &slurmclient.Config{
+		Server:        server,
+		TokenProvider: clienttoken.StaticProvider(authToken),
+		HTTPClient: &http.Client{
+			Timeout: 5 * time.Minute,
+		},
+	}
+	return slurmclient.NewClient(config, &slurmclient.ClientOptions{})
+}
+
+func tolerateError(err error) bool {
+	switch {
+	case err == nil, errors.Is(err, slurmerrors.ErrObjectNotFound):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newController() *slinkyv1beta1.Controller {
+	return &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+}
+
+func newSlurmJob() *slinkyv1beta1.SlurmJob {
+	return &slinkyv1beta1.SlurmJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "hello",
+			UID:       "1234",
+		},
+		Spec: slinkyv1beta1.SlurmJobSpec{
+			Script: slinkyv1beta1.SlurmJobScript{
+				Inline: ptr.To("#!/bin/sh\nhostname"),
+			},
+		},
+	}
+}
+
+func newJobInfo(jobID int32, comment string, state slurmapi.V0044JobInfoJobState) *types.V0044JobInfo {
+	return &types.V0044JobInfo{
+		V0044JobInfo: slurmapi.V0044JobInfo{
+			JobId:    ptr.To(jobID),
+			Comment:  ptr.To(comment),
+			JobState: ptr.To([]slurmapi.V0044JobInfoJobState{state}),
+		},
+	}
+}
+
+func Test_realSlurmControl_SubmitJob(t *testing.T) {
+	controller := newController()
+	slurmJob := newSlurmJob()
+	tests := []struct {
+		name        string
+		jobs        []types.V0044JobInfo
+		authToken   string
+		want        int32
+		wantCreate  bool
+		wantNewUser bool
+		wantErr     bool
+	}{
+		{
+			name:        "Submit as user",
+			jobs:        []types.V0044JobInfo{*newJobInfo(1, "other", slurmapi.V0044JobInfoJobStateRUNNING)},
+			authToken:   "token",
+			want:        42,
+			wantCreate:  true,
+			wantNewUser: true,
+		},
+		{
+			name:      "Already submitted",
+			jobs:      []types.V0044JobInfo{*newJobInfo(7, slurmJob.JobComment(), slurmapi.V0044JobInfoJobStatePENDING)},
+			authToken: "token",
+			want:      7,
+		},
+		{
+			name:    "No auth token",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, newUser bool
+			sclient := fake.NewClientBuilder().
+				WithLists(&types.V0044JobInfoList{Items: tt.jobs}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(_ context.Context, obj object.Object, _ any, _ ...client.CreateOption) error {
+						created = true
+						obj.(*types.V0044JobInfo).JobId = ptr.To[int32](42)
+						return nil
+					},
+				}).
+				Build()
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient)).(*realSlurmControl)
+			r.newClient = func(server, authToken string) (client.Client, error) {
+				newUser = true
+				require.Equal(t, tt.authToken, authToken)
+				return sclient, nil
+			}
+			got, err := r.SubmitJob(context.TODO(), controller, slurmJob, "#!/bin/sh\nhostname", tt.authToken)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.False(t, created)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantCreate, created)
+			require.Equal(t, tt.wantNewUser, newUser)
+		})
+	}
+}
+
+func Test_realSlurmControl_GetJob(t *testing.T) {
+	controller := newController()
+	sclient := fake.NewClientBuilder().
+		WithObjects(newJobInfo(42, "", slurmapi.V0044JobInfoJobStateCOMPLETED)).
+		Build()
+	r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+
+	got, err := r.GetJob(context.TODO(), controller, 42)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.True(t, got.IsCompleted())
+
+	got, err = r.GetJob(context.TODO(), controller, 7)
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
+
+func Test_realSlurmControl_CancelJob(t *testing.T) {
+	controller := newController()
+	var deleted bool
+	sclient := fake.NewClientBuilder().
+		WithObjects(newJobInfo(42, "", slurmapi.V0044JobInfoJobStateRUNNING)).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(context.Context, object.Object, ...client.DeleteOption) error {
+				deleted = true
+				return nil
+			},
+		}).
+		Build()
+	r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+	require.NoError(t, r.CancelJob(context.TODO(), controller, 42))
+	require.True(t, deleted)
+}
+
+func Test_realSlurmControl_NoClient(t *testing.T) {
+	controller := newController()
+	slurmJob := newSlurmJob()
+	r := NewSlurmControl(testutils.NewClientMap("other", corev1.NamespaceDefault, fake.NewFakeClient()))
+	_, err := r.SubmitJob(context.TODO(), controller, slurmJob, "", "")
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+	_, err = r.GetJob(context.TODO(), controller, 42)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+	err = r.CancelJob(context.TODO(), controller, 42)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+}
@@ -0,0 +1,335 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/token/slurmjwt"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjobutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	// pollInterval is how often an unfinished Slurm job is observed.
+	pollInterval = 10 * time.Second
+
+	// authTokenLifetime is the lifetime of the JWT a job is submitted with.
+	authTokenLifetime = 5 * time.Minute
+)
+
+// Sync implements control logic for synchronizing a SlurmJob.
+func (r *SlurmJobReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	logger := log.FromContext(ctx)
+
+	slurmJob := &slinkyv1beta1.SlurmJob{}
+	if err := r.Get(ctx, req.NamespacedName, slurmJob); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("SlurmJob has been deleted")
+			return nil
+		}
+		return err
+	}
+	slurmJob = slurmJob.DeepCopy()
+	key := objectutils.KeyFunc(slurmJob)
+
+	controller, err := r.refResolver.GetController(ctx, slurmJob.Spec.ControllerRef, slurmJob.Namespace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		controller = nil
+	}
+
+	if !slurmJob.DeletionTimestamp.IsZero() {
+		logger.Info("SlurmJob is being deleted")
+		return r.syncFinalizer(ctx, controller, slurmJob)
+	}
+
+	if isFinished(slurmJob) {
+		return r.syncTTL(ctx, slurmJob)
+	}
+
+	if err := r.addFinalizerIfNeeded(ctx, slurmJob); err != nil {
+		return err
+	}
+
+	newStatus := slurmJob.Status.DeepCopy()
+	if err := r.sync(ctx, controller, slurmJob, newStatus); err != nil {
+		// The Slurm job cannot be submitted nor observed while the Slurm
+		// client is unavailable.
+		if errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			durationStore.Push(key, pollInterval)
+			return nil
+		}
+		return r.syncStatus(ctx, slurmJob, newStatus, err)
+	}
+
+	if !isStatusFinished(newStatus) {
+		durationStore.Push(key, pollInterval)
+	}
+
+	return r.syncStatus(ctx, slurmJob, newStatus)
+}
+
+// sync submits the Slurm job, if it was not yet, otherwise observes it.
+func (r *SlurmJobReconciler) sync(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	slurmJob *slinkyv1beta1.SlurmJob,
+	newStatus *slinkyv1beta1.SlurmJobStatus,
+) error {
+	if controller == nil {
+		return fmt.Errorf("failed to get Controller(%s) of SlurmJob(%s): not found",
+			slurmJob.Spec.ControllerRef.Name, klog.KObj(slurmJob))
+	}
+
+	if newStatus.JobID == nil {
+		return r.submitJob(ctx, controller, slurmJob, newStatus)
+	}
+
+	return r.observeJob(ctx, controller, slurmJob, newStatus)
+}
+
+// submitJob submits the Slurm job and records its job ID.
+func (r *SlurmJobReconciler) submitJob(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	slurmJob *slinkyv1beta1.SlurmJob,
+	newStatus *slinkyv1beta1.SlurmJobStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	script, err := r.getScript(ctx, slurmJob)
+	if err != nil {
+		return err
+	}
+
+	authToken, err := r.getAuthToken(ctx, controller, slurmJob)
+	if err != nil {
+		return err
+	}
+
+	jobID, err := r.slurmControl.SubmitJob(ctx, controller, slurmJob, script, authToken)
+	if err != nil {
+		if !errors.Is(err, slurmcontrol.ErrNoSlurmClient) {
+			msg := fmt.Sprintf("Failed to submit Slurm job: %v", err)
+			r.eventRecorder.Eventf(slurmJob, nil, corev1.EventTypeWarning, SubmitFailedReason, "Submit", msg)
+		}
+		return err
+	}
+
+	logger.Info("Submitted Slurm job", "jobID", jobID)
+	r.eventRecorder.Eventf(slurmJob, nil, corev1.EventTypeNormal, SubmittedReason, "Submit",
+		"Submitted Slurm job %d", jobID)
+
+	newStatus.JobID = ptr.To(jobID)
+	newStatus.SubmitTime = ptr.To(metav1.Now())
+	newStatus.State = slurmjobutils.JobStatePending
+
+	return nil
+}
+
+// observeJob updates the status from the Slurm job.
+func (r *SlurmJobReconciler) observeJob(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	slurmJob *slinkyv1beta1.SlurmJob,
+	newStatus *slinkyv1beta1.SlurmJobStatus,
+) error {
+	jobID := ptr.Deref(newStatus.JobID, 0)
+	jobInfo, err := r.slurmControl.GetJob(ctx, controller, jobID)
+	if err != nil {
+		return err
+	}
+
+	// Slurm purges finished jobs after MinJobAge, a job which is gone was
+	// therefore never observed to finish.
+	if jobInfo == nil {
+		msg := fmt.Sprintf("Slurm job %d was not found", jobID)
+		newStatus.CompletionTime = ptr.To(metav1.Now())
+		meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{
+			Type:               slurmconditions.SlurmJobConditionFailed,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: slurmJob.Generation,
+			Reason:             NotFoundReason,
+			Message:            msg,
+		})
+		r.eventRecorder.Eventf(slurmJob, nil, corev1.EventTypeWarning, FailedReason, "Observe", msg)
+		return nil
+	}
+
+	newStatus.State = jobInfo.State()
+	newStatus.Nodes = jobInfo.Nodes
+	if startTime := jobInfo.GetStartTime(); startTime != nil && newStatus.StartTime == nil {
+		newStatus.StartTime = ptr.To(metav1.NewTime(*startTime))
+	}
+
+	if !jobInfo.IsFinished() {
+		return nil
+	}
+
+	newStatus.ExitCode = jobInfo.GetExitCode()
+	newStatus.CompletionTime = ptr.To(metav1.Now())
+	if endTime := jobInfo.GetEndTime(); endTime != nil {
+		newStatus.CompletionTime = ptr.To(metav1.NewTime(*endTime))
+	}
+
+	msg := fmt.Sprintf("Slurm job %d finished in state %s", jobID, newStatus.State)
+	condition := metav1.Condition{
+		Type:               slurmconditions.SlurmJobConditionComplete,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: slurmJob.Generation,
+		Reason:             CompletedReason,
+		Message:            msg,
+	}
+	eventType := corev1.EventTypeNormal
+	if !jobInfo.IsCompleted() {
+		condition.Type = slurmconditions.SlurmJobConditionFailed
+		condition.Reason = FailedReason
+		eventType = corev1.EventTypeWarning
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, condition)
+	r.eventRecorder.Eventf(slurmJob, nil, eventType, condition.Reason, "Observe", msg)
+
+	return nil
+}
+
+// getScript returns the batch script of the SlurmJob.
+func (r *SlurmJobReconciler) getScript(ctx context.Context, slurmJob *slinkyv1beta1.SlurmJob) (string, error) {
+	script := slurmJob.Spec.Script
+	if script.ConfigMapKeyRef != nil {
+		return r.refResolver.GetConfigMapKeyRef(ctx, *script.ConfigMapKeyRef, slurmJob.Namespace)
+	}
+	return ptr.Deref(script.Inline, ""), nil
+}
+
+// getAuthToken returns a JWT for the user of the SlurmJob. The job is never
+// submitted with the token of the Slurm client.
+func (r *SlurmJobReconciler) getAuthToken(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	slurmJob *slinkyv1beta1.SlurmJob,
+) (string, error) {
+	if slurmJob.Spec.Username == "" {
+		return "", errors.New("failed to create Slurm auth token: username is required")
+	}
+
+	signingKey, err := r.refResolver.GetSecretKeyRef(ctx, controller.AuthJwtRef(), controller.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	authToken, err := slurmjwt.NewToken(signingKey).
+		WithUsername(slurmJob.Spec.Username).
+		WithLifetime(authTokenLifetime).
+		NewSignedToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to create Slurm auth token: %w", err)
+	}
+
+	return authToken, nil
+}
+
+// syncTTL deletes the finished SlurmJob once its TTL expired.
+func (r *SlurmJobReconciler) syncTTL(ctx context.Context, slurmJob *slinkyv1beta1.SlurmJob) error {
+	logger := log.FromContext(ctx)
+
+	ttl, ok := slurmJob.TTLAfterFinished()
+	if !ok || slurmJob.Status.CompletionTime == nil {
+		return nil
+	}
+
+	remaining := time.Until(slurmJob.Status.CompletionTime.Add(ttl))
+	if remaining > 0 {
+		durationStore.Push(objectutils.KeyFunc(slurmJob), remaining)
+		return nil
+	}
+
+	logger.Info("Deleting SlurmJob, its TTL after finished has expired")
+	if err := r.Delete(ctx, slurmJob); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// syncFinalizer cancels the unfinished Slurm job, then removes the finalizer.
+func (r *SlurmJobReconciler) syncFinalizer(
+	ctx context.Context,
+	controller *slinkyv1beta1.Controller,
+	slurmJob *slinkyv1beta1.SlurmJob,
+) error {
+	if !controllerutil.ContainsFinalizer(slurmJob, slinkyv1beta1.FinalizerSlurmJob) {
+		return nil
+	}
+
+	// If the controller does not exist, the Slurm job cannot be cancelled and
+	// the finalizer must be removed to permit cleanup.
+	if controller != nil && slurmJob.Status.JobID != nil && !isFinished(slurmJob) {
+		if err := r.slurmControl.CancelJob(ctx, controller, *slurmJob.Status.JobID); err != nil {
+			return err
+		}
+	}
+
+	finalizers := set.New(slurmJob.Finalizers...)
+	finalizers.Delete(slinkyv1beta1.FinalizerSlurmJob)
+	return r.updateFinalizers(ctx, slurmJob, finalizers.SortedList())
+}
+
+func (r *SlurmJobReconciler) addFinalizerIfNeeded(ctx context.Context, slurmJob *slinkyv1beta1.SlurmJob) error {
+	if controllerutil.ContainsFinalizer(slurmJob, slinkyv1beta1.FinalizerSlurmJob) {
+		return nil
+	}
+
+	finalizers := slices.Concat(slurmJob.Finalizers, []string{slinkyv1beta1.FinalizerSlurmJob})
+	return r.updateFinalizers(ctx, slurmJob, finalizers)
+}
+
+func (r *SlurmJobReconciler) updateFinalizers(ctx context.Context, slurmJob *slinkyv1beta1.SlurmJob, newFinalizers []string) error {
+	logger := log.FromContext(ctx)
+
+	logger.V(1).Info("Pending SlurmJob Finalizer update", "newFinalizers", newFinalizers)
+
+	mutateFn := func(slurmJob *slinkyv1beta1.SlurmJob) error {
+		slurmJob.Finalizers = newFinalizers
+		return nil
+	}
+
+	if err := objectutils.PatchObject(r.Client, ctx, slurmJob, mutateFn); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isFinished returns true if the Slurm job of the SlurmJob will not run anymore.
+func isFinished(slurmJob *slinkyv1beta1.SlurmJob) bool {
+	return isStatusFinished(&slurmJob.Status)
+}
+
+func isStatusFinished(status *slinkyv1beta1.SlurmJobStatus) bool {
+	return meta.IsStatusConditionTrue(status.Conditions, slurmconditions.SlurmJobConditionComplete) ||
+		meta.IsStatusConditionTrue(status.Conditions, slurmconditions.SlurmJobConditionFailed)
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjob
+
+import (
+	"context"
+	"fmt"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+const (
+	SubmittedReason     = "Submitted"
+	SubmitFailedReason  = "SubmitFailed"
+	ObserveFailedReason = "ObserveFailed"
+	CompletedReason     = "Completed"
+	FailedReason        = "Failed"
+	NotFoundReason      = "NotFound"
+)
+
+// syncStatus handles determining and updating the status.
+func (r *SlurmJobReconciler) syncStatus(
+	ctx context.Context,
+	slurmJob *slinkyv1beta1.SlurmJob,
+	newStatus *slinkyv1beta1.SlurmJobStatus,
+	errors ...error,
+) error {
+	syncErr := utilerrors.NewAggregate(errors)
+	if err := r.syncSlurmJobStatus(ctx, slurmJob, newStatus, syncErr); err != nil {
+		errors = append(errors, err)
+	}
+
+	return utilerrors.NewAggregate(errors)
+}
+
+func (r *SlurmJobReconciler) syncSlurmJobStatus(
+	ctx context.Context,
+	slurmJob *slinkyv1beta1.SlurmJob,
+	newStatus *slinkyv1beta1.SlurmJobStatus,
+	syncErr error,
+) error {
+	logger := log.FromContext(ctx)
+
+	newStatus = newStatus.DeepCopy()
+	if newStatus.Conditions == nil {
+		newStatus.Conditions = []metav1.Condition{}
+	}
+	meta.SetStatusCondition(&newStatus.Conditions, newSubmittedCondition(slurmJob, newStatus, syncErr))
+
+	if apiequality.Semantic.DeepEqual(slurmJob.Status, *newStatus) {
+		logger.V(2).Info("SlurmJob Status has not changed, skipping status update",
+			"slurmJob", klog.KObj(slurmJob), "status", slurmJob.Status)
+		return nil
+	}
+
+	if err := r.updateStatus(ctx, slurmJob, newStatus); err != nil {
+		return fmt.Errorf("error updating SlurmJob(%s) status: %w",
+			klog.KObj(slurmJob), err)
+	}
+
+	return nil
+}
+
+// newSubmittedCondition returns the Submitted condition from the result of the
+// sync. Once submitted, a failure to observe the Slurm job is reported on it.
+func newSubmittedCondition(
+	slurmJob *slinkyv1beta1.SlurmJob,
+	newStatus *slinkyv1beta1.SlurmJobStatus,
+	syncErr error,
+) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               slurmconditions.SlurmJobConditionSubmitted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: slurmJob.Generation,
+		Reason:             SubmittedReason,
+	}
+	if newStatus.JobID != nil {
+		condition.Message = fmt.Sprintf("Submitted Slurm job %d", *newStatus.JobID)
+		if syncErr != nil {
+			condition.Reason = ObserveFailedReason
+			condition.Message = syncErr.Error()
+		}
+		return condition
+	}
+	if syncErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = SubmitFailedReason
+		condition.Message = syncErr.Error()
+	}
+	return condition
+}
+
+func (r *SlurmJobReconciler) updateStatus(
+	ctx context.Context,
+	slurmJob *slinkyv1beta1.SlurmJob,
+	newStatus *slinkyv1beta1.SlurmJobStatus,
+) error {
+	logger := log.FromContext(ctx)
+
+	namespacedName := types.NamespacedName{
+		Namespace: slurmJob.GetNamespace(),
+		Name:      slurmJob.GetName(),
+	}
+
+	logger.V(1).Info("Pending SlurmJob Status update",
+		"slurmJob", klog.KObj(slurmJob), "newStatus", newStatus)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		toUpdate := &slinkyv1beta1.SlurmJob{}
+		if err := r.Get(ctx, namespacedName, toUpdate); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		toUpdate.Status = *newStatus
+		return r.Status().Update(ctx, toUpdate)
+	})
+}
@@ -0,0 +1,351 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjob
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmjob/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/refresolver"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmjobutils"
+	slurmconditions "github.com/SlinkyProject/slurm-operator/pkg/conditions"
+)
+
+func init() {
+	utilruntime.Must(slinkyv1beta1.AddToScheme(clientgoscheme.Scheme))
+}
+
+type fakeSlurmControl struct {
+	err error
+	job *slurmjobutils.JobInfo
+
+	script    string
+	authToken string
+	submitted bool
+	cancelled bool
+}
+
+func (f *fakeSlurmControl) SubmitJob(_ context.Context, _ *slinkyv1beta1.Controller, _ *slinkyv1beta1.SlurmJob, script, authToken string) (int32, error) {
+	f.submitted = true
+	f.script = script
+	f.authToken = authToken
+	return 42, f.err
+}
+
+func (f *fakeSlurmControl) GetJob(context.Context, *slinkyv1beta1.Controller, int32) (*slurmjobutils.JobInfo, error) {
+	return f.job, f.err
+}
+
+func (f *fakeSlurmControl) CancelJob(context.Context, *slinkyv1beta1.Controller, int32) error {
+	f.cancelled = true
+	return f.err
+}
+
+var _ slurmcontrol.SlurmControlInterface = &fakeSlurmControl{}
+
+func newSlurmJob(jobID *int32) *slinkyv1beta1.SlurmJob {
+	return &slinkyv1beta1.SlurmJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "hello",
+		},
+		Spec: slinkyv1beta1.SlurmJobSpec{
+			ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+			Script: slinkyv1beta1.SlurmJobScript{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "scripts"},
+					Key:                  "hello.sh",
+				},
+			},
+		},
+		Status: slinkyv1beta1.SlurmJobStatus{
+			JobID: jobID,
+		},
+	}
+}
+
+func newObjects() []client.Object {
+	controller := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			JwtKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "jwt"},
+				Key:                  "jwt.key",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "jwt",
+		},
+		Data: map[string][]byte{
+			"jwt.key": []byte("signing-key"),
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "scripts",
+		},
+		Data: map[string]string{
+			"hello.sh": "#!/bin/sh\nhostname",
+		},
+	}
+	return []client.Object{controller, secret, configMap}
+}
+
+func newReconciler(c client.Client, slurmControl slurmcontrol.SlurmControlInterface) *SlurmJobReconciler {
+	return &SlurmJobReconciler{
+		Client:        c,
+		refResolver:   refresolver.New(c),
+		eventRecorder: events.NewFakeRecorder(10),
+		slurmControl:  slurmControl,
+	}
+}
+
+func TestSlurmJobReconciler_Sync_Submit(t *testing.T) {
+	tests := []struct {
+		name          string
+		username      string
+		err           error
+		wantSubmitted bool
+		wantJobID     *int32
+		wantCondition metav1.ConditionStatus
+	}{
+		{
+			name:          "Submit as user",
+			username:      "alice",
+			wantSubmitted: true,
+			wantJobID:     ptr.To[int32](42),
+			wantCondition: metav1.ConditionTrue,
+		},
+		{
+			name:          "No username",
+			wantCondition: metav1.ConditionFalse,
+		},
+		{
+			name:          "Submit error",
+			username:      "alice",
+			err:           errors.New("invalid partition"),
+			wantSubmitted: true,
+			wantCondition: metav1.ConditionFalse,
+		},
+		{
+			name:          "No Slurm client",
+			username:      "alice",
+			err:           slurmcontrol.ErrNoSlurmClient,
+			wantSubmitted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slurmJob := newSlurmJob(nil)
+			slurmJob.Spec.Username = tt.username
+			c := fake.NewClientBuilder().
+				WithObjects(newObjects()...).
+				WithObjects(slurmJob).
+				WithStatusSubresource(&slinkyv1beta1.SlurmJob{}).
+				Build()
+			slurmControl := &fakeSlurmControl{err: tt.err}
+			r := newReconciler(c, slurmControl)
+
+			req := client.ObjectKeyFromObject(slurmJob)
+			err := r.Sync(context.TODO(), reconcile.Request{NamespacedName: req})
+			require.Equal(t, tt.wantCondition == metav1.ConditionFalse, err != nil)
+			require.Equal(t, tt.wantSubmitted, slurmControl.submitted)
+			if tt.wantSubmitted {
+				require.Equal(t, "#!/bin/sh\nhostname", slurmControl.script)
+				require.NotEmpty(t, slurmControl.authToken)
+			}
+
+			got := &slinkyv1beta1.SlurmJob{}
+			require.NoError(t, c.Get(context.TODO(), req, got))
+			require.Contains(t, got.Finalizers, slinkyv1beta1.FinalizerSlurmJob)
+			require.Equal(t, tt.wantJobID, got.Status.JobID)
+			condition := meta.FindStatusCondition(got.Status.Conditions, slurmconditions.SlurmJobConditionSubmitted)
+			if tt.wantCondition == "" {
+				require.Nil(t, condition)
+				return
+			}
+			require.NotNil(t, condition)
+			require.Equal(t, tt.wantCondition, condition.Status)
+		})
+	}
+}
+
+func TestSlurmJobReconciler_Sync_Observe(t *testing.T) {
+	returnCode := func(code int64) *slurmjobutils.ExitCode {
+		return &slurmjobutils.ExitCode{
+			ReturnCode: &slurmjobutils.NoVal{Set: ptr.To(true), Number: ptr.To(code)},
+		}
+	}
+	tests := []struct {
+		name          string
+		job           *slurmjobutils.JobInfo
+		wantState     string
+		wantExitCode  *int32
+		wantCondition string
+	}{
+		{
+			name:      "Running",
+			job:       &slurmjobutils.JobInfo{JobState: []string{"RUNNING"}, Nodes: "slinky-0"},
+			wantState: "RUNNING",
+		},
+		{
+			name:          "Completed",
+			job:           &slurmjobutils.JobInfo{JobState: []string{"COMPLETED"}, ExitCode: returnCode(0)},
+			wantState:     "COMPLETED",
+			wantExitCode:  ptr.To[int32](0),
+			wantCondition: slurmconditions.SlurmJobConditionComplete,
+		},
+		{
+			name:          "Failed",
+			job:           &slurmjobutils.JobInfo{JobState: []string{"FAILED"}, ExitCode: returnCode(1)},
+			wantState:     "FAILED",
+			wantExitCode:  ptr.To[int32](1),
+			wantCondition: slurmconditions.SlurmJobConditionFailed,
+		},
+		{
+			name:          "Not found",
+			wantCondition: slurmconditions.SlurmJobConditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slurmJob := newSlurmJob(ptr.To[int32](42))
+			slurmJob.Finalizers = []string{slinkyv1beta1.FinalizerSlurmJob}
+			c := fake.NewClientBuilder().
+				WithObjects(newObjects()...).
+				WithObjects(slurmJob).
+				WithStatusSubresource(&slinkyv1beta1.SlurmJob{}).
+				Build()
+			slurmControl := &fakeSlurmControl{job: tt.job}
+			r := newReconciler(c, slurmControl)
+
+			req := client.ObjectKeyFromObject(slurmJob)
+			require.NoError(t, r.Sync(context.TODO(), reconcile.Request{NamespacedName: req}))
+			require.False(t, slurmControl.submitted)
+
+			got := &slinkyv1beta1.SlurmJob{}
+			require.NoError(t, c.Get(context.TODO(), req, got))
+			require.Equal(t, tt.wantState, got.Status.State)
+			require.Equal(t, tt.wantExitCode, got.Status.ExitCode)
+			require.Equal(t, tt.wantCondition != "", got.Status.CompletionTime != nil)
+			if tt.wantCondition != "" {
+				require.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, tt.wantCondition))
+			}
+		})
+	}
+}
+
+func TestSlurmJobReconciler_syncTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         *int32
+		finishedAgo time.Duration
+		wantDeleted bool
+	}{
+		{
+			name:        "No TTL",
+			finishedAgo: time.Hour,
+		},
+		{
+			name:        "TTL not expired",
+			ttl:         ptr.To[int32](3600),
+			finishedAgo: time.Minute,
+		},
+		{
+			name:        "TTL expired",
+			ttl:         ptr.To[int32](60),
+			finishedAgo: time.Hour,
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slurmJob := newSlurmJob(ptr.To[int32](42))
+			slurmJob.Finalizers = []string{slinkyv1beta1.FinalizerSlurmJob}
+			slurmJob.Spec.TTLSecondsAfterFinished = tt.ttl
+			slurmJob.Status.CompletionTime = ptr.To(metav1.NewTime(time.Now().Add(-tt.finishedAgo)))
+			slurmJob.Status.Conditions = []metav1.Condition{{
+				Type:               slurmconditions.SlurmJobConditionComplete,
+				Status:             metav1.ConditionTrue,
+				Reason:             CompletedReason,
+				LastTransitionTime: metav1.Now(),
+			}}
+			c := fake.NewClientBuilder().
+				WithObjects(newObjects()...).
+				WithObjects(slurmJob).
+				WithStatusSubresource(&slinkyv1beta1.SlurmJob{}).
+				Build()
+			slurmControl := &fakeSlurmControl{}
+			r := newReconciler(c, slurmControl)
+
+			req := client.ObjectKeyFromObject(slurmJob)
+			require.NoError(t, r.Sync(context.TODO(), reconcile.Request{NamespacedName: req}))
+			require.NoError(t, r.Sync(context.TODO(), reconcile.Request{NamespacedName: req}))
+			require.False(t, slurmControl.cancelled)
+			err := c.Get(context.TODO(), req, &slinkyv1beta1.SlurmJob{})
+			require.Equal(t, tt.wantDeleted, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestSlurmJobReconciler_syncFinalizer(t *testing.T) {
+	tests := []struct {
+		name          string
+		jobID         *int32
+		wantCancelled bool
+	}{
+		{
+			name:          "Cancel Slurm job",
+			jobID:         ptr.To[int32](42),
+			wantCancelled: true,
+		},
+		{
+			name: "Not submitted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slurmJob := newSlurmJob(tt.jobID)
+			slurmJob.Finalizers = []string{slinkyv1beta1.FinalizerSlurmJob}
+			c := fake.NewClientBuilder().
+				WithObjects(newObjects()...).
+				WithObjects(slurmJob).
+				WithStatusSubresource(&slinkyv1beta1.SlurmJob{}).
+				Build()
+			slurmControl := &fakeSlurmControl{}
+			r := newReconciler(c, slurmControl)
+
+			req := client.ObjectKeyFromObject(slurmJob)
+			require.NoError(t, c.Delete(context.TODO(), slurmJob))
+			require.NoError(t, r.Sync(context.TODO(), reconcile.Request{NamespacedName: req}))
+			require.Equal(t, tt.wantCancelled, slurmControl.cancelled)
+			err := c.Get(context.TODO(), req, &slinkyv1beta1.SlurmJob{})
+			require.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
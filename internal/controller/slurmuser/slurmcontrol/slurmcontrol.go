@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmclient "github.com/SlinkyProject/slurm-client/pkg/client"
+	slurmerrors "github.com/SlinkyProject/slurm-client/pkg/errors"
+	slurmobject "github.com/SlinkyProject/slurm-client/pkg/object"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/clientmap"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
+	slurmdbcontrol "github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb/slurmcontrol"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+)
+
+var ErrNoSlurmClient = slurmdb.ErrNoSlurmClient
+
+type SlurmControlInterface interface {
+	// SyncSlurmUser creates or updates the Slurm user, and its associations in
+	// the Slurm cluster of each controller. Other associations of the user in
+	// those clusters are deleted.
+	SyncSlurmUser(ctx context.Context, controllers []slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error
+	// DeleteSlurmUser deletes the Slurm user, if it exists.
+	DeleteSlurmUser(ctx context.Context, controllers []slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error
+}
+
+// realSlurmControl is the default implementation of SlurmControlInterface.
+type realSlurmControl struct {
+	clientMap *clientmap.ClientMap
+}
+
+// SyncSlurmUser implements SlurmControlInterface.
+func (r *realSlurmControl) SyncSlurmUser(ctx context.Context, controllers []slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := slurmdbcontrol.LookupClient(r.clientMap, controllers)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controllers, cannot do SyncSlurmUser()")
+		return ErrNoSlurmClient
+	}
+
+	if err := syncUser(ctx, slurmClient, slurmdbutils.NewUser(user)); err != nil {
+		return err
+	}
+
+	assocs, err := slurmdbcontrol.ListAssocs(ctx, slurmClient)
+	if err != nil {
+		return err
+	}
+
+	newAssocs := []slurmdbutils.Assoc{}
+	for _, controller := range controllers {
+		newAssocs = append(newAssocs, slurmdbutils.NewUserAssocs(user, controller.ClusterName())...)
+	}
+	for _, newAssoc := range newAssocs {
+		if err := slurmdbcontrol.SyncAssoc(ctx, slurmClient, assocs, newAssoc); err != nil {
+			return err
+		}
+	}
+
+	return deleteExtraAssocs(ctx, slurmClient, assocs, newAssocs)
+}
+
+// DeleteSlurmUser implements SlurmControlInterface.
+func (r *realSlurmControl) DeleteSlurmUser(ctx context.Context, controllers []slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser) error {
+	logger := log.FromContext(ctx)
+
+	slurmClient := slurmdbcontrol.LookupClient(r.clientMap, controllers)
+	if slurmClient == nil {
+		logger.V(2).Info("no client for controllers, cannot do DeleteSlurmUser()")
+		return ErrNoSlurmClient
+	}
+
+	name := user.UserName()
+	userInfo := &slurmtypes.V0044User{}
+	if err := slurmClient.Get(ctx, slurmobject.ObjectKey(name), userInfo); err != nil {
+		if errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := slurmClient.Delete(ctx, userInfo); err != nil && !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+		return fmt.Errorf("DeleteSlurmUser() failed to Delete UserName=%s with error=%w", name, err)
+	}
+
+	return nil
+}
+
+// syncUser creates the Slurm user, or updates it when it drifted.
+func syncUser(ctx context.Context, slurmClient slurmclient.Client, newUser slurmdbutils.User) error {
+	req := slurmapi.V0044User{}
+	if err := slurmdbutils.Convert(newUser, &req); err != nil {
+		return err
+	}
+
+	userInfo := &slurmtypes.V0044User{}
+	if err := slurmClient.Get(ctx, slurmobject.ObjectKey(newUser.Name), userInfo); err != nil {
+		if !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return err
+		}
+		userInfo = &slurmtypes.V0044User{V0044User: req}
+		if err := slurmClient.Create(ctx, userInfo, req); err != nil {
+			return fmt.Errorf("SyncSlurmUser() failed to Create UserName=%s with error=%w", newUser.Name, err)
+		}
+		return nil
+	}
+
+	oldUser := slurmdbutils.User{}
+	if err := slurmdbutils.Convert(userInfo, &oldUser); err != nil {
+		return err
+	}
+	if slurmdbutils.IsUserMatch(oldUser, newUser) {
+		return nil
+	}
+
+	if err := slurmClient.Update(ctx, userInfo, req); err != nil {
+		return fmt.Errorf("SyncSlurmUser() failed to Update UserName=%s with error=%w", newUser.Name, err)
+	}
+
+	return nil
+}
+
+// deleteExtraAssocs deletes the associations of the user, in the clusters of
+// the desired associations, which are not desired.
+func deleteExtraAssocs(ctx context.Context, slurmClient slurmclient.Client, assocs []slurmtypes.V0044Assoc, newAssocs []slurmdbutils.Assoc) error {
+	if len(newAssocs) == 0 {
+		return nil
+	}
+	user := newAssocs[0].User
+	clusters := set.New[string]()
+	for _, newAssoc := range newAssocs {
+		clusters.Insert(newAssoc.Cluster)
+	}
+
+	for _, assocInfo := range assocs {
+		oldAssoc := slurmdbutils.Assoc{}
+		if err := slurmdbutils.Convert(assocInfo, &oldAssoc); err != nil {
+			return err
+		}
+		if oldAssoc.User != user || !clusters.Has(oldAssoc.Cluster) {
+			continue
+		}
+		isDesired := false
+		for _, newAssoc := range newAssocs {
+			if slurmdbutils.IsAssocKeyMatch(oldAssoc, newAssoc) {
+				isDesired = true
+				break
+			}
+		}
+		if isDesired {
+			continue
+		}
+		if err := slurmClient.Delete(ctx, &assocInfo); err != nil && !errors.Is(err, slurmerrors.ErrObjectNotFound) {
+			return fmt.Errorf("SyncSlurmUser() failed to Delete Association(User=%s,Account=%s,Cluster=%s) with error=%w",
+				oldAssoc.User, oldAssoc.Account, oldAssoc.Cluster, err)
+		}
+	}
+
+	return nil
+}
+
+var _ SlurmControlInterface = &realSlurmControl{}
+
+func NewSlurmControl(clientMap *clientmap.ClientMap) SlurmControlInterface {
+	return &realSlurmControl{
+		clientMap: clientMap,
+	}
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmcontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SlinkyProject/slurm-client/pkg/client"
+	"github.com/SlinkyProject/slurm-client/pkg/client/fake"
+	"github.com/SlinkyProject/slurm-client/pkg/client/interceptor"
+	"github.com/SlinkyProject/slurm-client/pkg/object"
+	"github.com/SlinkyProject/slurm-client/pkg/types"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
+)
+
+func newController() slinkyv1beta1.Controller {
+	return slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "slurm",
+		},
+	}
+}
+
+func newSlurmUser(adminLevel slinkyv1beta1.SlurmUserAdminLevel) *slinkyv1beta1.SlurmUser {
+	return &slinkyv1beta1.SlurmUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: corev1.NamespaceDefault,
+			Name:      "alice",
+		},
+		Spec: slinkyv1beta1.SlurmUserSpec{
+			DefaultAccount: "physics",
+			AdminLevel:     adminLevel,
+		},
+	}
+}
+
+func newUserInfo(user *slinkyv1beta1.SlurmUser) *types.V0044User {
+	out := &types.V0044User{}
+	_ = slurmdbutils.Convert(slurmdbutils.NewUser(user), out)
+	return out
+}
+
+func newAssocInfo(assoc slurmdbutils.Assoc) types.V0044Assoc {
+	out := types.V0044Assoc{}
+	_ = slurmdbutils.Convert(assoc, &out)
+	return out
+}
+
+func Test_realSlurmControl_SyncSlurmUser(t *testing.T) {
+	controller := newController()
+	cluster := controller.ClusterName()
+	user := newSlurmUser(slinkyv1beta1.SlurmUserAdminLevelNone)
+	tests := []struct {
+		name       string
+		existing   *types.V0044User
+		assocs     []types.V0044Assoc
+		wantCreate int
+		wantUpdate int
+		wantDelete int
+	}{
+		{
+			name:       "Create",
+			wantCreate: 2,
+		},
+		{
+			name:     "Unchanged",
+			existing: newUserInfo(user),
+			assocs: []types.V0044Assoc{
+				newAssocInfo(slurmdbutils.Assoc{Account: "physics", Cluster: cluster, User: "alice"}),
+			},
+		},
+		{
+			name:     "Admin level drift",
+			existing: newUserInfo(newSlurmUser(slinkyv1beta1.SlurmUserAdminLevelAdministrator)),
+			assocs: []types.V0044Assoc{
+				newAssocInfo(slurmdbutils.Assoc{Account: "physics", Cluster: cluster, User: "alice"}),
+			},
+			wantUpdate: 1,
+		},
+		{
+			name:     "Extra associations",
+			existing: newUserInfo(user),
+			assocs: []types.V0044Assoc{
+				newAssocInfo(slurmdbutils.Assoc{Account: "physics", Cluster: cluster, User: "alice"}),
+				newAssocInfo(slurmdbutils.Assoc{Account: "chemistry", Cluster: cluster, User: "alice"}),
+				newAssocInfo(slurmdbutils.Assoc{Account: "chemistry", Cluster: "other", User: "alice"}),
+				newAssocInfo(slurmdbutils.Assoc{Account: "chemistry", Cluster: cluster, User: "bob"}),
+			},
+			wantDelete: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, updated, deleted int
+			builder := fake.NewClientBuilder().
+				WithLists(&types.V0044AssocList{Items: tt.assocs}).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(context.Context, object.Object, any, ...client.CreateOption) error {
+						created++
+						return nil
+					},
+					Update: func(context.Context, object.Object, any, ...client.UpdateOption) error {
+						updated++
+						return nil
+					},
+					Delete: func(context.Context, object.Object, ...client.DeleteOption) error {
+						deleted++
+						return nil
+					},
+				})
+			if tt.existing != nil {
+				builder = builder.WithObjects(tt.existing)
+			}
+			r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, builder.Build()))
+			err := r.SyncSlurmUser(context.TODO(), []slinkyv1beta1.Controller{controller}, user)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCreate, created)
+			require.Equal(t, tt.wantUpdate, updated)
+			require.Equal(t, tt.wantDelete, deleted)
+		})
+	}
+}
+
+func Test_realSlurmControl_DeleteSlurmUser(t *testing.T) {
+	controller := newController()
+	user := newSlurmUser(slinkyv1beta1.SlurmUserAdminLevelNone)
+	var deleted bool
+	sclient := fake.NewClientBuilder().
+		WithObjects(newUserInfo(user)).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(context.Context, object.Object, ...client.DeleteOption) error {
+				deleted = true
+				return nil
+			},
+		}).
+		Build()
+	r := NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, sclient))
+	require.NoError(t, r.DeleteSlurmUser(context.TODO(), []slinkyv1beta1.Controller{controller}, user))
+	require.True(t, deleted)
+
+	// Deleting an absent user is a no-op.
+	r = NewSlurmControl(testutils.NewClientMap(controller.Name, controller.Namespace, fake.NewFakeClient()))
+	require.NoError(t, r.DeleteSlurmUser(context.TODO(), []slinkyv1beta1.Controller{controller}, user))
+}
+
+func Test_realSlurmControl_NoClient(t *testing.T) {
+	controllers := []slinkyv1beta1.Controller{newController()}
+	user := newSlurmUser(slinkyv1beta1.SlurmUserAdminLevelNone)
+	r := NewSlurmControl(testutils.NewClientMap("other", corev1.NamespaceDefault, fake.NewFakeClient()))
+	err := r.SyncSlurmUser(context.TODO(), controllers, user)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+	err = r.DeleteSlurmUser(context.TODO(), controllers, user)
+	require.ErrorIs(t, err, ErrNoSlurmClient)
+}
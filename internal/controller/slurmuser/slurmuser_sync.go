@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmuser
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
+)
+
+// Sync implements control logic for synchronizing a SlurmUser.
+func (r *SlurmUserReconciler) Sync(ctx context.Context, req reconcile.Request) error {
+	return r.syncer().Sync(ctx, req)
+}
+
+// syncer returns the Syncer of the SlurmUser into the Slurm database.
+func (r *SlurmUserReconciler) syncer() *slurmdb.Syncer[*slinkyv1beta1.SlurmUser] {
+	return &slurmdb.Syncer[*slinkyv1beta1.SlurmUser]{
+		Client:        r.Client,
+		RefResolver:   r.refResolver,
+		DurationStore: durationStore,
+
+		Kind:      "SlurmUser",
+		Finalizer: slinkyv1beta1.FinalizerSlurmUser,
+
+		NewObject: func() *slinkyv1beta1.SlurmUser {
+			return &slinkyv1beta1.SlurmUser{}
+		},
+		SlurmdbRef: func(user *slinkyv1beta1.SlurmUser) slinkyv1beta1.SlurmdbRef {
+			return user.Spec.SlurmdbRef
+		},
+		Conditions: func(user *slinkyv1beta1.SlurmUser) *[]metav1.Condition {
+			return &user.Status.Conditions
+		},
+		StatusFn: syncSlurmUserStatus,
+
+		SyncFn:   r.slurmControl.SyncSlurmUser,
+		DeleteFn: r.slurmControl.DeleteSlurmUser,
+	}
+}
@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmuser
+
+import (
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/controller/slurmdb"
+)
+
+// syncSlurmUserStatus sets the status of the SlurmUser from the result of the sync.
+func syncSlurmUserStatus(controllers []slinkyv1beta1.Controller, user *slinkyv1beta1.SlurmUser, syncErr error) {
+	// The clusters are only known to be associated once synced.
+	if syncErr == nil {
+		user.Status.Clusters = slurmdb.ClusterNames(controllers)
+	}
+}
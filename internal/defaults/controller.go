@@ -4,6 +4,9 @@
 package defaults
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
@@ -13,6 +16,9 @@ import (
 const (
 	DefaultControllerPersistenceEnabled      bool  = true
 	DefaultControllerHighAvailabilityBackups int32 = 1
+
+	DefaultControllerPowerSavingResumeTimeout  time.Duration = 5 * time.Minute
+	DefaultControllerPowerSavingSuspendTimeout time.Duration = 1 * time.Minute
 )
 
 func SetControllerDefaults(controller *slinkyv1beta1.Controller) {
@@ -30,4 +36,11 @@ func SetControllerDefaults(controller *slinkyv1beta1.Controller) {
 			s.HighAvailability.Backups = new(DefaultControllerHighAvailabilityBackups)
 		}
 	}
+
+	if s.PowerSaving.ResumeTimeout.Duration == 0 {
+		s.PowerSaving.ResumeTimeout = metav1.Duration{Duration: DefaultControllerPowerSavingResumeTimeout}
+	}
+	if s.PowerSaving.SuspendTimeout.Duration == 0 {
+		s.PowerSaving.SuspendTimeout = metav1.Duration{Duration: DefaultControllerPowerSavingSuspendTimeout}
+	}
 }
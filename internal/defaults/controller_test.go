@@ -5,8 +5,10 @@ package defaults
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
@@ -24,6 +26,8 @@ func TestSetControllerDefaults(t *testing.T) {
 		if c.Spec.HighAvailability.Enabled {
 			require.Equal(t, new(DefaultControllerHighAvailabilityBackups), c.Spec.HighAvailability.Backups)
 		}
+		require.Equal(t, DefaultControllerPowerSavingResumeTimeout, c.Spec.PowerSaving.ResumeTimeout.Duration)
+		require.Equal(t, DefaultControllerPowerSavingSuspendTimeout, c.Spec.PowerSaving.SuspendTimeout.Duration)
 	})
 
 	t.Run("explicit values are not overridden", func(t *testing.T) {
@@ -32,11 +36,13 @@ func TestSetControllerDefaults(t *testing.T) {
 		c.Spec.HighAvailability.Enabled = true
 		const HABackups int32 = 2
 		c.Spec.HighAvailability.Backups = ptr.To(HABackups)
+		c.Spec.PowerSaving.ResumeTimeout = metav1.Duration{Duration: 10 * time.Minute}
 		SetControllerDefaults(c)
 		require.Equal(t, new(true), c.Spec.Persistence.Enabled)
 		if c.Spec.HighAvailability.Enabled {
 			require.Equal(t, new(HABackups), c.Spec.HighAvailability.Backups)
 		}
+		require.Equal(t, 10*time.Minute, c.Spec.PowerSaving.ResumeTimeout.Duration)
 		c.Spec.Persistence.Enabled = ptr.To(false)
 		SetControllerDefaults(c)
 		require.Equal(t, new(false), c.Spec.Persistence.Enabled)
@@ -4,6 +4,11 @@
 package defaults
 
 import (
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
@@ -17,11 +22,36 @@ const (
 	DefaultNodeSetScalingMode                  slinkyv1beta1.ScalingModeType                 = slinkyv1beta1.ScalingModeStatefulset
 	DefaultNodeSetUpdateStrategyType           slinkyv1beta1.NodeSetUpdateStrategyType       = slinkyv1beta1.RollingUpdateNodeSetStrategyType
 	DefaultNodeSetPruneSlurmNodeRecordType     slinkyv1beta1.NodeSetPruneSlurmNodeRecordType = slinkyv1beta1.NodeSetPruneNodeRecordTypeNever
+	DefaultNodeSetDrainEscalationAction        slinkyv1beta1.DrainEscalationActionType       = slinkyv1beta1.DrainEscalationActionWait
+	DefaultNodeSetNodeHealthAction             slinkyv1beta1.NodeHealthActionType            = slinkyv1beta1.NodeHealthActionNone
+	DefaultNodeSetDrainConditionStatus         corev1.ConditionStatus                        = corev1.ConditionTrue
+	DefaultNodeSetRemediationMaxRemediations   int32                                         = 1
+
+	DefaultNodeSetAutoscalingScaleUpStabilizationWindow   time.Duration = 30 * time.Second
+	DefaultNodeSetAutoscalingScaleDownStabilizationWindow time.Duration = 5 * time.Minute
+	DefaultNodeSetPowerSavingSuspendTime                  time.Duration = 10 * time.Minute
+	DefaultNodeSetRemediationFailedDuration               time.Duration = 10 * time.Minute
+	DefaultNodeSetRemediationWindow                       time.Duration = time.Hour
 )
 
 // Default values for NodeSet Spec fields when unspecified.
 var (
 	DefaultNodeSetRollingUpdateMaxUnavailable intstr.IntOrString = intstr.FromString("25%")
+
+	DefaultNodeSetGresResources = []slinkyv1beta1.NodeSetGresResource{
+		{ResourceName: "nvidia.com/gpu", Name: "gpu"},
+		{ResourceName: "amd.com/gpu", Name: "gpu"},
+	}
+
+	DefaultNodeSetPodInfoNodeLabels = []string{
+		corev1.LabelTopologyRegion,
+		corev1.LabelTopologyZone,
+		corev1.LabelInstanceTypeStable,
+		"cloud.google.com/gke-nodepool",
+		"eks.amazonaws.com/nodegroup",
+		"kubernetes.azure.com/agentpool",
+		"karpenter.sh/nodepool",
+	}
 )
 
 func SetNodeSetDefaults(nodeset *slinkyv1beta1.NodeSet) {
@@ -63,4 +93,61 @@ func SetNodeSetDefaults(nodeset *slinkyv1beta1.NodeSet) {
 	if s.PruneSlurmNodeRecords == "" {
 		s.PruneSlurmNodeRecords = DefaultNodeSetPruneSlurmNodeRecordType
 	}
+
+	if s.Autoscaling.Enabled {
+		if s.Autoscaling.ScaleUpStabilizationWindow.Duration == 0 {
+			s.Autoscaling.ScaleUpStabilizationWindow = metav1.Duration{Duration: DefaultNodeSetAutoscalingScaleUpStabilizationWindow}
+		}
+		if s.Autoscaling.ScaleDownStabilizationWindow.Duration == 0 {
+			s.Autoscaling.ScaleDownStabilizationWindow = metav1.Duration{Duration: DefaultNodeSetAutoscalingScaleDownStabilizationWindow}
+		}
+	}
+
+	if s.PowerSaving.Enabled {
+		if s.PowerSaving.SuspendTime.Duration == 0 {
+			s.PowerSaving.SuspendTime = metav1.Duration{Duration: DefaultNodeSetPowerSavingSuspendTime}
+		}
+	}
+
+	if s.DrainPolicy.MaxDrainDuration.Duration > 0 {
+		if s.DrainPolicy.EscalationAction == "" {
+			s.DrainPolicy.EscalationAction = DefaultNodeSetDrainEscalationAction
+		}
+	}
+
+	if s.NodeHealthPolicy.Enabled {
+		if s.NodeHealthPolicy.Action == "" {
+			s.NodeHealthPolicy.Action = DefaultNodeSetNodeHealthAction
+		}
+	}
+
+	for i := range s.ConditionDrainPolicy.Conditions {
+		condition := &s.ConditionDrainPolicy.Conditions[i]
+		if condition.Status == "" {
+			condition.Status = DefaultNodeSetDrainConditionStatus
+		}
+	}
+
+	if s.RemediationPolicy.Enabled {
+		if s.RemediationPolicy.FailedDuration.Duration == 0 {
+			s.RemediationPolicy.FailedDuration = metav1.Duration{Duration: DefaultNodeSetRemediationFailedDuration}
+		}
+		if s.RemediationPolicy.MaxRemediations == 0 {
+			s.RemediationPolicy.MaxRemediations = DefaultNodeSetRemediationMaxRemediations
+		}
+		if s.RemediationPolicy.RemediationWindow.Duration == 0 {
+			s.RemediationPolicy.RemediationWindow = metav1.Duration{Duration: DefaultNodeSetRemediationWindow}
+		}
+	}
+
+	if s.Gres.Enabled {
+		if len(s.Gres.Resources) == 0 {
+			s.Gres.Resources = slices.Clone(DefaultNodeSetGresResources)
+		}
+	}
+
+	// An explicit empty list disables the node labels.
+	if s.PodInfoNodeLabels == nil {
+		s.PodInfoNodeLabels = slices.Clone(DefaultNodeSetPodInfoNodeLabels)
+	}
 }
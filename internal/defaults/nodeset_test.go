@@ -5,8 +5,11 @@ package defaults
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
@@ -52,4 +55,120 @@ func TestSetNodeSetDefaults(t *testing.T) {
 		require.Equal(t, slinkyv1beta1.DeletePersistentVolumeClaimRetentionPolicyType, ns.Spec.PersistentVolumeClaimRetentionPolicy.WhenScaled)
 		require.Equal(t, slinkyv1beta1.NodeSetPruneNodeRecordTypeNodeNotFound, ns.Spec.PruneSlurmNodeRecords)
 	})
+
+	t.Run("autoscaling windows are defaulted when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.Autoscaling.Enabled = true
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, DefaultNodeSetAutoscalingScaleUpStabilizationWindow, ns.Spec.Autoscaling.ScaleUpStabilizationWindow.Duration)
+		require.Equal(t, DefaultNodeSetAutoscalingScaleDownStabilizationWindow, ns.Spec.Autoscaling.ScaleDownStabilizationWindow.Duration)
+	})
+
+	t.Run("autoscaling windows are not defaulted when disabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		SetNodeSetDefaults(ns)
+
+		require.Zero(t, ns.Spec.Autoscaling.ScaleUpStabilizationWindow.Duration)
+		require.Zero(t, ns.Spec.Autoscaling.ScaleDownStabilizationWindow.Duration)
+	})
+
+	t.Run("power saving suspend time is defaulted when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.PowerSaving.Enabled = true
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, DefaultNodeSetPowerSavingSuspendTime, ns.Spec.PowerSaving.SuspendTime.Duration)
+	})
+
+	t.Run("drain escalation action is defaulted when bounded", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.DrainPolicy.MaxDrainDuration = metav1.Duration{Duration: time.Hour}
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, DefaultNodeSetDrainEscalationAction, ns.Spec.DrainPolicy.EscalationAction)
+	})
+
+	t.Run("drain escalation action is not defaulted when unbounded", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		SetNodeSetDefaults(ns)
+
+		require.Empty(t, ns.Spec.DrainPolicy.EscalationAction)
+	})
+
+	t.Run("node health action is defaulted when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.NodeHealthPolicy.Enabled = true
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, DefaultNodeSetNodeHealthAction, ns.Spec.NodeHealthPolicy.Action)
+	})
+
+	t.Run("drain condition status is defaulted", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.ConditionDrainPolicy.Conditions = []slinkyv1beta1.NodeSetDrainCondition{
+			{Type: "GPUUnhealthy"},
+			{Type: "Ready", Status: corev1.ConditionFalse},
+		}
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, DefaultNodeSetDrainConditionStatus, ns.Spec.ConditionDrainPolicy.Conditions[0].Status)
+		require.Equal(t, corev1.ConditionFalse, ns.Spec.ConditionDrainPolicy.Conditions[1].Status)
+	})
+
+	t.Run("remediation policy is defaulted when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.RemediationPolicy.Enabled = true
+		ns.Spec.RemediationPolicy.MaxRemediations = 3
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, DefaultNodeSetRemediationFailedDuration, ns.Spec.RemediationPolicy.FailedDuration.Duration)
+		require.Equal(t, int32(3), ns.Spec.RemediationPolicy.MaxRemediations)
+		require.Equal(t, DefaultNodeSetRemediationWindow, ns.Spec.RemediationPolicy.RemediationWindow.Duration)
+	})
+
+	t.Run("gres resources are defaulted when enabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.Gres.Enabled = true
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, DefaultNodeSetGresResources, ns.Spec.Gres.Resources)
+	})
+
+	t.Run("gres resources are not overridden", func(t *testing.T) {
+		resources := []slinkyv1beta1.NodeSetGresResource{
+			{ResourceName: "example.com/fpga", Name: "fpga"},
+		}
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.Gres.Enabled = true
+		ns.Spec.Gres.Resources = resources
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, resources, ns.Spec.Gres.Resources)
+	})
+
+	t.Run("pod info node labels are defaulted", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, DefaultNodeSetPodInfoNodeLabels, ns.Spec.PodInfoNodeLabels)
+	})
+
+	t.Run("pod info node labels can be disabled", func(t *testing.T) {
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.PodInfoNodeLabels = []string{}
+		SetNodeSetDefaults(ns)
+
+		require.Empty(t, ns.Spec.PodInfoNodeLabels)
+		require.NotNil(t, ns.Spec.PodInfoNodeLabels)
+	})
+
+	t.Run("pod info node labels are not overridden", func(t *testing.T) {
+		nodeLabels := []string{"example.com/rack"}
+		ns := &slinkyv1beta1.NodeSet{}
+		ns.Spec.PodInfoNodeLabels = nodeLabels
+		SetNodeSetDefaults(ns)
+
+		require.Equal(t, nodeLabels, ns.Spec.PodInfoNodeLabels)
+	})
 }
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// Change is a changed key of a config file. A multi-value line is keyed by its
+// record (e.g. `NodeName=foo`), and an Include line by its path.
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+// Diff returns the changes between the old and new config files, sorted by key.
+// Keys are compared case-insensitively, and the last value of a key is used.
+func Diff(oldData, newData string) ([]Change, error) {
+	oldValues, err := indexValues(oldData)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := indexValues(newData)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []Change{}
+	for key, newValue := range newValues {
+		oldValue, ok := oldValues[key]
+		if ok && oldValue.value == newValue.value {
+			continue
+		}
+		changes = append(changes, Change{Key: newValue.key, Old: oldValue.value, New: newValue.value})
+	}
+	for key, oldValue := range oldValues {
+		if _, ok := newValues[key]; ok {
+			continue
+		}
+		changes = append(changes, Change{Key: oldValue.key, Old: oldValue.value})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return strings.ToLower(changes[i].Key) < strings.ToLower(changes[j].Key)
+	})
+
+	return changes, nil
+}
+
+type indexedValue struct {
+	key   string
+	value string
+}
+
+// indexValues returns the values of the config file, by lowercased key.
+func indexValues(data string) (map[string]indexedValue, error) {
+	lines, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]indexedValue)
+	for _, line := range lines {
+		switch {
+		case line.IsMultiValue(), line.IsInclude():
+			record := line.Params[0]
+			key := record.Key + "=" + record.Value
+			attrs := make([]string, 0, len(line.Params)-1)
+			for _, param := range line.Params[1:] {
+				attrs = append(attrs, param.Key+"="+param.Value)
+			}
+			out[strings.ToLower(key)] = indexedValue{key: key, value: strings.Join(attrs, " ")}
+		default:
+			for _, param := range line.Params {
+				out[strings.ToLower(param.Key)] = indexedValue{key: param.Key, value: param.Value}
+			}
+		}
+	}
+	return out, nil
+}
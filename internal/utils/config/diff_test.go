@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldData string
+		newData string
+		want    []Change
+		wantErr bool
+	}{
+		{
+			name:    "unchanged",
+			oldData: "ClusterName=slurm\n# comment\nNodeName=foo CPUs=4",
+			newData: "clustername=slurm\nNodeName=foo CPUs=4",
+			want:    []Change{},
+		},
+		{
+			name:    "changed, added and removed keys",
+			oldData: "SchedulerType=sched/backfill\nMinJobAge=60\nMinJobAge=120\nDebugFlags=Steps",
+			newData: "SchedulerType=sched/builtin\nminjobage=120\nMaxJobCount=1000",
+			want: []Change{
+				{Key: "DebugFlags", Old: "Steps"},
+				{Key: "MaxJobCount", New: "1000"},
+				{Key: "SchedulerType", Old: "sched/backfill", New: "sched/builtin"},
+			},
+		},
+		{
+			name:    "multi-value lines",
+			oldData: "NodeName=foo CPUs=4\nPartitionName=all Nodes=foo",
+			newData: "NodeName=foo CPUs=8\nPartitionName=all Nodes=foo\nNodeName=bar CPUs=2",
+			want: []Change{
+				{Key: "NodeName=bar", New: "CPUs=2"},
+				{Key: "NodeName=foo", Old: "CPUs=4", New: "CPUs=8"},
+			},
+		},
+		{
+			name:    "malformed",
+			oldData: "ClusterName=slurm",
+			newData: "ClusterName",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.oldData, tt.newData)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
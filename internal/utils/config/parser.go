@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// IncludeKey is the key of a line which includes another config file.
+	IncludeKey = "Include"
+)
+
+// multiValueKeys are the keys whose lines define a record, such that the other
+// params of the line are attributes of the record instead of config keys.
+//
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_NODE-CONFIGURATION
+var multiValueKeys = []string{
+	"BlockName",
+	"DownNodes",
+	"FrontendName",
+	"NodeName",
+	"NodeSet",
+	"PartitionName",
+	"SwitchName",
+}
+
+// IsMultiValueKey returns true if lines of the key define a record (e.g.
+// NodeName, PartitionName). Keys are case-insensitive.
+func IsMultiValueKey(key string) bool {
+	for _, k := range multiValueKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Param is a `Key=Value` pair of a config line.
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Is returns true if the param has the key. Keys are case-insensitive.
+func (p Param) Is(key string) bool {
+	return strings.EqualFold(p.Key, key)
+}
+
+// Line is a logical line of a Slurm config file, after its continuation lines
+// were joined and its comment was removed.
+type Line struct {
+	// Number is the line number in the file where the line starts.
+	Number int
+	// Params are the `Key=Value` pairs of the line, in order. An Include line
+	// has a single param whose value is the included path.
+	Params []Param
+}
+
+// Key returns the key of the first param of the line.
+func (l Line) Key() string {
+	if len(l.Params) == 0 {
+		return ""
+	}
+	return l.Params[0].Key
+}
+
+// IsMultiValue returns true if the line defines a record (e.g. NodeName).
+func (l Line) IsMultiValue() bool {
+	return IsMultiValueKey(l.Key())
+}
+
+// IsInclude returns true if the line includes another config file.
+func (l Line) IsInclude() bool {
+	return strings.EqualFold(l.Key(), IncludeKey)
+}
+
+// Parse parses the lines of a Slurm config file (e.g. `slurm.conf`,
+// `slurmdbd.conf`) in the `Key=Value` format. A line may have multiple
+// whitespace separated params, values may be double quoted, `#` starts a
+// comment unless escaped, and a line ending with `\` continues on the next.
+// Included files are not read, their Include lines are returned as is.
+//
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_DESCRIPTION
+func Parse(data string) ([]Line, error) {
+	lines := []Line{}
+
+	var logical strings.Builder
+	start := 0
+	flush := func() error {
+		defer logical.Reset()
+		params, err := parseLine(logical.String())
+		if err != nil {
+			return fmt.Errorf("line %d: %w", start, err)
+		}
+		if len(params) > 0 {
+			lines = append(lines, Line{Number: start, Params: params})
+		}
+		return nil
+	}
+
+	for i, physical := range strings.Split(data, "\n") {
+		if logical.Len() == 0 {
+			start = i + 1
+		}
+		text := strings.TrimRight(stripComment(physical), " \t\r")
+		if cont, ok := strings.CutSuffix(text, `\`); ok {
+			logical.WriteString(cont)
+			logical.WriteString(" ")
+			continue
+		}
+		logical.WriteString(text)
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	if logical.Len() > 0 {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	return lines, nil
+}
+
+// stripComment returns the line without its comment, unescaping `\#`.
+func stripComment(line string) string {
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line) && line[i+1] == '#':
+			b.WriteByte('#')
+			i++
+		case c == '"':
+			quoted = !quoted
+			b.WriteByte(c)
+		case c == '#' && !quoted:
+			return b.String()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// parseLine returns the params of the logical line.
+func parseLine(line string) ([]Param, error) {
+	fields, err := splitFields(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	if strings.EqualFold(fields[0], IncludeKey) {
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed %s: expected a single path", IncludeKey)
+		}
+		return []Param{{Key: IncludeKey, Value: fields[1]}}, nil
+	}
+
+	params := make([]Param, 0, len(fields))
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("malformed item %q: expected Key=Value", field)
+		}
+		params = append(params, Param{Key: key, Value: strings.ReplaceAll(val, `"`, "")})
+	}
+	return params, nil
+}
+
+// splitFields splits the line by whitespace, except within double quotes.
+func splitFields(line string) ([]string, error) {
+	fields := []string{}
+	var field strings.Builder
+	quoted := false
+	for _, c := range line {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			field.WriteRune(c)
+		case (c == ' ' || c == '\t' || c == '\r') && !quoted:
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in %q", strings.TrimSpace(line))
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Line
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			data: "",
+			want: []Line{},
+		},
+		{
+			name: "comments and blank lines",
+			data: "# comment\n\n   \n### SECTION ###\n",
+			want: []Line{},
+		},
+		{
+			name: "key value",
+			data: "ClusterName=slurm\nschedulertype=sched/backfill # trailing comment\n",
+			want: []Line{
+				{Number: 1, Params: []Param{{Key: "ClusterName", Value: "slurm"}}},
+				{Number: 2, Params: []Param{{Key: "schedulertype", Value: "sched/backfill"}}},
+			},
+		},
+		{
+			name: "multi-value line",
+			data: `NodeName=foo-[0-3] CPUs=4 Reason="in maintenance" Features=a,b`,
+			want: []Line{
+				{Number: 1, Params: []Param{
+					{Key: "NodeName", Value: "foo-[0-3]"},
+					{Key: "CPUs", Value: "4"},
+					{Key: "Reason", Value: "in maintenance"},
+					{Key: "Features", Value: "a,b"},
+				}},
+			},
+		},
+		{
+			name: "continuation lines",
+			data: "PartitionName=all \\\n  Nodes=ALL \\\n  Default=YES\nMaxNodeCount=8",
+			want: []Line{
+				{Number: 1, Params: []Param{
+					{Key: "PartitionName", Value: "all"},
+					{Key: "Nodes", Value: "ALL"},
+					{Key: "Default", Value: "YES"},
+				}},
+				{Number: 4, Params: []Param{{Key: "MaxNodeCount", Value: "8"}}},
+			},
+		},
+		{
+			name: "escaped and quoted comment",
+			data: `Reason="issue #1" Comment=a\#b`,
+			want: []Line{
+				{Number: 1, Params: []Param{
+					{Key: "Reason", Value: "issue #1"},
+					{Key: "Comment", Value: "a#b"},
+				}},
+			},
+		},
+		{
+			name: "include",
+			data: "include /etc/slurm/extra.conf",
+			want: []Line{
+				{Number: 1, Params: []Param{{Key: IncludeKey, Value: "/etc/slurm/extra.conf"}}},
+			},
+		},
+		{
+			name:    "missing value separator",
+			data:    "ClusterName=slurm\nWeight10",
+			wantErr: true,
+		},
+		{
+			name:    "empty key",
+			data:    "=foo",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated quote",
+			data:    `Reason="foo`,
+			wantErr: true,
+		},
+		{
+			name:    "include without path",
+			data:    "Include",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.data)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLine(t *testing.T) {
+	lines, err := Parse("NodeName=foo CPUs=4\nINCLUDE extra.conf\nSlurmUser=slurm")
+	require.NoError(t, err)
+	require.Len(t, lines, 3)
+
+	require.True(t, lines[0].IsMultiValue())
+	require.False(t, lines[0].IsInclude())
+	require.True(t, lines[1].IsInclude())
+	require.False(t, lines[2].IsMultiValue())
+	require.True(t, lines[2].Params[0].Is("slurmuser"))
+	require.Equal(t, "", Line{}.Key())
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package cronutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// maxSearchDays bounds how far ahead the Nth weekday of a month is searched.
+const maxSearchDays = 5 * 366
+
+// Parse parses a standard cron schedule (minute, hour, day of month, month,
+// day of week), which is evaluated in UTC unless prefixed with `CRON_TZ=`.
+// The day of week may have a `#N` suffix (e.g. `SUN#1`) to only match the
+// Nth such weekday of the month.
+func Parse(spec string) (cron.Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if !strings.HasPrefix(spec, "CRON_TZ=") && !strings.HasPrefix(spec, "TZ=") {
+		spec = "CRON_TZ=UTC " + spec
+	}
+
+	fields := strings.Fields(spec)
+	dowField := fields[len(fields)-1]
+	dow, nth, found := strings.Cut(dowField, "#")
+	if !found {
+		return cron.ParseStandard(spec)
+	}
+
+	n, err := strconv.Atoi(nth)
+	if err != nil || n < 1 || n > 5 {
+		return nil, fmt.Errorf("invalid day of week %q: #N must be within [1, 5]", dowField)
+	}
+	if strings.ContainsAny(dow, ",-/*?") {
+		return nil, fmt.Errorf("invalid day of week %q: #N requires a single day of week", dowField)
+	}
+	fields[len(fields)-1] = dow
+	schedule, err := cron.ParseStandard(strings.Join(fields, " "))
+	if err != nil {
+		return nil, err
+	}
+	return &nthWeekdaySchedule{Schedule: schedule, nth: n}, nil
+}
+
+// nthWeekdaySchedule only activates on the Nth weekday of the month.
+type nthWeekdaySchedule struct {
+	cron.Schedule
+	nth int
+}
+
+// Next returns the next activation time, later than the given time.
+func (s *nthWeekdaySchedule) Next(t time.Time) time.Time {
+	for range maxSearchDays {
+		t = s.Schedule.Next(t)
+		if t.IsZero() {
+			return t
+		}
+		if (t.Day()-1)/7+1 == s.nth {
+			return t
+		}
+		// Skip the remainder of the day.
+		t = time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+	}
+	return time.Time{}
+}
+
+// GetWindow returns the window of the schedule, lasting for duration, which is
+// active at now, or else the next one. The zero start time is returned if the
+// schedule has no window.
+func GetWindow(schedule cron.Schedule, duration time.Duration, now time.Time) (start, end time.Time) {
+	start = schedule.Next(now.Add(-duration))
+	if start.IsZero() {
+		return time.Time{}, time.Time{}
+	}
+	return start, start.Add(duration)
+}
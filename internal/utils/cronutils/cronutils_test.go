@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package cronutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		from    time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "Daily",
+			spec: "0 2 * * *",
+			from: time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2026, time.March, 11, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Time zone",
+			spec: "CRON_TZ=America/New_York 0 2 * * *",
+			from: time.Date(2026, time.January, 10, 12, 0, 0, 0, time.UTC),
+			want: time.Date(2026, time.January, 11, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "First Sunday",
+			spec: "0 2 * * SUN#1",
+			from: time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, time.April, 5, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Third Wednesday",
+			spec: "30 4 * * 3#3",
+			from: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, time.March, 18, 4, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "Invalid",
+			spec:    "0 2 * *",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid nth weekday",
+			spec:    "0 2 * * SUN#6",
+			wantErr: true,
+		},
+		{
+			name:    "Nth weekday of a range",
+			spec:    "0 2 * * MON-FRI#1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(schedule.Next(tt.from)), "got %v, want %v", schedule.Next(tt.from), tt.want)
+		})
+	}
+}
+
+func TestGetWindow(t *testing.T) {
+	schedule, err := Parse("0 2 * * SUN#1")
+	require.NoError(t, err)
+	duration := 6 * time.Hour
+	april := time.Date(2026, time.April, 5, 2, 0, 0, 0, time.UTC)
+	may := time.Date(2026, time.May, 3, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantStart time.Time
+	}{
+		{
+			name:      "Before the window",
+			now:       time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC),
+			wantStart: april,
+		},
+		{
+			name:      "Window start",
+			now:       april,
+			wantStart: april,
+		},
+		{
+			name:      "Within the window",
+			now:       april.Add(5 * time.Hour),
+			wantStart: april,
+		},
+		{
+			name:      "Window end",
+			now:       april.Add(duration),
+			wantStart: may,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := GetWindow(schedule, duration, tt.now)
+			require.True(t, tt.wantStart.Equal(start), "got %v, want %v", start, tt.wantStart)
+			require.True(t, tt.wantStart.Add(duration).Equal(end))
+		})
+	}
+}
@@ -16,6 +16,12 @@ type PodInfo struct {
 	Node        string `json:"node"`
 	NodeSetName string `json:"nodeSetName,omitempty"`
 	NodeSetUID  string `json:"nodeSetUID,omitempty"`
+	// NodeLabels are selected labels of the Kubernetes node (e.g. zone,
+	// instance type, node pool).
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+	// RebootPodUID is the UID of the pod which was deleted to reboot the Slurm
+	// node, while the reboot is pending.
+	RebootPodUID string `json:"rebootPodUID,omitempty"`
 }
 
 func (podInfo *PodInfo) Equal(cmp PodInfo) bool {
@@ -18,6 +18,7 @@ func TestPodInfo_Equal(t *testing.T) {
 		Node        string
 		NodeSetName string
 		NodeSetUID  string
+		NodeLabels  map[string]string
 	}
 	type args struct {
 		cmp PodInfo
@@ -125,6 +126,26 @@ func TestPodInfo_Equal(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "Mismatch NodeLabels",
+			fields: fields{
+				Namespace: corev1.NamespaceDefault,
+				PodName:   "foo",
+				NodeLabels: map[string]string{
+					"topology.kubernetes.io/zone": "zone-a",
+				},
+			},
+			args: args{
+				cmp: PodInfo{
+					Namespace: corev1.NamespaceDefault,
+					PodName:   "foo",
+					NodeLabels: map[string]string{
+						"topology.kubernetes.io/zone": "zone-b",
+					},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -134,6 +155,7 @@ func TestPodInfo_Equal(t *testing.T) {
 				Node:        tt.fields.Node,
 				NodeSetName: tt.fields.NodeSetName,
 				NodeSetUID:  tt.fields.NodeSetUID,
+				NodeLabels:  tt.fields.NodeLabels,
 			}
 			require.Equal(t, tt.want, podInfo.Equal(tt.args.cmp))
 		})
@@ -147,6 +169,7 @@ func TestPodInfo_ToString(t *testing.T) {
 		Node        string
 		NodeSetName string
 		NodeSetUID  string
+		NodeLabels  map[string]string
 	}
 	tests := []struct {
 		name   string
@@ -169,6 +192,19 @@ func TestPodInfo_ToString(t *testing.T) {
 			},
 			want: `{"namespace":"default","podName":"foo","node":"bar","nodeSetName":"foo-nodeset","nodeSetUID":"uid-foo"}`,
 		},
+		{
+			name: "With NodeLabels",
+			fields: fields{
+				Namespace: corev1.NamespaceDefault,
+				PodName:   "foo",
+				Node:      "bar",
+				NodeLabels: map[string]string{
+					"topology.kubernetes.io/zone":      "zone-a",
+					"node.kubernetes.io/instance-type": "m5.large",
+				},
+			},
+			want: `{"namespace":"default","podName":"foo","node":"bar","nodeLabels":{"node.kubernetes.io/instance-type":"m5.large","topology.kubernetes.io/zone":"zone-a"}}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -178,6 +214,7 @@ func TestPodInfo_ToString(t *testing.T) {
 				Node:        tt.fields.Node,
 				NodeSetName: tt.fields.NodeSetName,
 				NodeSetUID:  tt.fields.NodeSetUID,
+				NodeLabels:  tt.fields.NodeLabels,
 			}
 			require.Equal(t, tt.want, podInfo.ToString())
 		})
@@ -6,8 +6,11 @@ package refresolver
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -121,6 +124,54 @@ func (r *RefResolver) GetRestapisForController(ctx context.Context, controller *
 	return out, nil
 }
 
+func (r *RefResolver) GetPartitionsForController(ctx context.Context, controller *slinkyv1beta1.Controller) (*slinkyv1beta1.PartitionList, error) {
+	if controller == nil {
+		return &slinkyv1beta1.PartitionList{}, nil
+	}
+
+	list := &slinkyv1beta1.PartitionList{}
+	if err := r.reader.List(ctx, list, client.InNamespace(controller.Namespace)); err != nil {
+		return nil, err
+	}
+
+	out := &slinkyv1beta1.PartitionList{}
+	for _, item := range list.Items {
+		refKey := types.NamespacedName{
+			Namespace: item.Namespace,
+			Name:      item.Spec.ControllerRef.Name,
+		}
+		if IsKeyMatch(refKey, objectutils.NamespacedName(controller)) {
+			out.Items = append(out.Items, item)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *RefResolver) GetReservationsForController(ctx context.Context, controller *slinkyv1beta1.Controller) (*slinkyv1beta1.ReservationList, error) {
+	if controller == nil {
+		return &slinkyv1beta1.ReservationList{}, nil
+	}
+
+	list := &slinkyv1beta1.ReservationList{}
+	if err := r.reader.List(ctx, list, client.InNamespace(controller.Namespace)); err != nil {
+		return nil, err
+	}
+
+	out := &slinkyv1beta1.ReservationList{}
+	for _, item := range list.Items {
+		refKey := types.NamespacedName{
+			Namespace: item.Namespace,
+			Name:      item.Spec.ControllerRef.Name,
+		}
+		if IsKeyMatch(refKey, objectutils.NamespacedName(controller)) {
+			out.Items = append(out.Items, item)
+		}
+	}
+
+	return out, nil
+}
+
 func (r *RefResolver) GetControllersForAccounting(ctx context.Context, accounting *slinkyv1beta1.Accounting) (*slinkyv1beta1.ControllerList, error) {
 	if accounting == nil {
 		return &slinkyv1beta1.ControllerList{}, nil
@@ -148,6 +199,44 @@ func (r *RefResolver) GetControllersForAccounting(ctx context.Context, accountin
 	return out, nil
 }
 
+// GetControllersForSlurmdbRef returns the Controllers whose slurmdbd is
+// referenced, either directly by way of a Controller or by way of an Accounting.
+// An unresolvable reference results in an empty list.
+func (r *RefResolver) GetControllersForSlurmdbRef(ctx context.Context, ref slinkyv1beta1.SlurmdbRef, namespace string) (*slinkyv1beta1.ControllerList, error) {
+	out := &slinkyv1beta1.ControllerList{}
+
+	switch {
+	case ref.ControllerRef != nil:
+		controller, err := r.GetController(ctx, *ref.ControllerRef, namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return out, nil
+			}
+			return nil, err
+		}
+		out.Items = append(out.Items, *controller)
+	case ref.AccountingRef != nil:
+		accounting, err := r.GetAccounting(ctx, *ref.AccountingRef, namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return out, nil
+			}
+			return nil, err
+		}
+		list, err := r.GetControllersForAccounting(ctx, accounting)
+		if err != nil {
+			return nil, err
+		}
+		out.Items = list.Items
+	}
+
+	slices.SortFunc(out.Items, func(a, b slinkyv1beta1.Controller) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return out, nil
+}
+
 func (r *RefResolver) GetSecretKeyRef(ctx context.Context, selector corev1.SecretKeySelector, namespace string) ([]byte, error) {
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{
@@ -166,6 +255,24 @@ func (r *RefResolver) GetSecretKeyRef(ctx context.Context, selector corev1.Secre
 	return data, nil
 }
 
+func (r *RefResolver) GetConfigMapKeyRef(ctx context.Context, selector corev1.ConfigMapKeySelector, namespace string) (string, error) {
+	configMap := &corev1.ConfigMap{}
+	key := types.NamespacedName{
+		Name:      selector.Name,
+		Namespace: namespace,
+	}
+	if err := r.reader.Get(ctx, key, configMap); err != nil {
+		return "", err
+	}
+
+	data, ok := configMap.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("configmap key '%s' not found", selector.Key)
+	}
+
+	return data, nil
+}
+
 func IsKeyMatch(key1, key2 types.NamespacedName) bool {
 	if key1.Namespace == key2.Namespace && key1.Name == key2.Name {
 		return true
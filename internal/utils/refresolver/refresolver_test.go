@@ -532,6 +532,236 @@ func TestRefResolver_GetRestapisForController(t *testing.T) {
 	}
 }
 
+func TestRefResolver_GetPartitionsForController(t *testing.T) {
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx        context.Context
+		controller *slinkyv1beta1.Controller
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(&slinkyv1beta1.Partition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "slurm-foo",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm",
+							},
+						},
+					}).
+					WithObjects(&slinkyv1beta1.Partition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "slurm1",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Spec: slinkyv1beta1.PartitionSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm1",
+							},
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "list error",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithInterceptorFuncs(interceptor.Funcs{
+						List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+							return errors.New("list failed")
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetPartitionsForController(tt.args.ctx, tt.args.controller)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got.Items, tt.want)
+		})
+	}
+}
+
+func TestRefResolver_GetReservationsForController(t *testing.T) {
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx        context.Context
+		controller *slinkyv1beta1.Controller
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(&slinkyv1beta1.Reservation{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "slurm-foo",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Spec: slinkyv1beta1.ReservationSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm",
+							},
+						},
+					}).
+					WithObjects(&slinkyv1beta1.Reservation{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "slurm1",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Spec: slinkyv1beta1.ReservationSpec{
+							ControllerRef: corev1.LocalObjectReference{
+								Name: "slurm1",
+							},
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "list error",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithInterceptorFuncs(interceptor.Funcs{
+						List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+							return errors.New("list failed")
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				controller: &slinkyv1beta1.Controller{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "slurm",
+						Namespace: metav1.NamespaceDefault,
+					},
+				},
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetReservationsForController(tt.args.ctx, tt.args.controller)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got.Items, tt.want)
+		})
+	}
+}
+
 func TestRefResolver_GetControllersForAccounting(t *testing.T) {
 	type fields struct {
 		reader client.Reader
@@ -647,6 +877,167 @@ func TestRefResolver_GetControllersForAccounting(t *testing.T) {
 	}
 }
 
+func TestRefResolver_GetControllersForSlurmdbRef(t *testing.T) {
+	newController := func(name, accountingName string) *slinkyv1beta1.Controller {
+		controller := &slinkyv1beta1.Controller{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+		}
+		if accountingName != "" {
+			controller.Spec.AccountingRef = &corev1.LocalObjectReference{
+				Name: accountingName,
+			}
+		}
+		return controller
+	}
+	accounting := &slinkyv1beta1.Accounting{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "slurm",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx       context.Context
+		ref       slinkyv1beta1.SlurmdbRef
+		namespace string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx:       context.TODO(),
+				ref:       slinkyv1beta1.SlurmdbRef{},
+				namespace: metav1.NamespaceDefault,
+			},
+			want: []string{},
+		},
+		{
+			name: "controllerRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(newController("slurm", ""), newController("slurm1", "")).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				ref: slinkyv1beta1.SlurmdbRef{
+					ControllerRef: &corev1.LocalObjectReference{Name: "slurm"},
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			want: []string{"slurm"},
+		},
+		{
+			name: "controllerRef not found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				ref: slinkyv1beta1.SlurmdbRef{
+					ControllerRef: &corev1.LocalObjectReference{Name: "slurm"},
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			want: []string{},
+		},
+		{
+			name: "accountingRef",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(accounting.DeepCopy()).
+					WithObjects(newController("slurm-b", "slurm"), newController("slurm-a", "slurm"), newController("slurm-c", "")).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				ref: slinkyv1beta1.SlurmdbRef{
+					AccountingRef: &corev1.LocalObjectReference{Name: "slurm"},
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			want: []string{"slurm-a", "slurm-b"},
+		},
+		{
+			name: "accountingRef not found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(newController("slurm-a", "slurm")).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				ref: slinkyv1beta1.SlurmdbRef{
+					AccountingRef: &corev1.LocalObjectReference{Name: "slurm"},
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			want: []string{},
+		},
+		{
+			name: "get error",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Get: func(_ context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+							return errors.New("get failed")
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				ref: slinkyv1beta1.SlurmdbRef{
+					AccountingRef: &corev1.LocalObjectReference{Name: "slurm"},
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetControllersForSlurmdbRef(tt.args.ctx, tt.args.ref, tt.args.namespace)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			names := []string{}
+			for _, item := range got.Items {
+				names = append(names, item.Name)
+			}
+			require.Equal(t, tt.want, names)
+		})
+	}
+}
+
 func TestRefResolver_GetSecretKeyRef(t *testing.T) {
 	type fields struct {
 		reader client.Reader
@@ -726,3 +1117,83 @@ func TestRefResolver_GetSecretKeyRef(t *testing.T) {
 		})
 	}
 }
+
+func TestRefResolver_GetConfigMapKeyRef(t *testing.T) {
+	type fields struct {
+		reader client.Reader
+	}
+	type args struct {
+		ctx       context.Context
+		selector  corev1.ConfigMapKeySelector
+		namespace string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				selector: corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "script",
+					},
+					Key: "job.sh",
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			wantErr: true,
+		},
+		{
+			name: "found",
+			fields: fields{
+				reader: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "script",
+							Namespace: metav1.NamespaceDefault,
+						},
+						Data: map[string]string{
+							"job.sh": "#!/bin/sh\nhostname",
+						},
+					}).
+					Build(),
+			},
+			args: args{
+				ctx: context.TODO(),
+				selector: corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "script",
+					},
+					Key: "job.sh",
+				},
+				namespace: metav1.NamespaceDefault,
+			},
+			want: "#!/bin/sh\nhostname",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.fields.reader)
+			got, err := r.GetConfigMapKeyRef(tt.args.ctx, tt.args.selector, tt.args.namespace)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservationutils
+
+import (
+	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+)
+
+// Reservation describes a Slurm reservation to be created or updated.
+type Reservation struct {
+	// Name is the name of the Slurm reservation.
+	Name string
+	// StartTime is the time at which the reservation starts.
+	StartTime time.Time
+	// Duration is how long the reservation lasts.
+	Duration time.Duration
+	// Flags are the user flags of the reservation (e.g. MAINT).
+	Flags []string
+	// Users are the Slurm users which may use the reservation.
+	Users []string
+	// Accounts are the Slurm accounts which may use the reservation.
+	Accounts []string
+	// NodeList is the hostlist expression of the reserved Slurm nodes, if any.
+	NodeList string
+}
+
+// FormatReservation returns the Slurm reservation request and the expected
+// reservation info of the reservation. The required flags are always set.
+func FormatReservation(
+	reservation Reservation,
+	reqInfoFlags []slurmapi.V0044ReservationInfoFlags,
+	reqDescFlags []slurmapi.V0044ReservationDescMsgFlags,
+) (slurmapi.V0044ReservationDescMsg, slurmtypes.V0044ReservationInfo) {
+	name := reservation.Name
+	startTime := TimeToUint64NoVal(reservation.StartTime.In(time.UTC))
+	endTime := TimeToUint64NoVal(reservation.StartTime.In(time.UTC).Add(reservation.Duration))
+	duration := DurationToUint32NoVal(reservation.Duration)
+
+	descFlags := ParseReservationFlags(reservation.Flags, reqDescFlags)
+	infoFlags := ParseReservationFlags(reservation.Flags, reqInfoFlags)
+
+	reservationInfo := slurmtypes.V0044ReservationInfo{
+		V0044ReservationInfo: slurmapi.V0044ReservationInfo{
+			Name:      &name,
+			StartTime: &startTime,
+			EndTime:   &endTime,
+			Flags:     &infoFlags,
+		},
+	}
+	reservationDesc := slurmapi.V0044ReservationDescMsg{
+		Name:      &name,
+		StartTime: &startTime,
+		Duration:  &duration,
+		Flags:     &descFlags,
+	}
+	if len(reservation.Users) > 0 {
+		reservationInfo.Users = ptr.To(strings.Join(reservation.Users, ","))
+		reservationDesc.Users = ptr.To(slurmapi.V0044CsvString(reservation.Users))
+	}
+	if len(reservation.Accounts) > 0 {
+		reservationInfo.Accounts = ptr.To(strings.Join(reservation.Accounts, ","))
+		reservationDesc.Accounts = ptr.To(slurmapi.V0044CsvString(reservation.Accounts))
+	}
+	if reservation.NodeList != "" {
+		reservationInfo.NodeList = ptr.To(reservation.NodeList)
+		reservationDesc.NodeList = &slurmapi.V0044HostlistString{reservation.NodeList}
+	}
+
+	return reservationDesc, reservationInfo
+}
+
+// ParseReservationFlags returns the sorted union of the required flags and the
+// user flags, which are upper-cased.
+func ParseReservationFlags[T slurmapi.V0044ReservationInfoFlags | slurmapi.V0044ReservationDescMsgFlags](flags []string, reqFlags []T) []T {
+	// Required flags
+	flagSet := set.New(reqFlags...)
+
+	// User flags
+	for _, flag := range flags {
+		f := T(strings.ToUpper(flag))
+		flagSet.Insert(f)
+	}
+
+	return flagSet.SortedList()
+}
+
+// IsReservationActive returns true if the reservation is currently running.
+// Once the Slurm RestAPI provides a slurmapi.V0044ReservationInfoStatus field,
+// this helper function should be deleted in favor of using that field directly.
+func IsReservationActive(reservation slurmtypes.V0044ReservationInfo, now time.Time) bool {
+	if reservation.StartTime == nil || reservation.EndTime == nil {
+		return false
+	}
+	start := time.Unix(ptr.Deref(reservation.StartTime.Number, 0), 0)
+	end := time.Unix(ptr.Deref(reservation.EndTime.Number, 0), 0)
+	return start.Before(now) && end.After(now)
+}
+
+func TimeToUint64NoVal(t time.Time) slurmapi.V0044Uint64NoValStruct {
+	return slurmapi.V0044Uint64NoValStruct{
+		Infinite: new(false),
+		Number:   ptr.To(t.Unix()),
+		Set:      new(true),
+	}
+}
+
+func DurationToUint32NoVal(d time.Duration) slurmapi.V0044Uint32NoValStruct {
+	return slurmapi.V0044Uint32NoValStruct{
+		Infinite: new(false),
+		Number:   ptr.To(int32(d.Minutes())),
+		Set:      new(true),
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package reservationutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	slurmapi "github.com/SlinkyProject/slurm-client/api/v0044"
+	slurmtypes "github.com/SlinkyProject/slurm-client/pkg/types"
+)
+
+func TestFormatReservation(t *testing.T) {
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	reservation := Reservation{
+		Name:      "maint",
+		StartTime: startTime,
+		Duration:  2 * time.Hour,
+		Flags:     []string{"ignore_jobs"},
+		Users:     []string{"alice", "bob"},
+		Accounts:  []string{"physics"},
+		NodeList:  "node-[0-1]",
+	}
+	reqInfoFlags := []slurmapi.V0044ReservationInfoFlags{slurmapi.V0044ReservationInfoFlagsMAINT}
+	reqDescFlags := []slurmapi.V0044ReservationDescMsgFlags{slurmapi.V0044ReservationDescMsgFlagsMAINT}
+
+	desc, info := FormatReservation(reservation, reqInfoFlags, reqDescFlags)
+
+	require.Equal(t, "maint", ptr.Deref(desc.Name, ""))
+	require.Equal(t, startTime.Unix(), ptr.Deref(desc.StartTime.Number, 0))
+	require.Equal(t, int32(120), ptr.Deref(desc.Duration.Number, 0))
+	require.Equal(t, []slurmapi.V0044ReservationDescMsgFlags{
+		slurmapi.V0044ReservationDescMsgFlagsIGNOREJOBS,
+		slurmapi.V0044ReservationDescMsgFlagsMAINT,
+	}, ptr.Deref(desc.Flags, nil))
+	require.Equal(t, slurmapi.V0044CsvString{"alice", "bob"}, ptr.Deref(desc.Users, nil))
+	require.Equal(t, slurmapi.V0044CsvString{"physics"}, ptr.Deref(desc.Accounts, nil))
+	require.Equal(t, slurmapi.V0044HostlistString{"node-[0-1]"}, ptr.Deref(desc.NodeList, nil))
+
+	require.Equal(t, startTime.Add(2*time.Hour).Unix(), ptr.Deref(info.EndTime.Number, 0))
+	require.Equal(t, "alice,bob", ptr.Deref(info.Users, ""))
+	require.Equal(t, "physics", ptr.Deref(info.Accounts, ""))
+	require.Equal(t, "node-[0-1]", ptr.Deref(info.NodeList, ""))
+
+	// Without users, accounts and nodes, those fields are omitted.
+	desc, info = FormatReservation(Reservation{Name: "empty", StartTime: startTime}, nil, nil)
+	require.Nil(t, desc.Users)
+	require.Nil(t, desc.Accounts)
+	require.Nil(t, desc.NodeList)
+	require.Nil(t, info.NodeList)
+	require.Empty(t, ptr.Deref(desc.Flags, nil))
+}
+
+func TestIsReservationActive(t *testing.T) {
+	now := time.Now()
+	newReservationInfo := func(start, end time.Time) slurmtypes.V0044ReservationInfo {
+		return slurmtypes.V0044ReservationInfo{
+			V0044ReservationInfo: slurmapi.V0044ReservationInfo{
+				StartTime: ptr.To(TimeToUint64NoVal(start)),
+				EndTime:   ptr.To(TimeToUint64NoVal(end)),
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		reservation slurmtypes.V0044ReservationInfo
+		want        bool
+	}{
+		{
+			name:        "Empty",
+			reservation: slurmtypes.V0044ReservationInfo{},
+			want:        false,
+		},
+		{
+			name:        "Pending",
+			reservation: newReservationInfo(now.Add(time.Hour), now.Add(2*time.Hour)),
+			want:        false,
+		},
+		{
+			name:        "Active",
+			reservation: newReservationInfo(now.Add(-time.Hour), now.Add(time.Hour)),
+			want:        true,
+		},
+		{
+			name:        "Ended",
+			reservation: newReservationInfo(now.Add(-2*time.Hour), now.Add(-time.Hour)),
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsReservationActive(tt.reservation, now))
+		})
+	}
+}
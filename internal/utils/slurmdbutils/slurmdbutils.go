@@ -0,0 +1,305 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slurmdbutils holds the subset of the slurmdbd objects which is
+// managed by the operator. They are encoded with the field names of the Slurm
+// REST API, such that they convert to and from the Slurm client objects by way
+// of Convert().
+package slurmdbutils
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// Account is a Slurm account.
+type Account struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Organization string `json:"organization"`
+}
+
+// User is a Slurm user.
+type User struct {
+	Name               string       `json:"name"`
+	AdministratorLevel []string     `json:"administrator_level,omitempty"`
+	Default            *UserDefault `json:"default,omitempty"`
+}
+
+type UserDefault struct {
+	Account string `json:"account,omitempty"`
+}
+
+// Assoc is a Slurm association, of either an account or a user.
+type Assoc struct {
+	Account       string        `json:"account"`
+	Cluster       string        `json:"cluster"`
+	User          string        `json:"user"`
+	Partition     string        `json:"partition,omitempty"`
+	ParentAccount string        `json:"parent_account,omitempty"`
+	SharesRaw     *int32        `json:"shares_raw,omitempty"`
+	Qos           []string      `json:"qos,omitempty"`
+	Default       *AssocDefault `json:"default,omitempty"`
+}
+
+type AssocDefault struct {
+	Qos string `json:"qos,omitempty"`
+}
+
+// Qos is a Slurm QOS.
+type Qos struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Priority    *NoVal      `json:"priority,omitempty"`
+	Flags       []string    `json:"flags,omitempty"`
+	Preempt     *QosPreempt `json:"preempt,omitempty"`
+	Limits      *QosLimits  `json:"limits,omitempty"`
+}
+
+type QosPreempt struct {
+	List []string `json:"list,omitempty"`
+}
+
+type QosLimits struct {
+	Max *QosLimitsMax `json:"max,omitempty"`
+}
+
+type QosLimitsMax struct {
+	WallClock *QosLimitsWallClock `json:"wall_clock,omitempty"`
+}
+
+type QosLimitsWallClock struct {
+	Per *QosLimitsWallClockPer `json:"per,omitempty"`
+}
+
+type QosLimitsWallClockPer struct {
+	Job *NoVal `json:"job,omitempty"`
+}
+
+// NoVal is a Slurm number which may be unset or infinite.
+type NoVal struct {
+	Set      *bool  `json:"set,omitempty"`
+	Infinite *bool  `json:"infinite,omitempty"`
+	Number   *int64 `json:"number,omitempty"`
+}
+
+func newNoVal(number int64) *NoVal {
+	return &NoVal{
+		Set:      ptr.To(true),
+		Infinite: ptr.To(false),
+		Number:   ptr.To(number),
+	}
+}
+
+// Convert converts between Slurm client objects and the objects of this
+// package, by way of their shared JSON encoding.
+func Convert(in, out any) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// NewAccount returns the desired Slurm account of the Account.
+func NewAccount(account *slinkyv1beta1.Account) Account {
+	name := account.AccountName()
+	out := Account{
+		Name:         name,
+		Description:  account.Spec.Description,
+		Organization: account.Spec.Organization,
+	}
+	if out.Description == "" {
+		out.Description = name
+	}
+	if out.Organization == "" {
+		out.Organization = account.ParentAccountName()
+	}
+	return out
+}
+
+// NewAccountAssoc returns the desired association of the Account in the cluster.
+func NewAccountAssoc(account *slinkyv1beta1.Account, cluster string) Assoc {
+	out := Assoc{
+		Account:       account.AccountName(),
+		Cluster:       cluster,
+		ParentAccount: account.ParentAccountName(),
+		SharesRaw:     account.Spec.Fairshare,
+		Qos:           account.Spec.QOS,
+	}
+	if account.Spec.DefaultQOS != "" {
+		out.Default = &AssocDefault{Qos: account.Spec.DefaultQOS}
+	}
+	return out
+}
+
+// NewUser returns the desired Slurm user of the SlurmUser.
+func NewUser(user *slinkyv1beta1.SlurmUser) User {
+	adminLevel := user.Spec.AdminLevel
+	if adminLevel == "" {
+		adminLevel = slinkyv1beta1.SlurmUserAdminLevelNone
+	}
+	return User{
+		Name:               user.UserName(),
+		AdministratorLevel: []string{string(adminLevel)},
+		Default: &UserDefault{
+			Account: user.Spec.DefaultAccount,
+		},
+	}
+}
+
+// NewUserAssocs returns the desired associations of the SlurmUser in the cluster.
+func NewUserAssocs(user *slinkyv1beta1.SlurmUser, cluster string) []Assoc {
+	accounts := user.AccountNames()
+	out := make([]Assoc, 0, len(accounts))
+	for _, account := range accounts {
+		assoc := Assoc{
+			Account: account,
+			Cluster: cluster,
+			User:    user.UserName(),
+			Qos:     user.Spec.QOS,
+		}
+		if user.Spec.DefaultQOS != "" {
+			assoc.Default = &AssocDefault{Qos: user.Spec.DefaultQOS}
+		}
+		out = append(out, assoc)
+	}
+	return out
+}
+
+// NewQos returns the desired Slurm QOS of the QOS.
+func NewQos(qos *slinkyv1beta1.QOS) Qos {
+	out := Qos{
+		Name:        qos.QOSName(),
+		Description: qos.Spec.Description,
+		Flags:       qos.Spec.Flags,
+	}
+	if qos.Spec.Priority != nil {
+		out.Priority = newNoVal(int64(*qos.Spec.Priority))
+	}
+	if qos.Spec.Preempt != nil {
+		out.Preempt = &QosPreempt{List: qos.Spec.Preempt}
+	}
+	if qos.Spec.MaxWallDurationPerJob != nil {
+		minutes := int64(qos.Spec.MaxWallDurationPerJob.Duration / time.Minute)
+		out.Limits = &QosLimits{
+			Max: &QosLimitsMax{
+				WallClock: &QosLimitsWallClock{
+					Per: &QosLimitsWallClockPer{
+						Job: newNoVal(minutes),
+					},
+				},
+			},
+		}
+	}
+	return out
+}
+
+// IsAccountMatch returns true if the Slurm account already matches the desired one.
+func IsAccountMatch(old, new Account) bool {
+	return old.Name == new.Name &&
+		old.Description == new.Description &&
+		old.Organization == new.Organization
+}
+
+// IsAssocKeyMatch returns true if both associations are of the same account,
+// user and partition in the same cluster.
+func IsAssocKeyMatch(a, b Assoc) bool {
+	return a.Account == b.Account &&
+		a.Cluster == b.Cluster &&
+		a.User == b.User &&
+		a.Partition == b.Partition
+}
+
+// IsAssocMatch returns true if the Slurm association already matches the
+// desired one. Unset optional fields of the desired association are not
+// managed, hence ignored.
+func IsAssocMatch(old, new Assoc) bool {
+	if !IsAssocKeyMatch(old, new) {
+		return false
+	}
+	if new.ParentAccount != "" && old.ParentAccount != new.ParentAccount {
+		return false
+	}
+	if new.SharesRaw != nil && ptr.Deref(old.SharesRaw, 0) != *new.SharesRaw {
+		return false
+	}
+	if new.Qos != nil && !isSetMatch(old.Qos, new.Qos) {
+		return false
+	}
+	if new.Default != nil && (old.Default == nil || old.Default.Qos != new.Default.Qos) {
+		return false
+	}
+	return true
+}
+
+// IsUserMatch returns true if the Slurm user already matches the desired one.
+func IsUserMatch(old, new User) bool {
+	if old.Name != new.Name {
+		return false
+	}
+	if !isSetMatch(old.AdministratorLevel, new.AdministratorLevel) {
+		return false
+	}
+	oldDefault := ptr.Deref(old.Default, UserDefault{})
+	newDefault := ptr.Deref(new.Default, UserDefault{})
+	return oldDefault.Account == newDefault.Account
+}
+
+// IsQosMatch returns true if the Slurm QOS already matches the desired one.
+// Unset optional fields of the desired QOS are not managed, hence ignored.
+func IsQosMatch(old, new Qos) bool {
+	if old.Name != new.Name {
+		return false
+	}
+	if new.Description != "" && old.Description != new.Description {
+		return false
+	}
+	if new.Priority != nil && getNumber(old.Priority) != getNumber(new.Priority) {
+		return false
+	}
+	if new.Flags != nil && !isSetMatch(old.Flags, new.Flags) {
+		return false
+	}
+	if new.Preempt != nil && !isSetMatch(ptr.Deref(old.Preempt, QosPreempt{}).List, new.Preempt.List) {
+		return false
+	}
+	if new.Limits != nil && getNumber(getMaxWallClockPerJob(old)) != getNumber(getMaxWallClockPerJob(new)) {
+		return false
+	}
+	return true
+}
+
+func getMaxWallClockPerJob(qos Qos) *NoVal {
+	if qos.Limits == nil || qos.Limits.Max == nil || qos.Limits.Max.WallClock == nil || qos.Limits.Max.WallClock.Per == nil {
+		return nil
+	}
+	return qos.Limits.Max.WallClock.Per.Job
+}
+
+func getNumber(val *NoVal) int64 {
+	if val == nil || !ptr.Deref(val.Set, false) {
+		return 0
+	}
+	return ptr.Deref(val.Number, 0)
+}
+
+// isSetMatch returns true if both lists hold the same items, regardless of
+// order and case. Slurm reports flags in upper case.
+func isSetMatch(old, new []string) bool {
+	normalize := func(items []string) []string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			out = append(out, strings.ToUpper(item))
+		}
+		slices.Sort(out)
+		return slices.Compact(out)
+	}
+	return slices.Equal(normalize(old), normalize(new))
+}
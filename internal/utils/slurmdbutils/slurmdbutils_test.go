@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmdbutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+func TestConvert(t *testing.T) {
+	// A Slurm REST API object holds more fields than are managed.
+	in := map[string]any{
+		"name":        "normal",
+		"description": "Normal QOS",
+		"flags":       []string{"DENY_LIMIT"},
+		"priority":    map[string]any{"set": true, "infinite": false, "number": 10},
+		"usage_factor": map[string]any{
+			"set": true, "infinite": false, "number": 1.0,
+		},
+	}
+	out := Qos{}
+	require.NoError(t, Convert(in, &out))
+	require.Equal(t, Qos{
+		Name:        "normal",
+		Description: "Normal QOS",
+		Flags:       []string{"DENY_LIMIT"},
+		Priority:    newNoVal(10),
+	}, out)
+}
+
+func TestNewAccount(t *testing.T) {
+	account := &slinkyv1beta1.Account{
+		ObjectMeta: metav1.ObjectMeta{Name: "physics"},
+		Spec: slinkyv1beta1.AccountSpec{
+			Fairshare:  ptr.To[int32](10),
+			DefaultQOS: "normal",
+		},
+	}
+	require.Equal(t, Account{
+		Name:         "physics",
+		Description:  "physics",
+		Organization: "root",
+	}, NewAccount(account))
+	require.Equal(t, Assoc{
+		Account:       "physics",
+		Cluster:       "slurm",
+		ParentAccount: "root",
+		SharesRaw:     ptr.To[int32](10),
+		Default:       &AssocDefault{Qos: "normal"},
+	}, NewAccountAssoc(account, "slurm"))
+}
+
+func TestNewUser(t *testing.T) {
+	user := &slinkyv1beta1.SlurmUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice"},
+		Spec: slinkyv1beta1.SlurmUserSpec{
+			DefaultAccount: "physics",
+			Accounts:       []string{"physics", "chemistry"},
+		},
+	}
+	require.Equal(t, User{
+		Name:               "alice",
+		AdministratorLevel: []string{"None"},
+		Default:            &UserDefault{Account: "physics"},
+	}, NewUser(user))
+	require.Equal(t, []Assoc{
+		{Account: "chemistry", Cluster: "slurm", User: "alice"},
+		{Account: "physics", Cluster: "slurm", User: "alice"},
+	}, NewUserAssocs(user, "slurm"))
+}
+
+func TestIsAssocMatch(t *testing.T) {
+	old := Assoc{
+		Account:       "physics",
+		Cluster:       "slurm",
+		ParentAccount: "root",
+		SharesRaw:     ptr.To[int32](1),
+		Qos:           []string{"normal", "high"},
+	}
+	tests := []struct {
+		name string
+		new  Assoc
+		want bool
+	}{
+		{
+			name: "Unmanaged fields",
+			new:  Assoc{Account: "physics", Cluster: "slurm"},
+			want: true,
+		},
+		{
+			name: "Match",
+			new: Assoc{
+				Account:       "physics",
+				Cluster:       "slurm",
+				ParentAccount: "root",
+				SharesRaw:     ptr.To[int32](1),
+				Qos:           []string{"high", "normal"},
+			},
+			want: true,
+		},
+		{
+			name: "Other cluster",
+			new:  Assoc{Account: "physics", Cluster: "other"},
+			want: false,
+		},
+		{
+			name: "Fairshare drift",
+			new:  Assoc{Account: "physics", Cluster: "slurm", SharesRaw: ptr.To[int32](10)},
+			want: false,
+		},
+		{
+			name: "QOS drift",
+			new:  Assoc{Account: "physics", Cluster: "slurm", Qos: []string{}},
+			want: false,
+		},
+		{
+			name: "Default QOS drift",
+			new:  Assoc{Account: "physics", Cluster: "slurm", Default: &AssocDefault{Qos: "high"}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsAssocMatch(old, tt.new))
+		})
+	}
+}
+
+func TestIsQosMatch(t *testing.T) {
+	qos := &slinkyv1beta1.QOS{
+		ObjectMeta: metav1.ObjectMeta{Name: "normal"},
+		Spec: slinkyv1beta1.QOSSpec{
+			Priority:              ptr.To[int32](10),
+			Flags:                 []string{"DenyOnLimit"},
+			Preempt:               []string{"scavenger"},
+			MaxWallDurationPerJob: &metav1.Duration{Duration: 2*time.Hour + 30*time.Second},
+		},
+	}
+	new := NewQos(qos)
+	require.Equal(t, int64(120), getNumber(getMaxWallClockPerJob(new)))
+
+	old := Qos{}
+	require.NoError(t, Convert(new, &old))
+	old.Flags = []string{"DENYONLIMIT"}
+	require.True(t, IsQosMatch(old, new))
+
+	old.Priority = newNoVal(1)
+	require.False(t, IsQosMatch(old, new))
+
+	// Unmanaged fields are ignored.
+	require.True(t, IsQosMatch(old, Qos{Name: "normal"}))
+}
+
+func TestIsUserMatch(t *testing.T) {
+	new := User{
+		Name:               "alice",
+		AdministratorLevel: []string{"Operator"},
+		Default:            &UserDefault{Account: "physics"},
+	}
+	require.True(t, IsUserMatch(new, new))
+	require.False(t, IsUserMatch(User{Name: "alice", AdministratorLevel: []string{"None"}, Default: new.Default}, new))
+	require.False(t, IsUserMatch(User{Name: "alice", AdministratorLevel: new.AdministratorLevel}, new))
+}
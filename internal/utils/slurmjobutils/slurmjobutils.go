@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slurmjobutils holds the subset of the Slurm job submission and job
+// information which is managed by the operator. They are encoded with the
+// field names of the Slurm REST API.
+package slurmjobutils
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+const (
+	defaultWorkingDirectory = "/tmp"
+	defaultPath             = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+	mebibyte = 1024 * 1024
+)
+
+// JobSubmitReq is a Slurm job submission.
+type JobSubmitReq struct {
+	Script string  `json:"script"`
+	Job    JobDesc `json:"job"`
+}
+
+// JobDesc is the description of a Slurm job.
+type JobDesc struct {
+	Name                    string   `json:"name,omitempty"`
+	Partition               string   `json:"partition,omitempty"`
+	Account                 string   `json:"account,omitempty"`
+	Qos                     string   `json:"qos,omitempty"`
+	Nodes                   string   `json:"nodes,omitempty"`
+	Tasks                   *int32   `json:"tasks,omitempty"`
+	CpusPerTask             *int32   `json:"cpus_per_task,omitempty"`
+	MemoryPerNode           *NoVal   `json:"memory_per_node,omitempty"`
+	TimeLimit               *NoVal   `json:"time_limit,omitempty"`
+	Constraints             string   `json:"constraints,omitempty"`
+	CurrentWorkingDirectory string   `json:"current_working_directory"`
+	Environment             []string `json:"environment"`
+	StandardOutput          string   `json:"standard_output,omitempty"`
+	StandardError           string   `json:"standard_error,omitempty"`
+	Comment                 string   `json:"comment,omitempty"`
+}
+
+// JobInfo is the information of a Slurm job.
+type JobInfo struct {
+	JobId     *int32    `json:"job_id,omitempty"`
+	JobState  []string  `json:"job_state,omitempty"`
+	ExitCode  *ExitCode `json:"exit_code,omitempty"`
+	Nodes     string    `json:"nodes,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+	StartTime *NoVal    `json:"start_time,omitempty"`
+	EndTime   *NoVal    `json:"end_time,omitempty"`
+}
+
+type ExitCode struct {
+	ReturnCode *NoVal `json:"return_code,omitempty"`
+}
+
+// NoVal is a Slurm number which may be unset or infinite.
+type NoVal struct {
+	Set      *bool  `json:"set,omitempty"`
+	Infinite *bool  `json:"infinite,omitempty"`
+	Number   *int64 `json:"number,omitempty"`
+}
+
+func newNoVal(number int64) *NoVal {
+	return &NoVal{
+		Set:      ptr.To(true),
+		Infinite: ptr.To(false),
+		Number:   ptr.To(number),
+	}
+}
+
+func (v *NoVal) get() (int64, bool) {
+	if v == nil || !ptr.Deref(v.Set, false) || ptr.Deref(v.Infinite, false) {
+		return 0, false
+	}
+	return ptr.Deref(v.Number, 0), true
+}
+
+// NewJobSubmitReq returns the Slurm job submission of the SlurmJob.
+func NewJobSubmitReq(slurmJob *slinkyv1beta1.SlurmJob, script string) JobSubmitReq {
+	opts := slurmJob.Spec.Options
+
+	job := JobDesc{
+		Name:                    slurmJob.JobName(),
+		Partition:               opts.Partition,
+		Account:                 opts.Account,
+		Qos:                     opts.QOS,
+		Nodes:                   opts.Nodes,
+		Tasks:                   opts.Tasks,
+		CpusPerTask:             opts.CPUsPerTask,
+		Constraints:             opts.Constraints,
+		CurrentWorkingDirectory: opts.WorkingDirectory,
+		StandardOutput:          opts.StandardOutput,
+		StandardError:           opts.StandardError,
+		Comment:                 slurmJob.JobComment(),
+	}
+	if job.CurrentWorkingDirectory == "" {
+		job.CurrentWorkingDirectory = defaultWorkingDirectory
+	}
+	if opts.MemoryPerNode != nil {
+		job.MemoryPerNode = newNoVal(opts.MemoryPerNode.Value() / mebibyte)
+	}
+	if opts.TimeLimit != nil {
+		job.TimeLimit = newNoVal(int64(opts.TimeLimit.Duration / time.Minute))
+	}
+
+	env := map[string]string{
+		"PATH": defaultPath,
+	}
+	for k, v := range opts.Environment {
+		env[k] = v
+	}
+	for k, v := range env {
+		job.Environment = append(job.Environment, fmt.Sprintf("%s=%s", k, v))
+	}
+	slices.Sort(job.Environment)
+
+	return JobSubmitReq{
+		Script: script,
+		Job:    job,
+	}
+}
+
+// Slurm job states.
+// Ref: https://slurm.schedmd.com/job_state_codes.html
+const (
+	JobStatePending   = "PENDING"
+	JobStateRunning   = "RUNNING"
+	JobStateCompleted = "COMPLETED"
+)
+
+// finishedJobStates are the base states of a job which will not run anymore.
+var finishedJobStates = []string{
+	"BOOT_FAIL",
+	"CANCELLED",
+	JobStateCompleted,
+	"DEADLINE",
+	"FAILED",
+	"NODE_FAIL",
+	"OUT_OF_MEMORY",
+	"PREEMPTED",
+	"TIMEOUT",
+}
+
+// State returns the base state of the job.
+func (j *JobInfo) State() string {
+	for _, state := range j.JobState {
+		if state == JobStatePending || state == JobStateRunning || slices.Contains(finishedJobStates, state) {
+			return state
+		}
+	}
+	if len(j.JobState) > 0 {
+		return j.JobState[0]
+	}
+	return ""
+}
+
+// IsFinished returns true if the job will not run anymore.
+func (j *JobInfo) IsFinished() bool {
+	return slices.Contains(finishedJobStates, j.State())
+}
+
+// IsCompleted returns true if the job finished successfully.
+func (j *JobInfo) IsCompleted() bool {
+	return j.State() == JobStateCompleted
+}
+
+// GetExitCode returns the exit code of the batch script, if known.
+func (j *JobInfo) GetExitCode() *int32 {
+	if j.ExitCode == nil {
+		return nil
+	}
+	code, ok := j.ExitCode.ReturnCode.get()
+	if !ok {
+		return nil
+	}
+	return ptr.To(int32(code))
+}
+
+// GetStartTime returns the time at which the job started, if it did.
+func (j *JobInfo) GetStartTime() *time.Time {
+	return getTime(j.StartTime)
+}
+
+// GetEndTime returns the time at which the job ended, if it did.
+func (j *JobInfo) GetEndTime() *time.Time {
+	return getTime(j.EndTime)
+}
+
+func getTime(val *NoVal) *time.Time {
+	seconds, ok := val.get()
+	if !ok || seconds <= 0 {
+		return nil
+	}
+	return ptr.To(time.Unix(seconds, 0))
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package slurmjobutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/slurmdbutils"
+)
+
+func TestNewJobSubmitReq(t *testing.T) {
+	slurmJob := &slinkyv1beta1.SlurmJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hello",
+			UID:  "1234",
+		},
+		Spec: slinkyv1beta1.SlurmJobSpec{
+			Options: slinkyv1beta1.SlurmJobOptions{
+				Partition:     "debug",
+				Tasks:         ptr.To[int32](2),
+				MemoryPerNode: ptr.To(resource.MustParse("2Gi")),
+				TimeLimit:     &metav1.Duration{Duration: 90 * time.Second},
+				Environment: map[string]string{
+					"FOO":  "bar",
+					"PATH": "/opt/bin",
+				},
+			},
+		},
+	}
+	require.Equal(t, JobSubmitReq{
+		Script: "#!/bin/sh\nhostname",
+		Job: JobDesc{
+			Name:                    "hello",
+			Partition:               "debug",
+			Tasks:                   ptr.To[int32](2),
+			MemoryPerNode:           newNoVal(2048),
+			TimeLimit:               newNoVal(1),
+			CurrentWorkingDirectory: "/tmp",
+			Environment:             []string{"FOO=bar", "PATH=/opt/bin"},
+			Comment:                 "slurmjob.slinky.slurm.net/1234",
+		},
+	}, NewJobSubmitReq(slurmJob, "#!/bin/sh\nhostname"))
+
+	slurmJob.Spec.Options = slinkyv1beta1.SlurmJobOptions{Name: "other"}
+	got := NewJobSubmitReq(slurmJob, "")
+	require.Equal(t, "other", got.Job.Name)
+	require.Equal(t, []string{"PATH=" + defaultPath}, got.Job.Environment)
+}
+
+func TestJobInfo(t *testing.T) {
+	// A Slurm REST API job holds more fields than are read.
+	in := map[string]any{
+		"job_id":    int32(42),
+		"job_state": []string{"COMPLETED", "REQUEUED"},
+		"exit_code": map[string]any{
+			"status":      []string{"SUCCESS"},
+			"return_code": map[string]any{"set": true, "infinite": false, "number": 0},
+		},
+		"nodes":      "slinky-[0-1]",
+		"start_time": map[string]any{"set": true, "infinite": false, "number": 1700000000},
+		"end_time":   map[string]any{"set": true, "infinite": false, "number": 0},
+		"user_name":  "slurm",
+	}
+	job := JobInfo{}
+	require.NoError(t, slurmdbutils.Convert(in, &job))
+	require.Equal(t, ptr.To[int32](42), job.JobId)
+	require.Equal(t, JobStateCompleted, job.State())
+	require.True(t, job.IsFinished())
+	require.True(t, job.IsCompleted())
+	require.Equal(t, ptr.To[int32](0), job.GetExitCode())
+	require.Equal(t, ptr.To(time.Unix(1700000000, 0)), job.GetStartTime())
+	require.Nil(t, job.GetEndTime())
+}
+
+func TestJobInfo_State(t *testing.T) {
+	tests := []struct {
+		name         string
+		jobState     []string
+		want         string
+		wantFinished bool
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:     "Pending",
+			jobState: []string{"PENDING"},
+			want:     JobStatePending,
+		},
+		{
+			name:     "Running with flags",
+			jobState: []string{"CONFIGURING", "RUNNING"},
+			want:     JobStateRunning,
+		},
+		{
+			name:         "Timeout",
+			jobState:     []string{"TIMEOUT"},
+			want:         "TIMEOUT",
+			wantFinished: true,
+		},
+		{
+			name:     "Unknown",
+			jobState: []string{"SUSPENDED"},
+			want:     "SUSPENDED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &JobInfo{JobState: tt.jobState}
+			require.Equal(t, tt.want, job.State())
+			require.Equal(t, tt.wantFinished, job.IsFinished())
+			require.False(t, job.IsCompleted())
+			require.Nil(t, job.GetExitCode())
+		})
+	}
+}
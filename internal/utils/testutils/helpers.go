@@ -291,6 +291,30 @@ func NewRestapi(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta
 	}
 }
 
+func NewPartition(name string, controller *slinkyv1beta1.Controller) *slinkyv1beta1.Partition {
+	var controllerRef corev1.LocalObjectReference
+	if controller != nil {
+		controllerRef = corev1.LocalObjectReference{
+			Name: controller.Name,
+		}
+	}
+	return &slinkyv1beta1.Partition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: slinkyv1beta1.PartitionAPIVersion,
+			Kind:       slinkyv1beta1.PartitionKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.PartitionSpec{
+			ControllerRef:   controllerRef,
+			NodeSetSelector: &metav1.LabelSelector{},
+			State:           slinkyv1beta1.PartitionStateUp,
+		},
+	}
+}
+
 func NewToken(name string, jwtKeySecret *corev1.Secret) *slinkyv1beta1.Token {
 	return &slinkyv1beta1.Token{
 		TypeMeta: metav1.TypeMeta{
@@ -313,6 +337,18 @@ func NewToken(name string, jwtKeySecret *corev1.Secret) *slinkyv1beta1.Token {
 	}
 }
 
+// NewSlurmdbObjects returns an Accounting and the Controllers which reference
+// it, such that they resolve the SlurmdbRef of the slurmdbd objects.
+func NewSlurmdbObjects(name string, controllerNames ...string) []client.Object {
+	accounting := NewAccounting(name, NewSlurmKeyRef(name), NewJwtKeyRef(name), NewPasswordRef(name))
+	objs := []client.Object{accounting}
+	for _, controllerName := range controllerNames {
+		controller := NewController(controllerName, NewSlurmKeyRef(controllerName), NewJwtKeyRef(controllerName), accounting)
+		objs = append(objs, controller)
+	}
+	return objs
+}
+
 func NewClientMap(controllerName, namespace string, sclient slurmclient.Client) *clientmap.ClientMap {
 	cm := clientmap.NewClientMap()
 	if controllerName == "" {
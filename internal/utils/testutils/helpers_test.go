@@ -180,6 +180,20 @@ func TestNewAccounting(t *testing.T) {
 	}
 }
 
+func TestNewSlurmdbObjects(t *testing.T) {
+	got := NewSlurmdbObjects("slurmdbd", "slurm-a", "slurm-b")
+	require.Len(t, got, 3)
+
+	accounting, ok := got[0].(*slinkyv1beta1.Accounting)
+	require.True(t, ok)
+	require.Equal(t, "slurmdbd", accounting.Name)
+	for _, obj := range got[1:] {
+		controller, ok := obj.(*slinkyv1beta1.Controller)
+		require.True(t, ok)
+		require.Equal(t, "slurmdbd", controller.Spec.AccountingRef.Name)
+	}
+}
+
 func TestNewPasswordRef(t *testing.T) {
 	type args struct {
 		name string
@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package topologyutils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+// invalidNameChars matches the characters which are not allowed in Slurm
+// switch and block names.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+
+// Ref: https://slurm.schedmd.com/topology.yaml.html
+type topologyConfig struct {
+	Topology       string       `json:"topology"`
+	ClusterDefault bool         `json:"cluster_default"`
+	Tree           *treeConfig  `json:"tree,omitempty"`
+	Block          *blockConfig `json:"block,omitempty"`
+}
+
+type treeConfig struct {
+	Switches []switchConfig `json:"switches"`
+}
+
+type switchConfig struct {
+	Switch   string `json:"switch"`
+	Children string `json:"children,omitempty"`
+}
+
+type blockConfig struct {
+	BlockSizes []int32      `json:"block_sizes,omitempty"`
+	Blocks     []blockEntry `json:"blocks"`
+}
+
+type blockEntry struct {
+	Block string `json:"block"`
+}
+
+// GetNodeTopologySpec returns the Slurm dynamic topology line of the Kubernetes
+// node (e.g. "topo-switch:s2,topo-block:b2"). The node topology annotation, when
+// present, takes precedence over the line generated from the node labels.
+func GetNodeTopologySpec(topology slinkyv1beta1.ControllerTopology, node *corev1.Node) string {
+	if topologySpec, ok := node.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]; ok {
+		return topologySpec
+	}
+	return BuildNodeTopologySpec(topology, node.Labels)
+}
+
+// BuildNodeTopologySpec returns the Slurm dynamic topology line generated from
+// the Kubernetes node labels. Topologies whose labels are missing are omitted.
+func BuildNodeTopologySpec(topology slinkyv1beta1.ControllerTopology, nodeLabels map[string]string) string {
+	items := make([]string, 0, len(topology.Topologies))
+	for _, spec := range topology.Topologies {
+		switch {
+		case spec.Tree != nil:
+			switches := getSwitchPath(spec.Tree, nodeLabels)
+			if len(switches) == 0 {
+				continue
+			}
+			items = append(items, fmt.Sprintf("%s:%s", spec.Name, switches[len(switches)-1]))
+		case spec.Block != nil:
+			block := getBlock(spec.Block, nodeLabels)
+			if block == "" {
+				continue
+			}
+			items = append(items, fmt.Sprintf("%s:%s", spec.Name, block))
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// BuildTopologyYaml returns the Slurm `topology.yaml` generated from the labels
+// of the Kubernetes nodes. Switches and blocks do not list nodes, as NodeSet
+// pods register as dynamic nodes with their topology line. Topologies without
+// any switch or block are omitted. Returns empty if there are none.
+// Ref: https://slurm.schedmd.com/topology.yaml.html
+func BuildTopologyYaml(topology slinkyv1beta1.ControllerTopology, nodes []corev1.Node) (string, error) {
+	configs := make([]topologyConfig, 0, len(topology.Topologies))
+	for _, spec := range topology.Topologies {
+		config := topologyConfig{
+			Topology:       spec.Name,
+			ClusterDefault: spec.ClusterDefault,
+		}
+		switch {
+		case spec.Tree != nil:
+			switches := buildSwitches(spec.Name, spec.Tree, nodes)
+			if len(switches) == 0 {
+				continue
+			}
+			config.Tree = &treeConfig{Switches: switches}
+		case spec.Block != nil:
+			blocks := buildBlocks(spec.Block, nodes)
+			if len(blocks) == 0 {
+				continue
+			}
+			config.Block = &blockConfig{
+				BlockSizes: spec.Block.BlockSizes,
+				Blocks:     blocks,
+			}
+		default:
+			continue
+		}
+		configs = append(configs, config)
+	}
+	if len(configs) == 0 {
+		return "", nil
+	}
+
+	out, err := yaml.Marshal(configs)
+	if err != nil {
+		return "", err
+	}
+	return "---\n" + string(out), nil
+}
+
+// buildSwitches returns the switches of the tree, ordered from the top tier to
+// the leaf switches. A root switch connects multiple top tier switches.
+func buildSwitches(name string, tree *slinkyv1beta1.ControllerTopologyTree, nodes []corev1.Node) []switchConfig {
+	tiers := make([]sets.Set[string], len(tree.SwitchLabels))
+	for i := range tiers {
+		tiers[i] = sets.New[string]()
+	}
+	children := make(map[string]sets.Set[string])
+	for _, node := range nodes {
+		switches := getSwitchPath(tree, node.Labels)
+		for i, sw := range switches {
+			tiers[i].Insert(sw)
+			if i == 0 {
+				continue
+			}
+			parent := switches[i-1]
+			if _, ok := children[parent]; !ok {
+				children[parent] = sets.New[string]()
+			}
+			children[parent].Insert(sw)
+		}
+	}
+	if tiers[0].Len() == 0 {
+		return nil
+	}
+
+	out := make([]switchConfig, 0)
+	if tiers[0].Len() > 1 {
+		out = append(out, switchConfig{
+			Switch:   sanitizeName(name + "_root"),
+			Children: strings.Join(sets.List(tiers[0]), ","),
+		})
+	}
+	for _, tier := range tiers {
+		for _, sw := range sets.List(tier) {
+			config := switchConfig{Switch: sw}
+			if c, ok := children[sw]; ok {
+				config.Children = strings.Join(sets.List(c), ",")
+			}
+			out = append(out, config)
+		}
+	}
+	return out
+}
+
+// buildBlocks returns the blocks, sorted by name.
+func buildBlocks(block *slinkyv1beta1.ControllerTopologyBlock, nodes []corev1.Node) []blockEntry {
+	blocks := sets.New[string]()
+	for _, node := range nodes {
+		if name := getBlock(block, node.Labels); name != "" {
+			blocks.Insert(name)
+		}
+	}
+	out := make([]blockEntry, 0, blocks.Len())
+	for _, name := range sets.List(blocks) {
+		out = append(out, blockEntry{Block: name})
+	}
+	return out
+}
+
+// getSwitchPath returns the switch names of each tier from the node labels,
+// or nil if any label is missing. Switch names are qualified by their parent
+// switches, as label values (e.g. rack numbers) are not unique across them.
+func getSwitchPath(tree *slinkyv1beta1.ControllerTopologyTree, nodeLabels map[string]string) []string {
+	out := make([]string, 0, len(tree.SwitchLabels))
+	for i, label := range tree.SwitchLabels {
+		value := nodeLabels[label]
+		if value == "" {
+			return nil
+		}
+		name := sanitizeName(value)
+		if i > 0 {
+			name = out[i-1] + "_" + name
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+// getBlock returns the block name from the node labels, or empty if missing.
+func getBlock(block *slinkyv1beta1.ControllerTopologyBlock, nodeLabels map[string]string) string {
+	value := nodeLabels[block.BlockLabel]
+	if value == "" {
+		return ""
+	}
+	return sanitizeName(value)
+}
+
+// sanitizeName replaces the characters not allowed in Slurm switch and block names.
+func sanitizeName(name string) string {
+	return invalidNameChars.ReplaceAllString(name, "_")
+}
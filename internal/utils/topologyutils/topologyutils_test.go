@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package topologyutils
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+)
+
+const (
+	rackLabel   = "example.com/rack"
+	nvlinkLabel = "example.com/nvlink-domain"
+)
+
+var testTopology = slinkyv1beta1.ControllerTopology{
+	Topologies: []slinkyv1beta1.ControllerTopologySpec{
+		{
+			Name:           "topo-switch",
+			ClusterDefault: true,
+			Tree: &slinkyv1beta1.ControllerTopologyTree{
+				SwitchLabels: []string{corev1.LabelTopologyZone, rackLabel},
+			},
+		},
+		{
+			Name: "topo-block",
+			Block: &slinkyv1beta1.ControllerTopologyBlock{
+				BlockLabel: nvlinkLabel,
+				BlockSizes: []int32{2, 4},
+			},
+		},
+	},
+}
+
+func newNode(name string, nodeLabels, annotations map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      nodeLabels,
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestBuildNodeTopologySpec(t *testing.T) {
+	type args struct {
+		topology   slinkyv1beta1.ControllerTopology
+		nodeLabels map[string]string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "empty",
+			args: args{
+				topology: slinkyv1beta1.ControllerTopology{},
+				nodeLabels: map[string]string{
+					corev1.LabelTopologyZone: "zone-a",
+				},
+			},
+			want: "",
+		},
+		{
+			name: "tree and block",
+			args: args{
+				topology: testTopology,
+				nodeLabels: map[string]string{
+					corev1.LabelTopologyZone: "zone-a",
+					rackLabel:                "r1",
+					nvlinkLabel:              "nvl0",
+				},
+			},
+			want: "topo-switch:zone-a_r1,topo-block:nvl0",
+		},
+		{
+			name: "partial tree labels",
+			args: args{
+				topology: testTopology,
+				nodeLabels: map[string]string{
+					corev1.LabelTopologyZone: "zone-a",
+					nvlinkLabel:              "nvl0",
+				},
+			},
+			want: "topo-block:nvl0",
+		},
+		{
+			name: "sanitized names",
+			args: args{
+				topology: testTopology,
+				nodeLabels: map[string]string{
+					corev1.LabelTopologyZone: "zone:a",
+					rackLabel:                "r 1",
+				},
+			},
+			want: "topo-switch:zone_a_r_1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildNodeTopologySpec(tt.args.topology, tt.args.nodeLabels); got != tt.want {
+				t.Errorf("BuildNodeTopologySpec() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNodeTopologySpec(t *testing.T) {
+	nodeLabels := map[string]string{
+		corev1.LabelTopologyZone: "zone-a",
+		rackLabel:                "r1",
+	}
+	type args struct {
+		topology slinkyv1beta1.ControllerTopology
+		node     corev1.Node
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "labels",
+			args: args{
+				topology: testTopology,
+				node:     newNode("node0", nodeLabels, nil),
+			},
+			want: "topo-switch:zone-a_r1",
+		},
+		{
+			name: "annotation override",
+			args: args{
+				topology: testTopology,
+				node: newNode("node0", nodeLabels, map[string]string{
+					slinkyv1beta1.AnnotationNodeTopologySpec: "topo-switch:s0",
+				}),
+			},
+			want: "topo-switch:s0",
+		},
+		{
+			name: "empty annotation override",
+			args: args{
+				topology: testTopology,
+				node: newNode("node0", nodeLabels, map[string]string{
+					slinkyv1beta1.AnnotationNodeTopologySpec: "",
+				}),
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetNodeTopologySpec(tt.args.topology, &tt.args.node); got != tt.want {
+				t.Errorf("GetNodeTopologySpec() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildTopologyYaml(t *testing.T) {
+	nodes := []corev1.Node{
+		newNode("node0", map[string]string{
+			corev1.LabelTopologyZone: "zone-a",
+			rackLabel:                "r1",
+			nvlinkLabel:              "nvl0",
+		}, nil),
+		newNode("node1", map[string]string{
+			corev1.LabelTopologyZone: "zone-a",
+			rackLabel:                "r2",
+			nvlinkLabel:              "nvl0",
+		}, nil),
+		newNode("node2", map[string]string{
+			corev1.LabelTopologyZone: "zone-b",
+			rackLabel:                "r1",
+			nvlinkLabel:              "nvl1",
+		}, nil),
+		newNode("node3", nil, nil),
+	}
+	type args struct {
+		topology slinkyv1beta1.ControllerTopology
+		nodes    []corev1.Node
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			args: args{
+				topology: slinkyv1beta1.ControllerTopology{},
+				nodes:    nodes,
+			},
+			want: "",
+		},
+		{
+			name: "no matching nodes",
+			args: args{
+				topology: testTopology,
+				nodes:    nodes[3:],
+			},
+			want: "",
+		},
+		{
+			name: "single top tier switch",
+			args: args{
+				topology: slinkyv1beta1.ControllerTopology{
+					Topologies: testTopology.Topologies[:1],
+				},
+				nodes: nodes[:2],
+			},
+			want: `---
+- cluster_default: true
+  topology: topo-switch
+  tree:
+    switches:
+    - children: zone-a_r1,zone-a_r2
+      switch: zone-a
+    - switch: zone-a_r1
+    - switch: zone-a_r2
+`,
+		},
+		{
+			name: "tree and block",
+			args: args{
+				topology: testTopology,
+				nodes:    nodes,
+			},
+			want: `---
+- cluster_default: true
+  topology: topo-switch
+  tree:
+    switches:
+    - children: zone-a,zone-b
+      switch: topo-switch_root
+    - children: zone-a_r1,zone-a_r2
+      switch: zone-a
+    - children: zone-b_r1
+      switch: zone-b
+    - switch: zone-a_r1
+    - switch: zone-a_r2
+    - switch: zone-b_r1
+- block:
+    block_sizes:
+    - 2
+    - 4
+    blocks:
+    - block: nvl0
+    - block: nvl1
+  cluster_default: false
+  topology: topo-block
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildTopologyYaml(tt.args.topology, tt.args.nodes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BuildTopologyYaml() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("BuildTopologyYaml() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -69,6 +69,10 @@ func (r *AccountingWebhook) validateAccounting(accounting *slinkyv1beta1.Account
 	var warns admission.Warnings
 	var errs []error
 
+	extraConfWarns, extraConfErrs := validateSlurmConf("extraConf", accounting.Spec.ExtraConf, slurmdbdConfRules)
+	warns = append(warns, extraConfWarns...)
+	errs = append(errs, extraConfErrs...)
+
 	// Prevent MitM via CVE-2020-8554
 	if accounting.Spec.Service.ServiceSpecWrapper.ExternalIPs != nil {
 		warns = append(warns, "ExternalIPs may not be set for accounting service")
@@ -52,6 +52,14 @@ var _ = Describe("Accounting Webhook", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(warnings).To(ContainElement("ExternalIPs may not be set for accounting service"))
 		})
+
+		It("Should deny if extraConf overrides an operator-owned key", func() {
+			newAccounting := testutils.NewAccounting("test-accounting", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, corev1.SecretKeySelector{})
+			newAccounting.Spec.ExtraConf = "PurgeJobAfter=12months\nStoragePass=hunter2"
+
+			_, err := accountingWebhook.ValidateCreate(ctx, newAccounting)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Context("When deleting Accounting with Validating Webhook", func() {
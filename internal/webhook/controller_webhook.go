@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/controllerbuilder"
 	"github.com/SlinkyProject/slurm-operator/internal/defaults"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/structutils"
 )
@@ -156,6 +157,12 @@ func (r *ControllerWebhook) validateController(ctx context.Context, controller *
 		}
 	}
 
+	extraConfWarns, extraConfErrs := validateSlurmConf("extraConf", controller.Spec.ExtraConf, slurmConfRules)
+	warns = append(warns, extraConfWarns...)
+	errs = append(errs, extraConfErrs...)
+	schedulingKeys := controllerbuilder.SchedulingConfKeys(controller.Spec.Scheduling)
+	warns = append(warns, validateSlurmConfOverrides("extraConf", controller.Spec.ExtraConf, "scheduling", schedulingKeys)...)
+
 	// Prevent MitM via CVE-2020-8554
 	if controller.Spec.Service.ServiceSpecWrapper.ExternalIPs != nil {
 		warns = append(warns, "ExternalIPs may not be set for controller service")
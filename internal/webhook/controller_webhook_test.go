@@ -122,6 +122,69 @@ var _ = Describe("Controller Webhook", func() {
 			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("Should deny if extraConf overrides an operator-owned key", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := oldController.DeepCopy()
+			newController.Spec.ExtraConf = "MaxJobCount=10000\nslurmctldhost=evil"
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("slurmctldhost is managed by slurm-operator"))
+		})
+
+		It("Should deny if extraConf overrides an operator-owned program", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := oldController.DeepCopy()
+			newController.Spec.ExtraConf = "RebootProgram=/bin/reboot\nResumeProgram=/bin/true"
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("RebootProgram is managed by slurm-operator"))
+			Expect(err.Error()).To(ContainSubstring("ResumeProgram is managed by slurm-operator"))
+		})
+
+		It("Should deny if extraConf sets an operator-owned SlurmctldParameters option", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := oldController.DeepCopy()
+			newController.Spec.ExtraConf = "SlurmctldParameters=enable_stepmgr,idle_on_node_suspend"
+
+			_, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("SlurmctldParameters option idle_on_node_suspend is managed by slurm-operator"))
+
+			newController.Spec.ExtraConf = "SlurmctldParameters=enable_stepmgr"
+			_, err = controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should warn if extraConf overrides a typed scheduling key", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := oldController.DeepCopy()
+			newController.Spec.Scheduling.SchedulerType = "sched/backfill"
+			newController.Spec.Scheduling.SchedulerParameters = []string{"bf_continue"}
+			newController.Spec.ExtraConf = "SchedulerParameters=bf_window=4320\nschedulertype=sched/builtin"
+
+			warns, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(ConsistOf(
+				"extraConf: schedulertype is also set by scheduling, the extraConf one takes precedence (line 2)",
+			))
+		})
+
+		It("Should warn if extraConf has unknown or duplicate keys", func(ctx SpecContext) {
+			oldController := testutils.NewController("cluster", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			newController := oldController.DeepCopy()
+			newController.Spec.ExtraConf = "SchedulerTyp=sched/backfill\nMinJobAge=60\nMinJobAge=120\nNodeName=ext-0 CPUs=4"
+
+			warns, err := controllerWebhook.ValidateUpdate(ctx, oldController, newController)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(ContainElements(
+				"extraConf: SchedulerTyp is unknown to Slurm (line 1)",
+				"extraConf: MinJobAge is set more than once, the last one takes precedence (lines 2 and 3)",
+			))
+			Expect(warns).To(HaveLen(2))
+		})
 	})
 
 	Context("When Deleting a Controller with Validating Webhook", func() {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -20,7 +21,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
-	"github.com/SlinkyProject/slurm-operator/internal/builder/workerbuilder"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/cronutils"
 )
 
 // +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=delete;create;update
@@ -93,6 +95,48 @@ func (r *NodeSetWebhook) validateNodeSet(nodeset *slinkyv1beta1.NodeSet) (admiss
 		}
 	}
 
+	if ms := nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge; ms != nil {
+		if maxSurge, err := intstr.GetScaledValueFromIntOrPercent(ms, 100, true); err != nil {
+			errs = append(errs, fmt.Errorf("invalid maxSurge: %w", err))
+		} else if maxSurge < 0 {
+			errs = append(errs, fmt.Errorf("maxSurge must not be negative, got %s", ms.String()))
+		} else if maxSurge > 0 {
+			if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+				errs = append(errs, errors.New("maxSurge is not supported when scalingMode is DaemonSet"))
+			}
+			if nodeset.Spec.PowerSaving.Enabled {
+				errs = append(errs, errors.New("maxSurge and powerSaving are mutually exclusive"))
+			}
+			if nodeset.Spec.PreRegisterSlurmNodes {
+				errs = append(errs, errors.New("maxSurge and preRegisterSlurmNodes are mutually exclusive"))
+			}
+		}
+	}
+
+	if partition := nodeset.Spec.UpdateStrategy.RollingUpdate.Partition; partition != nil {
+		if *partition < 0 {
+			errs = append(errs, fmt.Errorf("partition must not be negative, got %d", *partition))
+		} else if *partition > 0 {
+			if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+				errs = append(errs, errors.New("partition is not supported when scalingMode is DaemonSet, use canarySelector instead"))
+			}
+			if ms := nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge; ms != nil {
+				if maxSurge, err := intstr.GetScaledValueFromIntOrPercent(ms, 100, true); err == nil && maxSurge > 0 {
+					errs = append(errs, errors.New("partition and maxSurge are mutually exclusive"))
+				}
+			}
+		}
+	}
+
+	if canarySelector := nodeset.Spec.UpdateStrategy.RollingUpdate.CanarySelector; canarySelector != nil {
+		if nodeset.Spec.ScalingMode != slinkyv1beta1.ScalingModeDaemonset {
+			errs = append(errs, errors.New("canarySelector is only supported when scalingMode is DaemonSet, use partition instead"))
+		}
+		if _, err := metav1.LabelSelectorAsSelector(canarySelector); err != nil {
+			errs = append(errs, fmt.Errorf("invalid canarySelector: %w", err))
+		}
+	}
+
 	zeroDuration := metav1.Duration{}
 	if duration := nodeset.Spec.UpdateStrategy.ScheduledUpdate.Duration; duration != zeroDuration {
 		if duration.Duration < time.Minute {
@@ -100,6 +144,18 @@ func (r *NodeSetWebhook) validateNodeSet(nodeset *slinkyv1beta1.NodeSet) (admiss
 		}
 	}
 
+	if schedule := nodeset.Spec.UpdateStrategy.ScheduledUpdate.Schedule; schedule != "" {
+		if _, err := cronutils.Parse(schedule); err != nil {
+			errs = append(errs, fmt.Errorf("invalid UpdateStrategy.ScheduledUpdate.Schedule: %w", err))
+		}
+		for _, flag := range nodeset.Spec.UpdateStrategy.ScheduledUpdate.Flags {
+			switch strings.ToUpper(flag) {
+			case "HOURLY", "DAILY", "WEEKLY", "WEEKDAY", "WEEKEND":
+				errs = append(errs, fmt.Errorf("UpdateStrategy.ScheduledUpdate.Flags must not recur (%s) when a schedule is set", flag))
+			}
+		}
+	}
+
 	if nodeset.Spec.Ssh.Enabled && nodeset.Spec.Ssh.SssdConfRef.Name == "" {
 		errs = append(errs, errors.New("ssh.sssdConfRef.name must not be empty when ssh is enabled"))
 	}
@@ -111,8 +167,54 @@ func (r *NodeSetWebhook) validateNodeSet(nodeset *slinkyv1beta1.NodeSet) (admiss
 		}
 	}
 
-	if _, err := workerbuilder.ParseExtraConf(nodeset.Spec.ExtraConf); err != nil {
+	if _, err := common.ParseExtraConf(nodeset.Spec.ExtraConf); err != nil {
 		errs = append(errs, fmt.Errorf("invalid extraConf: %w", err))
+	} else {
+		extraConfWarns, extraConfErrs := validateSlurmConf("extraConf", nodeset.Spec.ExtraConf, nodeConfRules)
+		warns = append(warns, extraConfWarns...)
+		errs = append(errs, extraConfErrs...)
+	}
+
+	partitionWarns, partitionErrs := validateSlurmConf("partition.config", nodeset.Spec.Partition.Config, partitionConfRules)
+	warns = append(warns, partitionWarns...)
+	errs = append(errs, partitionErrs...)
+
+	if autoscaling := nodeset.Spec.Autoscaling; autoscaling.Enabled {
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			errs = append(errs, errors.New("autoscaling is not supported when scalingMode is DaemonSet"))
+		}
+		if autoscaling.MaxReplicas < 1 {
+			errs = append(errs, fmt.Errorf("autoscaling.maxReplicas must be > 0, got %d", autoscaling.MaxReplicas))
+		}
+		if autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			errs = append(errs, fmt.Errorf("autoscaling.minReplicas (%d) must not be greater than autoscaling.maxReplicas (%d)",
+				autoscaling.MinReplicas, autoscaling.MaxReplicas))
+		}
+		if len(autoscaling.Partitions) == 0 && !nodeset.Spec.Partition.Enabled {
+			errs = append(errs, errors.New("autoscaling.partitions must not be empty when partition is not enabled"))
+		}
+	}
+
+	if powerSaving := nodeset.Spec.PowerSaving; powerSaving.Enabled {
+		if nodeset.Spec.ScalingMode == slinkyv1beta1.ScalingModeDaemonset {
+			errs = append(errs, errors.New("powerSaving is not supported when scalingMode is DaemonSet"))
+		}
+		if nodeset.Spec.Autoscaling.Enabled {
+			errs = append(errs, errors.New("powerSaving and autoscaling are mutually exclusive"))
+		}
+		if !nodeset.Spec.Partition.Enabled {
+			errs = append(errs, errors.New("powerSaving requires partition to be enabled"))
+		}
+		if nodeset.Spec.PreRegisterSlurmNodes {
+			errs = append(errs, errors.New("powerSaving and preRegisterSlurmNodes are mutually exclusive"))
+		}
+		if powerSaving.SuspendTime.Duration < 0 {
+			errs = append(errs, fmt.Errorf("powerSaving.suspendTime must not be negative, got %s", powerSaving.SuspendTime.Duration))
+		}
+	}
+
+	if maxDrainDuration := nodeset.Spec.DrainPolicy.MaxDrainDuration.Duration; maxDrainDuration < 0 {
+		errs = append(errs, fmt.Errorf("drainPolicy.maxDrainDuration must not be negative, got %s", maxDrainDuration))
 	}
 
 	return warns, errs
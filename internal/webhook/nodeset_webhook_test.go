@@ -4,6 +4,8 @@
 package webhook
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -11,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/testutils"
 )
 
@@ -93,6 +96,307 @@ var _ = Describe("NodeSet Webhook", func() {
 			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("Should deny if extraConf overrides an operator-owned key", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ExtraConf = "Weight=5 NodeName=foo"
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if partition config overrides an operator-owned key", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.Partition.Config = "MaxTime=UNLIMITED Nodes=ALL"
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny if partition config spans multiple lines", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.Partition.Config = "MaxTime=UNLIMITED\nSlurmUser=root"
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should warn if partition config has unknown keys", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.Partition.Config = "MaxTime=UNLIMITED MaxTme=1:00:00"
+
+			warns, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(ContainElement("partition.config: MaxTme is unknown to Slurm (line 1)"))
+		})
+
+		It("Should deny autoscaling if minReplicas is greater than maxReplicas", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MinReplicas = 4
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny autoscaling without partitions", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = false
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny autoscaling in DaemonSet mode", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+			nodeset.Spec.Autoscaling.Partitions = []string{"all"}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit valid autoscaling", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MinReplicas = 1
+			nodeset.Spec.Autoscaling.MaxReplicas = 8
+			nodeset.Spec.Autoscaling.Partitions = []string{"all"}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny powerSaving with autoscaling", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSaving.Enabled = true
+			nodeset.Spec.Autoscaling.Enabled = true
+			nodeset.Spec.Autoscaling.MaxReplicas = 2
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny powerSaving without partition", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = false
+			nodeset.Spec.PowerSaving.Enabled = true
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny powerSaving in DaemonSet mode", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSaving.Enabled = true
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny powerSaving with preRegisterSlurmNodes", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSaving.Enabled = true
+			nodeset.Spec.PreRegisterSlurmNodes = true
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit valid powerSaving", func(ctx SpecContext) {
+			controller := testutils.NewController("some-controller", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.Partition.Enabled = true
+			nodeset.Spec.PowerSaving.Enabled = true
+			nodeset.Spec.PowerSaving.SuspendTime = metav1.Duration{Duration: 5 * time.Minute}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject a negative maxSurge", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(-1))
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should reject maxSurge in DaemonSet mode", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromString("25%"))
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should reject maxSurge with preRegisterSlurmNodes", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.PreRegisterSlurmNodes = true
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(1))
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit maxSurge", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromString("25%"))
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject partition with maxSurge", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 4)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](2)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(1))
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should reject partition in DaemonSet mode", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](2)
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should reject canarySelector in StatefulSet mode", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.CanarySelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"canary": "true"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should reject an invalid canarySelector", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.UpdateStrategy.RollingUpdate.CanarySelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "canary", Operator: "Bogus"},
+				},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a paused, partitioned rollout", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 4)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.Partition = ptr.To[int32](2)
+			nodeset.Spec.UpdateStrategy.RollingUpdate.Paused = true
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should admit a canarySelector in DaemonSet mode", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.ScalingMode = slinkyv1beta1.ScalingModeDaemonset
+			nodeset.Spec.UpdateStrategy.RollingUpdate.CanarySelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"canary": "true"},
+			}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject an invalid scheduledUpdate schedule", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.ScheduledUpdateNodeSetStrategyType
+			nodeset.Spec.UpdateStrategy.ScheduledUpdate.Schedule = "0 2 * * SUN#6"
+			nodeset.Spec.UpdateStrategy.ScheduledUpdate.Duration = metav1.Duration{Duration: 6 * time.Hour}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should reject a scheduledUpdate schedule with recurring flags", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.ScheduledUpdateNodeSetStrategyType
+			nodeset.Spec.UpdateStrategy.ScheduledUpdate.Schedule = "0 2 * * SUN#1"
+			nodeset.Spec.UpdateStrategy.ScheduledUpdate.Duration = metav1.Duration{Duration: 6 * time.Hour}
+			nodeset.Spec.UpdateStrategy.ScheduledUpdate.Flags = []string{"WEEKLY"}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a scheduledUpdate schedule", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.UpdateStrategy.Type = slinkyv1beta1.ScheduledUpdateNodeSetStrategyType
+			nodeset.Spec.UpdateStrategy.ScheduledUpdate.Schedule = "CRON_TZ=Europe/Berlin 0 2 * * SUN#1"
+			nodeset.Spec.UpdateStrategy.ScheduledUpdate.Duration = metav1.Duration{Duration: 6 * time.Hour}
+			nodeset.Spec.UpdateStrategy.ScheduledUpdate.Flags = []string{"IGNORE_JOBS"}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject a negative drainPolicy maxDrainDuration", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.DrainPolicy.MaxDrainDuration = metav1.Duration{Duration: -time.Minute}
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should admit a drainPolicy", func(ctx SpecContext) {
+			controller := testutils.NewController("slurm", corev1.SecretKeySelector{}, corev1.SecretKeySelector{}, nil)
+			nodeset := testutils.NewNodeset("test-nodeset", controller, 1)
+			nodeset.Spec.DrainPolicy.MaxDrainDuration = metav1.Duration{Duration: time.Hour}
+			nodeset.Spec.DrainPolicy.EscalationAction = slinkyv1beta1.DrainEscalationActionRequeue
+
+			_, err := nodeSetWebhook.ValidateCreate(ctx, nodeset)
+			Expect(err).NotTo(HaveOccurred())
+		})
 	})
 
 	Context("When Updating a NodeSet with Validating Webhook", func() {
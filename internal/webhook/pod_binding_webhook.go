@@ -9,6 +9,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -18,7 +19,9 @@ import (
 
 	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
 	"github.com/SlinkyProject/slurm-operator/internal/builder/labels"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/workerbuilder"
 	"github.com/SlinkyProject/slurm-operator/internal/utils/objectutils"
+	"github.com/SlinkyProject/slurm-operator/internal/utils/topologyutils"
 )
 
 type PodBindingWebhook struct {
@@ -36,6 +39,8 @@ func (r *PodBindingWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;update;patch;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=nodesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=controllers,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods/binding,verbs=get;list;watch
 // +kubebuilder:webhook:path=/mutate--v1-binding,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=NoneOnDryRun,groups="",resources=pods/binding,verbs=create,versions=v1,name=podsbinding-v1.kb.io,admissionReviewVersions=v1
 
@@ -76,9 +81,24 @@ func (r *PodBindingWebhook) Default(ctx context.Context, binding *corev1.Binding
 		return err
 	}
 
-	topologySpec := node.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec]
+	nodeset, err := r.getNodeSet(ctx, pod)
+	if err != nil {
+		return err
+	}
+	cpuSpec := getCpuSpec(nodeset, pod, node)
+	topologySpec, err := r.getTopologySpec(ctx, nodeset, node)
+	if err != nil {
+		return err
+	}
+
 	mutateFn := func(pod *corev1.Pod) error {
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
 		pod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec] = topologySpec
+		if cpuSpec != "" {
+			pod.Annotations[slinkyv1beta1.AnnotationPodCpuSpec] = cpuSpec
+		}
 		return nil
 	}
 	if err := objectutils.PatchObject(r.Client, ctx, pod, mutateFn); err != nil {
@@ -93,3 +113,48 @@ func (r *PodBindingWebhook) Default(ctx context.Context, binding *corev1.Binding
 
 	return nil
 }
+
+// getNodeSet returns the NodeSet of the pod, or nil if not found.
+func (r *PodBindingWebhook) getNodeSet(ctx context.Context, pod *corev1.Pod) (*slinkyv1beta1.NodeSet, error) {
+	controllerRef := metav1.GetControllerOf(pod)
+	if controllerRef == nil || controllerRef.Kind != slinkyv1beta1.NodeSetKind {
+		return nil, nil
+	}
+	nodeset := &slinkyv1beta1.NodeSet{}
+	nodesetKey := types.NamespacedName{Namespace: pod.Namespace, Name: controllerRef.Name}
+	if err := r.Get(ctx, nodesetKey, nodeset); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return nodeset, nil
+}
+
+// getCpuSpec returns the Slurm node CPU spec of the NodeSet pod on the node,
+// or empty if its NodeSet does not derive the CPU topology.
+func getCpuSpec(nodeset *slinkyv1beta1.NodeSet, pod *corev1.Pod, node *corev1.Node) string {
+	if nodeset == nil || !nodeset.Spec.CpuTopology.Enabled {
+		return ""
+	}
+	topology := workerbuilder.GetNodeCpuTopology(nodeset, pod, node)
+	return workerbuilder.FormatNodeCpuTopology(topology)
+}
+
+// getTopologySpec returns the Slurm dynamic topology line of the node, either
+// from its annotation or generated from its labels by the Controller topology.
+func (r *PodBindingWebhook) getTopologySpec(ctx context.Context, nodeset *slinkyv1beta1.NodeSet, node *corev1.Node) (string, error) {
+	var topology slinkyv1beta1.ControllerTopology
+	if nodeset != nil {
+		controller := &slinkyv1beta1.Controller{}
+		controllerKey := types.NamespacedName{Namespace: nodeset.Namespace, Name: nodeset.Spec.ControllerRef.Name}
+		if err := r.Get(ctx, controllerKey, controller); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return "", err
+			}
+		} else {
+			topology = controller.Spec.Topology
+		}
+	}
+	return topologyutils.GetNodeTopologySpec(topology, node), nil
+}
@@ -11,7 +11,11 @@ import (
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -61,6 +65,119 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 		},
 	}
 
+	nodesetWithCpuTopology := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			OversubscribeNode: true,
+			CpuTopology: slinkyv1beta1.NodeSetCpuTopology{
+				Enabled: true,
+			},
+		},
+	}
+
+	nodesetPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-1",
+			Namespace: corev1.NamespaceDefault,
+			Labels: map[string]string{
+				labels.AppLabel: labels.WorkerApp,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(nodesetWithCpuTopology, slinkyv1beta1.NodeSetGVK),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: labels.WorkerApp,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("12"),
+							corev1.ResourceMemory: resource.MustParse("60Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	nodeWithCpuTopology := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-3",
+			Labels: map[string]string{
+				slinkyv1beta1.LabelNodeCpuSockets:        "2",
+				slinkyv1beta1.LabelNodeCpuCoresPerSocket: "4",
+				slinkyv1beta1.LabelNodeCpuThreadsPerCore: "2",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("15900m"),
+				corev1.ResourceMemory: resource.MustParse("62Gi"),
+			},
+		},
+	}
+
+	topologyController := &slinkyv1beta1.Controller{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "slurm",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.ControllerSpec{
+			Topology: slinkyv1beta1.ControllerTopology{
+				Topologies: []slinkyv1beta1.ControllerTopologySpec{
+					{
+						Name: "topo-switch",
+						Tree: &slinkyv1beta1.ControllerTopologyTree{
+							SwitchLabels: []string{corev1.LabelTopologyZone},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	nodesetWithTopology := &slinkyv1beta1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-topology",
+			Namespace: corev1.NamespaceDefault,
+		},
+		Spec: slinkyv1beta1.NodeSetSpec{
+			ControllerRef: corev1.LocalObjectReference{
+				Name: topologyController.Name,
+			},
+		},
+	}
+
+	nodesetTopologyPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-topology-0",
+			Namespace: corev1.NamespaceDefault,
+			Labels: map[string]string{
+				labels.AppLabel: labels.WorkerApp,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(nodesetWithTopology, slinkyv1beta1.NodeSetGVK),
+			},
+		},
+	}
+
+	nodeWithTopologyLabels := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-4",
+			Labels: map[string]string{
+				corev1.LabelTopologyZone: "zone-a",
+			},
+		},
+	}
+
+	testScheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(testScheme))
+	utilruntime.Must(slinkyv1beta1.AddToScheme(testScheme))
+
 	type args struct {
 		ctx     context.Context
 		binding *corev1.Binding
@@ -71,6 +188,7 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 		args          args
 		wantErr       bool
 		wantTopology  string
+		wantCpuSpec   string
 		checkTopology bool
 	}{
 		{
@@ -98,6 +216,63 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 			wantTopology:  "topo-switch:s2,topo-block:b2",
 			checkTopology: true,
 		},
+		{
+			name: "NodeSet pod gets cpu spec from node",
+			client: fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(nodesetWithCpuTopology.DeepCopy(), nodesetPod.DeepCopy(), nodeWithCpuTopology.DeepCopy()).
+				Build(),
+			args: args{
+				ctx: admission.NewContextWithRequest(
+					context.TODO(),
+					admission.Request{
+						AdmissionRequest: v1.AdmissionRequest{
+							UID:    "test-request",
+							DryRun: ptr.To(false),
+						},
+					},
+				),
+				binding: &corev1.Binding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      nodesetPod.Name,
+						Namespace: nodesetPod.Namespace,
+					},
+					Target: corev1.ObjectReference{Name: nodeWithCpuTopology.Name},
+				},
+			},
+			wantErr:       false,
+			wantTopology:  "",
+			wantCpuSpec:   "CoreSpecCount=1 CoresPerSocket=4 MemSpecLimit=2048 Sockets=2 ThreadsPerCore=2",
+			checkTopology: true,
+		},
+		{
+			name: "NodeSet pod gets topology from node labels",
+			client: fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithObjects(topologyController.DeepCopy(), nodesetWithTopology.DeepCopy(), nodesetTopologyPod.DeepCopy(), nodeWithTopologyLabels.DeepCopy()).
+				Build(),
+			args: args{
+				ctx: admission.NewContextWithRequest(
+					context.TODO(),
+					admission.Request{
+						AdmissionRequest: v1.AdmissionRequest{
+							UID:    "test-request",
+							DryRun: ptr.To(false),
+						},
+					},
+				),
+				binding: &corev1.Binding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      nodesetTopologyPod.Name,
+						Namespace: nodesetTopologyPod.Namespace,
+					},
+					Target: corev1.ObjectReference{Name: nodeWithTopologyLabels.Name},
+				},
+			},
+			wantErr:       false,
+			wantTopology:  "topo-switch:zone-a",
+			checkTopology: true,
+		},
 		{
 			name:   "Worker pod gets empty topology when node has no annotation",
 			client: fake.NewFakeClient(workerPod.DeepCopy(), nodeWithoutTopology.DeepCopy()),
@@ -285,6 +460,7 @@ func TestPodBindingWebhook_Default(t *testing.T) {
 				podKey := client.ObjectKeyFromObject(tt.args.binding)
 				require.NoError(t, tt.client.Get(tt.args.ctx, podKey, gotPod))
 				require.Equal(t, tt.wantTopology, gotPod.Annotations[slinkyv1beta1.AnnotationNodeTopologySpec])
+				require.Equal(t, tt.wantCpuSpec, gotPod.Annotations[slinkyv1beta1.AnnotationPodCpuSpec])
 			}
 		})
 	}
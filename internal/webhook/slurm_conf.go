@@ -0,0 +1,561 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/SlinkyProject/slurm-operator/internal/utils/config"
+)
+
+// confRules describes which keys may be set in a Slurm config field.
+type confRules struct {
+	// forbidden are the keys owned by slurm-operator, which cannot be overridden.
+	forbidden set.Set[string]
+	// ownedOptions are the options of list keys owned by slurm-operator, which
+	// cannot be set. The other options are merged with those of slurm-operator.
+	ownedOptions map[string]set.Set[string]
+	// known are the keys known to Slurm, others are warned about.
+	known set.Set[string]
+	// singleLine is true if the field is rendered into a single config line.
+	singleLine bool
+}
+
+// newKeySet returns the set of the keys, lowercased as keys are case-insensitive.
+func newKeySet(keys ...string) set.Set[string] {
+	s := set.New[string]()
+	for _, key := range keys {
+		s.Insert(strings.ToLower(key))
+	}
+	return s
+}
+
+// validateSlurmConf validates the Slurm config of the field against the rules.
+// Forbidden keys are rejected, while unknown and duplicate keys are warned about.
+// The attributes of multi-value lines (e.g. NodeName) are not validated.
+func validateSlurmConf(field, data string, rules confRules) (admission.Warnings, []error) {
+	var warns admission.Warnings
+	var errs []error
+
+	lines, err := config.Parse(data)
+	if err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %w", field, err)}
+	}
+	if rules.singleLine && len(lines) > 1 {
+		errs = append(errs, fmt.Errorf("invalid %s: must be a single line", field))
+	}
+
+	seen := make(map[string]int)
+	for _, line := range lines {
+		params := line.Params
+		if line.IsMultiValue() {
+			params = params[:1]
+		}
+		for _, param := range params {
+			key := strings.ToLower(param.Key)
+			switch {
+			case rules.forbidden.Has(key):
+				errs = append(errs, fmt.Errorf("%s: %s is managed by slurm-operator and cannot be set (line %d)",
+					field, param.Key, line.Number))
+				continue
+			case rules.ownedOptions[key] != nil:
+				for option := range strings.SplitSeq(param.Value, ",") {
+					optionKey, _, _ := strings.Cut(option, "=")
+					if rules.ownedOptions[key].Has(strings.ToLower(strings.TrimSpace(optionKey))) {
+						errs = append(errs, fmt.Errorf("%s: %s option %s is managed by slurm-operator and cannot be set (line %d)",
+							field, param.Key, optionKey, line.Number))
+					}
+				}
+			case !rules.known.Has(key):
+				warns = append(warns, fmt.Sprintf("%s: %s is unknown to Slurm (line %d)",
+					field, param.Key, line.Number))
+			}
+			if line.IsMultiValue() || line.IsInclude() {
+				continue
+			}
+			if prev, ok := seen[key]; ok {
+				warns = append(warns, fmt.Sprintf("%s: %s is set more than once, the last one takes precedence (lines %d and %d)",
+					field, param.Key, prev, line.Number))
+			}
+			seen[key] = line.Number
+		}
+	}
+
+	return warns, errs
+}
+
+// validateSlurmConfOverrides warns about the keys of the field which override
+// the keys set by a typed field, as the field is rendered after it.
+func validateSlurmConfOverrides(field, data, typedField string, typedKeys []string) admission.Warnings {
+	var warns admission.Warnings
+
+	lines, err := config.Parse(data)
+	if err != nil {
+		// Reported by validateSlurmConf.
+		return nil
+	}
+
+	keys := newKeySet(typedKeys...)
+	for _, line := range lines {
+		if line.IsMultiValue() || line.IsInclude() {
+			continue
+		}
+		for _, param := range line.Params {
+			if keys.Has(strings.ToLower(param.Key)) {
+				warns = append(warns, fmt.Sprintf("%s: %s is also set by %s, the %s one takes precedence (line %d)",
+					field, param.Key, typedField, field, line.Number))
+			}
+		}
+	}
+
+	return warns
+}
+
+// slurmConfRules are the rules of the Controller ExtraConf, appended onto
+// `slurm.conf`.
+//
+// Ref: https://slurm.schedmd.com/slurm.conf.html
+var slurmConfRules = confRules{
+	forbidden: newKeySet(
+		"AuthAltTypes",
+		"AuthType",
+		"BackupAddr",
+		"BackupController",
+		"ClusterName",
+		"ControlAddr",
+		"ControlMachine",
+		"CredType",
+		"RebootProgram",
+		"ResumeProgram",
+		"ResumeTimeout",
+		"SlurmctldAddr",
+		"SlurmctldHost",
+		"SlurmctldPort",
+		"SlurmdPort",
+		"SlurmdSpoolDir",
+		"SlurmdUser",
+		"SlurmUser",
+		"StateSaveLocation",
+		"SuspendProgram",
+		"SuspendTime",
+		"SuspendTimeout",
+	),
+	ownedOptions: map[string]set.Set[string]{
+		"slurmctldparameters": newKeySet(
+			"cloud_reg_addrs",
+			"enable_configless",
+			"idle_on_node_suspend",
+			"reconfig_on_restart",
+		),
+	},
+	known: newKeySet(
+		config.IncludeKey,
+		"AccountingStorageBackupHost",
+		"AccountingStorageEnforce",
+		"AccountingStorageExternalHost",
+		"AccountingStorageHost",
+		"AccountingStorageParameters",
+		"AccountingStoragePass",
+		"AccountingStoragePort",
+		"AccountingStorageTRES",
+		"AccountingStorageType",
+		"AccountingStoreFlags",
+		"AcctGatherEnergyType",
+		"AcctGatherFilesystemType",
+		"AcctGatherInterconnectType",
+		"AcctGatherNodeFreq",
+		"AcctGatherProfileType",
+		"AllowSpecResourcesUsage",
+		"AuthAltParameters",
+		"AuthAltTypes",
+		"AuthInfo",
+		"AuthType",
+		"BatchStartTimeout",
+		"BcastExclude",
+		"BcastParameters",
+		"BurstBufferType",
+		"CertgenParameters",
+		"CertgenType",
+		"CertmgrParameters",
+		"CertmgrType",
+		"CliFilterParameters",
+		"CliFilterPlugins",
+		"ClusterName",
+		"CommunicationParameters",
+		"CompleteWait",
+		"CpuFreqDef",
+		"CpuFreqGovernors",
+		"CredType",
+		"DataParserParameters",
+		"DebugFlags",
+		"DefCpuPerGPU",
+		"DefMemPerCPU",
+		"DefMemPerGPU",
+		"DefMemPerNode",
+		"DependencyParameters",
+		"DisableRootJobs",
+		"EioTimeout",
+		"EnforcePartLimits",
+		"Epilog",
+		"EpilogMsgTime",
+		"EpilogSlurmctld",
+		"EpilogTimeout",
+		"FairShareDampeningFactor",
+		"FederationParameters",
+		"FirstJobId",
+		"GetEnvTimeout",
+		"GresTypes",
+		"GroupUpdateForce",
+		"GroupUpdateTime",
+		"GpuFreqDef",
+		"HashPlugin",
+		"HealthCheckInterval",
+		"HealthCheckNodeState",
+		"HealthCheckProgram",
+		"HttpParserType",
+		"InactiveLimit",
+		"InteractiveStepOptions",
+		"JobAcctGatherFrequency",
+		"JobAcctGatherParams",
+		"JobAcctGatherType",
+		"JobCompHost",
+		"JobCompLoc",
+		"JobCompParams",
+		"JobCompPass",
+		"JobCompPort",
+		"JobCompType",
+		"JobCompUser",
+		"JobContainerType",
+		"JobDefaults",
+		"JobFileAppend",
+		"JobRequeue",
+		"JobSubmitPlugins",
+		"KeepAliveTime",
+		"KillOnBadExit",
+		"KillWait",
+		"LaunchParameters",
+		"Licenses",
+		"LogTimeFormat",
+		"MailDomain",
+		"MailProg",
+		"MaxArraySize",
+		"MaxBatchRequeue",
+		"MaxDBDMsgs",
+		"MaxJobCount",
+		"MaxJobId",
+		"MaxMemPerCPU",
+		"MaxMemPerNode",
+		"MaxNodeCount",
+		"MaxStepCount",
+		"MaxTasksPerNode",
+		"MCSParameters",
+		"MCSPlugin",
+		"MessageTimeout",
+		"MetricsType",
+		"MinJobAge",
+		"MpiDefault",
+		"MpiParams",
+		"NamespaceType",
+		"NodeFeaturesPlugins",
+		"OverTimeLimit",
+		"PluginDir",
+		"PlugStackConfig",
+		"PowerParameters",
+		"PowerPlugin",
+		"PreemptExemptTime",
+		"PreemptMode",
+		"PreemptParameters",
+		"PreemptType",
+		"PrEpParameters",
+		"PrEpPlugins",
+		"PriorityCalcPeriod",
+		"PriorityDecayHalfLife",
+		"PriorityFavorSmall",
+		"PriorityFlags",
+		"PriorityMaxAge",
+		"PriorityParameters",
+		"PrioritySiteFactorParameters",
+		"PrioritySiteFactorPlugin",
+		"PriorityType",
+		"PriorityUsageResetPeriod",
+		"PriorityWeightAge",
+		"PriorityWeightAssoc",
+		"PriorityWeightFairshare",
+		"PriorityWeightJobSize",
+		"PriorityWeightPartition",
+		"PriorityWeightQOS",
+		"PriorityWeightTRES",
+		"PrivateData",
+		"ProctrackType",
+		"Prolog",
+		"PrologEpilogTimeout",
+		"PrologFlags",
+		"PrologSlurmctld",
+		"PrologTimeout",
+		"PropagatePrioProcess",
+		"PropagateResourceLimits",
+		"PropagateResourceLimitsExcept",
+		"RebootProgram",
+		"ReconfigFlags",
+		"RequeueExit",
+		"RequeueExitHold",
+		"ResumeFailProgram",
+		"ResumeProgram",
+		"ResumeRate",
+		"ResumeTimeout",
+		"ResvEpilog",
+		"ResvOverRun",
+		"ResvProlog",
+		"ReturnToService",
+		"SchedulerParameters",
+		"SchedulerTimeSlice",
+		"SchedulerType",
+		"ScronParameters",
+		"SelectType",
+		"SelectTypeParameters",
+		"SlurmctldAddr",
+		"SlurmctldDebug",
+		"SlurmctldHost",
+		"SlurmctldLogFile",
+		"SlurmctldParameters",
+		"SlurmctldPidFile",
+		"SlurmctldPort",
+		"SlurmctldPrimaryOffProg",
+		"SlurmctldPrimaryOnProg",
+		"SlurmctldSyslogDebug",
+		"SlurmctldTimeout",
+		"SlurmdDebug",
+		"SlurmdLogFile",
+		"SlurmdParameters",
+		"SlurmdPidFile",
+		"SlurmdPort",
+		"SlurmdSpoolDir",
+		"SlurmdSyslogDebug",
+		"SlurmdTimeout",
+		"SlurmdUser",
+		"SlurmSchedLogFile",
+		"SlurmSchedLogLevel",
+		"SlurmUser",
+		"SrunEpilog",
+		"SrunPortRange",
+		"SrunProlog",
+		"StateSaveLocation",
+		"SuspendExcNodes",
+		"SuspendExcParts",
+		"SuspendExcStates",
+		"SuspendProgram",
+		"SuspendRate",
+		"SuspendTime",
+		"SuspendTimeout",
+		"SwitchParameters",
+		"SwitchType",
+		"TaskEpilog",
+		"TaskPlugin",
+		"TaskPluginParam",
+		"TaskProlog",
+		"TCPTimeout",
+		"TLSParameters",
+		"TLSType",
+		"TmpFS",
+		"TopologyParam",
+		"TopologyPlugin",
+		"TrackWCKey",
+		"TreeWidth",
+		"UnkillableStepProgram",
+		"UnkillableStepTimeout",
+		"UrlParserType",
+		"UsePAM",
+		"VSizeFactor",
+		"WaitTime",
+		"X11Parameters",
+		"BlockName",
+		"DownNodes",
+		"FrontendName",
+		"NodeName",
+		"NodeSet",
+		"PartitionName",
+		"SwitchName",
+	),
+}
+
+// slurmdbdConfRules are the rules of the Accounting ExtraConf, appended onto
+// `slurmdbd.conf`.
+//
+// Ref: https://slurm.schedmd.com/slurmdbd.conf.html
+var slurmdbdConfRules = confRules{
+	forbidden: newKeySet(
+		"AuthAltTypes",
+		"AuthType",
+		"DbdAddr",
+		"DbdHost",
+		"DbdPort",
+		"SlurmUser",
+		"StorageHost",
+		"StorageLoc",
+		"StoragePass",
+		"StoragePort",
+		"StorageType",
+		"StorageUser",
+	),
+	known: newKeySet(
+		config.IncludeKey,
+		"AllowNoDefAcct",
+		"AllResourcesAbsolute",
+		"ArchiveDir",
+		"ArchiveEvents",
+		"ArchiveJobs",
+		"ArchiveResvs",
+		"ArchiveScript",
+		"ArchiveSteps",
+		"ArchiveSuspend",
+		"ArchiveTXN",
+		"ArchiveUsage",
+		"AuthAltParameters",
+		"AuthAltTypes",
+		"AuthInfo",
+		"AuthType",
+		"CommitDelay",
+		"CommunicationParameters",
+		"DbdAddr",
+		"DbdBackupHost",
+		"DbdHost",
+		"DbdPort",
+		"DebugFlags",
+		"DebugLevel",
+		"DebugLevelSyslog",
+		"DefaultQOS",
+		"DisableCoordDBD",
+		"HashPlugin",
+		"LogFile",
+		"LogTimeFormat",
+		"MaxQueryTimeRange",
+		"MessageTimeout",
+		"Parameters",
+		"PidFile",
+		"PluginDir",
+		"PrivateData",
+		"PurgeEventAfter",
+		"PurgeJobAfter",
+		"PurgeResvAfter",
+		"PurgeStepAfter",
+		"PurgeSuspendAfter",
+		"PurgeTXNAfter",
+		"PurgeUsageAfter",
+		"SlurmUser",
+		"StorageBackupHost",
+		"StorageHost",
+		"StorageLoc",
+		"StorageParameters",
+		"StoragePass",
+		"StoragePassScript",
+		"StoragePort",
+		"StorageType",
+		"StorageUser",
+		"TCPTimeout",
+		"TLSParameters",
+		"TLSType",
+		"TrackSlurmctldDown",
+		"TrackWCKey",
+	),
+}
+
+// nodeConfRules are the rules of the NodeSet ExtraConf, passed to slurmd as
+// `--conf` and registered as the Slurm node configuration.
+//
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_NODE-CONFIGURATION
+var nodeConfRules = confRules{
+	forbidden: newKeySet(
+		"NodeAddr",
+		"NodeHostname",
+		"NodeName",
+		"Port",
+	),
+	known: newKeySet(
+		"Boards",
+		"Comment",
+		"CoreSpecCount",
+		"CoresPerSocket",
+		"CpuBind",
+		"CPUs",
+		"CPUSpecList",
+		"Extra",
+		"Feature",
+		"Features",
+		"Gres",
+		"MemSpecLimit",
+		"Procs",
+		"RealMemory",
+		"Reason",
+		"RestrictedCoresPerGPU",
+		"Sockets",
+		"SocketsPerBoard",
+		"State",
+		"ThreadsPerCore",
+		"TmpDisk",
+		"Topology",
+		"Weight",
+	),
+	singleLine: true,
+}
+
+// partitionConfRules are the rules of the NodeSet partition Config, added to
+// the partition line in `slurm.conf`.
+//
+// Ref: https://slurm.schedmd.com/slurm.conf.html#SECTION_PARTITION-CONFIGURATION
+var partitionConfRules = confRules{
+	forbidden: newKeySet(
+		"Nodes",
+		"PartitionName",
+	),
+	known: newKeySet(
+		"AllocNodes",
+		"AllowAccounts",
+		"AllowGroups",
+		"AllowQos",
+		"Alternate",
+		"CpuBind",
+		"Default",
+		"DefaultTime",
+		"DefCpuPerGPU",
+		"DefMemPerCPU",
+		"DefMemPerGPU",
+		"DefMemPerNode",
+		"DenyAccounts",
+		"DenyQos",
+		"DisableRootJobs",
+		"ExclusiveTopo",
+		"ExclusiveUser",
+		"GraceTime",
+		"Hidden",
+		"LLN",
+		"MaxCPUsPerNode",
+		"MaxCPUsPerSocket",
+		"MaxMemPerCPU",
+		"MaxMemPerNode",
+		"MaxNodes",
+		"MaxTime",
+		"MinNodes",
+		"OverSubscribe",
+		"OverTimeLimit",
+		"PowerDownOnIdle",
+		"PreemptMode",
+		"PriorityJobFactor",
+		"PriorityTier",
+		"QOS",
+		"ReqResv",
+		"ResumeTimeout",
+		"RootOnly",
+		"SelectTypeParameters",
+		"State",
+		"SuspendTime",
+		"SuspendTimeout",
+		"Topology",
+		"TRESBillingWeights",
+	),
+	singleLine: true,
+}
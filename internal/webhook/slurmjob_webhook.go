@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/set"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+)
+
+// +kubebuilder:rbac:groups=slinky.slurm.net,resources=slurmjobs,verbs=delete;create;update
+
+// AllNamespaces is the namespace of the allowed users of all namespaces.
+const AllNamespaces = "*"
+
+// privilegedUsers are the Slurm users whom no SlurmJob may be submitted as.
+var privilegedUsers = set.New("root", common.SlurmUser)
+
+type SlurmJobWebhook struct {
+	// AllowedUsers maps a namespace to the Slurm users whom its SlurmJobs may
+	// be submitted as. The users of AllNamespaces are allowed in every
+	// namespace. No SlurmJob is admitted if empty.
+	AllowedUsers map[string]set.Set[string]
+}
+
+// log is for logging in this package.
+var slurmjoblog = logf.Log.WithName("slurmjob-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *SlurmJobWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &slinkyv1beta1.SlurmJob{}).
+		WithValidator(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-slinky-slurm-net-v1beta1-slurmjob,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,sideEffects=None,groups=slinky.slurm.net,resources=slurmjobs,verbs=create,versions=v1beta1,name=slurmjob-v1beta1.kb.io,admissionReviewVersions=v1beta1
+
+var _ admission.Validator[*slinkyv1beta1.SlurmJob] = &SlurmJobWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmJobWebhook) ValidateCreate(ctx context.Context, slurmJob *slinkyv1beta1.SlurmJob) (admission.Warnings, error) {
+	slurmjoblog.Info("validate create", "slurmJob", klog.KObj(slurmJob))
+
+	var warns admission.Warnings
+	var errs []error
+
+	if err := r.validateUsername(slurmJob); err != nil {
+		errs = append(errs, err)
+	}
+
+	return warns, utilerrors.NewAggregate(errs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmJobWebhook) ValidateUpdate(ctx context.Context, oldSlurmJob, newSlurmJob *slinkyv1beta1.SlurmJob) (admission.Warnings, error) {
+	slurmjoblog.Info("validate update", "newSlurmJob", klog.KObj(newSlurmJob))
+
+	// The username is immutable, and was validated on create. A change of the
+	// allowed users must not block the finalizer of existing SlurmJobs.
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *SlurmJobWebhook) ValidateDelete(ctx context.Context, slurmJob *slinkyv1beta1.SlurmJob) (admission.Warnings, error) {
+	slurmjoblog.Info("validate delete", "slurmJob", klog.KObj(slurmJob))
+
+	return nil, nil
+}
+
+// validateUsername returns an error unless the SlurmJob may be submitted as
+// its user.
+func (r *SlurmJobWebhook) validateUsername(slurmJob *slinkyv1beta1.SlurmJob) error {
+	username := slurmJob.Spec.Username
+	switch {
+	case username == "":
+		return errors.New("username is required")
+	case privilegedUsers.Has(username):
+		return fmt.Errorf("username %q is a privileged Slurm user", username)
+	case !r.AllowedUsers[slurmJob.Namespace].Has(username) && !r.AllowedUsers[AllNamespaces].Has(username):
+		return fmt.Errorf("username %q is not allowed in namespace %q", username, slurmJob.Namespace)
+	}
+	return nil
+}
+
+// ParseAllowedUsers parses the allowed users of SlurmJobs, given as
+// semicolon-separated `<namespace>=<user>[,<user>...]` entries.
+func ParseAllowedUsers(value string) (map[string]set.Set[string], error) {
+	out := map[string]set.Set[string]{}
+	for entry := range strings.SplitSeq(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		namespace, users, ok := strings.Cut(entry, "=")
+		namespace = strings.TrimSpace(namespace)
+		if !ok || namespace == "" {
+			return nil, fmt.Errorf("invalid allowed users entry %q: expected <namespace>=<user>[,<user>...]", entry)
+		}
+		if out[namespace] == nil {
+			out[namespace] = set.New[string]()
+		}
+		for user := range strings.SplitSeq(users, ",") {
+			user = strings.TrimSpace(user)
+			if user == "" {
+				continue
+			}
+			if privilegedUsers.Has(user) {
+				return nil, fmt.Errorf("invalid allowed users entry %q: %q is a privileged Slurm user", entry, user)
+			}
+			out[namespace].Insert(user)
+		}
+	}
+	return out, nil
+}
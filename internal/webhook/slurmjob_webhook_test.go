@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: Copyright (C) SchedMD LLC.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/set"
+
+	slinkyv1beta1 "github.com/SlinkyProject/slurm-operator/api/v1beta1"
+	"github.com/SlinkyProject/slurm-operator/internal/builder/common"
+)
+
+func newSlurmJob(namespace, username string) *slinkyv1beta1.SlurmJob {
+	return &slinkyv1beta1.SlurmJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "hello",
+		},
+		Spec: slinkyv1beta1.SlurmJobSpec{
+			ControllerRef: corev1.LocalObjectReference{Name: "slurm"},
+			Username:      username,
+			Script: slinkyv1beta1.SlurmJobScript{
+				Inline: ptr.To("#!/bin/sh\nhostname"),
+			},
+		},
+	}
+}
+
+var _ = Describe("SlurmJob Webhook", func() {
+	slurmJobWebhook := SlurmJobWebhook{
+		AllowedUsers: map[string]set.Set[string]{
+			"physics":     set.New("alice"),
+			AllNamespaces: set.New("ci"),
+		},
+	}
+
+	Context("When creating SlurmJob under Validating Webhook", func() {
+		It("Should admit an allowed user of the namespace", func() {
+			_, err := slurmJobWebhook.ValidateCreate(ctx, newSlurmJob("physics", "alice"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should admit an allowed user of all namespaces", func() {
+			_, err := slurmJobWebhook.ValidateCreate(ctx, newSlurmJob("chemistry", "ci"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a user of another namespace", func() {
+			_, err := slurmJobWebhook.ValidateCreate(ctx, newSlurmJob("chemistry", "alice"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny a missing username", func() {
+			_, err := slurmJobWebhook.ValidateCreate(ctx, newSlurmJob("physics", ""))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny privileged users", func() {
+			privileged := SlurmJobWebhook{
+				AllowedUsers: map[string]set.Set[string]{
+					AllNamespaces: set.New("root", common.SlurmUser),
+				},
+			}
+			_, err := privileged.ValidateCreate(ctx, newSlurmJob("physics", "root"))
+			Expect(err).To(HaveOccurred())
+			_, err = privileged.ValidateCreate(ctx, newSlurmJob("physics", common.SlurmUser))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Should deny all users without allowed users", func() {
+			_, err := (&SlurmJobWebhook{}).ValidateCreate(ctx, newSlurmJob("physics", "alice"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When updating SlurmJob under Validating Webhook", func() {
+		It("Should admit a SlurmJob whose user is no longer allowed", func() {
+			slurmJob := newSlurmJob("chemistry", "alice")
+			slurmJob.Finalizers = []string{slinkyv1beta1.FinalizerSlurmJob}
+
+			_, err := slurmJobWebhook.ValidateUpdate(ctx, slurmJob, newSlurmJob("chemistry", "alice"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
+
+func TestParseAllowedUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]set.Set[string]
+		wantErr bool
+	}{
+		{
+			name:  "Empty",
+			value: "",
+			want:  map[string]set.Set[string]{},
+		},
+		{
+			name:  "Namespaces",
+			value: "physics=alice,bob; *=ci",
+			want: map[string]set.Set[string]{
+				"physics":     set.New("alice", "bob"),
+				AllNamespaces: set.New("ci"),
+			},
+		},
+		{
+			name:    "No namespace",
+			value:   "alice",
+			wantErr: true,
+		},
+		{
+			name:    "Privileged user",
+			value:   "physics=alice,root",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAllowedUsers(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -128,6 +128,9 @@ var _ = BeforeSuite(func() {
 	err = (&restapiWebhook).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&SlurmJobWebhook{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&tokenWebhook).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -22,19 +22,52 @@ const (
 	PodConditionUnknown   corev1.PodConditionType = PodStatePrefix + "Unknown"
 
 	// Slurm Flag States
-	PodConditionCompleting    corev1.PodConditionType = PodStatePrefix + "Completing"
-	PodConditionDrain         corev1.PodConditionType = PodStatePrefix + "Drain"
-	PodConditionFail          corev1.PodConditionType = PodStatePrefix + "Fail"
-	PodConditionInvalid       corev1.PodConditionType = PodStatePrefix + "Invalid"
-	PodConditionInvalidReg    corev1.PodConditionType = PodStatePrefix + "InvalidReg"
-	PodConditionMaintenance   corev1.PodConditionType = PodStatePrefix + "Maintenance"
-	PodConditionNotResponding corev1.PodConditionType = PodStatePrefix + "NotResponding"
-	PodConditionUndrain       corev1.PodConditionType = PodStatePrefix + "Undrain"
+	PodConditionCompleting      corev1.PodConditionType = PodStatePrefix + "Completing"
+	PodConditionDrain           corev1.PodConditionType = PodStatePrefix + "Drain"
+	PodConditionFail            corev1.PodConditionType = PodStatePrefix + "Fail"
+	PodConditionInvalid         corev1.PodConditionType = PodStatePrefix + "Invalid"
+	PodConditionInvalidReg      corev1.PodConditionType = PodStatePrefix + "InvalidReg"
+	PodConditionMaintenance     corev1.PodConditionType = PodStatePrefix + "Maintenance"
+	PodConditionNotResponding   corev1.PodConditionType = PodStatePrefix + "NotResponding"
+	PodConditionRebootIssued    corev1.PodConditionType = PodStatePrefix + "RebootIssued"
+	PodConditionRebootRequested corev1.PodConditionType = PodStatePrefix + "RebootRequested"
+	PodConditionUndrain         corev1.PodConditionType = PodStatePrefix + "Undrain"
+
+	// Slurm Job Occupancy
+	PodConditionJobsRunning corev1.PodConditionType = "SlurmJobsRunning"
+)
+
+const (
+	// Node Condition Type
+	NodeConditionSlurmUnhealthy corev1.NodeConditionType = "SlurmNodeUnhealthy"
+)
+
+const (
+	// Controller Condition Type
+	ControllerConditionConfigApplied = "ConfigApplied"
 )
 
 const (
 	// NodeSet Condition Type
 	NodeSetConditionReservationCreated = "ReservationCreated"
+	NodeSetConditionDrainEscalated     = "DrainEscalated"
+)
+
+const (
+	// Reservation Condition Type
+	ReservationConditionSynced = "Synced"
+)
+
+const (
+	// Account, SlurmUser, and QOS Condition Type
+	SlurmdbConditionSynced = "Synced"
+)
+
+const (
+	// SlurmJob Condition Type
+	SlurmJobConditionSubmitted = "Submitted"
+	SlurmJobConditionComplete  = "Complete"
+	SlurmJobConditionFailed    = "Failed"
 )
 
 func IsConditionTrue(status *corev1.PodStatus, condType corev1.PodConditionType) bool {
@@ -63,3 +96,26 @@ func IsNodeDrain(status *corev1.PodStatus) bool {
 	return IsConditionTrue(status, PodConditionDrain) &&
 		!IsConditionTrue(status, PodConditionUndrain)
 }
+
+// IsNodeRegistered is a conceptual state that means the node has registered
+// with Slurm and can be scheduled work.
+func IsNodeRegistered(status *corev1.PodStatus) bool {
+	isUp := IsConditionTrue(status, PodConditionIdle) ||
+		IsConditionTrue(status, PodConditionAllocated) ||
+		IsConditionTrue(status, PodConditionMixed)
+	return isUp && !IsNodeDrain(status) && !IsConditionTrue(status, PodConditionNotResponding)
+}
+
+// IsNodeIdle is a conceptual state that means the node has registered with
+// Slurm and is ready to be scheduled work, but has none yet.
+func IsNodeIdle(status *corev1.PodStatus) bool {
+	isIdle := IsConditionTrue(status, PodConditionIdle) && !IsNodeBusy(status)
+	return isIdle && !IsNodeDrain(status) && !IsConditionTrue(status, PodConditionNotResponding)
+}
+
+// IsNodeRebootPending is a conceptual state that means a reboot of the node was
+// requested or issued, and has not completed yet.
+func IsNodeRebootPending(status *corev1.PodStatus) bool {
+	return IsConditionTrue(status, PodConditionRebootRequested) ||
+		IsConditionTrue(status, PodConditionRebootIssued)
+}
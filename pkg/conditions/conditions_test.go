@@ -346,3 +346,259 @@ func TestAreJobsRunning(t *testing.T) {
 		})
 	}
 }
+
+func TestIsNodeRegistered(t *testing.T) {
+	type args struct {
+		status *corev1.PodStatus
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Node is idle",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Node is allocated",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionAllocated,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Node is drained",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   PodConditionDrain,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Node is not responding",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   PodConditionNotResponding,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Node is not registered",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsNodeRegistered(tt.args.status))
+		})
+	}
+}
+
+func TestIsNodeIdle(t *testing.T) {
+	type args struct {
+		status *corev1.PodStatus
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Node is idle",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Node is allocated",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionAllocated,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Node is idle and completing",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   PodConditionCompleting,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Node is drained",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   PodConditionDrain,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Node is not responding",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   PodConditionNotResponding,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsNodeIdle(tt.args.status))
+		})
+	}
+}
+
+func TestIsNodeRebootPending(t *testing.T) {
+	type args struct {
+		status *corev1.PodStatus
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Reboot requested",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   PodConditionRebootRequested,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Reboot issued",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionDown,
+							Status: corev1.ConditionTrue,
+						},
+						{
+							Type:   PodConditionRebootIssued,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "No reboot",
+			args: args{
+				status: &corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   PodConditionIdle,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, IsNodeRebootPending(tt.args.status))
+		})
+	}
+}